@@ -231,6 +231,16 @@ It specifies that calls to the function should not be inlined, overriding
 the compiler's usual optimization rules. This is typically only needed
 for special runtime functions or when debugging the compiler.
 
+	//go:inlinehint hot|cold|prefer-inline
+
+The //go:inlinehint directive must be followed by a function declaration.
+It gives the compiler's inlining heuristics (see -d=inlheuristics) a hint
+about the function: "hot" says that it is called often, "cold" that it is
+called rarely, and "prefer-inline" that calls to it should be inlined
+wherever the inlining budget allows. Unlike //go:noinline, the hint only
+adjusts the scores of the function's call sites and doesn't force a
+decision; it has no effect unless the heuristics are enabled.
+
 	//go:norace
 
 The //go:norace directive must be followed by a function declaration.
@@ -21,7 +21,16 @@ type DebugFlags struct {
 	Closure               int    `help:"print information about closure compilation"`
 	Defer                 int    `help:"print information about defer compilation"`
 	DisableNil            int    `help:"disable nil checks" concurrent:"ok"`
-	DumpInlFuncProps      string `help:"dump function properties from inl heuristics to specified file"`
+	DumpInlFuncProps      string `help:"dump function properties from inl heuristics to specified file (- for stdout, -stderr for stderr, or several destinations separated by +; file:regexp to dump only matching functions, or file:name1/name2 to dump only the named functions; append :json to write a JSON document, or :csv for CSV)"`
+	DumpInlCallSiteScores string `help:"dump a table of the inline heuristic scores computed for each call site, with the cost and adjustments applied, to the specified file (requires -d=inlheuristics)"`
+	DumpInlCallSiteSource int    `help:"include the source line text of each call site in the -d=dumpinlcallsitescores table"`
+	DumpInlChangedOnly    int    `help:"restrict the -d=dumpinlcallsitescores table to the call sites whose inlining decision was changed by the inline heuristics"`
+	DumpInlPropsCollapse  int    `help:"collapse the instantiations of each generic function into a single entry in the function properties dump, recording the number of instantiations"`
+	DumpInlPropsStream    int    `help:"spill function properties dump entries to a temporary file as they are computed, to bound memory use"`
+	DumpInlPropsIncr      int    `help:"compute function properties incrementally when dumping them (for testing)"`
+	DumpInlPropsIndent    int    `help:"write the function properties in the dump as indented (multi-line) JSON"`
+	DumpInlPropsAppend    int    `help:"append the function properties dump for the package, preceded by a package preamble, to the dump file rather than truncating it, so that one file can describe all of the packages in a build"`
+	DumpInlSkipGen        string `help:"kinds of compiler-generated functions to leave out of the function properties dump, separated by slashes (eq, hash, deferwrap, gowrap, rangefunc, instwrap, wrapper), or all, or none; eq by default"`
 	DumpPtrs              int    `help:"show Node pointers values in dump output"`
 	DwarfInl              int    `help:"print information about DWARF inlined function creation"`
 	EscapeMutationsCalls  int    `help:"print extra escape analysis diagnostics about mutations and calls" concurrent:"ok"`
@@ -31,7 +40,32 @@ type DebugFlags struct {
 	GCCheck               int    `help:"check heap/gc use by compiler" concurrent:"ok"`
 	GCProg                int    `help:"print dump of GC programs"`
 	Gossahash             string `help:"hash value for use in debugging the compiler"`
+	InlBudget             int    `help:"inline budget for the package being compiled: the maximum cost of an inlinable function, and of a call inlined into a function that is not big (0 means use the default of 80)"`
+	InlBudgetExtend       string `help:"raise the inline budget by the given amount for calls whose callee has the named function, param or result property flag (a slash-separated list of flag:amount entries; for example FuncPropIsLeaf:20; requires -d=inlheuristics)"`
+	InlBudgetWhatIf       string `help:"report how many more functions and call sites would be inlinable if the inline budget were raised by each of the specified amounts (a slash-separated list; for example 10/20/40), along with their aggregate cost"`
+	InlCallGraphDOT       string `help:"write the package's call graph to the specified file in Graphviz DOT format, with functions labeled by their inline heuristics properties and calls by their inline score, threshold and decision"`
+	InlColdCalleeAdj      int    `help:"inline heuristic score adjustment for calls to callees with no samples in the PGO profile (0 means use the default)"`
+	InlCostBreakdown      string `help:"print the contribution of each IR node to the inline cost of the named function (or of each function with one of a slash-separated list of names), in source order, along with the inline heuristics score adjustments applied to it"`
+	InlCoverProfile       string `help:"read a Go coverage profile (as written by go test -coverprofile) from the specified file, and treat functions that it shows were never executed as cold when scoring calls to them (requires -d=inlheuristics)"`
+	InlDecisionsJSON      string `help:"write the inlining decision for each direct call site, with its position, cost, score, threshold and the reason it was not inlined, to the specified file as versioned JSON for use by editor tooling"`
 	InlFuncsWithClosures  int    `help:"allow functions with closures to be inlined" concurrent:"ok"`
+	InlHeuristics         int    `help:"use inline heuristics (function properties) to adjust inlining costs"`
+	InlHeurDiffCSV        string `help:"append a CSV table of the call sites whose inlining decision differs between the baseline cost model (the callee's unadjusted inline cost) and the inline heuristics score, with both scores and decisions, to the specified file (requires -d=inlheuristics)"`
+	InlHeurDumpIR         string `help:"dump the IR of each function for which the inline heuristics compute the named function, param or result property flag (for example FuncPropNeverReturns)"`
+	InlHeurEscapePass     int    `help:"after escape analysis, refine the inline heuristics properties of the package's inlinable functions using its results, for use when scoring calls from importing packages (requires -d=inlheuristics)"`
+	InlHeurMaxNodes       int    `help:"skip the inline heuristics analysis of functions with more than the specified number of IR nodes, marking them FuncPropTooLarge instead (0 means no limit)"`
+	InlHeurOutcomes       string `help:"append a summary of the call sites that were inlined only because of the inline heuristics score adjustments, or not inlined only because of them, to the specified file (requires -d=inlheuristics)"`
+	InlHeurStats          int    `help:"print statistics on the cost of the inline heuristics for each package (functions analyzed, node visits, time per analyzer, dump buffer size and score adjustments)"`
+	InlHeurVerify         int    `help:"compute the inline heuristics properties of each function twice, with fresh analyzers, and report any difference as an internal compiler error"`
+	InlHotCallSiteAdj     int    `help:"inline heuristic score adjustment for hot call sites in the PGO profile (0 means use the default)"`
+	InlPropsCacheStats    int    `help:"print the number of hits and misses in the inline heuristics function properties cache"`
+	InlPropsOverride      string `help:"force the inline heuristics function properties of the functions listed in the specified file (see cmd/compile/internal/inline/inlheur/props_override.go for the format)"`
+	InlPropsWorkers       int    `help:"number of goroutines used to compute inline heuristics function properties (0 or 1 means analyze functions serially)"`
+	InlReasons            string `help:"write the inlining decision for each direct call site, with its cost, score, threshold and heuristic adjustments, to the specified file"`
+	InlReplay             string `help:"read inlining decisions from the specified file (as written by -d=inldecisionsjson) and reproduce them, bypassing the inline cost and score checks for the call sites it lists"`
+	InlScoreAdj           string `help:"override inline heuristic score adjustments, as a slash-separated list of name:value pairs (for example wrapper:-30/tailcall:0)"`
+	InlScoreHash          string `help:"hash value for bisecting the functions to which inline heuristic score adjustments are applied"`
+	InlScoreStats         int    `help:"print a summary of the inline heuristic score adjustments applied, by property"`
 	InlStaticInit         int    `help:"allow static initialization of inlined calls" concurrent:"ok"`
 	InterfaceCycles       int    `help:"allow anonymous interface cycles"`
 	Libfuzzer             int    `help:"enable coverage instrumentation for libfuzzer"`
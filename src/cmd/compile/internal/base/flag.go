@@ -256,6 +256,10 @@ func ParseFlags() {
 		FmaHash = NewHashDebug("fmahash", Debug.Fmahash, nil)
 	}
 
+	if Debug.InlScoreHash != "" {
+		InlScoreHash = NewHashDebug("inlscorehash", Debug.InlScoreHash, nil)
+	}
+
 	if Flag.MSan && !platform.MSanSupported(buildcfg.GOOS, buildcfg.GOARCH) {
 		log.Fatalf("%s/%s does not support -msan", buildcfg.GOOS, buildcfg.GOARCH)
 	}
@@ -53,8 +53,9 @@ func (d *HashDebug) SetInlineSuffixOnly(b bool) *HashDebug {
 // The default compiler-debugging HashDebug, for "-d=gossahash=..."
 var hashDebug *HashDebug
 
-var FmaHash *HashDebug     // for debugging fused-multiply-add floating point changes
-var LoopVarHash *HashDebug // for debugging shared/private loop variable changes
+var FmaHash *HashDebug      // for debugging fused-multiply-add floating point changes
+var LoopVarHash *HashDebug  // for debugging shared/private loop variable changes
+var InlScoreHash *HashDebug // for debugging inline heuristic score adjustments
 
 // DebugHashMatchPkgFunc reports whether debug variable Gossahash
 //
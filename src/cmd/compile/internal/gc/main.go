@@ -267,6 +267,7 @@ func Main(archInit func(*ssagen.ArchInfo)) {
 	// because large values may contain pointers, it must happen early.
 	base.Timer.Start("fe", "escapes")
 	escape.Funcs(typecheck.Target.Funcs)
+	inline.RefinePropsAfterEscape(typecheck.Target.Funcs)
 
 	loopvar.LogTransformations(transformed)
 
@@ -0,0 +1,159 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package golden provides support for tests of the inliner that
+// compare the output of a compilation against expected results
+// recorded in the test case files themselves, in the style of the
+// inline heuristics function properties tests (see
+// cmd/compile/internal/inline/inlheur/testdata/props/README.txt).
+//
+// A test case file is a compilable Go file that begins with a
+// copyright header (three comment lines followed by a blank line),
+// and in which the expected results are written as column-0 ("//")
+// comments, typically in a block immediately preceding each
+// function of interest. Since remastering a file (see Rewrite)
+// replaces all of its column-0 comments other than compiler
+// directives ("//go:..."), comments that should be preserved must be
+// indented or written with "/* */".
+//
+// The format of the expected results themselves (including any
+// delimiters separating the sections of an entry) is up to the
+// individual test; this package takes care of extracting them from
+// the file, of running the compiler to produce new results, and of
+// writing out a remastered file when the test is run with the
+// -update-expected flag.
+package golden
+
+import (
+	"flag"
+	"fmt"
+	"internal/testenv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Update is set by the -update-expected flag, which requests that
+// tests using this package write out remastered versions of their
+// test case files (see WriteNew), rather than comparing against the
+// existing expected results.
+var Update = flag.Bool("update-expected", false, "if true, generate updated golden results in testcases for all props tests")
+
+// headerLines is the number of lines in the copyright header of a
+// test case file, including the blank line that follows it.
+const headerLines = 4
+
+// File is a test case file containing expected results.
+type File struct {
+	Path   string
+	header []string // copyright header
+	body   []string // everything after the header
+}
+
+// Read reads in the test case file 'path'.
+func Read(path string) (*File, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(string(content), "\n")
+	if len(lines) < headerLines || !strings.HasPrefix(lines[0], "// Copyright") {
+		return nil, fmt.Errorf("%s: missing copyright header", path)
+	}
+	return &File{
+		Path:   path,
+		header: lines[:headerLines],
+		body:   lines[headerLines:],
+	}, nil
+}
+
+// Expected returns the expected results recorded in the file, namely
+// the text of its column-0 comments (following the copyright header)
+// in order, one line per comment line, including the leading "// ".
+func (f *File) Expected() string {
+	var sb strings.Builder
+	for _, line := range f.body {
+		if strings.HasPrefix(line, "// ") {
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// Rewrite returns new contents for the file, in which the existing
+// column-0 comments have been replaced by fresh expected results.
+// The copyright header is preserved, and is followed by the lines of
+// 'preamble'. For each line of the file that begins a function
+// declaration (that is, begins with "func "), the lines returned by
+// 'entry' for that declaration are inserted before it; 'entry' may
+// return nil for functions that have no expected results. Both
+// 'preamble' and the entries are written as is, so they should
+// include the leading "// ". Compiler directives are kept, and are
+// written out immediately before the declaration that follows them
+// (after its entry, if any), so that they still apply to it.
+func (f *File) Rewrite(preamble []string, entry func(funcLine string) []string) []byte {
+	lines := append([]string{}, f.header...)
+	lines = append(lines, preamble...)
+	var directives []string
+	for _, line := range f.body {
+		if strings.HasPrefix(line, "func ") {
+			lines = append(lines, entry(line)...)
+		}
+		if strings.HasPrefix(line, "//go:") {
+			directives = append(directives, line)
+			continue
+		}
+		if strings.HasPrefix(line, "//") {
+			continue
+		}
+		lines = append(lines, directives...)
+		directives = nil
+		lines = append(lines, line)
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// WriteNew writes 'content' (typically produced by Rewrite) to the
+// file "X.new", where X is the path of 'f', and logs a message asking
+// the developer to compare the two.
+func (f *File) WriteNew(t testing.TB, content []byte) {
+	t.Helper()
+	newpath := f.Path + ".new"
+	if err := os.WriteFile(newpath, content, 0644); err != nil {
+		t.Fatalf("writing %s: %v", newpath, err)
+	}
+	t.Logf("update-expected: emitted updated file %s", newpath)
+	t.Logf("please compare the two files, then overwrite %s with %s\n",
+		f.Path, newpath)
+}
+
+// UniquePath returns a path within directory 'dir' for a file whose
+// name begins with 'prefix' and ends with 'suffix', with a salt in
+// between that makes it unique. This is needed for output files
+// written by the compiler as a side effect (such as dumps requested
+// with -d flags), since the go command may otherwise satisfy a repeat
+// build from its cache, in which case the file is never written.
+func UniquePath(dir, prefix, suffix string) string {
+	salt := fmt.Sprintf(".p%dt%d", os.Getpid(), time.Now().UnixNano())
+	return filepath.Join(dir, prefix+salt+suffix)
+}
+
+// Build compiles the Go file 'gopath' with "go build" (rather than
+// "go tool compile", since test cases may import packages from the
+// standard library), passing 'gcflags' to the compiler and writing
+// the resulting package to 'outpath'. Any output from the build is
+// logged.
+func Build(t testing.TB, gopath, outpath, gcflags string) error {
+	t.Helper()
+	run := []string{testenv.GoToolPath(t), "build",
+		"-gcflags=" + gcflags, "-o", outpath, gopath}
+	out, err := testenv.Command(t, run[0], run[1:]...).CombinedOutput()
+	if strings.TrimSpace(string(out)) != "" {
+		t.Logf("%s", out)
+	}
+	return err
+}
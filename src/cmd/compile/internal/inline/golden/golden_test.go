@@ -0,0 +1,101 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testFile = `// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// old preamble
+package p
+
+// F old
+// more
+func F() {
+	// indented comment
+}
+
+/* block comment */
+func G() {}
+`
+
+func TestReadRewrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "p.go")
+	if err := os.WriteFile(path, []byte(testFile), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := f.Expected(), "// old preamble\n// F old\n// more\n"; got != want {
+		t.Errorf("Expected: got %q, want %q", got, want)
+	}
+
+	var funcs []string
+	got := string(f.Rewrite([]string{"// new preamble"}, func(line string) []string {
+		funcs = append(funcs, line)
+		if strings.HasPrefix(line, "func F") {
+			return []string{"// F new"}
+		}
+		return nil
+	}))
+	want := strings.Replace(testFile, "// old preamble\n", "// new preamble\n", 1)
+	want = strings.Replace(want, "// F old\n// more\n", "// F new\n", 1)
+	if got != want {
+		t.Errorf("Rewrite: got:\n%s\nwant:\n%s", got, want)
+	}
+	if len(funcs) != 2 || funcs[0] != "func F() {" || funcs[1] != "func G() {}" {
+		t.Errorf("Rewrite: entry called for %q, want F and G", funcs)
+	}
+}
+
+func TestRewriteDirectives(t *testing.T) {
+	const file = `// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package p
+
+// F old
+//go:noinline
+func F() {}
+`
+	path := filepath.Join(t.TempDir(), "p.go")
+	if err := os.WriteFile(path, []byte(file), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := f.Expected(), "// F old\n"; got != want {
+		t.Errorf("Expected: got %q, want %q", got, want)
+	}
+	got := string(f.Rewrite(nil, func(string) []string {
+		return []string{"// F new"}
+	}))
+	want := strings.Replace(file, "// F old\n", "// F new\n", 1)
+	if got != want {
+		t.Errorf("Rewrite: got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestReadNoCopyright(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "p.go")
+	if err := os.WriteFile(path, []byte("package p\n\n\n\nfunc F() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Read(path); err == nil {
+		t.Errorf("Read succeeded on file with no copyright header")
+	}
+}
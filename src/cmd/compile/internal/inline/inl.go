@@ -29,6 +29,7 @@ package inline
 import (
 	"fmt"
 	"go/constant"
+	"os"
 	"sort"
 	"strconv"
 
@@ -75,6 +76,10 @@ var (
 
 	// Budget increased due to hotness.
 	inlineHotMaxBudget int32 = 2000
+
+	// Set of linker symbol names of functions that are the callee
+	// of at least one edge with a non-zero weight in the profile.
+	pgoSampledCallees = make(map[string]bool)
 )
 
 // pgoInlinePrologue records the hot callsites from ir-graph.
@@ -108,12 +113,34 @@ func pgoInlinePrologue(p *pgo.Profile, funcs []*ir.Func) {
 		}
 	}
 
+	if base.Debug.InlHeuristics != 0 {
+		for k, w := range p.NodeMap {
+			if w.EWeight > 0 {
+				pgoSampledCallees[k.CalleeName] = true
+			}
+		}
+		inlheur.SetProfileHotness(pgoCallSiteHotness)
+	}
+
 	if base.Debug.PGODebug >= 3 {
 		fmt.Printf("hot-cg before inline in dot format:")
 		p.PrintWeightedCallGraphDOT(inlineHotCallSiteThresholdPercent)
 	}
 }
 
+// pgoCallSiteHotness reports whether the call 'call' from 'caller' to
+// 'callee' is hot according to the profile (that is, it is one of the
+// hot call sites selected by pgoInlinePrologue), and whether it is
+// cold, meaning that the callee was never seen to be called in the
+// profile.
+func pgoCallSiteHotness(caller *ir.Func, call *ir.CallExpr, callee *ir.Func) (hot, cold bool) {
+	csi := pgo.CallSiteInfo{LineOffset: pgo.NodeLineOffset(call, caller), Caller: caller}
+	if _, ok := candHotEdgeMap[csi]; ok {
+		return true, false
+	}
+	return false, !pgoSampledCallees[ir.LinkFuncName(callee)]
+}
+
 // hotNodesFromCDF computes an edge weight threshold and the list of hot
 // nodes that make up the given percentage of the CDF. The threshold, as
 // a percent, is the lower bound of weight for nodes to be considered hot
@@ -161,6 +188,38 @@ func InlinePackage(p *pgo.Profile) {
 		p = nil
 	}
 
+	if base.Debug.DumpInlFuncProps != "" && base.Debug.DumpInlPropsStream != 0 {
+		inlheur.StreamFuncPropsDump()
+	}
+	if base.Debug.InlBudget > 0 {
+		inlheur.SetInlineBudget(base.Debug.InlBudget)
+	}
+	if base.Debug.InlScoreAdj != "" {
+		inlheur.SetScoreAdjustments(base.Debug.InlScoreAdj)
+	}
+	if base.Debug.InlPropsOverride != "" {
+		inlheur.SetPropsOverrides(base.Debug.InlPropsOverride)
+	}
+	if base.Debug.InlScoreStats != 0 {
+		inlheur.EnableScoreStats()
+	}
+	if base.Debug.InlHeurStats != 0 {
+		inlheur.EnableHeurStats()
+	}
+	if base.Debug.DumpInlCallSiteScores != "" {
+		inlheur.EnableCallSiteScoreDump()
+	}
+	if base.Debug.InlBudgetWhatIf != "" {
+		enableWhatIf(base.Debug.InlBudgetWhatIf)
+	}
+	if base.Debug.InlReplay != "" {
+		loadInlReplay(base.Debug.InlReplay, base.Ctxt.Pkgpath)
+	}
+
+	if base.Debug.InlHeuristics != 0 || base.Debug.DumpInlFuncProps != "" {
+		inlheur.FindEscapingClosures(typecheck.Target.Funcs)
+	}
+
 	InlineDecls(p, typecheck.Target.Funcs, true)
 
 	// Perform a garbage collection of hidden closures functions that
@@ -168,9 +227,43 @@ func InlinePackage(p *pgo.Profile) {
 	// inlining. See #59404 and #59638 for more context.
 	garbageCollectUnreferencedHiddenClosures()
 
+	if base.Debug.InlHeuristics != 0 {
+		inlheur.WriteObjProps(typecheck.Target.Funcs)
+	}
 	if base.Debug.DumpInlFuncProps != "" {
 		inlheur.DumpFuncProps(nil, base.Debug.DumpInlFuncProps, nil)
 	}
+	if base.Debug.InlHeurStats != 0 {
+		inlheur.DumpHeurStats(os.Stdout, base.Ctxt.Pkgpath)
+	} else if base.Debug.InlScoreStats != 0 {
+		inlheur.DumpScoreStats(os.Stdout)
+	}
+	if base.Debug.DumpInlCallSiteScores != "" {
+		inlheur.DumpCallSiteScores(base.Debug.DumpInlCallSiteScores)
+	}
+	if base.Debug.InlPropsCacheStats != 0 {
+		inlheur.DumpPropsCacheStats(os.Stdout)
+	}
+	if base.Debug.InlCallGraphDOT != "" {
+		writeInlCallGraphDOT(base.Debug.InlCallGraphDOT, base.Ctxt.Pkgpath)
+	}
+	if base.Debug.InlDecisionsJSON != "" {
+		writeInlDecisionsJSON(base.Debug.InlDecisionsJSON, base.Ctxt.Pkgpath)
+	}
+	if base.Debug.InlReasons != "" {
+		writeInlDecisions(base.Debug.InlReasons)
+	}
+	inlDecisions = nil
+	if base.Debug.InlBudgetWhatIf != "" {
+		writeWhatIfReport(os.Stdout, base.Ctxt.Pkgpath)
+	}
+	if base.Debug.InlHeurOutcomes != "" && base.Debug.InlHeuristics != 0 {
+		writeHeurOutcomes(base.Debug.InlHeurOutcomes, base.Ctxt.Pkgpath)
+	}
+	if base.Debug.InlHeurDiffCSV != "" && base.Debug.InlHeuristics != 0 {
+		writeHeurDiffCSV(base.Debug.InlHeurDiffCSV, base.Ctxt.Pkgpath)
+	}
+	resetHeurOutcomes()
 }
 
 // InlineDecls applies inlining to the given batch of declarations.
@@ -189,9 +282,49 @@ func InlineDecls(p *pgo.Profile, funcs []*ir.Func, doInline bool) {
 			if base.Flag.LowerM > 1 && n.OClosure == nil {
 				fmt.Printf("%v: cannot inline %v: recursive\n", ir.Line(n), n.Nname)
 			}
+			if recordingInlDecisions() {
+				cannotInlineReasons[n] = "recursive"
+			}
+			if base.Debug.DumpInlFuncProps != "" && !deferPropsAnalysis {
+				// Recursive functions can't be inlined, but we
+				// still want them to appear in the props dump.
+				inlheur.DumpFuncProps(n, base.Debug.DumpInlFuncProps,
+					func(fn *ir.Func) {
+						CanInline(fn, p)
+					})
+			}
 		}
 	}
 
+	if base.Debug.InlPropsWorkers > 1 && (base.Debug.InlHeuristics != 0 || base.Debug.DumpInlFuncProps != "") {
+		// When computing function properties in parallel, first
+		// compute inlinability for all functions, then analyze them
+		// as a single batch, and only then inline calls (since
+		// inlining changes the function bodies being analyzed).
+		var lists [][]*ir.Func
+		var recursives []bool
+		deferPropsAnalysis = true
+		ir.VisitFuncsBottomUp(funcs, func(list []*ir.Func, recursive bool) {
+			numfns := numNonClosures(list)
+			for _, n := range list {
+				doCanInline(n, recursive, numfns)
+			}
+			// Note that VisitFuncsBottomUp reuses 'list'.
+			lists = append(lists, append([]*ir.Func(nil), list...))
+			recursives = append(recursives, recursive)
+		})
+		deferPropsAnalysis = false
+		analyzePropsBatch(p, lists, recursives)
+		if doInline {
+			for _, list := range lists {
+				for _, n := range list {
+					InlineCalls(n, p)
+				}
+			}
+		}
+		return
+	}
+
 	ir.VisitFuncsBottomUp(funcs, func(list []*ir.Func, recursive bool) {
 		numfns := numNonClosures(list)
 		// We visit functions within an SCC in fairly arbitrary order,
@@ -204,6 +337,18 @@ func InlineDecls(p *pgo.Profile, funcs []*ir.Func, doInline bool) {
 		for _, n := range list {
 			doCanInline(n, recursive, numfns)
 		}
+		// ... including any properties that depend on the SCC as
+		// a whole, or on the order in which the SCC's functions
+		// were analyzed ...
+		if recursive && (base.Debug.InlHeuristics != 0 || base.Debug.DumpInlFuncProps != "") {
+			inlheur.MarkRecursive(list)
+		}
+		if numfns > 1 && (base.Debug.InlHeuristics != 0 || base.Debug.DumpInlFuncProps != "") {
+			inlheur.PropagateNeverReturns(list)
+		}
+		if base.Debug.DumpInlFuncProps != "" {
+			inlheur.FlushFuncPropsDump(base.Debug.DumpInlFuncProps)
+		}
 		// ... then make a second pass to do inlining of calls.
 		if doInline {
 			for _, n := range list {
@@ -213,6 +358,49 @@ func InlineDecls(p *pgo.Profile, funcs []*ir.Func, doInline bool) {
 	})
 }
 
+// deferPropsAnalysis is set while InlineDecls is computing
+// inlinability ahead of a batch analysis of function properties (see
+// analyzePropsBatch), in which case CanInline leaves the analysis of
+// the function (and capturing it for the props dump) to the batch.
+var deferPropsAnalysis bool
+
+// analyzePropsBatch computes the inline heuristics properties of the
+// functions in 'lists' (given in bottom-up order) as a single batch,
+// capturing them for the props dump if requested. Here
+// 'recursives[i]' reports whether the functions in 'lists[i]' are
+// recursive.
+func analyzePropsBatch(p *pgo.Profile, lists [][]*ir.Func, recursives []bool) {
+	canInline := func(fn *ir.Func) {
+		CanInline(fn, p)
+	}
+	var all, inlinable []*ir.Func
+	for _, list := range lists {
+		for _, fn := range list {
+			all = append(all, fn)
+			if fn.Inl != nil {
+				inlinable = append(inlinable, fn)
+			}
+		}
+	}
+	if base.Debug.DumpInlFuncProps != "" {
+		inlheur.DumpFuncPropsBatch(all, base.Debug.DumpInlFuncProps, canInline)
+	}
+	if base.Debug.InlHeuristics != 0 {
+		inlheur.AnalyzeFuncs(inlinable, canInline)
+	}
+	for i, list := range lists {
+		if recursives[i] {
+			inlheur.MarkRecursive(list)
+		}
+		if numNonClosures(list) > 1 {
+			inlheur.PropagateNeverReturns(list)
+		}
+	}
+	if base.Debug.DumpInlFuncProps != "" {
+		inlheur.FlushFuncPropsDump(base.Debug.DumpInlFuncProps)
+	}
+}
+
 // garbageCollectUnreferencedHiddenClosures makes a pass over all the
 // top-level (non-hidden-closure) functions looking for nested closure
 // functions that are reachable, then sweeps through the Target.Decls
@@ -264,13 +452,27 @@ func garbageCollectUnreferencedHiddenClosures() {
 	}
 }
 
+// defaultInlineBudget returns the inline budget for functions in the
+// package being compiled, which is also the maximum cost of a call
+// inlined into a function that is not big, unless PGO says otherwise.
+// This is inlineMaxBudget unless overridden with -d=inlbudget. Note
+// that the override doesn't apply when functions from the package
+// are inlined into other packages (unless the same flag is given for
+// those packages).
+func defaultInlineBudget() int32 {
+	if b := base.Debug.InlBudget; b > 0 {
+		return int32(b)
+	}
+	return inlineMaxBudget
+}
+
 // inlineBudget determines the max budget for function 'fn' prior to
 // analyzing the hairyness of the body of 'fn'. We pass in the pgo
 // profile if available, which can change the budget. If 'verbose' is
 // set, then print a remark where we boost the budget due to PGO.
 func inlineBudget(fn *ir.Func, profile *pgo.Profile, verbose bool) int32 {
 	// Update the budget for profile-guided inlining.
-	budget := int32(inlineMaxBudget)
+	budget := defaultInlineBudget()
 	if profile != nil {
 		if n, ok := profile.WeightedCG.IRNodes[ir.LinkFuncName(fn)]; ok {
 			if _, ok := candHotCalleeMap[n]; ok {
@@ -284,6 +486,18 @@ func inlineBudget(fn *ir.Func, profile *pgo.Profile, verbose bool) int32 {
 	return budget
 }
 
+// RefinePropsAfterEscape refines the inline heuristics properties of
+// the functions in 'funcs' using the results of escape analysis, for
+// the "-d=inlheurescapepass" command line flag (see
+// inlheur.RefineAfterEscape). It must be called after escape
+// analysis, and before the export data is written.
+func RefinePropsAfterEscape(funcs []*ir.Func) {
+	if base.Debug.InlHeuristics == 0 || base.Debug.InlHeurEscapePass == 0 {
+		return
+	}
+	inlheur.RefineAfterEscape(funcs)
+}
+
 // CanInline determines whether fn is inlineable.
 // If so, CanInline saves copies of fn.Body and fn.Dcl in fn.Inl.
 // fn and fn.Body will already have been typechecked.
@@ -292,7 +506,7 @@ func CanInline(fn *ir.Func, profile *pgo.Profile) {
 		base.Fatalf("CanInline no nname %+v", fn)
 	}
 
-	if base.Debug.DumpInlFuncProps != "" {
+	if base.Debug.DumpInlFuncProps != "" && !deferPropsAnalysis {
 		inlheur.DumpFuncProps(fn, base.Debug.DumpInlFuncProps,
 			func(fn *ir.Func) {
 				CanInline(fn, profile)
@@ -300,9 +514,12 @@ func CanInline(fn *ir.Func, profile *pgo.Profile) {
 	}
 
 	var reason string // reason, if any, that the function was not inlined
-	if base.Flag.LowerM > 1 || logopt.Enabled() {
+	if base.Flag.LowerM > 1 || logopt.Enabled() || recordingInlDecisions() {
 		defer func() {
 			if reason != "" {
+				if recordingInlDecisions() {
+					cannotInlineReasons[fn] = reason
+				}
 				if base.Flag.LowerM > 1 {
 					fmt.Printf("%v: cannot inline %v: %s\n", ir.Line(fn), fn.Nname, reason)
 				}
@@ -351,8 +568,14 @@ func CanInline(fn *ir.Func, profile *pgo.Profile) {
 		extraCallCost: cc,
 		profile:       profile,
 	}
+	if wantCostBreakdown(fn) {
+		visitor.breakdown = new(costBreakdown)
+	}
 	if visitor.tooHairy(fn) {
 		reason = visitor.reason
+		if visitor.breakdown != nil {
+			visitor.breakdown.write(os.Stdout, fn, budget-visitor.budget, budget, reason)
+		}
 		return
 	}
 
@@ -364,11 +587,25 @@ func CanInline(fn *ir.Func, profile *pgo.Profile) {
 		CanDelayResults: canDelayResults(fn),
 	}
 
+	if base.Debug.InlHeuristics != 0 && !deferPropsAnalysis {
+		inlheur.AnalyzeFunc(fn, func(fn *ir.Func) {
+			CanInline(fn, profile)
+		})
+	}
+	if visitor.breakdown != nil {
+		visitor.breakdown.write(os.Stdout, fn, budget-visitor.budget, budget, "")
+	}
+
 	if base.Flag.LowerM > 1 {
 		fmt.Printf("%v: can inline %v with cost %d as: %v { %v }\n", ir.Line(fn), n, budget-visitor.budget, fn.Type(), ir.Nodes(fn.Body))
 	} else if base.Flag.LowerM != 0 {
 		fmt.Printf("%v: can inline %v\n", ir.Line(fn), n)
 	}
+	if base.Flag.LowerM > 2 && base.Debug.InlHeuristics != 0 {
+		if fp, ok := inlheur.FuncPropsFor(fn); ok {
+			fmt.Printf("%v: inline heuristics properties of %v: %s\n", ir.Line(fn), n, fp.Summary())
+		}
+	}
 	if logopt.Enabled() {
 		logopt.LogOpt(fn.Pos(), "canInlineFunction", "inline", ir.FuncName(fn), fmt.Sprintf("cost: %d", budget-visitor.budget))
 	}
@@ -488,15 +725,22 @@ type hairyVisitor struct {
 	usedLocals    ir.NameSet
 	do            func(ir.Node) bool
 	profile       *pgo.Profile
+	breakdown     *costBreakdown // for -d=inlcostbreakdown, or nil
 }
 
 func (v *hairyVisitor) tooHairy(fn *ir.Func) bool {
 	v.do = v.doNode // cache closure
+	if v.breakdown != nil {
+		v.do = func(n ir.Node) bool { return v.breakdown.doNode(v, n) }
+	}
 	if ir.DoChildren(fn, v.do) {
 		return true
 	}
 	if v.budget < 0 {
 		v.reason = fmt.Sprintf("function too complex: cost %d exceeds budget %d", v.maxBudget-v.budget, v.maxBudget)
+		if base.Debug.InlBudgetWhatIf != "" {
+			recordWhatIfFunc(v.curFunc, v.maxBudget-v.budget, v.maxBudget)
+		}
 		return true
 	}
 	return false
@@ -766,7 +1010,8 @@ opSwitch:
 	v.budget--
 
 	// When debugging, don't stop early, to get full cost of inlining this function
-	if v.budget < 0 && base.Flag.LowerM < 2 && !logopt.Enabled() {
+	if v.budget < 0 && base.Flag.LowerM < 2 && !logopt.Enabled() &&
+		base.Debug.InlBudgetWhatIf == "" && v.breakdown == nil {
 		v.reason = "too expensive"
 		return true
 	}
@@ -899,8 +1144,17 @@ func inlnode(callerfn *ir.Func, n ir.Node, bigCaller bool, inlCalls *[]*ir.Inlin
 		if ir.IsIntrinsicCall(call) {
 			break
 		}
-		if fn := inlCallee(callerfn, call.X, profile); fn != nil && typecheck.HaveInlineBody(fn) {
-			n = mkinlcall(callerfn, call, fn, bigCaller, inlCalls)
+		if fn := inlCallee(callerfn, call.X, profile); fn != nil {
+			if typecheck.HaveInlineBody(fn) {
+				n = mkinlcall(callerfn, call, fn, bigCaller, inlCalls)
+			} else {
+				if recordingInlDecisions() {
+					recordInlDecision(callerfn, call, fn, notInlinableReason(fn), -1, -1)
+				}
+				if base.Debug.InlBudgetWhatIf != "" {
+					recordWhatIfCall(call, fn, -1, baseMaxCost(bigCaller))
+				}
+			}
 		}
 	}
 
@@ -954,29 +1208,72 @@ var InlineCall = func(callerfn *ir.Func, call *ir.CallExpr, fn *ir.Func, inlInde
 	panic("unreachable")
 }
 
-// inlineCostOK returns true if call n from caller to callee is cheap enough to
-// inline. bigCaller indicates that caller is a big function.
-//
-// If inlineCostOK returns false, it also returns the max cost that the callee
-// exceeded.
-func inlineCostOK(n *ir.CallExpr, caller, callee *ir.Func, bigCaller bool) (bool, int32) {
-	maxCost := int32(inlineMaxBudget)
+// baseMaxCost returns the maximum cost of a callee inlined into a
+// caller that is big if 'bigCaller' is set, before any extension by
+// the inline heuristics or PGO (as for a cold call site; see
+// inlineCostOK).
+func baseMaxCost(bigCaller bool) int32 {
 	if bigCaller {
 		// We use this to restrict inlining into very big functions.
 		// See issue 26546 and 17566.
-		maxCost = inlineBigFunctionMaxCost
+		return inlineBigFunctionMaxCost
+	}
+	return defaultInlineBudget()
+}
+
+// inlineCostOK returns true if call n from caller to callee is cheap enough to
+// inline. bigCaller indicates that caller is a big function.
+//
+// inlineCostOK also returns the max cost that applies to the call (which
+// the callee exceeded, if inlineCostOK returns false), and the cost of
+// the callee as adjusted by the inline heuristics, if enabled.
+func inlineCostOK(n *ir.CallExpr, caller, callee *ir.Func, bigCaller bool) (bool, int32, int32) {
+	maxCost := baseMaxCost(bigCaller)
+
+	if inlined, ok := replayedInlDecision(n, callee); ok {
+		// The decision was given by -d=inlreplay.
+		return inlined, maxCost, callee.Inl.Cost
+	}
+
+	cost := callee.Inl.Cost
+	if base.Debug.InlHeuristics != 0 {
+		cost, _ = inlheur.GetCallSiteScore(caller, n, callee, cost)
+		// Callees with particular properties may be granted a
+		// larger budget (see -d=inlbudgetextend), though not in
+		// big callers.
+		if !bigCaller {
+			maxCost += inlheur.BudgetExtension(callee)
+		}
 	}
 
-	if callee.Inl.Cost <= maxCost {
+	if cost <= maxCost {
 		// Simple case. Function is already cheap enough.
-		return true, 0
+		return true, maxCost, cost
 	}
 
-	// We'll also allow inlining of hot functions below inlineHotMaxBudget,
-	// but only in small functions.
+	csi := pgo.CallSiteInfo{LineOffset: pgo.NodeLineOffset(n, caller), Caller: caller}
+	ok, maxCost := costWithinBudget(csi, cost, maxCost, bigCaller)
+	if _, hot := candHotEdgeMap[csi]; hot && base.Debug.PGODebug > 0 {
+		if bigCaller {
+			fmt.Printf("hot-big check disallows inlining for call %s (cost %d) at %v in big function %s\n", ir.PkgFuncName(callee), callee.Inl.Cost, ir.Line(n), ir.PkgFuncName(caller))
+		} else if ok {
+			fmt.Printf("hot-budget check allows inlining for call %s (cost %d) at %v in function %s\n", ir.PkgFuncName(callee), callee.Inl.Cost, ir.Line(n), ir.PkgFuncName(caller))
+		}
+	}
+	return ok, maxCost, cost
+}
 
-	lineOffset := pgo.NodeLineOffset(n, caller)
-	csi := pgo.CallSiteInfo{LineOffset: lineOffset, Caller: caller}
+// costWithinBudget reports whether a callee with cost 'cost' may be
+// inlined at call site 'csi', where 'maxCost' is the maximum cost for
+// the caller and 'bigCaller' indicates that the caller is a big
+// function, and also returns the max cost that applies to the call.
+//
+// We'll also allow inlining of hot functions below inlineHotMaxBudget,
+// but only in small functions.
+func costWithinBudget(csi pgo.CallSiteInfo, cost, maxCost int32, bigCaller bool) (bool, int32) {
+	if cost <= maxCost {
+		return true, maxCost
+	}
 	if _, ok := candHotEdgeMap[csi]; !ok {
 		// Cold
 		return false, maxCost
@@ -985,21 +1282,47 @@ func inlineCostOK(n *ir.CallExpr, caller, callee *ir.Func, bigCaller bool) (bool
 	// Hot
 
 	if bigCaller {
-		if base.Debug.PGODebug > 0 {
-			fmt.Printf("hot-big check disallows inlining for call %s (cost %d) at %v in big function %s\n", ir.PkgFuncName(callee), callee.Inl.Cost, ir.Line(n), ir.PkgFuncName(caller))
-		}
 		return false, maxCost
 	}
 
-	if callee.Inl.Cost > inlineHotMaxBudget {
+	if cost > inlineHotMaxBudget {
 		return false, inlineHotMaxBudget
 	}
 
-	if base.Debug.PGODebug > 0 {
-		fmt.Printf("hot-budget check allows inlining for call %s (cost %d) at %v in function %s\n", ir.PkgFuncName(callee), callee.Inl.Cost, ir.Line(n), ir.PkgFuncName(caller))
-	}
+	return true, inlineHotMaxBudget
+}
 
-	return true, 0
+// baseInlineCostOK is like inlineCostOK, but ignores the inline
+// heuristics: it reports whether call n from caller to callee would be
+// inlined given the callee's unadjusted cost, without any budget
+// extension, and returns the max cost that applies to the call in that
+// case.
+func baseInlineCostOK(n *ir.CallExpr, caller, callee *ir.Func, bigCaller bool) (bool, int32) {
+	maxCost := baseMaxCost(bigCaller)
+	cost := callee.Inl.Cost
+	if cost <= maxCost {
+		return true, maxCost
+	}
+	csi := pgo.CallSiteInfo{LineOffset: pgo.NodeLineOffset(n, caller), Caller: caller}
+	return costWithinBudget(csi, cost, maxCost, bigCaller)
+}
+
+// printCallSiteHeuristics prints the "-m=3" diagnostic describing the
+// inline heuristics' view of the call 'n' from 'caller' to 'callee':
+// the callee's cost, its score (the cost adjusted by the heuristics)
+// and the threshold it was compared against, followed by the score
+// adjustments that were applied and the callee's properties.
+func printCallSiteHeuristics(caller *ir.Func, n *ir.CallExpr, callee *ir.Func, score, maxCost int32) {
+	adjs := inlheur.CallSiteAdjustments(caller, n)
+	if adjs == "" {
+		adjs = "none"
+	}
+	props := "unknown"
+	if fp, ok := inlheur.FuncPropsFor(callee); ok {
+		props = fp.Summary()
+	}
+	fmt.Printf("%v: inline heuristics for call to %v: cost %d score %d threshold %d adjustments %s properties %s\n",
+		ir.Line(n), callee, callee.Inl.Cost, score, maxCost, adjs, props)
 }
 
 // If n is a OCALLFUNC node, and fn is an ONAME node for a
@@ -1016,14 +1339,43 @@ func mkinlcall(callerfn *ir.Func, n *ir.CallExpr, fn *ir.Func, bigCaller bool, i
 			logopt.LogOpt(n.Pos(), "cannotInlineCall", "inline", ir.FuncName(callerfn),
 				fmt.Sprintf("%s cannot be inlined", ir.PkgFuncName(fn)))
 		}
+		if recordingInlDecisions() {
+			recordInlDecision(callerfn, n, fn, notInlinableReason(fn), -1, -1)
+		}
+		if base.Debug.InlBudgetWhatIf != "" {
+			recordWhatIfCall(n, fn, -1, baseMaxCost(bigCaller))
+		}
 		return n
 	}
 
-	if ok, maxCost := inlineCostOK(n, callerfn, fn, bigCaller); !ok {
+	ok, maxCost, score := inlineCostOK(n, callerfn, fn, bigCaller)
+	if base.Debug.InlHeuristics != 0 {
+		inlheur.RecordCallSiteDecision(callerfn, n, ok, maxCost)
+	}
+	if base.Flag.LowerM > 2 && base.Debug.InlHeuristics != 0 {
+		printCallSiteHeuristics(callerfn, n, fn, score, maxCost)
+	}
+	if !ok {
 		if logopt.Enabled() {
 			logopt.LogOpt(n.Pos(), "cannotInlineCall", "inline", ir.FuncName(callerfn),
 				fmt.Sprintf("cost %d of %s exceeds max caller cost %d", fn.Inl.Cost, ir.PkgFuncName(fn), maxCost))
 		}
+		if recordingInlDecisions() {
+			reason := "too expensive"
+			if bigCaller {
+				reason = "too expensive for big caller"
+			}
+			if _, ok := replayedInlDecision(n, fn); ok {
+				reason = "not inlined in replayed decisions"
+			}
+			recordInlDecision(callerfn, n, fn, reason, score, maxCost)
+		}
+		if base.Debug.InlBudgetWhatIf != "" {
+			recordWhatIfCall(n, fn, score, maxCost)
+		}
+		if recordingHeurOutcomes() {
+			recordHeurOutcome(callerfn, n, fn, false, score, maxCost, bigCaller)
+		}
 		return n
 	}
 
@@ -1032,6 +1384,9 @@ func mkinlcall(callerfn *ir.Func, n *ir.CallExpr, fn *ir.Func, bigCaller bool, i
 		if logopt.Enabled() {
 			logopt.LogOpt(n.Pos(), "cannotInlineCall", "inline", fmt.Sprintf("recursive call to %s", ir.FuncName(callerfn)))
 		}
+		if recordingInlDecisions() {
+			recordInlDecision(callerfn, n, fn, "recursive call", score, maxCost)
+		}
 		return n
 	}
 
@@ -1042,9 +1397,15 @@ func mkinlcall(callerfn *ir.Func, n *ir.CallExpr, fn *ir.Func, bigCaller bool, i
 		// we disable inlining of runtime functions when instrumenting.
 		// The example that we observed is inlining of LockOSThread,
 		// which lead to false race reports on m contents.
+		if recordingInlDecisions() {
+			recordInlDecision(callerfn, n, fn, "callee not instrumented", score, maxCost)
+		}
 		return n
 	}
 	if base.Flag.Race && types.IsNoRacePkg(fn.Sym().Pkg) {
+		if recordingInlDecisions() {
+			recordInlDecision(callerfn, n, fn, "callee not race instrumented", score, maxCost)
+		}
 		return n
 	}
 
@@ -1063,6 +1424,9 @@ func mkinlcall(callerfn *ir.Func, n *ir.CallExpr, fn *ir.Func, bigCaller bool, i
 			if base.Flag.LowerM > 1 {
 				fmt.Printf("%v: cannot inline %v into %v: repeated recursive cycle\n", ir.Line(n), fn, ir.FuncName(callerfn))
 			}
+			if recordingInlDecisions() {
+				recordInlDecision(callerfn, n, fn, "repeated recursive cycle", score, maxCost)
+			}
 			return n
 		}
 	}
@@ -1123,6 +1487,12 @@ func mkinlcall(callerfn *ir.Func, n *ir.CallExpr, fn *ir.Func, bigCaller bool, i
 	if base.Flag.LowerM != 0 {
 		fmt.Printf("%v: inlining call to %v\n", ir.Line(n), fn)
 	}
+	if recordingInlDecisions() {
+		recordInlDecision(callerfn, n, fn, "", score, maxCost)
+	}
+	if recordingHeurOutcomes() {
+		recordHeurOutcome(callerfn, n, fn, true, score, maxCost, bigCaller)
+	}
 	if base.Flag.LowerM > 2 {
 		fmt.Printf("%v: Before inlining: %+v\n", ir.Line(n), n)
 	}
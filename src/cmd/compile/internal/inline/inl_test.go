@@ -0,0 +1,41 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inline
+
+import (
+	"testing"
+
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/pgo"
+)
+
+func TestCostWithinBudget(t *testing.T) {
+	caller := new(ir.Func)
+	hot := pgo.CallSiteInfo{LineOffset: 3, Caller: caller}
+	cold := pgo.CallSiteInfo{LineOffset: 4, Caller: caller}
+	candHotEdgeMap[hot] = struct{}{}
+	defer delete(candHotEdgeMap, hot)
+
+	const maxCost = 80
+	testcases := []struct {
+		csi       pgo.CallSiteInfo
+		cost      int32
+		bigCaller bool
+		wantOK    bool
+		wantMax   int32
+	}{
+		{cold, 60, false, true, maxCost},
+		{cold, 100, false, false, maxCost},
+		{hot, 100, false, true, inlineHotMaxBudget},
+		{hot, inlineHotMaxBudget + 1, false, false, inlineHotMaxBudget},
+		{hot, 100, true, false, maxCost},
+	}
+	for k, tc := range testcases {
+		ok, max := costWithinBudget(tc.csi, tc.cost, maxCost, tc.bigCaller)
+		if ok != tc.wantOK || max != tc.wantMax {
+			t.Errorf("test %d: got (%v, %d), want (%v, %d)", k, ok, max, tc.wantOK, tc.wantMax)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inline
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/inline/inlheur"
+	"cmd/compile/internal/ir"
+	"cmd/internal/src"
+)
+
+// This file implements the "-d=inlcostbreakdown=F" report, which
+// shows how the inline cost of function F is made up. As the
+// hairyVisitor walks the body of F, it records the cost charged for
+// each IR node on its own (excluding its children), and CanInline
+// then prints the nodes in the order visited, which is essentially
+// source order, indented to show the tree structure. For example
+//
+//	inline cost breakdown for f at p.go:5:6:
+//	  p.go:6:4: AS 1
+//	  p.go:6:2:   DCL 1
+//	  p.go:6:2:     NAME s 1
+//	  ...
+//	  p.go:8:12:     CALLFUNC 5
+//	  p.go:8:12:       NAME leaf 1
+//	  p.go:8:12:       NAME v 1
+//	  p.go:10:2: RETURN 1
+//	  p.go:10:2:   NAME s 1
+//	  total cost 22 (budget 80)
+//	  inline heuristics score 22
+//
+// Names are shown at the position of the node that uses them. If F
+// is not inlinable, the reason is appended to the total. The last
+// line, which lists the adjustments applied to the score if there
+// are any, appears only with -d=inlheuristics, for functions that
+// are inlinable and whose properties have been computed. F is the
+// name of the function as it appears in the -m output, such as "f",
+// "T.m", "(*T).m" or "f.func1"; several names may be given,
+// separated by slashes. When the report is requested for a function,
+// the inliner computes its full cost rather than stopping once the
+// budget has been exceeded.
+
+// costBreakdown records the cost of each node visited by a
+// hairyVisitor.
+type costBreakdown struct {
+	entries []costEntry
+	// stack holds, for each node being visited, the index of its
+	// entry and the total cost of its children visited so far.
+	stack []costFrame
+}
+
+// costEntry records the cost charged for a single node.
+type costEntry struct {
+	pos   src.XPos
+	what  string
+	depth int
+	cost  int32
+}
+
+// costFrame is an entry in costBreakdown.stack.
+type costFrame struct {
+	entry    int
+	children int32
+}
+
+// wantCostBreakdown reports whether the -d=inlcostbreakdown report
+// was requested for 'fn'.
+func wantCostBreakdown(fn *ir.Func) bool {
+	if base.Debug.InlCostBreakdown == "" {
+		return false
+	}
+	name := ir.FuncName(fn)
+	for _, s := range strings.Split(base.Debug.InlCostBreakdown, "/") {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+// doNode is used in place of hairyVisitor.doNode when a breakdown is
+// being recorded. It records the cost of 'n' itself, that is, the
+// amount by which visiting it decreased the budget less the costs of
+// its children.
+func (cb *costBreakdown) doNode(v *hairyVisitor, n ir.Node) bool {
+	if n == nil {
+		return false
+	}
+	e := costEntry{pos: n.Pos(), what: strings.TrimPrefix(n.Op().String(), "O"), depth: len(cb.stack)}
+	if n, ok := n.(*ir.Name); ok {
+		// A name is shared by all its uses, and has the position
+		// of its declaration; show it at the position of its user.
+		if n.Sym() != nil {
+			e.what += " " + n.Sym().Name
+		}
+		if len(cb.stack) > 0 {
+			e.pos = cb.entries[cb.stack[len(cb.stack)-1].entry].pos
+		}
+	}
+	i := len(cb.entries)
+	cb.entries = append(cb.entries, e)
+	cb.stack = append(cb.stack, costFrame{entry: i})
+	before := v.budget
+	stop := v.doNode(n)
+	total := before - v.budget
+	top := len(cb.stack) - 1
+	cb.entries[i].cost = total - cb.stack[top].children
+	cb.stack = cb.stack[:top]
+	if top > 0 {
+		cb.stack[top-1].children += total
+	}
+	return stop
+}
+
+// write writes the breakdown of the cost of 'fn' to 'w'. Here 'cost'
+// is its total cost, 'budget' the budget it was checked against, and
+// 'reason' the reason it is not inlinable, if any.
+func (cb *costBreakdown) write(w io.Writer, fn *ir.Func, cost, budget int32, reason string) {
+	fmt.Fprintf(w, "inline cost breakdown for %v at %v:\n", ir.FuncName(fn), ir.Line(fn))
+	for _, e := range cb.entries {
+		fmt.Fprintf(w, "  %v: %s%s %d\n", base.FmtPos(e.pos),
+			strings.Repeat("  ", e.depth), e.what, e.cost)
+	}
+	fmt.Fprintf(w, "  total cost %d (budget %d)", cost, budget)
+	if reason != "" {
+		fmt.Fprintf(w, ": %s", reason)
+	}
+	fmt.Fprintln(w)
+	if reason != "" || base.Debug.InlHeuristics == 0 {
+		return
+	}
+	if score, adjs, ok := inlheur.FuncScoreAdjustments(fn, cost); ok {
+		fmt.Fprintf(w, "  inline heuristics score %d", score)
+		if len(adjs) != 0 {
+			fmt.Fprintf(w, ": %s", strings.Join(adjs, ","))
+		}
+		fmt.Fprintln(w)
+	}
+}
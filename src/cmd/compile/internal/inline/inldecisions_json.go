@@ -0,0 +1,121 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inline
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+
+	"cmd/compile/internal/base"
+)
+
+// This file implements the "-d=inldecisionsjson=file" output, which
+// records the same per-call-site decisions as the "-d=inlreasons"
+// report (see inlreasons.go), but as a JSON document intended for
+// consumption by editor tooling (such as gopls inlay hints) that
+// wants to annotate call sites as inlined or not. The document has
+// the form
+//
+//	{
+//	  "version": 1,
+//	  "package": "p",
+//	  "calls": [
+//	    {"file": "/src/p/foo.go", "line": 12, "col": 9,
+//	     "caller": "F", "callee": "p.g", "inlined": true,
+//	     "cost": 57, "score": 32, "threshold": 80,
+//	     "adjustments": "wrapperAdj|tailCallAdj"},
+//	    {"file": "/src/p/foo.go", "line": 19, "col": 3,
+//	     "caller": "F", "callee": "p.h", "inlined": false,
+//	     "reason": "too expensive", "cost": 97, "score": 97,
+//	     "threshold": 80}
+//	  ]
+//	}
+//
+// with the calls sorted by position. Calls within compiler-generated
+// functions, which have no source position, are omitted. "file" is an
+// absolute path, and "line" and "col" are 1-based. "reason" is
+// present only for calls that were not inlined, and "cost", "score"
+// and "threshold" only if the inliner got as far as considering the
+// callee's cost. The schema
+// is versioned: fields may be added without changing the version, but
+// any change to the meaning of an existing field, or its removal,
+// requires a new version.
+
+// inlDecisionsVersion is the version of the JSON schema written for
+// -d=inldecisionsjson.
+const inlDecisionsVersion = 1
+
+// jsonInlDecisions is the top-level JSON document written for
+// -d=inldecisionsjson.
+type jsonInlDecisions struct {
+	Version int               `json:"version"`
+	Package string            `json:"package"`
+	Calls   []jsonInlDecision `json:"calls"`
+}
+
+// jsonInlDecision is the JSON form of an inlDecision.
+type jsonInlDecision struct {
+	File        string `json:"file"`
+	Line        uint   `json:"line"`
+	Col         uint   `json:"col"`
+	Caller      string `json:"caller"`
+	Callee      string `json:"callee"`
+	Inlined     bool   `json:"inlined"`
+	Reason      string `json:"reason,omitempty"`
+	Cost        *int32 `json:"cost,omitempty"`
+	Score       *int32 `json:"score,omitempty"`
+	Threshold   *int32 `json:"threshold,omitempty"`
+	Adjustments string `json:"adjustments,omitempty"`
+}
+
+// writeInlDecisionsJSON writes the decisions recorded so far for
+// package 'pkg' to the file 'path' as JSON.
+func writeInlDecisionsJSON(path, pkg string) {
+	doc := jsonInlDecisions{
+		Version: inlDecisionsVersion,
+		Package: pkg,
+		Calls:   make([]jsonInlDecision, 0, len(inlDecisions)),
+	}
+	for _, d := range inlDecisions {
+		if d.file == "<autogenerated>" {
+			continue
+		}
+		jd := jsonInlDecision{
+			File:        d.file,
+			Line:        d.line,
+			Col:         d.col,
+			Caller:      d.caller,
+			Callee:      d.callee,
+			Inlined:     d.reason == "",
+			Reason:      d.reason,
+			Adjustments: d.adjustments,
+		}
+		if d.threshold >= 0 {
+			cost, score, threshold := d.cost, d.score, d.threshold
+			jd.Cost, jd.Score, jd.Threshold = &cost, &score, &threshold
+		}
+		doc.Calls = append(doc.Calls, jd)
+	}
+	calls := doc.Calls
+	sort.SliceStable(calls, func(i, j int) bool {
+		a, b := &calls[i], &calls[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Col < b.Col
+	})
+	data, err := json.MarshalIndent(&doc, "", "  ")
+	if err != nil {
+		base.Fatalf("encoding inline decisions: %v", err)
+	}
+	data = append(data, '\n')
+	if err := os.WriteFile(path, data, 0666); err != nil {
+		base.Fatalf("writing inline decisions file %q: %v", path, err)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inline
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/inline/inlheur"
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/types"
+)
+
+// This file implements the "-d=inlcallgraphdot=file" output, which
+// writes the package's call graph, as seen by the inliner, in
+// Graphviz DOT format, for example
+//
+//	digraph "p" {
+//	  node [shape=box];
+//	  n0 [label="p.F\nflags=FuncPropIsLeaf"];
+//	  n1 [label="p.g\nflags=FuncPropIsWrapper"];
+//	  n0 -> n1 [label="32/80", tooltip="foo.go:12:9"];
+//	  ...
+//	}
+//
+// There is a node for each function that makes or receives a direct
+// call considered by the inliner (see inlreasons.go), labeled with
+// its name and a summary of its inline heuristics properties (when
+// -d=inlheuristics is in effect), and an edge for each call site,
+// labeled with the call's inline score and threshold, and for calls
+// that were not inlined, the reason. Inlined calls are drawn solid
+// and calls that were not inlined dashed, so that chains of calls
+// that the inliner stopped short of stand out; functions from other
+// packages are drawn with a dashed outline. The graph can be rendered
+// with, for example, "dot -Tsvg".
+
+// writeInlCallGraphDOT writes the call graph formed by the decisions
+// recorded so far for package 'pkg' to the file 'path' in DOT format.
+func writeInlCallGraphDOT(path, pkg string) {
+	decisions := make([]*inlDecision, 0, len(inlDecisions))
+	for i := range inlDecisions {
+		if d := &inlDecisions[i]; d.file != "<autogenerated>" {
+			decisions = append(decisions, d)
+		}
+	}
+	sort.SliceStable(decisions, func(i, j int) bool {
+		a, b := decisions[i], decisions[j]
+		if a.file != b.file {
+			return a.file < b.file
+		}
+		if a.line != b.line {
+			return a.line < b.line
+		}
+		return a.col < b.col
+	})
+
+	f, err := os.Create(path)
+	if err != nil {
+		base.Fatalf("opening inline call graph file %q: %v", path, err)
+	}
+	w := bufio.NewWriter(f)
+	fmt.Fprintf(w, "digraph %s {\n", dotQuote(pkg))
+	fmt.Fprintf(w, "  node [shape=box];\n")
+
+	// Assign node IDs in order of first appearance, writing out each
+	// node as it is assigned.
+	ids := make(map[*ir.Func]string)
+	nodeID := func(fn *ir.Func) string {
+		if id, ok := ids[fn]; ok {
+			return id
+		}
+		id := fmt.Sprintf("n%d", len(ids))
+		ids[fn] = id
+		label := ir.PkgFuncName(fn)
+		if fp, ok := inlheur.FuncPropsFor(fn); ok {
+			label += "\n" + fp.Summary()
+		}
+		fmt.Fprintf(w, "  %s [label=%s", id, dotQuote(label))
+		if fn.Sym() != nil && fn.Sym().Pkg != types.LocalPkg {
+			fmt.Fprintf(w, ", style=dashed")
+		}
+		fmt.Fprintf(w, "];\n")
+		return id
+	}
+	for _, d := range decisions {
+		from, to := nodeID(d.callerFn), nodeID(d.calleeFn)
+		var label []string
+		if d.threshold >= 0 {
+			label = append(label, fmt.Sprintf("%d/%d", d.score, d.threshold))
+		}
+		if d.reason != "" {
+			label = append(label, d.reason)
+		}
+		tooltip := fmt.Sprintf("%s:%d:%d", filepath.Base(d.file), d.line, d.col)
+		fmt.Fprintf(w, "  %s -> %s [label=%s, tooltip=%s", from, to,
+			dotQuote(strings.Join(label, "\n")), dotQuote(tooltip))
+		if d.reason != "" {
+			fmt.Fprintf(w, ", style=dashed")
+		}
+		fmt.Fprintf(w, "];\n")
+	}
+	fmt.Fprintf(w, "}\n")
+
+	if err := w.Flush(); err != nil {
+		base.Fatalf("writing inline call graph file %q: %v", path, err)
+	}
+	if err := f.Close(); err != nil {
+		base.Fatalf("closing inline call graph file %q: %v", path, err)
+	}
+}
+
+// dotQuote returns 's' as a DOT quoted string, with newlines written
+// as the "\n" escape (which DOT renders as a centered line break).
+func dotQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
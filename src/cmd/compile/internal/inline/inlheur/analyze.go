@@ -7,19 +7,27 @@ package inlheur
 import (
 	"cmd/compile/internal/base"
 	"cmd/compile/internal/ir"
+	"cmd/compile/internal/types"
 	"encoding/json"
 	"fmt"
 	"io"
-	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 )
 
 const (
 	debugTraceFuncs = 1 << iota
 	debugTraceFuncFlags
 	debugTraceResults
+	debugTraceScoring
+	debugTraceCalls
+	debugTraceParams
+	debugTraceNodes
 )
 
 // propAnalyzer interface is used for defining one or more analyzer
@@ -30,11 +38,31 @@ const (
 // there will be a sequence of calls to nodeVisitPre and nodeVisitPost
 // as the nodes within a function are visited, then a followup call to
 // setResults so that the analyzer can transfer its results into the
-// final properties object.
+// final properties object. The name method returns a short
+// descriptive name for the analyzer, for use in instrumentation.
 type propAnalyzer interface {
 	nodeVisitPre(n ir.Node)
 	nodeVisitPost(n ir.Node)
 	setResults(fp *FuncProps)
+	name() string
+}
+
+// analyzerObserver, if non-nil, is invoked by computeFuncProps after
+// each analyzer completes. See SetAnalyzerObserver.
+var analyzerObserver func(name string, fn *ir.Func, dur time.Duration)
+
+// SetAnalyzerObserver installs 'obs' as a callback to be invoked
+// after each property analyzer has finished with a function, passing
+// the name of the analyzer, the function analyzed, and the time
+// spent by the analyzer (including its walk over the function
+// body). Passing nil removes any previously installed observer.
+//
+// Note that when an observer is installed, each analyzer makes its
+// own separate walk over the function body (so as to be able to
+// attribute time to individual analyzers), which makes analysis
+// somewhat more expensive overall.
+func SetAnalyzerObserver(obs func(name string, fn *ir.Func, dur time.Duration)) {
+	analyzerObserver = obs
 }
 
 // fnInlHeur contains inline heuristics state information about
@@ -43,48 +71,377 @@ type fnInlHeur struct {
 	fname string
 	file  string
 	line  uint
+	col   uint
+	// idx is the number of entries for functions defined on the
+	// same line of the same file that were recorded before this one
+	// (see lineCounts).
+	idx   uint
 	props *FuncProps
+	fn    *ir.Func
+	cstab CallSiteTab
+	hints []specHint
+	// csites summarizes the call sites in cstab for the dump; unlike
+	// cstab, it is also populated for entries read back from a dump.
+	csites []callSiteInfo
+	// ninst is the number of instantiations collapsed into this
+	// entry, and 'disagree' the names of those whose properties
+	// differ from the first (see collapseInstantiations); 'ninst' is
+	// zero for entries that are not collapsed.
+	ninst    int
+	disagree []string
+	// pkg and buildID identify the package containing the function,
+	// for entries read back from an appended dump (see
+	// dump_append.go); they are empty otherwise.
+	pkg, buildID string
+	// version is the schema version of the dump that the entry was
+	// read back from (see dumpSchemaVersion), or zero for entries
+	// that were not read from a dump.
+	version int
 }
 
 // computeFuncProps examines the Go function 'fn' and computes for it
 // a function "properties" object, to be used to drive inlining
 // heuristics. See comments on the FuncProps type for more info.
+// Results are memoized (see propsCache).
 func computeFuncProps(fn *ir.Func, canInline func(*ir.Func)) *FuncProps {
-	enableDebugTraceIfEnv()
+	if fp, ok := lookupPropsCache(fn); ok {
+		return fp
+	}
+	fp := analyzeFunc(fn, canInline)
+	fp = applyPropsOverride(fn, fp)
+	addPropsCache(fn, fp)
+	return fp
+}
+
+// analyzeFunc runs the property analyzers over 'fn', returning the
+// resulting properties.
+func analyzeFunc(fn *ir.Func, canInline func(*ir.Func)) *FuncProps {
+	if hasExternalImpl(fn) {
+		return markerProps(fn, FuncPropExternalImpl)
+	}
+	if tooLargeToAnalyze(fn) {
+		return markerProps(fn, FuncPropTooLarge)
+	}
+	fp := runAnalyzers(fn, canInline)
+	if base.Debug.InlHeurVerify != 0 {
+		verifyFuncProps(fn, canInline, fp)
+	}
+	maybeDumpIR(fn, fp)
+	return fp
+}
+
+// hasExternalImpl returns true if function 'fn' is declared without a
+// body, meaning that it is implemented in assembly or pulled in from
+// elsewhere via //go:linkname.
+func hasExternalImpl(fn *ir.Func) bool {
+	return len(fn.Body) == 0
+}
+
+// tooLargeToAnalyze returns true if function 'fn' has more IR nodes
+// than the limit set with the "-d=inlheurmaxnodes" command line flag
+// (if any). The count stops as soon as the limit is exceeded, so the
+// check is cheap even for enormous functions.
+func tooLargeToAnalyze(fn *ir.Func) bool {
+	limit := base.Debug.InlHeurMaxNodes
+	if limit <= 0 {
+		return false
+	}
+	nodes := 0
+	return ir.Any(fn, func(ir.Node) bool {
+		nodes++
+		return nodes > limit
+	})
+}
+
+// markerProps returns the properties for a function 'fn' that the
+// analyzers are not run for, either because it has no body (see
+// hasExternalImpl) or because it is too large (see
+// tooLargeToAnalyze). The only property set is 'flag', which records
+// the reason; the param and result flags are all empty.
+func markerProps(fn *ir.Func, flag FuncPropBits) *FuncProps {
+	return &FuncProps{
+		Flags:       flag,
+		ParamFlags:  make([]ParamPropBits, len(fn.Type().RecvParams())),
+		ResultFlags: make([]ResultPropBits, len(fn.Type().Results())),
+	}
+}
+
+// runAnalyzers is a helper for analyzeFunc that does the work of
+// running the analyzers.
+func runAnalyzers(fn *ir.Func, canInline func(*ir.Func)) *FuncProps {
+	if analyzerObserver == nil && heurStats == nil {
+		b := NewFuncPropsBuilder(fn, canInline)
+		runAnalyzersOnFunction(fn, b.analyzers)
+		return b.Finish()
+	}
+	enableDebugTraceIfEnv(fn)
+	traceAnalysisStart(fn)
+	var allocs0, allocBytes0 uint64
+	if heurStats != nil {
+		heurStats.funcs++
+		allocs0, allocBytes0 = heapAllocs()
+	}
+	fp := new(FuncProps)
+	for _, a := range makeAnalyzers(fn, canInline) {
+		start := time.Now()
+		runAnalyzersOnFunction(fn, []propAnalyzer{a})
+		a.setResults(fp)
+		dur := time.Since(start)
+		if heurStats != nil {
+			heurStats.analyzerTime[a.name()] += dur
+			heurStats.analyzerCalls[a.name()]++
+		}
+		if analyzerObserver != nil {
+			analyzerObserver(a.name(), fn, dur)
+		}
+	}
+	if heurStats != nil {
+		allocs, allocBytes := heapAllocs()
+		heurStats.allocs += allocs - allocs0
+		heurStats.allocBytes += allocBytes - allocBytes0
+	}
+	disableDebugTrace()
+	return fp
+}
+
+// verifyFuncProps checks the properties 'fp' computed for 'fn' by
+// computing them again from scratch, with a fresh set of analyzers,
+// for the "-d=inlheurverify" command line flag. Any difference means
+// that some analyzer's results depend on something other than the
+// function being analyzed (such as map iteration order, or state
+// left over from an earlier function), and is reported as a fatal
+// error.
+func verifyFuncProps(fn *ir.Func, canInline func(*ir.Func), fp *FuncProps) {
+	b := NewFuncPropsBuilder(fn, canInline)
+	runAnalyzersOnFunction(fn, b.analyzers)
+	fp2 := b.Finish()
+	if !reflect.DeepEqual(fp, fp2) {
+		base.Fatalf("inline heuristics: properties for %v differ between runs:\nfirst:\n%+v\nsecond:\n%+v",
+			fn, *fp, *fp2)
+	}
+}
+
+// analyzerFactory creates a property analyzer for function 'fn',
+// where 'canInline' is a callback used to check the inlinability of
+// closures returned by 'fn'. A factory may return nil to indicate
+// that its analyzer shouldn't be run for 'fn' (for example, because
+// it is experimental and enabled only by a debug flag).
+type analyzerFactory func(fn *ir.Func, canInline func(*ir.Func)) propAnalyzer
+
+// registeredAnalyzer is an entry in the analyzer registry.
+type registeredAnalyzer struct {
+	priority int
+	factory  analyzerFactory
+}
+
+// analyzerRegistry holds the registered property analyzers, ordered
+// by priority (see registerAnalyzer).
+var analyzerRegistry []registeredAnalyzer
+
+// registerAnalyzer adds 'factory' to the set of property analyzer
+// factories consulted by makeAnalyzers. Analyzers are run (and have
+// their results transferred into the function's properties) in order
+// of increasing priority, with analyzers of equal priority running in
+// the order in which they were registered. Registration is expected
+// to happen during package initialization, before any functions are
+// analyzed.
+func registerAnalyzer(priority int, factory analyzerFactory) {
+	i := sort.Search(len(analyzerRegistry), func(i int) bool {
+		return analyzerRegistry[i].priority > priority
+	})
+	analyzerRegistry = slices.Insert(analyzerRegistry, i,
+		registeredAnalyzer{priority: priority, factory: factory})
+}
+
+func init() {
+	// Note: the loops, iter and concat analyzers must come after the
+	// params analyzer, since they add to the param flags that it
+	// computes.
+	registerAnalyzer(10, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer { return makeFuncFlagsAnalyzer(fn) })
+	registerAnalyzer(20, func(fn *ir.Func, canInline func(*ir.Func)) propAnalyzer { return makeResultsAnalyzer(fn, canInline) })
+	registerAnalyzer(30, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer { return makeShapeAnalyzer(fn) })
+	registerAnalyzer(40, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer { return makeParamDepAnalyzer(fn) })
+	registerAnalyzer(50, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer { return makeReturnCountAnalyzer(fn) })
+	registerAnalyzer(60, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer { return makeBlockingAnalyzer(fn) })
+	registerAnalyzer(70, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer { return makeParamsAnalyzer(fn) })
+	registerAnalyzer(80, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer { return makeCallArgsAnalyzer(fn) })
+	registerAnalyzer(90, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer { return makeUnsafeAnalyzer(fn) })
+	registerAnalyzer(95, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer { return makeLowLevelAnalyzer(fn) })
+	registerAnalyzer(100, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer { return makePurityAnalyzer(fn) })
+	registerAnalyzer(110, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer { return makeNodeCountAnalyzer(fn) })
+	registerAnalyzer(120, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer { return makeHotspotAnalyzer(fn) })
+	registerAnalyzer(130, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer { return makeLoopAnalyzer(fn) })
+	registerAnalyzer(135, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer { return makeIterAnalyzer(fn) })
+	registerAnalyzer(140, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer { return makeDeferAnalyzer(fn) })
+	registerAnalyzer(150, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer { return makeAllocAnalyzer(fn) })
+	registerAnalyzer(160, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer { return makeConcurrencyAnalyzer(fn) })
+	registerAnalyzer(170, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer { return makeConcatAnalyzer(fn) })
+	registerAnalyzer(175, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer { return makeRecvOnlyAnalyzer(fn) })
+}
+
+// makeAnalyzers returns the set of property analyzers to be run
+// over function 'fn', in priority order (see registerAnalyzer).
+func makeAnalyzers(fn *ir.Func, canInline func(*ir.Func)) []propAnalyzer {
+	analyzers := make([]propAnalyzer, 0, len(analyzerRegistry))
+	for _, r := range analyzerRegistry {
+		if a := r.factory(fn, canInline); a != nil {
+			analyzers = append(analyzers, a)
+		}
+	}
+	return analyzers
+}
+
+func traceAnalysisStart(fn *ir.Func) {
 	if debugTrace&debugTraceFuncs != 0 {
-		fmt.Fprintf(os.Stderr, "=-= starting analysis of func %v:\n%+v\n",
-			fn.Sym().Name, fn)
+		traceEvent("funcstart", "func", fn.Sym().Name,
+			"ir", fmt.Sprintf("%+v", fn))
+	}
+}
+
+// FuncPropsBuilder computes function properties incrementally, for
+// use by clients that construct the IR for a function piecemeal and
+// want to feed nodes to the property analyzers as they go, rather
+// than analyzing the complete function afterwards. The client calls
+// VisitPre and VisitPost for each node in the function, in the same
+// order as a depth-first walk of the function (starting with the
+// function itself) would, then calls Finish to obtain the resulting
+// properties. Note that some analyzers examine the function body as
+// a whole when computing their results, so the body must be complete
+// by the time Finish is called.
+type FuncPropsBuilder struct {
+	fn        *ir.Func
+	analyzers []propAnalyzer
+	done      bool
+}
+
+// NewFuncPropsBuilder returns a new builder for computing the
+// properties of 'fn'. Here 'canInline' is a callback used to check
+// the inlinability of closures returned by 'fn'.
+func NewFuncPropsBuilder(fn *ir.Func, canInline func(*ir.Func)) *FuncPropsBuilder {
+	enableDebugTraceIfEnv(fn)
+	traceAnalysisStart(fn)
+	return &FuncPropsBuilder{
+		fn:        fn,
+		analyzers: makeAnalyzers(fn, canInline),
+	}
+}
+
+// VisitPre passes node 'n' to the analyzers on the way down the walk
+// (that is, before any of its children).
+func (b *FuncPropsBuilder) VisitPre(n ir.Node) {
+	for _, a := range b.analyzers {
+		a.nodeVisitPre(n)
+	}
+}
+
+// VisitPost passes node 'n' to the analyzers on the way back up the
+// walk (that is, after all of its children).
+func (b *FuncPropsBuilder) VisitPost(n ir.Node) {
+	for _, a := range b.analyzers {
+		a.nodeVisitPost(n)
+	}
+}
+
+// Finish collects and returns the results from the analyzers. No
+// further calls to VisitPre or VisitPost may be made afterwards.
+func (b *FuncPropsBuilder) Finish() *FuncProps {
+	if b.done {
+		base.Fatalf("FuncPropsBuilder for %v finished twice", b.fn)
+	}
+	b.done = true
+	if hasExternalImpl(b.fn) {
+		disableDebugTrace()
+		return markerProps(b.fn, FuncPropExternalImpl)
+	}
+	if tooLargeToAnalyze(b.fn) {
+		disableDebugTrace()
+		return markerProps(b.fn, FuncPropTooLarge)
 	}
-	ra := makeResultsAnalyzer(fn, canInline)
-	ffa := makeFuncFlagsAnalyzer(fn)
-	analyzers := []propAnalyzer{ffa, ra}
 	fp := new(FuncProps)
-	runAnalyzersOnFunction(fn, analyzers)
-	for _, a := range analyzers {
+	for _, a := range b.analyzers {
 		a.setResults(fp)
 	}
 	disableDebugTrace()
 	return fp
 }
 
+// computeFuncPropsIncremental is equivalent to computeFuncProps, but
+// feeds the nodes of 'fn' to a FuncPropsBuilder one top-level
+// statement at a time, in the manner of a client building up the IR
+// for the function incrementally. Used for unit testing (see the
+// "-d=dumpinlpropsincr" command line flag).
+func computeFuncPropsIncremental(fn *ir.Func, canInline func(*ir.Func)) *FuncProps {
+	b := NewFuncPropsBuilder(fn, canInline)
+	var visit func(n ir.Node) bool
+	visit = func(n ir.Node) bool {
+		b.VisitPre(n)
+		ir.DoChildren(n, visit)
+		b.VisitPost(n)
+		return false
+	}
+	b.VisitPre(fn)
+	for _, stmt := range fn.Body {
+		visit(stmt)
+	}
+	b.VisitPost(fn)
+	return b.Finish()
+}
+
+// AnalyzeFunc computes function properties for 'fn' and records them
+// for use in subsequent scoring (see GetCallSiteScore). Here
+// 'canInline' is a callback used to check the inlinability of
+// closures returned by 'fn'.
+func AnalyzeFunc(fn *ir.Func, canInline func(*ir.Func)) {
+	if funcPropsTab == nil {
+		funcPropsTab = make(map[*ir.Func]*FuncProps)
+	}
+	if _, ok := funcPropsTab[fn]; ok {
+		return
+	}
+	fp := computeFuncProps(fn, canInline)
+	funcPropsTab[fn] = fp
+	logFuncProps(fn, fp)
+	if fn.Inl != nil {
+		// Record the properties in the export data as well, so
+		// that they're available to importing packages.
+		fn.Inl.Properties = fp.SerializeToString()
+	}
+}
+
+// runAnalyzersOnFunction walks 'fn', passing each node to the
+// analyzers in 'analyzers'. When node tracing (debugTraceNodes) is
+// enabled, a "pre" and a "post" trace event is emitted for each node,
+// indented according to its depth in the walk (see traceNode).
 func runAnalyzersOnFunction(fn *ir.Func, analyzers []propAnalyzer) {
+	depth := 0
 	var doNode func(ir.Node) bool
 	doNode = func(n ir.Node) bool {
+		if heurStats != nil {
+			heurStats.nodeVisits += int64(len(analyzers))
+		}
+		if debugTrace&debugTraceNodes != 0 {
+			traceNode("pre", depth, n)
+		}
 		for _, a := range analyzers {
 			a.nodeVisitPre(n)
 		}
+		depth++
 		ir.DoChildren(n, doNode)
+		depth--
 		for _, a := range analyzers {
 			a.nodeVisitPost(n)
 		}
+		if debugTrace&debugTraceNodes != 0 {
+			traceNode("post", depth, n)
+		}
 		return false
 	}
 	doNode(fn)
 }
 
-func fnFileLine(fn *ir.Func) (string, uint) {
+func fnFileLine(fn *ir.Func) (string, uint, uint) {
 	p := base.Ctxt.InnermostPos(fn.Pos())
-	return filepath.Base(p.Filename()), p.Line()
+	return filepath.Base(p.Filename()), p.Line(), p.Col()
 }
 
 func UnitTesting() bool {
@@ -94,8 +451,13 @@ func UnitTesting() bool {
 // DumpFuncProps computes and caches function properties for the func
 // 'fn', or if fn is nil, writes out the cached set of properties to
 // the file given in 'dumpfile'. Used for the "-d=dumpinlfuncprops=..."
-// command line flag, intended for use primarily in unit testing.
+// command line flag, intended for use primarily in unit testing. The
+// 'dumpfile' spec may take the form "file:pattern", in which case
+// only functions whose names match the regular expression 'pattern'
+// are captured, and 'file' may list several destinations, including
+// "-" for the standard output (see dump_sink.go).
 func DumpFuncProps(fn *ir.Func, dumpfile string, canInline func(*ir.Func)) {
+	dumpfile = parseDumpSpec(dumpfile)
 	if fn != nil {
 		captureFuncDumpEntry(fn, canInline)
 	} else {
@@ -103,65 +465,372 @@ func DumpFuncProps(fn *ir.Func, dumpfile string, canInline func(*ir.Func)) {
 	}
 }
 
-// emitDumpToFile writes out the buffer function property dump entries
-// to a file, for unit testing. Dump entries need to be sorted by
-// definition line, and due to generics we need to account for the
-// possibility that several ir.Func's will have the same def line.
+// StreamFuncPropsDump switches the function properties dump into
+// "streaming" mode, in which dump entries are written out to a
+// temporary file as they are completed (see FlushFuncPropsDump),
+// as opposed to being held in memory until the end of the
+// compilation. The entries are merged into the dump file, in the
+// usual order, when the dump is finalized.
+func StreamFuncPropsDump() {
+	if base.Debug.DumpInlPropsCollapse != 0 {
+		base.Fatalf("-d=dumpinlpropscollapse is not supported with -d=dumpinlpropsstream")
+	}
+	parseDumpSpec(base.Debug.DumpInlFuncProps)
+	dumpSpill = newSpillWriter()
+}
+
+// emitDumpToFile writes out the function property dump entries to
+// the destinations given by 'dumpfile' (see dump_sink.go), for unit
+// testing, then closes them. Entries are written grouped by source
+// file, with files in the order in which they were first seen, and
+// entries in the order in which they were recorded (in streaming
+// mode, all of the entries will have been spilled by this point).
 func emitDumpToFile(dumpfile string) {
-	outf, err := os.OpenFile(dumpfile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	outf := openDumpOut(dumpfile)
+	initDumpBuffer()
+	scoreDumpCallSites()
+	if dumpSpill != nil {
+		FlushFuncPropsDump(dumpfile)
+		if err := dumpSpill.finish(outf); err != nil {
+			base.Fatalf("function props dump: %v\n", err)
+		}
+	}
+	entries := dumpBuffer.entries
+	if dumpSpill == nil {
+		// Specialization hints require information about all of the
+		// call sites in the package, so they are only available if
+		// we're not streaming.
+		hints := computeSpecHints(entries, dumpFuncValues)
+		for i := range entries {
+			entries[i].hints = hints[entries[i].fn]
+		}
+	}
+	for _, sl := range groupByFile(entries, func(e *fnInlHeur) string { return e.file }) {
+		atline := dumpBuffer.atline
+		if base.Debug.DumpInlPropsCollapse != 0 {
+			sl, atline = collapseInstantiations(sl)
+		}
+		writeDumpGroup(outf, sl, atline)
+	}
+	if dumpJSON {
+		dumpJSONPostamble(outf)
+	}
+	if err := outf.Close(); err != nil {
+		base.Fatalf("closing function props dump %q: %v\n", dumpfile, err)
+	}
+	dumpOut = nil
+	dumpBuffer = nil
+	dumpSpill = nil
+	dumpFuncValues = nil
+	dumpJSONCount = 0
+}
+
+// openDumpOut returns the output for the function properties dump,
+// opening it (and writing out the file preamble) on first use.
+func openDumpOut(dumpfile string) *dumpSink {
+	if dumpOut != nil {
+		return dumpOut
+	}
+	appending := base.Debug.DumpInlPropsAppend != 0
+	if appending && (dumpJSON || dumpCSV) {
+		base.Fatalf("-d=dumpinlpropsappend is not supported for JSON or CSV dumps")
+	}
+	outf, err := openDumpSink(dumpfile, appending)
 	if err != nil {
-		base.Fatalf("opening function props dump file %q: %v\n", dumpfile, err)
+		base.Fatalf("opening function props dump %q: %v\n", dumpfile, err)
 	}
-	defer outf.Close()
-	dumpFilePreamble(outf)
+	switch {
+	case dumpJSON:
+		dumpJSONPreamble(outf)
+	case dumpCSV:
+		if err := dumpCSVPreamble(outf); err != nil {
+			base.Fatalf("function props dump: %v\n", err)
+		}
+	default:
+		if appending {
+			dumpPackagePreamble(outf)
+		}
+		dumpFilePreamble(outf)
+	}
+	dumpOut = outf
+	return outf
+}
 
-	atline := map[uint]uint{}
-	sl := make([]fnInlHeur, 0, len(dumpBuffer))
-	for _, e := range dumpBuffer {
-		sl = append(sl, e)
-		atline[e.line] = atline[e.line] + 1
+// writeDumpGroup writes out the function property dump entries in
+// 'sl', all of which originate from the same source file, in the
+// format selected by the dump spec. Here 'atline' counts the entries
+// defined on each line.
+func writeDumpGroup(w io.Writer, sl []fnInlHeur, atline lineCounts) {
+	var err error
+	switch {
+	case dumpJSON:
+		err = emitJSONDumpGroup(w, sl)
+	case dumpCSV:
+		err = emitCSVDumpGroup(w, sl)
+	default:
+		emitDumpGroup(w, sl, atline)
+	}
+	if err != nil {
+		base.Fatalf("function props dump: %v\n", err)
 	}
-	sl = sortFnInlHeurSlice(sl)
+}
 
-	prevline := uint(0)
-	for _, entry := range sl {
-		idx := uint(0)
-		if prevline == entry.line {
-			idx++
-		}
-		prevline = entry.line
-		atl := atline[entry.line]
-		if err := dumpFnPreamble(outf, &entry, idx, atl); err != nil {
+// emitDumpGroup writes out the function property dump entries in 'sl',
+// all of which originate from the same source file, where 'atline'
+// counts the entries defined on each line. Due to closures and
+// generics, several ir.Func's may share a definition line; the
+// function preambles record the number of them ("atl") and the
+// position of each among them ("idx").
+func emitDumpGroup(w io.Writer, sl []fnInlHeur, atline lineCounts) {
+	for i := range sl {
+		e := &sl[i]
+		if err := dumpFnPreamble(w, e, e.idx, atline.count(e.file, e.line)); err != nil {
 			base.Fatalf("function props dump: %v\n", err)
 		}
 	}
-	dumpBuffer = nil
+}
+
+// groupByFile splits 'sl' into groups of elements from the same source
+// file (as given by 'file'), with files in the order in which they
+// first appear in 'sl', preserving the order of the elements for
+// each file.
+func groupByFile[T any](sl []T, file func(*T) string) [][]T {
+	var groups [][]T
+	which := make(map[string]int)
+	for i := range sl {
+		f := file(&sl[i])
+		k, ok := which[f]
+		if !ok {
+			k = len(groups)
+			which[f] = k
+			groups = append(groups, nil)
+		}
+		groups[k] = append(groups[k], sl[i])
+	}
+	return groups
+}
+
+// lineCounts counts the function properties dump entries defined on
+// each line of each source file, so that the position of each entry
+// among those sharing its line is known as soon as it is recorded.
+type lineCounts map[dumpLine]uint
+
+// dumpLine identifies a line of a source file.
+type dumpLine struct {
+	file string
+	line uint
+}
+
+// add counts another entry defined on line 'line' of 'file', and
+// returns the number of entries counted for that line before it.
+func (lc lineCounts) add(file string, line uint) uint {
+	k := dumpLine{file, line}
+	n := lc[k]
+	lc[k] = n + 1
+	return n
+}
+
+// count returns the number of entries counted for line 'line' of
+// 'file'.
+func (lc lineCounts) count(file string, line uint) uint {
+	return lc[dumpLine{file, line}]
+}
+
+// skipDumpCapture returns true if function 'fn' should be excluded
+// from the function properties dump.
+func skipDumpCapture(fn *ir.Func) bool {
+	// avoid capturing compiler-generated funcs (by default, just
+	// the equality funcs).
+	if skipGeneratedInDump(fn) {
+		return true
+	}
+	if fn.Sym().IsBlank() {
+		// Functions named "_" can't be told apart by symbol (see
+		// dumpBuf.index), nor referred to.
+		return true
+	}
+	name := fn.Sym().Name
+	if dumpNames != nil {
+		return !dumpNameMatch(name)
+	}
+	return dumpFilter != nil && !dumpFilter.MatchString(name)
+}
+
+// dumpNameMatch reports whether the function named 'name' is one of
+// those listed in 'dumpNames', or a closure within one of them.
+func dumpNameMatch(name string) bool {
+	for {
+		if dumpNames[name] {
+			return true
+		}
+		i := strings.LastIndex(name, ".func")
+		if i < 0 {
+			return false
+		}
+		name = name[:i]
+	}
+}
+
+// parseDumpSpec parses the function properties dump spec 'spec',
+// which is either a file name or "file:pattern", where 'pattern' is
+// a regular expression used to select the functions to be captured.
+// The pattern (if any) is compiled into 'dumpFilter' on first use.
+// Alternatively, the pattern may be a slash-separated list of
+// function names, as in "file:FuncA/FuncB" (commas can't be used,
+// since they separate -d options), which selects exactly the named
+// functions and their closures; the names are recorded in
+// 'dumpNames'.
+// Either form may be followed by ":json" to request that the dump be
+// written as a single JSON document (see dump_json.go), which sets
+// 'dumpJSON', or by ":csv" to request CSV output (see dump_csv.go),
+// which sets 'dumpCSV'; to filter on the pattern "json" or "csv"
+// itself, write it as "file:(json)". Returns the name of the dump
+// file, which may be a list of destinations (see dump_sink.go).
+func parseDumpSpec(spec string) string {
+	file, pat := spec, ""
+	// Skip over a Windows drive letter, if present.
+	start := 0
+	if len(spec) >= 2 && spec[1] == ':' {
+		start = 2
+	}
+	if i := strings.Index(spec[start:], ":"); i >= 0 {
+		file, pat = spec[:start+i], spec[start+i+1:]
+	}
+	if pat == "json" {
+		pat, dumpJSON = "", true
+	} else if p, ok := strings.CutSuffix(pat, ":json"); ok {
+		pat, dumpJSON = p, true
+	} else if pat == "csv" {
+		pat, dumpCSV = "", true
+	} else if p, ok := strings.CutSuffix(pat, ":csv"); ok {
+		pat, dumpCSV = p, true
+	}
+	if strings.Contains(pat, "/") {
+		if dumpNames == nil {
+			dumpNames = make(map[string]bool)
+			for _, name := range strings.Split(pat, "/") {
+				if name != "" {
+					dumpNames[name] = true
+				}
+			}
+		}
+		return file
+	}
+	if pat != "" && dumpFilter == nil {
+		re, err := regexp.Compile(pat)
+		if err != nil {
+			base.Fatalf("invalid function name pattern %q in -d=dumpinlfuncprops=%s: %v", pat, spec, err)
+		}
+		dumpFilter = re
+	}
+	return file
 }
 
 // captureFuncDumpEntry analyzes function 'fn' and adds a entry
 // for it to 'dumpBuffer'. Used for unit testing.
 func captureFuncDumpEntry(fn *ir.Func, canInline func(*ir.Func)) {
-	// avoid capturing compiler-generated equality funcs.
-	if strings.HasPrefix(fn.Sym().Name, ".eq.") {
+	if skipDumpCapture(fn) {
 		return
 	}
-	if dumpBuffer == nil {
-		dumpBuffer = make(map[*ir.Func]fnInlHeur)
-	}
-	if _, ok := dumpBuffer[fn]; ok {
+	initDumpBuffer()
+	if dumpBuffer.captured(fn) {
 		// we can wind up seeing closures multiple times here,
 		// so don't add them more than once.
 		return
 	}
-	fp := computeFuncProps(fn, canInline)
-	file, line := fnFileLine(fn)
+	dumpBuffer.note(fn)
+	var fp *FuncProps
+	if base.Debug.DumpInlPropsIncr != 0 {
+		fp = computeFuncPropsIncremental(fn, canInline)
+	} else {
+		fp = computeFuncProps(fn, canInline)
+	}
+	recordFuncDumpEntry(fn, fp)
+}
+
+// initDumpBuffer sets up the state used to capture dump entries, if
+// not already done.
+func initDumpBuffer() {
+	if dumpBuffer == nil {
+		dumpBuffer = &dumpBuf{
+			index:  make(map[*types.Sym]int),
+			atline: make(lineCounts),
+		}
+		dumpFuncValues = make(map[*ir.Func]bool)
+	}
+}
+
+// recordFuncDumpEntry adds a dump entry for function 'fn' with
+// properties 'fp' to 'dumpBuffer', along with the call site table for
+// 'fn'.
+func recordFuncDumpEntry(fn *ir.Func, fp *FuncProps) {
+	dumpBuffer.note(fn)
+	cstab, fvals := computeCallSiteTable(fn)
+	for f := range fvals {
+		dumpFuncValues[f] = true
+	}
+	// Record the properties, so that they're available to the
+	// analysis of callers of 'fn' (see propsForFunc).
+	if funcPropsTab == nil {
+		funcPropsTab = make(map[*ir.Func]*FuncProps)
+	}
+	if _, ok := funcPropsTab[fn]; !ok {
+		funcPropsTab[fn] = fp
+	}
+	file, line, col := fnFileLine(fn)
 	entry := fnInlHeur{
 		fname: fn.Sym().Name,
 		file:  file,
 		line:  line,
+		col:   col,
+		idx:   dumpBuffer.atline.add(file, line),
 		props: fp,
+		fn:    fn,
+		cstab: cstab,
+
+		csites: callSiteInfos(cstab),
+	}
+	dumpBuffer.entries = append(dumpBuffer.entries, entry)
+	if heurStats != nil {
+		heurStats.noteDumpEntry()
 	}
-	dumpBuffer[fn] = entry
+}
+
+// FlushFuncPropsDump scores the call sites of the function
+// properties dump entries recorded since the last flush, then spills
+// the buffered entries to a temporary file, when the dump is being
+// streamed (see StreamFuncPropsDump), and frees up the memory used by
+// them. The inliner calls this after each batch of functions has been
+// analyzed, since the properties of the functions in a batch can
+// still be refined up to that point (see PropagateNeverReturns).
+func FlushFuncPropsDump(dumpfile string) {
+	scoreDumpCallSites()
+	if dumpSpill == nil || dumpBuffer == nil || len(dumpBuffer.entries) == 0 {
+		return
+	}
+	for i := range dumpBuffer.entries {
+		if err := dumpSpill.add(&dumpBuffer.entries[i]); err != nil {
+			base.Fatalf("function props dump: %v\n", err)
+		}
+	}
+	dumpBuffer.entries = nil
+	dumpBuffer.scored = 0
+}
+
+// scoreDumpCallSites scores the call sites of the dump entries
+// recorded since the last call (see scoreCallSiteInfos). The inliner
+// flushes the dump (see FlushFuncPropsDump) once the functions in
+// each strongly connected component have been analyzed, but before
+// any calls are inlined, so the scores are computed at that point.
+func scoreDumpCallSites() {
+	if dumpBuffer == nil {
+		return
+	}
+	for i := dumpBuffer.scored; i < len(dumpBuffer.entries); i++ {
+		e := &dumpBuffer.entries[i]
+		scoreCallSiteInfos(e.csites, e.cstab)
+	}
+	dumpBuffer.scored = len(dumpBuffer.entries)
 }
 
 // dumpFilePreamble writes out a file-level preamble for a given
@@ -170,6 +839,7 @@ func dumpFilePreamble(w io.Writer) {
 	fmt.Fprintf(w, "// DO NOT EDIT (use 'go test -v -update-expected' instead.)\n")
 	fmt.Fprintf(w, "// See cmd/compile/internal/inline/inlheur/testdata/props/README.txt\n")
 	fmt.Fprintf(w, "// for more information on the format of this file.\n")
+	fmt.Fprintf(w, "// %s %d\n", schemaVersionTag, dumpSchemaVersion)
 	fmt.Fprintf(w, "// %s\n", preambleDelimiter)
 }
 
@@ -178,36 +848,159 @@ func dumpFilePreamble(w io.Writer) {
 // README.txt file in testdata/props for more on the format of
 // this preamble.
 func dumpFnPreamble(w io.Writer, fih *fnInlHeur, idx, atl uint) error {
-	fmt.Fprintf(w, "// %s %s %d %d %d\n",
-		fih.file, fih.fname, fih.line, idx, atl)
-	// emit props as comments, followed by delimiter
-	fmt.Fprintf(w, "%s// %s\n", fih.props.ToString("// "), comDelimiter)
-	data, err := json.Marshal(fih.props)
+	dumpFnHeader(w, fih.file, fih.fname, fih.line, idx, atl, fih.col)
+	return dumpFnBody(w, fih)
+}
+
+// dumpFnHeader writes out the first line of a function-level preamble,
+// identifying the function.
+func dumpFnHeader(w io.Writer, file, fname string, line, idx, atl, col uint) {
+	fmt.Fprintf(w, "// %s %s %d %d %d %d\n", file, fname, line, idx, atl, col)
+}
+
+// dumpFnBody writes out the remainder of a function-level preamble
+// (everything following the header line) for 'fih'.
+func dumpFnBody(w io.Writer, fih *fnInlHeur) error {
+	// emit props, hints and call sites as comments, followed by
+	// delimiter
+	fmt.Fprintf(w, "%s%s%s%s// %s\n", fih.props.ToString("// "),
+		specHintsToString(fih.hints, "// "),
+		callSitesToString(fih.csites, "// "),
+		instantiationsToString(fih, "// "), comDelimiter)
+	var data []byte
+	var err error
+	if base.Debug.DumpInlPropsIndent != 0 {
+		data, err = json.MarshalIndent(fih.props, "", "  ")
+	} else {
+		data, err = json.Marshal(fih.props)
+	}
 	if err != nil {
 		return fmt.Errorf("marshall error %v\n", err)
 	}
-	fmt.Fprintf(w, "// %s\n// %s\n", string(data), fnDelimiter)
+	for _, line := range strings.Split(string(data), "\n") {
+		fmt.Fprintf(w, "// %s\n", line)
+	}
+	fmt.Fprintf(w, "// %s\n", fnDelimiter)
 	return nil
 }
 
-// sortFnInlHeurSlice sorts a slice of fnInlHeur based on
-// the starting line of the function definition, then by name.
-func sortFnInlHeurSlice(sl []fnInlHeur) []fnInlHeur {
-	sort.SliceStable(sl, func(i, j int) bool {
-		if sl[i].line != sl[j].line {
-			return sl[i].line < sl[j].line
-		}
-		return sl[i].fname < sl[j].fname
-	})
-	return sl
-}
-
 // delimiters written to various preambles to make parsing of
 // dumps easier.
 const preambleDelimiter = "<endfilepreamble>"
 const fnDelimiter = "<endfuncpreamble>"
 const comDelimiter = "<endpropsdump>"
 
-// dumpBuffer stores up function properties dumps when
-// "-d=dumpinlfuncprops=..." is in effect.
-var dumpBuffer map[*ir.Func]fnInlHeur
+// dumpSchemaVersion is the version of the function properties dump
+// format, which is recorded in the file preamble (see
+// dumpFilePreamble). It must be incremented whenever FuncProps
+// changes shape (a field is added, removed or renamed, or a flag
+// changes meaning), or the way the properties are written changes,
+// so that dumps written before the change (including the expected
+// results in testdata/props) are detected as stale rather than
+// quietly compared field by field. Dumps written before versions
+// were recorded are treated as version 1.
+const dumpSchemaVersion = 2
+
+// schemaVersionTag introduces the schema version in the preamble of
+// a dump.
+const schemaVersionTag = "schema version"
+
+// funcPropsTab stores the properties computed by AnalyzeFunc (or
+// when capturing a dump entry; see captureFuncDumpEntry).
+var funcPropsTab map[*ir.Func]*FuncProps
+
+// propsForFunc returns the properties previously computed for 'fn',
+// or nil if 'fn' hasn't been analyzed. Since functions are analyzed
+// in bottom-up order, the properties of a function's callees (other
+// than those in the same recursive cycle) are generally available
+// when the function itself is analyzed. For inlinable functions
+// imported from other packages, the properties are decoded from the
+// export data (see ir.Inline.Properties), if the exporting package
+// was compiled with the heuristics enabled.
+func propsForFunc(fn *ir.Func) *FuncProps {
+	funcPropsMu.Lock()
+	defer funcPropsMu.Unlock()
+	if fp, ok := funcPropsTab[fn]; ok {
+		return fp
+	}
+	if fn.Inl == nil || fn.Inl.Properties == "" {
+		return nil
+	}
+	if funcPropsTab == nil {
+		funcPropsTab = make(map[*ir.Func]*FuncProps)
+	}
+	fp := DeserializeFromString(fn.Inl.Properties)
+	funcPropsTab[fn] = fp
+	return fp
+}
+
+// FuncPropsFor returns the properties computed for 'fn' by the inline
+// heuristics, for use by other compiler phases (such as escape
+// analysis or devirtualization) that would otherwise need to
+// re-derive facts like whether 'fn' never returns or is pure. The
+// second result is false if no properties are available, which is
+// the case if the heuristics are disabled, if 'fn' hasn't been
+// analyzed yet, or if 'fn' is imported from a package compiled
+// without the heuristics. The returned properties are shared, and
+// must not be modified.
+func FuncPropsFor(fn *ir.Func) (*FuncProps, bool) {
+	fp := propsForFunc(fn)
+	return fp, fp != nil
+}
+
+// dumpBuf stores up function properties dump entries when
+// "-d=dumpinlfuncprops=..." is in effect. The entries are kept in the
+// order in which they were recorded, which is also the order in
+// which they are written out (grouped by source file).
+type dumpBuf struct {
+	entries []fnInlHeur
+	// index maps the symbol of each function captured for the dump
+	// to the position of its entry in the order in which entries
+	// were recorded, counting those that have been spilled.
+	index map[*types.Sym]int
+	// atline counts the entries recorded for each source line,
+	// including those that have been spilled.
+	atline lineCounts
+	// scored is the number of entries at the start of 'entries'
+	// whose call sites have been scored (see scoreDumpCallSites).
+	scored int
+}
+
+// captured reports whether 'fn' has already been captured for the
+// dump.
+func (b *dumpBuf) captured(fn *ir.Func) bool {
+	_, ok := b.index[fn.Sym()]
+	return ok
+}
+
+// note records that 'fn' has been captured for the dump, if not
+// already done.
+func (b *dumpBuf) note(fn *ir.Func) {
+	if _, ok := b.index[fn.Sym()]; !ok {
+		b.index[fn.Sym()] = len(b.index)
+	}
+}
+
+// dumpBuffer is the dump entry buffer, or nil if no entries have
+// been captured yet.
+var dumpBuffer *dumpBuf
+
+// dumpSpill is non-nil when the function properties dump is being
+// streamed (see StreamFuncPropsDump).
+var dumpSpill *spillWriter
+
+// dumpFuncValues records functions referenced as values (as opposed
+// to being called directly) by any of the functions captured for the
+// function properties dump.
+var dumpFuncValues map[*ir.Func]bool
+
+// dumpFilter, if non-nil, restricts the function properties dump to
+// functions whose names match (see parseDumpSpec).
+var dumpFilter *regexp.Regexp
+
+// dumpNames, if non-nil, restricts the function properties dump to
+// the named functions and their closures (see parseDumpSpec).
+var dumpNames map[string]bool
+
+// dumpOut is the function properties dump output, once opened.
+var dumpOut *dumpSink
@@ -0,0 +1,212 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"sync"
+)
+
+// This file contains support for computing the properties of a
+// batch of functions in parallel (see "-d=inlpropsworkers=N").
+//
+// The node walks that feed the property analyzers only read the IR,
+// so they can safely be run concurrently for different functions.
+// The remaining steps (collecting results via setResults, which may
+// call back into the inliner to check closures, and recording the
+// properties computed) are carried out serially, in a fixed order,
+// so that the results don't depend on goroutine scheduling.
+//
+// The one complication is that the analysis of a function can
+// consult the properties of its callees (see propsForFunc). When
+// analyzing serially, a function sees the properties of a callee in
+// the same batch only if the callee comes earlier in the batch. To
+// preserve this, the functions in the batch are partitioned into
+// "waves": a function is placed in a later wave than any of the
+// earlier callees it calls, and in no earlier a wave than any of
+// the earlier functions that call it. Properties are only recorded
+// at the end of each wave, so a function's walk sees exactly the
+// same set of callee properties as it would in a serial analysis.
+
+// funcPropsMu guards funcPropsTab against the lazy updates made by
+// propsForFunc when called from concurrent node walks.
+var funcPropsMu sync.Mutex
+
+// AnalyzeFuncs is the batch version of AnalyzeFunc: it computes and
+// records properties for each of the functions in 'fns', using up
+// to base.Debug.InlPropsWorkers goroutines for the node walks. The
+// functions should be given in the order in which they would be
+// passed to AnalyzeFunc. Here 'canInline' is a callback used to
+// check the inlinability of closures returned by the functions.
+func AnalyzeFuncs(fns []*ir.Func, canInline func(*ir.Func)) {
+	if funcPropsTab == nil {
+		funcPropsTab = make(map[*ir.Func]*FuncProps)
+	}
+	analyzed := func(fn *ir.Func) bool {
+		_, ok := funcPropsTab[fn]
+		return ok
+	}
+	analyzeBatch(fns, canInline, base.Debug.InlPropsWorkers, analyzed,
+		func(fn *ir.Func, fp *FuncProps) {
+			funcPropsTab[fn] = fp
+			logFuncProps(fn, fp)
+			if fn.Inl != nil {
+				fn.Inl.Properties = fp.SerializeToString()
+			}
+		})
+}
+
+// DumpFuncPropsBatch is the batch version of DumpFuncProps: it
+// computes properties for each of the functions in 'fns' (using up
+// to base.Debug.InlPropsWorkers goroutines) and captures dump
+// entries for them. Entries are added to the dump buffer in the
+// same order regardless of the number of workers.
+func DumpFuncPropsBatch(fns []*ir.Func, dumpfile string, canInline func(*ir.Func)) {
+	parseDumpSpec(dumpfile)
+	if base.Debug.DumpInlPropsIncr != 0 {
+		for _, fn := range fns {
+			captureFuncDumpEntry(fn, canInline)
+		}
+		return
+	}
+	initDumpBuffer()
+	captured := func(fn *ir.Func) bool {
+		return skipDumpCapture(fn) || dumpBuffer.captured(fn)
+	}
+	analyzeBatch(fns, canInline, base.Debug.InlPropsWorkers, captured,
+		func(fn *ir.Func, fp *FuncProps) {
+			recordFuncDumpEntry(fn, fp)
+		})
+}
+
+// analyzeBatch computes properties for the functions in 'fns' using
+// up to 'workers' goroutines, invoking 'record' for each function
+// and its properties; 'record' is expected to make the properties
+// available to propsForFunc. Functions for which 'done' returns
+// true are skipped, including those that were handled along the way
+// (via 'canInline') by the time their turn comes to be recorded.
+func analyzeBatch(fns []*ir.Func, canInline func(*ir.Func), workers int, done func(*ir.Func) bool, record func(*ir.Func, *FuncProps)) {
+	var todo []*ir.Func
+	for _, fn := range fns {
+		if done(fn) {
+			continue
+		}
+		if fp, ok := lookupPropsCache(fn); ok {
+			record(fn, fp)
+			continue
+		}
+		todo = append(todo, fn)
+	}
+	fns = todo
+
+	// Fall back to serial analysis when there's nothing to be
+	// gained from parallelism, and also when tracing, observing or
+	// timing the analyzers, so that their output is emitted in order.
+	enableDebugTraceIfEnv(nil)
+	serial := workers <= 1 || len(fns) <= 1 || debugTrace != 0 ||
+		analyzerObserver != nil || heurStats != nil
+	disableDebugTrace()
+	if serial {
+		for _, fn := range fns {
+			if !done(fn) {
+				fp := applyPropsOverride(fn, analyzeFunc(fn, canInline))
+				addPropsCache(fn, fp)
+				record(fn, fp)
+			}
+		}
+		return
+	}
+
+	builders := make([]*FuncPropsBuilder, len(fns))
+	for _, wave := range batchWaves(fns) {
+		work := make(chan int)
+		var wg sync.WaitGroup
+		for w := 0; w < workers && w < len(wave); w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range work {
+					b := &FuncPropsBuilder{
+						fn:        fns[i],
+						analyzers: makeAnalyzers(fns[i], canInline),
+					}
+					if !tooLargeToAnalyze(fns[i]) {
+						runAnalyzersOnFunction(fns[i], b.analyzers)
+					}
+					builders[i] = b
+				}
+			}()
+		}
+		for _, i := range wave {
+			work <- i
+		}
+		close(work)
+		wg.Wait()
+
+		for _, i := range wave {
+			fp := builders[i].Finish()
+			builders[i] = nil
+			if base.Debug.InlHeurVerify != 0 {
+				verifyFuncProps(fns[i], canInline, fp)
+			}
+			maybeDumpIR(fns[i], fp)
+			fp = applyPropsOverride(fns[i], fp)
+			if !done(fns[i]) {
+				addPropsCache(fns[i], fp)
+				record(fns[i], fp)
+			}
+		}
+	}
+}
+
+// batchWaves partitions the functions in 'fns' into waves of
+// functions that can be analyzed concurrently (see the comment at
+// the top of this file), returning the indices of the functions in
+// each wave in increasing order.
+func batchWaves(fns []*ir.Func) [][]int {
+	idx := make(map[*ir.Func]int, len(fns))
+	for i, fn := range fns {
+		idx[fn] = i
+	}
+	// For each function, collect the earlier functions in the batch
+	// that it calls, and the earlier functions that call it.
+	callees := make([][]int, len(fns))
+	callers := make([][]int, len(fns))
+	for i, fn := range fns {
+		ir.Visit(fn, func(n ir.Node) {
+			if n.Op() != ir.OCALLFUNC {
+				return
+			}
+			j, ok := idx[staticCallee(n.(*ir.CallExpr))]
+			switch {
+			case !ok || j == i:
+			case j < i:
+				callees[i] = append(callees[i], j)
+			default:
+				callers[j] = append(callers[j], i)
+			}
+		})
+	}
+	level := make([]int, len(fns))
+	var waves [][]int
+	for i := range fns {
+		for _, j := range callees[i] {
+			if level[j] >= level[i] {
+				level[i] = level[j] + 1
+			}
+		}
+		for _, k := range callers[i] {
+			if level[k] > level[i] {
+				level[i] = level[k]
+			}
+		}
+		if level[i] == len(waves) {
+			waves = append(waves, nil)
+		}
+		waves[level[i]] = append(waves[level[i]], i)
+	}
+	return waves
+}
@@ -0,0 +1,412 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/types"
+)
+
+// This file contains the call site analyzers (see callSiteAnalyzer)
+// that compute the CSPropBits flags and argument masks for the call
+// sites in a function.
+
+// callSetAnalyzer sets the flag 'flag' for the calls picked out by
+// 'find', which is applied to each node visited and returns the calls
+// (if any) that the node says something about, for example the call
+// whose result is returned by a return statement.
+type callSetAnalyzer struct {
+	aname string
+	flag  CSPropBits
+	find  func(n ir.Node) []*ir.CallExpr
+	calls map[*ir.CallExpr]bool
+}
+
+func makeCallSetAnalyzer(name string, flag CSPropBits, find func(n ir.Node) []*ir.CallExpr) *callSetAnalyzer {
+	return &callSetAnalyzer{
+		aname: name,
+		flag:  flag,
+		find:  find,
+		calls: make(map[*ir.CallExpr]bool),
+	}
+}
+
+// makeFuncCallSetAnalyzer is like makeCallSetAnalyzer, but applies
+// 'find' to all the nodes of 'fn' up front, for use when the node
+// that says something about a call comes after the call itself.
+func makeFuncCallSetAnalyzer(fn *ir.Func, name string, flag CSPropBits, find func(n ir.Node) []*ir.CallExpr) *callSetAnalyzer {
+	a := makeCallSetAnalyzer(name, flag, func(ir.Node) []*ir.CallExpr { return nil })
+	ir.Visit(fn, func(n ir.Node) {
+		for _, call := range find(n) {
+			a.calls[call] = true
+		}
+	})
+	return a
+}
+
+func (a *callSetAnalyzer) name() string {
+	return a.aname
+}
+
+func (a *callSetAnalyzer) nodeVisitPre(n ir.Node) {
+	for _, call := range a.find(n) {
+		a.calls[call] = true
+	}
+}
+
+func (a *callSetAnalyzer) nodeVisitPost(n ir.Node) {
+}
+
+func (a *callSetAnalyzer) setResults(call *ir.CallExpr, callee *ir.Func, csp *CallSiteProps) {
+	if a.calls[call] {
+		csp.Flags |= a.flag
+	}
+}
+
+// loopCallSiteAnalyzer flags calls made within loops
+// (CallSiteInLoop), by tracking the number of enclosing loops.
+type loopCallSiteAnalyzer struct {
+	depth int
+}
+
+func (a *loopCallSiteAnalyzer) name() string {
+	return "loop"
+}
+
+func (a *loopCallSiteAnalyzer) nodeVisitPre(n ir.Node) {
+	if n.Op() == ir.OFOR || n.Op() == ir.ORANGE {
+		a.depth++
+	}
+}
+
+func (a *loopCallSiteAnalyzer) nodeVisitPost(n ir.Node) {
+	if n.Op() == ir.OFOR || n.Op() == ir.ORANGE {
+		a.depth--
+	}
+}
+
+func (a *loopCallSiteAnalyzer) setResults(call *ir.CallExpr, callee *ir.Func, csp *CallSiteProps) {
+	if a.depth != 0 {
+		csp.Flags |= CallSiteInLoop
+	}
+}
+
+// selectCallSiteAnalyzer flags calls made within select statements
+// (CallSiteInSelect) and within event loops (CallSiteInEventLoop), by
+// tracking the number of enclosing selects and event loops.
+type selectCallSiteAnalyzer struct {
+	selects, eventLoops int
+}
+
+func (a *selectCallSiteAnalyzer) name() string {
+	return "select"
+}
+
+func (a *selectCallSiteAnalyzer) nodeVisitPre(n ir.Node) {
+	if n.Op() == ir.OSELECT {
+		a.selects++
+	}
+	if isEventLoop(n) {
+		a.eventLoops++
+	}
+}
+
+func (a *selectCallSiteAnalyzer) nodeVisitPost(n ir.Node) {
+	if n.Op() == ir.OSELECT {
+		a.selects--
+	}
+	if isEventLoop(n) {
+		a.eventLoops--
+	}
+}
+
+func (a *selectCallSiteAnalyzer) setResults(call *ir.CallExpr, callee *ir.Func, csp *CallSiteProps) {
+	if a.selects != 0 {
+		csp.Flags |= CallSiteInSelect
+	}
+	if a.eventLoops != 0 {
+		csp.Flags |= CallSiteInEventLoop
+	}
+}
+
+// isEventLoop returns true if 'n' is a "for" or "range" loop whose
+// body has a "select" statement among its top-level statements (see
+// CallSiteInEventLoop).
+func isEventLoop(n ir.Node) bool {
+	var body ir.Nodes
+	switch n.Op() {
+	case ir.OFOR:
+		body = n.(*ir.ForStmt).Body
+	case ir.ORANGE:
+		body = n.(*ir.RangeStmt).Body
+	default:
+		return false
+	}
+	for _, st := range body {
+		if st.Op() == ir.OSELECT {
+			return true
+		}
+	}
+	return false
+}
+
+// rangeLoop records the slice and index variable of a "for range"
+// loop enclosing the node currently being visited.
+type rangeLoop struct {
+	x, key *ir.Name
+}
+
+// rangeCallSiteAnalyzer computes the mask of args that are the slice
+// being ranged over by an enclosing loop (see CallSiteInRangeOverArg),
+// keeping a stack of the enclosing "for range" loops over slices.
+type rangeCallSiteAnalyzer struct {
+	ranges []rangeLoop
+}
+
+func (a *rangeCallSiteAnalyzer) name() string {
+	return "range"
+}
+
+func (a *rangeCallSiteAnalyzer) nodeVisitPre(n ir.Node) {
+	if rl, ok := sliceRangeLoop(n); ok {
+		a.ranges = append(a.ranges, rl)
+	}
+}
+
+func (a *rangeCallSiteAnalyzer) nodeVisitPost(n ir.Node) {
+	if _, ok := sliceRangeLoop(n); ok {
+		a.ranges = a.ranges[:len(a.ranges)-1]
+	}
+}
+
+func (a *rangeCallSiteAnalyzer) setResults(call *ir.CallExpr, callee *ir.Func, csp *CallSiteProps) {
+	if ranged := rangedArgs(call, a.ranges); ranged != 0 {
+		csp.Flags |= CallSiteInRangeOverArg
+		csp.RangedArgs = ranged
+	}
+}
+
+// panicPathAnalyzer flags calls made within a branch of a conditional
+// statement that ends in a panic (CallSiteOnPanicPath). When an "if"
+// statement or a case clause is visited, the statements in each of
+// its branches that ends in a panic are noted, so that the number of
+// enclosing panicking branches can be tracked as they are visited.
+// Note that there is no need to look for branches guarded by
+// constant conditions here, since the front end discards the dead
+// branch of such an "if" statement when constructing the IR.
+type panicPathAnalyzer struct {
+	panicStmts map[ir.Node]bool
+	depth      int
+}
+
+func (a *panicPathAnalyzer) name() string {
+	return "panicpath"
+}
+
+func (a *panicPathAnalyzer) nodeVisitPre(n ir.Node) {
+	if a.panicStmts[n] {
+		a.depth++
+	}
+	switch n := n.(type) {
+	case *ir.IfStmt:
+		a.noteBranch(n.Body)
+		a.noteBranch(n.Else)
+	case *ir.CaseClause:
+		a.noteBranch(n.Body)
+	case *ir.CommClause:
+		a.noteBranch(n.Body)
+	}
+}
+
+// noteBranch records the statements in 'list', which make up one
+// branch of a conditional statement, if the branch ends in a panic.
+func (a *panicPathAnalyzer) noteBranch(list ir.Nodes) {
+	if !endsInPanic(list) {
+		return
+	}
+	for _, n := range list {
+		a.panicStmts[n] = true
+	}
+}
+
+func (a *panicPathAnalyzer) nodeVisitPost(n ir.Node) {
+	if a.panicStmts[n] {
+		a.depth--
+	}
+}
+
+func (a *panicPathAnalyzer) setResults(call *ir.CallExpr, callee *ir.Func, csp *CallSiteProps) {
+	if a.depth != 0 {
+		csp.Flags |= CallSiteOnPanicPath
+	}
+}
+
+// errorPathAnalyzer flags calls made on error handling paths
+// (CallSiteOnErrorPath), namely the body of an "if err != nil"
+// statement (or the else branch of an "if err == nil"), and any
+// branch of a conditional statement that ends by returning a non-nil
+// error. It tracks the number of enclosing error handling branches in
+// the same way as panicPathAnalyzer.
+type errorPathAnalyzer struct {
+	errStmts map[ir.Node]bool
+	depth    int
+}
+
+func (a *errorPathAnalyzer) name() string {
+	return "errorpath"
+}
+
+func (a *errorPathAnalyzer) nodeVisitPre(n ir.Node) {
+	if a.errStmts[n] {
+		a.depth++
+	}
+	switch n := n.(type) {
+	case *ir.IfStmt:
+		errNonNil, errNil := errNilCheck(n.Cond)
+		a.noteBranch(n.Body, errNonNil)
+		a.noteBranch(n.Else, errNil)
+	case *ir.CaseClause:
+		a.noteBranch(n.Body, false)
+	case *ir.CommClause:
+		a.noteBranch(n.Body, false)
+	}
+}
+
+// noteBranch records the statements in 'list', which make up one
+// branch of a conditional statement, if the branch handles an error
+// (as indicated by 'isErr') or ends by returning one.
+func (a *errorPathAnalyzer) noteBranch(list ir.Nodes, isErr bool) {
+	if !isErr && !endsInErrorReturn(list) {
+		return
+	}
+	for _, n := range list {
+		a.errStmts[n] = true
+	}
+}
+
+func (a *errorPathAnalyzer) nodeVisitPost(n ir.Node) {
+	if a.errStmts[n] {
+		a.depth--
+	}
+}
+
+func (a *errorPathAnalyzer) setResults(call *ir.CallExpr, callee *ir.Func, csp *CallSiteProps) {
+	if a.depth != 0 {
+		csp.Flags |= CallSiteOnErrorPath
+	}
+}
+
+// errNilCheck reports whether 'cond' compares a value of type error
+// against nil, returning true in the first result for "err != nil"
+// and in the second for "err == nil".
+func errNilCheck(cond ir.Node) (nonNil, isNil bool) {
+	if cond.Op() != ir.ONE && cond.Op() != ir.OEQ {
+		return false, false
+	}
+	be := cond.(*ir.BinaryExpr)
+	x, y := be.X, be.Y
+	if x.Op() == ir.ONIL {
+		x, y = y, x
+	}
+	if y.Op() != ir.ONIL || !isErrorType(x.Type()) {
+		return false, false
+	}
+	return cond.Op() == ir.ONE, cond.Op() == ir.OEQ
+}
+
+// isErrorType reports whether 't' is the predeclared type error.
+func isErrorType(t *types.Type) bool {
+	return t != nil && types.Identical(t, types.ErrorType)
+}
+
+// noEscapeAnalyzer flags calls whose results don't escape the caller
+// (CallSiteResultNoEscape), as determined up front by noEscapeCalls.
+type noEscapeAnalyzer struct {
+	calls map[*ir.CallExpr]bool
+}
+
+func (a *noEscapeAnalyzer) name() string {
+	return "noescape"
+}
+
+func (a *noEscapeAnalyzer) nodeVisitPre(n ir.Node) {
+}
+
+func (a *noEscapeAnalyzer) nodeVisitPost(n ir.Node) {
+}
+
+func (a *noEscapeAnalyzer) setResults(call *ir.CallExpr, callee *ir.Func, csp *CallSiteProps) {
+	if a.calls[call] {
+		csp.Flags |= CallSiteResultNoEscape
+	}
+}
+
+// coldCallSiteAnalyzer flags calls on paths that run at most once
+// (CallSiteCold). Here 'cold' is set if the function being analyzed
+// runs at most once per process.
+type coldCallSiteAnalyzer struct {
+	cold bool
+}
+
+func (a *coldCallSiteAnalyzer) name() string {
+	return "cold"
+}
+
+func (a *coldCallSiteAnalyzer) nodeVisitPre(n ir.Node) {
+}
+
+func (a *coldCallSiteAnalyzer) nodeVisitPost(n ir.Node) {
+}
+
+func (a *coldCallSiteAnalyzer) setResults(call *ir.CallExpr, callee *ir.Func, csp *CallSiteProps) {
+	if a.cold || isFlagSetupCall(callee) {
+		csp.Flags |= CallSiteCold
+	}
+}
+
+// constArgsAnalyzer computes the mask of constant args for each call,
+// and flags the calls all of whose args are constant
+// (CallSiteAllArgsConst).
+type constArgsAnalyzer struct{}
+
+func (a *constArgsAnalyzer) name() string {
+	return "constargs"
+}
+
+func (a *constArgsAnalyzer) nodeVisitPre(n ir.Node) {
+}
+
+func (a *constArgsAnalyzer) nodeVisitPost(n ir.Node) {
+}
+
+func (a *constArgsAnalyzer) setResults(call *ir.CallExpr, callee *ir.Func, csp *CallSiteProps) {
+	csp.ConstArgs = constArgs(call)
+	if allArgsConst(call) {
+		csp.Flags |= CallSiteAllArgsConst
+	}
+}
+
+// funcLitArgAnalyzer flags the calls that pass a function literal
+// as an argument (CallSitePassesFuncLit).
+type funcLitArgAnalyzer struct{}
+
+func (a *funcLitArgAnalyzer) name() string {
+	return "funclitarg"
+}
+
+func (a *funcLitArgAnalyzer) nodeVisitPre(n ir.Node) {
+}
+
+func (a *funcLitArgAnalyzer) nodeVisitPost(n ir.Node) {
+}
+
+func (a *funcLitArgAnalyzer) setResults(call *ir.CallExpr, callee *ir.Func, csp *CallSiteProps) {
+	for _, arg := range call.Args {
+		if arg.Op() == ir.OCLOSURE {
+			csp.Flags |= CallSitePassesFuncLit
+			return
+		}
+	}
+}
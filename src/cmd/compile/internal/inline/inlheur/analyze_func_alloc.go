@@ -0,0 +1,84 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import "cmd/compile/internal/ir"
+
+// allocAnalyzer looks for operations within a function that
+// unconditionally allocate memory that may wind up on the heap: calls
+// to "new" and "make", "append" (which may need to grow its slice),
+// and composite literals whose address is taken (as in "&T{...}") or
+// that are slice or map literals. Since this analysis runs before
+// escape analysis, whether the memory really escapes isn't known;
+// the point is that if the allocation doesn't escape the caller once
+// the function is inlined, it can be moved to the stack.
+//
+// An allocation is unconditional if it is not within a loop, the
+// branch of an "if", "switch" or "select", or the right operand of
+// a "&&" or "||", and is not preceded by a statement that can leave
+// the function early (a return, goto or panic). Allocations within
+// nested function literals are not examined.
+type allocAnalyzer struct {
+	fn        *ir.Func
+	condDepth int
+	exited    bool
+	allocates bool
+}
+
+func makeAllocAnalyzer(fn *ir.Func) *allocAnalyzer {
+	return &allocAnalyzer{
+		fn: fn,
+	}
+}
+
+func (aa *allocAnalyzer) name() string {
+	return "alloc"
+}
+
+func (aa *allocAnalyzer) nodeVisitPre(n ir.Node) {
+	if isCondNode(n) {
+		aa.condDepth++
+	}
+	if aa.allocates || aa.exited || aa.condDepth != 0 {
+		return
+	}
+	switch n.Op() {
+	case ir.ONEW, ir.OMAKESLICE, ir.OMAKESLICECOPY, ir.OMAKEMAP,
+		ir.OMAKECHAN, ir.OAPPEND, ir.OPTRLIT, ir.OSLICELIT, ir.OMAPLIT:
+		aa.allocates = true
+	}
+}
+
+func (aa *allocAnalyzer) nodeVisitPost(n ir.Node) {
+	if isCondNode(n) {
+		aa.condDepth--
+	}
+	switch n.Op() {
+	case ir.ORETURN, ir.OTAILCALL, ir.OGOTO, ir.OPANIC:
+		aa.exited = true
+	}
+}
+
+// isCondNode reports whether the children of 'n' (or some of them)
+// are executed conditionally, or possibly more than once.
+func isCondNode(n ir.Node) bool {
+	switch n.Op() {
+	case ir.OIF, ir.OSWITCH, ir.OSELECT, ir.OFOR, ir.ORANGE,
+		ir.OANDAND, ir.OOROR:
+		return true
+	}
+	return false
+}
+
+// setResults transfers the allocation flag to 'fp'.
+func (aa *allocAnalyzer) setResults(fp *FuncProps) {
+	if debugTrace&debugTraceFuncFlags != 0 {
+		traceEvent("result", "analyzer", "alloc", "func", aa.fn.Sym().Name,
+			"allocates", aa.allocates)
+	}
+	if aa.allocates {
+		fp.Flags |= FuncPropAllocates
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import "cmd/compile/internal/ir"
+
+// blockingAnalyzer looks for operations within a function that may
+// block the calling goroutine: channel sends and receives, ranging
+// over a channel, "select" statements without a default case, and
+// calls to well-known blocking functions from the standard library.
+// Channel operations that appear as the cases of a "select" with a
+// default case are non-blocking and are not counted. Since the node
+// walk doesn't descend into closures, operations within nested
+// function literals are not counted either.
+type blockingAnalyzer struct {
+	fn          *ir.Func
+	mayBlock    bool
+	nonBlocking map[ir.Node]bool
+}
+
+func makeBlockingAnalyzer(fn *ir.Func) *blockingAnalyzer {
+	return &blockingAnalyzer{
+		fn:          fn,
+		nonBlocking: make(map[ir.Node]bool),
+	}
+}
+
+func (ba *blockingAnalyzer) name() string {
+	return "blocking"
+}
+
+func (ba *blockingAnalyzer) nodeVisitPre(n ir.Node) {
+	if ba.mayBlock || ba.nonBlocking[n] {
+		return
+	}
+	switch n.Op() {
+	case ir.OSEND, ir.ORECV:
+		ba.mayBlock = true
+	case ir.ORANGE:
+		if x := n.(*ir.RangeStmt).X; x.Type() != nil && x.Type().IsChan() {
+			ba.mayBlock = true
+		}
+	case ir.OSELECT:
+		sel := n.(*ir.SelectStmt)
+		if !hasDefaultCase(sel) {
+			ba.mayBlock = true
+			return
+		}
+		// The channel operations in the cases of this select
+		// won't block, so exempt them.
+		for _, op := range selectCommOps(sel) {
+			ba.nonBlocking[op] = true
+		}
+	case ir.OCALLFUNC:
+		if isCallTo(n.(*ir.CallExpr), blockingFuncs) {
+			ba.mayBlock = true
+		}
+	}
+}
+
+func (ba *blockingAnalyzer) nodeVisitPost(n ir.Node) {
+}
+
+// setResults transfers the "may block" flag to 'fp'.
+func (ba *blockingAnalyzer) setResults(fp *FuncProps) {
+	if debugTrace&debugTraceFuncFlags != 0 {
+		traceEvent("result", "analyzer", "blocking", "func", ba.fn.Sym().Name,
+			"mayblock", ba.mayBlock)
+	}
+	if ba.mayBlock {
+		fp.Flags |= FuncPropMayBlock
+	}
+}
+
+// hasDefaultCase reports whether 'sel' has a default case.
+func hasDefaultCase(sel *ir.SelectStmt) bool {
+	for _, cas := range sel.Cases {
+		if cas.Comm == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// selectCommOps returns the channel send and receive operations
+// appearing in the cases of 'sel'.
+func selectCommOps(sel *ir.SelectStmt) []ir.Node {
+	var rv []ir.Node
+	for _, cas := range sel.Cases {
+		if cas.Comm == nil {
+			continue
+		}
+		ir.Visit(cas.Comm, func(n ir.Node) {
+			if n.Op() == ir.OSEND || n.Op() == ir.ORECV {
+				rv = append(rv, n)
+			}
+		})
+	}
+	return rv
+}
+
+// blockingFuncs is the set of well-known standard library functions
+// and methods that may block the calling goroutine, keyed by package
+// path and then symbol name.
+var blockingFuncs = map[string]map[string]bool{
+	"sync": {
+		"(*Mutex).Lock":     true,
+		"(*RWMutex).Lock":   true,
+		"(*RWMutex).RLock":  true,
+		"(*WaitGroup).Wait": true,
+		"(*Cond).Wait":      true,
+		"(*Once).Do":        true,
+	},
+	"time": {
+		"Sleep": true,
+	},
+}
+
+// isCallTo reports whether 'call' is a direct call to one of the
+// functions in 'funcs', which is keyed by package path and then
+// symbol name (see blockingFuncs).
+func isCallTo(call *ir.CallExpr, funcs map[string]map[string]bool) bool {
+	var name *ir.Name
+	switch call.X.Op() {
+	case ir.ONAME:
+		name = call.X.(*ir.Name)
+		if name.Class != ir.PFUNC {
+			return false
+		}
+	case ir.OMETHEXPR:
+		name = ir.MethodExprName(call.X)
+	}
+	if name == nil || name.Sym() == nil {
+		return false
+	}
+	s := name.Sym()
+	return funcs[s.Pkg.Path][s.Name]
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import "cmd/compile/internal/ir"
+
+// callArgsAnalyzer counts the calls made by a function, and records
+// the largest number of arguments passed by any one of them. Since
+// the node walk doesn't descend into closures, calls in nested
+// function literals are not counted.
+//
+// Receivers count as arguments. For calls to variadic functions, a
+// spread argument ("f(s...)") counts as a single argument, whereas
+// the individual values passed for the variadic param ("f(a, b)")
+// are counted separately, since each of them has to be materialized
+// at the call site.
+type callArgsAnalyzer struct {
+	fn       *ir.Func
+	maxArgs  int
+	numCalls int
+}
+
+func makeCallArgsAnalyzer(fn *ir.Func) *callArgsAnalyzer {
+	return &callArgsAnalyzer{
+		fn: fn,
+	}
+}
+
+func (caa *callArgsAnalyzer) name() string {
+	return "callargs"
+}
+
+func (caa *callArgsAnalyzer) nodeVisitPre(n ir.Node) {
+	switch n.Op() {
+	case ir.OCALLFUNC, ir.OCALLINTER:
+		nargs := callArgCount(n.(*ir.CallExpr))
+		if nargs > caa.maxArgs {
+			caa.maxArgs = nargs
+		}
+		caa.numCalls++
+	}
+}
+
+func (caa *callArgsAnalyzer) nodeVisitPost(n ir.Node) {
+}
+
+// setResults transfers the call counts to 'fp'.
+func (caa *callArgsAnalyzer) setResults(fp *FuncProps) {
+	if debugTrace&debugTraceCalls != 0 {
+		traceEvent("result", "analyzer", "callargs", "func", caa.fn.Sym().Name,
+			"maxargs", caa.maxArgs, "calls", caa.numCalls)
+	}
+	fp.MaxCallArgs = caa.maxArgs
+	fp.NumCalls = caa.numCalls
+}
+
+// callArgCount returns the number of arguments passed by 'call'.
+func callArgCount(call *ir.CallExpr) int {
+	nargs := len(call.Args)
+	switch call.X.Op() {
+	case ir.ODOTMETH, ir.ODOTINTER:
+		// receiver is not part of the argument list
+		nargs++
+	}
+	if !call.IsDDD || len(call.Args) == 0 {
+		return nargs
+	}
+	// The front end packs the values passed for a variadic param
+	// into an implicit slice literal (or nil, if there are none);
+	// unpack them so as to count each value separately.
+	last := call.Args[len(call.Args)-1]
+	switch {
+	case last.Op() == ir.OSLICELIT && last.(*ir.CompLitExpr).Implicit():
+		nargs += len(last.(*ir.CompLitExpr).List) - 1
+	case last.Op() == ir.ONIL:
+		// Note that this also catches an explicit "f(nil...)",
+		// which is harmless since passing nil costs nothing.
+		nargs--
+	}
+	return nargs
+}
@@ -0,0 +1,576 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"cmd/compile/internal/ir"
+	"fmt"
+)
+
+// callSiteAnalyzer is the call site counterpart of propAnalyzer: a
+// helper object tasked with computing some specific subset of the
+// properties of the call sites in a function. As the nodes within the
+// function are visited, there is a sequence of calls to nodeVisitPre
+// and nodeVisitPost, which the analyzer can use to track whatever
+// context it needs (for example, the enclosing loops). Each time a
+// call site is reached (after nodeVisitPre for the call, but before
+// its arguments are visited), setResults is invoked so that the
+// analyzer can add its findings for the call to 'csp'. The name
+// method returns a short descriptive name for the analyzer.
+type callSiteAnalyzer interface {
+	nodeVisitPre(n ir.Node)
+	nodeVisitPost(n ir.Node)
+	setResults(call *ir.CallExpr, callee *ir.Func, csp *CallSiteProps)
+	name() string
+}
+
+// makeCallSiteAnalyzers returns the call site analyzers to be run
+// over the body of 'fn'. New call site heuristics are added by
+// implementing callSiteAnalyzer and registering the analyzer here.
+func makeCallSiteAnalyzers(fn *ir.Func) []callSiteAnalyzer {
+	return []callSiteAnalyzer{
+		makeCallSetAnalyzer("tailpos", CallSiteTailPos, func(n ir.Node) []*ir.CallExpr {
+			if call := tailCall(n); call != nil {
+				return []*ir.CallExpr{call}
+			}
+			return nil
+		}),
+		makeCallSetAnalyzer("makesize", CallSiteFeedsMakeSize, makeSizeCalls),
+		makeCallSetAnalyzer("cond", CallSiteResultFeedsCond, condCalls),
+		&loopCallSiteAnalyzer{},
+		&selectCallSiteAnalyzer{},
+		&rangeCallSiteAnalyzer{},
+		&panicPathAnalyzer{panicStmts: make(map[ir.Node]bool)},
+		&errorPathAnalyzer{errStmts: make(map[ir.Node]bool)},
+		&noEscapeAnalyzer{calls: noEscapeCalls(fn)},
+		&coldCallSiteAnalyzer{cold: isInitFunc(fn) || isOnceClosure(fn)},
+		&constArgsAnalyzer{},
+		&funcLitArgAnalyzer{},
+		makeFuncCallSetAnalyzer(fn, "errcheck", CallSiteErrorChecked, errCheckCalls),
+	}
+}
+
+// callSiteTabBuilder walks the body of a function and builds up a
+// table of the direct calls it contains (see CallSite), running the
+// call site analyzers to compute their properties. Along the way it
+// also records any functions that are referenced as values as opposed
+// to being called directly, since such functions may be invoked from
+// call sites that we can't see.
+type callSiteTabBuilder struct {
+	fn         *ir.Func
+	cstab      CallSiteTab
+	funcValues map[*ir.Func]bool
+	analyzers  []callSiteAnalyzer
+	nextID     uint
+}
+
+// computeCallSiteTable builds and returns a table of the call sites
+// in 'fn', along with the set of functions referenced by value
+// within the body of 'fn'. Calls made from within closures nested in
+// 'fn' are not included; they belong to the table of the closure.
+func computeCallSiteTable(fn *ir.Func) (CallSiteTab, map[*ir.Func]bool) {
+	ctb := &callSiteTabBuilder{
+		fn:         fn,
+		cstab:      make(CallSiteTab),
+		funcValues: make(map[*ir.Func]bool),
+		analyzers:  makeCallSiteAnalyzers(fn),
+	}
+	var doNode func(ir.Node) bool
+	doNode = func(n ir.Node) bool {
+		if n == nil {
+			return false
+		}
+		for _, a := range ctb.analyzers {
+			a.nodeVisitPre(n)
+		}
+		if call, callee := directCall(n); callee != nil {
+			ctb.addCallSite(callee, call)
+			// Visit everything except the callee expression,
+			// so that the callee isn't treated as a func value.
+			for _, n := range call.Init() {
+				doNode(n)
+			}
+			for _, arg := range call.Args {
+				doNode(arg)
+			}
+		} else {
+			ctb.checkFuncValue(n)
+			ir.DoChildren(n, doNode)
+		}
+		for _, a := range ctb.analyzers {
+			a.nodeVisitPost(n)
+		}
+		return false
+	}
+	ir.DoChildren(fn, doNode)
+	return ctb.cstab, ctb.funcValues
+}
+
+// directCall returns 'n' and its callee if 'n' is a direct call to a
+// statically known function, or nils otherwise.
+func directCall(n ir.Node) (*ir.CallExpr, *ir.Func) {
+	if n.Op() != ir.OCALLFUNC {
+		return nil, nil
+	}
+	call := n.(*ir.CallExpr)
+	if callee := staticCallee(call); callee != nil {
+		return call, callee
+	}
+	return nil, nil
+}
+
+// endsInPanic reports whether the last statement in 'list' is a panic
+// or a call to a function that doesn't return (see isExitCall).
+func endsInPanic(list ir.Nodes) bool {
+	if len(list) == 0 {
+		return false
+	}
+	last := list[len(list)-1]
+	return last.Op() == ir.OPANIC || isExitCall(last)
+}
+
+// endsInErrorReturn reports whether the last statement in 'list' is
+// a return statement whose last result is an error other than nil.
+func endsInErrorReturn(list ir.Nodes) bool {
+	if len(list) == 0 || list[len(list)-1].Op() != ir.ORETURN {
+		return false
+	}
+	rs := list[len(list)-1].(*ir.ReturnStmt)
+	if len(rs.Results) == 0 {
+		return false
+	}
+	last := rs.Results[len(rs.Results)-1]
+	for last.Op() == ir.OCONVIFACE || last.Op() == ir.OCONVNOP {
+		last = last.(*ir.ConvExpr).X
+	}
+	return last.Op() != ir.ONIL && isErrorType(rs.Results[len(rs.Results)-1].Type())
+}
+
+// staticCallee returns the function targeted by the direct call
+// 'call' (or by a call through a method value whose method can be
+// determined), or nil if the callee can't be determined statically.
+func staticCallee(call *ir.CallExpr) *ir.Func {
+	sv := ir.StaticValue(call.X)
+	switch sv.Op() {
+	case ir.OCLOSURE:
+		return nil
+	case ir.OMETHVALUE:
+		// A call through a method value, as in "f := x.M; f()".
+		if name := methodValueName(sv.(*ir.SelectorExpr)); name != nil {
+			return name.Func
+		}
+		return nil
+	}
+	if name := ir.StaticCalleeName(sv); name != nil {
+		return name.Func
+	}
+	return nil
+}
+
+// tailCall returns the call whose result is directly returned by the
+// return statement 'n', or nil if 'n' is not such a statement.
+func tailCall(n ir.Node) *ir.CallExpr {
+	if n.Op() != ir.ORETURN {
+		return nil
+	}
+	rs := n.(*ir.ReturnStmt)
+	if len(rs.Results) != 1 || rs.Results[0].Op() != ir.OCALLFUNC {
+		return nil
+	}
+	return rs.Results[0].(*ir.CallExpr)
+}
+
+// makeSizeCalls returns the calls (if any) whose results feed
+// directly (modulo conversions) into the length or capacity operands
+// of 'n', if 'n' is a "make" of a slice or map.
+func makeSizeCalls(n ir.Node) []*ir.CallExpr {
+	var sizes []ir.Node
+	switch n.Op() {
+	case ir.OMAKESLICE:
+		mk := n.(*ir.MakeExpr)
+		sizes = []ir.Node{mk.Len, mk.Cap}
+	case ir.OMAKEMAP:
+		sizes = []ir.Node{n.(*ir.MakeExpr).Len}
+	default:
+		return nil
+	}
+	var rv []*ir.CallExpr
+	for _, sz := range sizes {
+		if sz == nil {
+			continue
+		}
+		for sz.Op() == ir.OCONV || sz.Op() == ir.OCONVNOP {
+			sz = sz.(*ir.ConvExpr).X
+		}
+		if sz.Op() == ir.OCALLFUNC {
+			rv = append(rv, sz.(*ir.CallExpr))
+		}
+	}
+	return rv
+}
+
+// condCalls returns the calls whose results feed directly into the
+// condition of 'n', if it is an "if" statement, or its tag, if it is
+// a "switch" statement. The result of a call may feed the condition
+// via a comparison, a logical operator, or a local variable that is
+// assigned only once, as in
+//
+//	if err := f(); err == io.EOF { ... }
+func condCalls(n ir.Node) []*ir.CallExpr {
+	var rv []*ir.CallExpr
+	var visit func(n ir.Node)
+	visit = func(n ir.Node) {
+		if n == nil {
+			return
+		}
+		for n.Op() == ir.OCONV || n.Op() == ir.OCONVNOP {
+			n = n.(*ir.ConvExpr).X
+		}
+		switch n.Op() {
+		case ir.ONOT:
+			visit(n.(*ir.UnaryExpr).X)
+		case ir.OANDAND, ir.OOROR:
+			n := n.(*ir.LogicalExpr)
+			visit(n.X)
+			visit(n.Y)
+		case ir.OEQ, ir.ONE, ir.OLT, ir.OLE, ir.OGT, ir.OGE:
+			n := n.(*ir.BinaryExpr)
+			visit(n.X)
+			visit(n.Y)
+		case ir.ONAME:
+			if sv := ir.StaticValue(n); sv != n {
+				visit(sv)
+			}
+		case ir.OCALLFUNC:
+			rv = append(rv, n.(*ir.CallExpr))
+		}
+	}
+	switch n.Op() {
+	case ir.OIF:
+		visit(n.(*ir.IfStmt).Cond)
+	case ir.OSWITCH:
+		visit(n.(*ir.SwitchStmt).Tag)
+	}
+	return rv
+}
+
+// errCheckCalls returns the call (if any) an error result of which is
+// compared against nil in the condition of 'n', if it is an "if"
+// statement, as in
+//
+//	v, err := f()
+//	if err != nil { ... }
+func errCheckCalls(n ir.Node) []*ir.CallExpr {
+	if n.Op() != ir.OIF {
+		return nil
+	}
+	cond := n.(*ir.IfStmt).Cond
+	if nonNil, isNil := errNilCheck(cond); !nonNil && !isNil {
+		return nil
+	}
+	be := cond.(*ir.BinaryExpr)
+	x := be.X
+	if x.Op() == ir.ONIL {
+		x = be.Y
+	}
+	if call, _, ok := multiValueCallResult(x, nil); ok {
+		return []*ir.CallExpr{call}
+	}
+	if sv := ir.StaticValue(x); sv.Op() == ir.OCALLFUNC {
+		return []*ir.CallExpr{sv.(*ir.CallExpr)}
+	}
+	return nil
+}
+
+// noEscapeCalls returns the set of calls in 'fn' whose results
+// don't escape 'fn' (see CallSiteResultNoEscape). This is a
+// syntactic approximation of escape analysis, which hasn't run yet:
+// the result must be a single value of pointer, slice, map or channel
+// type, and each use of it must be one that reads through it (a
+// dereference, field selection, index, range loop, or call to len or
+// cap), or a comparison. A result assigned to a local variable is
+// tracked through the variable, provided the variable is assigned
+// only once, doesn't have its address taken, and isn't captured by a
+// closure.
+func noEscapeCalls(fn *ir.Func) map[*ir.CallExpr]bool {
+	rv := make(map[*ir.CallExpr]bool)
+	// Collect the candidate calls, along with the uses of each local
+	// variable.
+	var calls []*ir.CallExpr
+	vars := make(map[*ir.Name]*ir.CallExpr)
+	safe := make(map[ir.Node]bool)
+	bad := make(map[*ir.Name]bool)
+	addressed := make(map[ir.Node]bool)
+	var visit func(n, parent ir.Node, inClosure bool)
+	visit = func(n, parent ir.Node, inClosure bool) {
+		if n == nil {
+			return
+		}
+		switch n.Op() {
+		case ir.OCLOSURE:
+			clo := n.(*ir.ClosureExpr)
+			ir.DoChildren(clo.Func, func(c ir.Node) bool {
+				visit(c, n, true)
+				return false
+			})
+			return
+		case ir.OADDR:
+			// Taking the address of (part of) the memory that a
+			// value refers to, as in "&p.f", lets it escape.
+			addressed[addrBase(n.(*ir.AddrExpr).X)] = true
+		case ir.ONAME:
+			name := n.(*ir.Name).Canonical()
+			if inClosure || !usedSafely(n, parent) {
+				bad[name] = true
+			}
+			return
+		case ir.OCALLFUNC:
+			call := n.(*ir.CallExpr)
+			if !inClosure && escapableResult(call) {
+				calls = append(calls, call)
+				if usedSafely(n, parent) {
+					safe[call] = true
+				}
+			}
+		case ir.OAS:
+			as := n.(*ir.AssignStmt)
+			if call, ok := as.Y.(*ir.CallExpr); ok && !inClosure &&
+				as.Y.Op() == ir.OCALLFUNC && escapableResult(call) &&
+				as.X != nil && as.X.Op() == ir.ONAME {
+				name := as.X.(*ir.Name)
+				if name.Class == ir.PAUTO && !name.Addrtaken() && !ir.Reassigned(name) {
+					calls = append(calls, call)
+					vars[name] = call
+					// Skip the assignment of the call to the
+					// variable, which is not a use of either.
+					for _, c := range as.Init() {
+						visit(c, n, inClosure)
+					}
+					ir.DoChildren(call, func(c ir.Node) bool {
+						visit(c, call, inClosure)
+						return false
+					})
+					return
+				}
+			}
+		}
+		ir.DoChildren(n, func(c ir.Node) bool {
+			visit(c, n, inClosure)
+			return false
+		})
+	}
+	ir.DoChildren(fn, func(c ir.Node) bool {
+		visit(c, fn, false)
+		return false
+	})
+	for name, call := range vars {
+		if !bad[name] && !addressed[name] {
+			safe[call] = true
+		}
+	}
+	for _, call := range calls {
+		if safe[call] && !addressed[call] {
+			rv[call] = true
+		}
+	}
+	return rv
+}
+
+// escapableResult reports whether 'call' returns a single value that
+// refers to memory that could be allocated by the callee.
+func escapableResult(call *ir.CallExpr) bool {
+	t := call.Type()
+	if t == nil || t.IsFuncArgStruct() {
+		return false
+	}
+	return t.IsPtr() || t.IsSlice() || t.IsMap() || t.IsChan()
+}
+
+// addrBase returns the value at the base of the addressable
+// expression 'n', stripping off field selections, indexing and
+// dereferences, so that for "&p.a[i].b" it returns "p".
+func addrBase(n ir.Node) ir.Node {
+	for {
+		switch n.Op() {
+		case ir.ODOT, ir.ODOTPTR:
+			n = n.(*ir.SelectorExpr).X
+		case ir.OINDEX:
+			n = n.(*ir.IndexExpr).X
+		case ir.ODEREF:
+			n = n.(*ir.StarExpr).X
+		case ir.OCONVNOP:
+			n = n.(*ir.ConvExpr).X
+		case ir.ONAME:
+			return n.(*ir.Name).Canonical()
+		default:
+			return n
+		}
+	}
+}
+
+// usedSafely reports whether the use of the value 'n' as an operand
+// of 'parent' can't cause the memory referred to by 'n' to escape;
+// see noEscapeCalls.
+func usedSafely(n, parent ir.Node) bool {
+	switch parent.Op() {
+	case ir.ODEREF, ir.ODOTPTR, ir.OINDEX, ir.OINDEXMAP,
+		ir.OLEN, ir.OCAP, ir.OEQ, ir.ONE:
+		return true
+	case ir.ORANGE:
+		return parent.(*ir.RangeStmt).X == n
+	case ir.ODCL:
+		return true
+	}
+	return false
+}
+
+// sliceRangeLoop returns the slice and index variable for 'n' if it
+// is a "for range" loop over a local slice variable (or param) with
+// an index variable.
+func sliceRangeLoop(n ir.Node) (rangeLoop, bool) {
+	if n.Op() != ir.ORANGE {
+		return rangeLoop{}, false
+	}
+	rs := n.(*ir.RangeStmt)
+	x := rs.X
+	for x.Op() == ir.OCONVNOP {
+		x = x.(*ir.ConvExpr).X
+	}
+	if x.Op() != ir.ONAME || !x.Type().IsSlice() {
+		return rangeLoop{}, false
+	}
+	if rs.Key == nil || rs.Key.Op() != ir.ONAME || ir.IsBlank(rs.Key) {
+		return rangeLoop{}, false
+	}
+	return rangeLoop{x: x.(*ir.Name), key: rs.Key.(*ir.Name)}, true
+}
+
+// rangedArgs returns a mask of the callee param slots for 'call'
+// whose argument is the slice of one of the enclosing range loops
+// 'ranges', where the index variable of that same loop is also passed
+// as an argument.
+func rangedArgs(call *ir.CallExpr, ranges []rangeLoop) uint64 {
+	off := argSlotOffset(call)
+	var mask uint64
+	for _, rl := range ranges {
+		sawKey := false
+		var m uint64
+		for i, arg := range call.Args {
+			for arg.Op() == ir.OCONVNOP || arg.Op() == ir.OCONV {
+				arg = arg.(*ir.ConvExpr).X
+			}
+			switch {
+			case arg == rl.key:
+				sawKey = true
+			case arg == rl.x && i+off < 64:
+				m |= 1 << (i + off)
+			}
+		}
+		if sawKey {
+			mask |= m
+		}
+	}
+	return mask
+}
+
+// constArgs returns a mask of the callee param slots for 'call'
+// whose argument is a constant (modulo conversions).
+func constArgs(call *ir.CallExpr) uint64 {
+	off := argSlotOffset(call)
+	var mask uint64
+	for i, arg := range call.Args {
+		for arg.Op() == ir.OCONVNOP || arg.Op() == ir.OCONV {
+			arg = arg.(*ir.ConvExpr).X
+		}
+		if ir.IsConstNode(arg) && i+off < 64 {
+			mask |= 1 << (i + off)
+		}
+	}
+	return mask
+}
+
+// allArgsConst returns true if 'call' has at least one argument, and
+// all of its arguments are constants (modulo conversions). The
+// receiver of a method call doesn't count as an argument here.
+func allArgsConst(call *ir.CallExpr) bool {
+	if len(call.Args) == 0 {
+		return false
+	}
+	for _, arg := range call.Args {
+		for arg.Op() == ir.OCONVNOP || arg.Op() == ir.OCONV {
+			arg = arg.(*ir.ConvExpr).X
+		}
+		if !ir.IsConstNode(arg) {
+			return false
+		}
+	}
+	return true
+}
+
+// argSlotOffset returns the offset to add to the index of an
+// argument in 'call' to get the callee param slot (receiver first,
+// if any) it corresponds to. For method calls of the form
+// "x.M(...)", and calls through a method value "x.M" (in which the
+// receiver is bound), the receiver is not part of the argument list.
+func argSlotOffset(call *ir.CallExpr) int {
+	if call.X.Op() == ir.ODOTMETH || ir.StaticValue(call.X).Op() == ir.OMETHVALUE {
+		return 1
+	}
+	return 0
+}
+
+// addCallSite adds a call site for the call 'call' to 'callee' to
+// the table, with the properties computed by the call site analyzers.
+func (ctb *callSiteTabBuilder) addCallSite(callee *ir.Func, call *ir.CallExpr) {
+	var csp CallSiteProps
+	for _, a := range ctb.analyzers {
+		a.setResults(call, callee, &csp)
+	}
+	cs := &CallSite{
+		Callee:     callee,
+		Call:       call,
+		ID:         ctb.nextID,
+		Flags:      csp.Flags,
+		RangedArgs: csp.RangedArgs,
+		ConstArgs:  csp.ConstArgs,
+	}
+	ctb.nextID++
+	ctb.cstab[call] = cs
+	if debugTrace&debugTraceCalls != 0 {
+		traceEvent("callsite", "callee", callee.Sym().Name,
+			"call", fmt.Sprint(call), "flags", csp.Flags)
+	}
+}
+
+// checkFuncValue records the function referenced by 'n' (if any) as
+// a func value, since we've already screened out direct calls.
+func (ctb *callSiteTabBuilder) checkFuncValue(n ir.Node) {
+	var name *ir.Name
+	switch n.Op() {
+	case ir.ONAME:
+		if nn := n.(*ir.Name); nn.Class == ir.PFUNC {
+			name = nn
+		}
+	case ir.OMETHEXPR:
+		name = ir.MethodExprName(n)
+	case ir.OMETHVALUE:
+		name = methodValueName(n.(*ir.SelectorExpr))
+	}
+	if name != nil && name.Func != nil {
+		ctb.funcValues[name.Func] = true
+	}
+}
+
+// methodValueName returns the name of the method selected by the
+// method value 'sel' (as in "x.M" used as a func value), or nil if
+// it can't be determined statically (for example, if 'x' is of
+// interface type).
+func methodValueName(sel *ir.SelectorExpr) *ir.Name {
+	if sel.Selection == nil || sel.X.Type() == nil || sel.X.Type().IsInterface() {
+		return nil
+	}
+	name, _ := sel.Selection.Nname.(*ir.Name)
+	return name
+}
@@ -0,0 +1,188 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import "cmd/compile/internal/ir"
+
+// capturesAnalyzer computes the ClosureCaptures properties of a
+// closure: the number of variables it captures, how many of them are
+// captured by reference, and whether it escapes. Escape analysis
+// hasn't run yet when the properties are computed, so the last two
+// are approximations of its results:
+//
+//   - a variable is taken to be captured by reference if it is
+//     reassigned or has its address taken, or if its type is larger
+//     than 128 bytes, mirroring the rule escape analysis uses to
+//     decide how to capture it;
+//
+//   - a closure is taken not to escape only if it is called in
+//     place, as in "func() { ... }()" (including in a "defer"
+//     statement, but not a "go" statement), or if it is assigned to
+//     a local variable that is not reassigned and that is only ever
+//     called directly, as in "f := func() { ... }; f(); f()". Any
+//     other use, such as passing it to another function, storing it
+//     or returning it, counts as an escape.
+//
+// Like the other analyzers, this one doesn't look inside nested
+// closures; the node walk only finds the variables captured by the
+// closure itself, which are listed in its ClosureVars.
+type capturesAnalyzer struct {
+	fn *ir.Func
+}
+
+// maxByValCapture is the size in bytes of the largest variable that
+// escape analysis will capture by value.
+const maxByValCapture = 128
+
+func init() {
+	registerAnalyzer(185, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer {
+		if fn.OClosure == nil {
+			return nil
+		}
+		return &capturesAnalyzer{fn: fn}
+	})
+}
+
+func (ca *capturesAnalyzer) name() string {
+	return "captures"
+}
+
+func (ca *capturesAnalyzer) nodeVisitPre(n ir.Node) {
+}
+
+func (ca *capturesAnalyzer) nodeVisitPost(n ir.Node) {
+}
+
+// setResults transfers the closure's capture properties to 'fp'.
+func (ca *capturesAnalyzer) setResults(fp *FuncProps) {
+	var c ClosureCaptures
+	for _, cv := range ca.fn.ClosureVars {
+		c.Vars++
+		if capturedByRef(cv) {
+			c.ByRef++
+		}
+	}
+	c.Escapes = closureEscapes(ca.fn)
+	if debugTrace&debugTraceFuncFlags != 0 {
+		traceEvent("result", "analyzer", "captures", "func", ca.fn.Sym().Name,
+			"vars", c.Vars, "byref", c.ByRef, "escapes", c.Escapes)
+	}
+	fp.Captures = c
+}
+
+// capturedByRef reports whether the closure variable 'cv' is likely
+// to be captured by reference (see capturesAnalyzer).
+func capturedByRef(cv *ir.Name) bool {
+	v := cv.Canonical()
+	if v.Addrtaken() || ir.Reassigned(v) {
+		return true
+	}
+	t := v.Type()
+	return t == nil || t.Size() > maxByValCapture
+}
+
+// escapingClosures records, for each function literal in the current
+// package, whether it may escape (see capturesAnalyzer). It is
+// computed by FindEscapingClosures before any calls are inlined.
+var escapingClosures map[*ir.Func]bool
+
+// FindEscapingClosures determines which of the function literals
+// within 'funcs' (and the closures nested within them) may escape,
+// for the ClosureCaptures properties. Since a closure doesn't record
+// the function that encloses it, this walks all of the functions in
+// the package to find the uses of each closure, and must be done
+// before inlining starts, while the closures are still where they
+// were written.
+func FindEscapingClosures(funcs []*ir.Func) {
+	escapingClosures = findEscapingClosures(funcs)
+}
+
+// closureEscapes reports whether the closure 'fn' may escape. Closures
+// not seen by FindEscapingClosures, such as those copied into other
+// functions by inlining, are assumed to escape.
+func closureEscapes(fn *ir.Func) bool {
+	v, ok := escapingClosures[fn]
+	return !ok || v
+}
+
+// findEscapingClosures returns a map recording, for each function
+// literal within 'funcs' (and the closures nested within them),
+// whether it may escape.
+func findEscapingClosures(funcs []*ir.Func) map[*ir.Func]bool {
+	escapes := make(map[*ir.Func]bool)
+	// Closures assigned to local variables, which escape if the
+	// variable is used other than as the callee of a direct call.
+	vars := make(map[*ir.Name]*ir.Func)
+	bad := make(map[*ir.Name]bool)
+	goCalls := make(map[ir.Node]bool)
+
+	// calledBy reports whether 'n' is the callee of 'parent', a
+	// direct call not made by a "go" statement.
+	calledBy := func(n, parent ir.Node) bool {
+		if parent == nil || parent.Op() != ir.OCALLFUNC || goCalls[parent] {
+			return false
+		}
+		return parent.(*ir.CallExpr).X == n
+	}
+	var visit func(n, parent ir.Node, inClosure bool)
+	visit = func(n, parent ir.Node, inClosure bool) {
+		if n == nil {
+			return
+		}
+		switch n.Op() {
+		case ir.OGO:
+			goCalls[n.(*ir.GoDeferStmt).Call] = true
+		case ir.OCLOSURE:
+			clo := n.(*ir.ClosureExpr)
+			switch {
+			case calledBy(n, parent):
+				escapes[clo.Func] = false
+			case parent != nil && parent.Op() == ir.OAS && parent.(*ir.AssignStmt).Y == n:
+				name, ok := parent.(*ir.AssignStmt).X.(*ir.Name)
+				if ok && name.Class == ir.PAUTO && !ir.Reassigned(name) {
+					vars[name] = clo.Func
+					escapes[clo.Func] = false
+				} else {
+					escapes[clo.Func] = true
+				}
+			default:
+				escapes[clo.Func] = true
+			}
+			ir.DoChildren(clo.Func, func(c ir.Node) bool {
+				visit(c, n, true)
+				return false
+			})
+			return
+		case ir.ONAME:
+			name := n.(*ir.Name).Canonical()
+			if _, ok := vars[name]; !ok {
+				break
+			}
+			if inClosure || !calledBy(n, parent) {
+				bad[name] = true
+			}
+		}
+		ir.DoChildren(n, func(c ir.Node) bool {
+			visit(c, n, inClosure)
+			return false
+		})
+	}
+	for _, f := range funcs {
+		if f.OClosure != nil {
+			// Visited within the enclosing function.
+			continue
+		}
+		ir.DoChildren(f, func(c ir.Node) bool {
+			visit(c, nil, false)
+			return false
+		})
+	}
+	for name, fn := range vars {
+		if bad[name] {
+			escapes[fn] = true
+		}
+	}
+	return escapes
+}
@@ -0,0 +1,186 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/types"
+	"strings"
+)
+
+// concatAnalyzer looks for functions whose work is mostly string
+// building: string concatenation with "+", appending the bytes of a
+// string or byte slice to a byte slice (as in "append(b, s...)"), or
+// formatting with one of the print functions from the fmt package
+// (Sprintf, Errorf, Fprint and so on). It sets ParamFeedsStringConcat
+// for each param that is an operand of one of these operations
+// (possibly via a conversion, as in "string(b)" or an implicit
+// conversion to interface), and FuncPropConcatDominated if at least
+// one param is so flagged and at least half of the nodes in the
+// function body are part of these operations. When such a function
+// is called with constant strings, inlining lets the compiler fold
+// much of the concatenation away. As with the other param flags,
+// params that are reassigned or have their address taken are not
+// flagged. Nested function literals are not examined.
+type concatAnalyzer struct {
+	fn *ir.Func
+	paramTracker
+	concat map[*ir.Name]bool
+	// depth is the number of concatenation or formatting operations
+	// enclosing the node currently being visited.
+	depth int
+	// nodes counts the nodes in the function body, and inConcat
+	// those that are part of a concatenation or formatting
+	// operation.
+	nodes, inConcat int
+}
+
+func makeConcatAnalyzer(fn *ir.Func) *concatAnalyzer {
+	return &concatAnalyzer{
+		fn:           fn,
+		paramTracker: makeParamTracker(fn),
+		concat:       make(map[*ir.Name]bool),
+	}
+}
+
+func (ca *concatAnalyzer) name() string {
+	return "concat"
+}
+
+// setResults adds ParamFeedsStringConcat to the param flags in 'fp',
+// and sets FuncPropConcatDominated if appropriate.
+func (ca *concatAnalyzer) setResults(fp *FuncProps) {
+	if len(fp.ParamFlags) != len(ca.params) {
+		fp.ParamFlags = make([]ParamPropBits, len(ca.params))
+	}
+	flagged := false
+	for i, p := range ca.params {
+		if p != nil && ca.concat[p] && !ca.reassigned[p] {
+			fp.ParamFlags[i] |= ParamFeedsStringConcat
+			flagged = true
+		}
+	}
+	dominated := flagged && 2*ca.inConcat >= ca.nodes
+	if dominated {
+		fp.Flags |= FuncPropConcatDominated
+	}
+	if debugTrace&debugTraceParams != 0 {
+		traceEvent("result", "analyzer", "concat", "func", ca.fn.Sym().Name,
+			"paramflags", fp.ParamFlags, "nodes", ca.nodes,
+			"inconcat", ca.inConcat, "dominated", dominated)
+	}
+}
+
+func (ca *concatAnalyzer) nodeVisitPre(n ir.Node) {
+	if n.Op() == ir.ODCLFUNC {
+		return
+	}
+	ca.nodes++
+	if ca.depth != 0 {
+		ca.inConcat++
+	}
+	ca.trackAssignment(n)
+	switch n.Op() {
+	case ir.OADDSTR:
+		for _, x := range n.(*ir.AddStringExpr).List {
+			ca.operand(x)
+		}
+		ca.enter()
+	case ir.OAPPEND:
+		call := n.(*ir.CallExpr)
+		if isBytesAppend(call) {
+			ca.operand(call.Args[1])
+			ca.enter()
+		}
+	case ir.OCALLFUNC:
+		call := n.(*ir.CallExpr)
+		if isFmtPrintCall(call) {
+			for _, arg := range call.Args {
+				// The values passed for the variadic param are
+				// packed into an implicit slice literal.
+				if arg.Op() == ir.OSLICELIT && arg.(*ir.CompLitExpr).Implicit() {
+					for _, elt := range arg.(*ir.CompLitExpr).List {
+						ca.operand(elt)
+					}
+					continue
+				}
+				ca.operand(arg)
+			}
+			ca.enter()
+		}
+	}
+}
+
+func (ca *concatAnalyzer) nodeVisitPost(n ir.Node) {
+	switch n.Op() {
+	case ir.OADDSTR:
+		ca.depth--
+	case ir.OAPPEND:
+		if isBytesAppend(n.(*ir.CallExpr)) {
+			ca.depth--
+		}
+	case ir.OCALLFUNC:
+		if isFmtPrintCall(n.(*ir.CallExpr)) {
+			ca.depth--
+		}
+	}
+}
+
+// enter records entry into a concatenation or formatting operation.
+// The operation node itself counts as part of it.
+func (ca *concatAnalyzer) enter() {
+	if ca.depth == 0 {
+		ca.inConcat++
+	}
+	ca.depth++
+}
+
+// operand records 'x', an operand of a concatenation or formatting
+// operation, if it is one of our params (with any conversions
+// removed).
+func (ca *concatAnalyzer) operand(x ir.Node) {
+	for {
+		switch x.Op() {
+		case ir.OCONV, ir.OCONVNOP, ir.OCONVIFACE, ir.OBYTES2STR,
+			ir.OSTR2BYTES:
+			x = x.(*ir.ConvExpr).X
+			continue
+		}
+		break
+	}
+	if p := ca.paramName(x); p != nil &&
+		(p.Type().IsString() || isByteSlice(p.Type())) {
+		ca.concat[p] = true
+	}
+}
+
+// isBytesAppend reports whether 'call' is an append of the bytes of
+// a string or byte slice to a byte slice, as in "append(b, s...)".
+func isBytesAppend(call *ir.CallExpr) bool {
+	return call.Op() == ir.OAPPEND && call.IsDDD && len(call.Args) == 2 &&
+		isByteSlice(call.Args[0].Type())
+}
+
+// isByteSlice reports whether 't' is a slice of bytes.
+func isByteSlice(t *types.Type) bool {
+	return t != nil && t.IsSlice() && t.Elem().Kind() == types.TUINT8
+}
+
+// isFmtPrintCall reports whether 'call' is a direct call to one of
+// the functions in the fmt package that format their arguments
+// (Print, Sprintf, Fprintln, Errorf, Appendf and so on).
+func isFmtPrintCall(call *ir.CallExpr) bool {
+	if call.X.Op() != ir.ONAME {
+		return false
+	}
+	name := call.X.(*ir.Name)
+	if name.Class != ir.PFUNC || name.Sym() == nil ||
+		name.Sym().Pkg.Path != "fmt" {
+		return false
+	}
+	fname := name.Sym().Name
+	return strings.Contains(fname, "rint") || fname == "Errorf" ||
+		strings.HasPrefix(fname, "Append")
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import "cmd/compile/internal/ir"
+
+// concurrencyAnalyzer looks for concurrency constructs within a
+// function: "go" statements (FuncPropSpawnsGoroutine), channel sends
+// and receives, including ranging over a channel and the
+// communication clauses of a "select" (FuncPropUsesChannels),
+// "select" statements (FuncPropUsesSelect), and calls to the lock
+// and unlock methods of sync.Mutex and sync.RWMutex
+// (FuncPropUsesMutex). Unlike blockingAnalyzer, it doesn't care
+// whether an operation may block; each construct has costs (for
+// example synchronization, or runtime calls that can't be optimized
+// away) that inlining can replicate. Since the node walk doesn't
+// descend into closures, constructs within nested function literals
+// are not counted, though a "go" statement that starts one is.
+type concurrencyAnalyzer struct {
+	fn    *ir.Func
+	flags FuncPropBits
+}
+
+func makeConcurrencyAnalyzer(fn *ir.Func) *concurrencyAnalyzer {
+	return &concurrencyAnalyzer{
+		fn: fn,
+	}
+}
+
+func (ca *concurrencyAnalyzer) name() string {
+	return "concurrency"
+}
+
+func (ca *concurrencyAnalyzer) nodeVisitPre(n ir.Node) {
+	switch n.Op() {
+	case ir.OGO:
+		ca.flags |= FuncPropSpawnsGoroutine
+	case ir.OSEND, ir.ORECV:
+		ca.flags |= FuncPropUsesChannels
+	case ir.ORANGE:
+		if x := n.(*ir.RangeStmt).X; x.Type() != nil && x.Type().IsChan() {
+			ca.flags |= FuncPropUsesChannels
+		}
+	case ir.OSELECT:
+		ca.flags |= FuncPropUsesSelect
+	case ir.OCALLFUNC:
+		if isCallTo(n.(*ir.CallExpr), mutexFuncs) {
+			ca.flags |= FuncPropUsesMutex
+		}
+	}
+}
+
+func (ca *concurrencyAnalyzer) nodeVisitPost(n ir.Node) {
+}
+
+// setResults transfers the concurrency-related function flags to 'fp'.
+func (ca *concurrencyAnalyzer) setResults(fp *FuncProps) {
+	if debugTrace&debugTraceFuncFlags != 0 {
+		traceEvent("result", "analyzer", "concurrency", "func", ca.fn.Sym().Name,
+			"flags", ca.flags)
+	}
+	fp.Flags |= ca.flags
+}
+
+// mutexFuncs is the set of lock and unlock methods of the sync
+// package's mutex types, keyed by package path and then symbol name
+// (as with blockingFuncs).
+var mutexFuncs = map[string]map[string]bool{
+	"sync": {
+		"(*Mutex).Lock":       true,
+		"(*Mutex).TryLock":    true,
+		"(*Mutex).Unlock":     true,
+		"(*RWMutex).Lock":     true,
+		"(*RWMutex).TryLock":  true,
+		"(*RWMutex).Unlock":   true,
+		"(*RWMutex).RLock":    true,
+		"(*RWMutex).TryRLock": true,
+		"(*RWMutex).RUnlock":  true,
+	},
+}
+
+// concurrencyFlags is the set of function flags computed by
+// concurrencyAnalyzer.
+const concurrencyFlags = FuncPropSpawnsGoroutine | FuncPropUsesChannels |
+	FuncPropUsesSelect | FuncPropUsesMutex
@@ -0,0 +1,202 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"bufio"
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// coverageAnalyzer marks functions that a Go coverage profile (as
+// written by "go test -coverprofile", and supplied with the
+// "-d=inlcoverprofile=file" command line flag) shows were never
+// executed with FuncPropCoverageCold. This provides a crude notion of
+// coldness for builds that have coverage data but no PGO profile.
+// The analyzer doesn't look at the function body at all; it only
+// consults the profile, and is only run when the flag is given.
+//
+// Profile entries are matched to functions by package path and file
+// name (as in "example.com/p/foo.go") and by line range. A function
+// is considered cold if the profile has at least one block within
+// its line range and none of them have a nonzero count. Functions in
+// files that the profile doesn't cover (including those in package
+// main, which the compiler sees under the path "main") are never
+// considered cold.
+type coverageAnalyzer struct {
+	fn *ir.Func
+}
+
+func init() {
+	registerAnalyzer(180, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer {
+		if base.Debug.InlCoverProfile == "" {
+			return nil
+		}
+		return &coverageAnalyzer{fn: fn}
+	})
+}
+
+func (ca *coverageAnalyzer) name() string {
+	return "coverage"
+}
+
+func (ca *coverageAnalyzer) nodeVisitPre(n ir.Node) {
+}
+
+func (ca *coverageAnalyzer) nodeVisitPost(n ir.Node) {
+}
+
+// setResults consults the coverage profile and transfers the
+// resulting "coverage cold" flag to 'fp'.
+func (ca *coverageAnalyzer) setResults(fp *FuncProps) {
+	cold := coverageCold(ca.fn)
+	if debugTrace&debugTraceFuncFlags != 0 {
+		traceEvent("result", "analyzer", "coverage", "func", ca.fn.Sym().Name,
+			"cold", cold)
+	}
+	if cold {
+		fp.Flags |= FuncPropCoverageCold
+	}
+}
+
+// coverBlock is a block from a coverage profile, giving the lines
+// spanned by the block and the number of times it was executed
+// (summed over all of the profile's entries for the block).
+type coverBlock struct {
+	startLine, endLine uint
+	count              int
+}
+
+var (
+	coverProfileOnce sync.Once
+	coverProfile     map[string][]coverBlock
+)
+
+// coverageCold reports whether the coverage profile shows that
+// function 'fn' was never executed.
+func coverageCold(fn *ir.Func) bool {
+	coverProfileOnce.Do(func() {
+		path := base.Debug.InlCoverProfile
+		f, err := os.Open(path)
+		if err != nil {
+			base.Fatalf("reading coverage profile: %v", err)
+		}
+		defer f.Close()
+		coverProfile, err = parseCoverProfile(f)
+		if err != nil {
+			base.Fatalf("reading coverage profile %s: %v", path, err)
+		}
+	})
+	if !fn.Endlineno.IsKnown() {
+		return false
+	}
+	p := base.Ctxt.InnermostPos(fn.Pos())
+	key := base.Ctxt.Pkgpath + "/" + filepath.Base(p.Filename())
+	end := base.Ctxt.InnermostPos(fn.Endlineno).Line()
+	return coverRangeCold(coverProfile[key], p.Line(), end)
+}
+
+// coverRangeCold reports whether 'blocks' has at least one block that
+// overlaps lines 'start' through 'end', and none of those blocks were
+// executed.
+func coverRangeCold(blocks []coverBlock, start, end uint) bool {
+	found := false
+	for _, b := range blocks {
+		if b.endLine < start || b.startLine > end {
+			continue
+		}
+		if b.count != 0 {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// parseCoverProfile parses a coverage profile in the text format
+// written by "go test -coverprofile", returning its blocks grouped by
+// file. Each line after the initial "mode:" line has the form
+//
+//	example.com/p/foo.go:12.34,15.2 3 1
+//
+// giving the start line and column, end line and column, number of
+// statements, and execution count of a block. A profile may contain
+// several lines for the same block (for example if several profiles
+// have been concatenated), in which case their counts are summed.
+func parseCoverProfile(r io.Reader) (map[string][]coverBlock, error) {
+	type blockKey struct {
+		file, span string
+	}
+	index := make(map[blockKey]int)
+	prof := make(map[string][]coverBlock)
+	s := bufio.NewScanner(r)
+	for lno := 1; s.Scan(); lno++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+		file, b, span, err := parseCoverLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lno, err)
+		}
+		key := blockKey{file, span}
+		if i, ok := index[key]; ok {
+			prof[file][i].count += b.count
+			continue
+		}
+		index[key] = len(prof[file])
+		prof[file] = append(prof[file], b)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return prof, nil
+}
+
+// parseCoverLine parses a single block line of a coverage profile
+// (see parseCoverProfile), returning the file, the block, and the
+// block's position span (the text between the file name and the
+// statement count).
+func parseCoverLine(line string) (string, coverBlock, string, error) {
+	var b coverBlock
+	bad := fmt.Errorf("malformed block %q", line)
+	colon := strings.LastIndex(line, ":")
+	if colon < 0 {
+		return "", b, "", bad
+	}
+	file, rest := line[:colon], line[colon+1:]
+	fields := strings.Fields(rest)
+	if len(fields) != 3 {
+		return "", b, "", bad
+	}
+	span := fields[0]
+	from, to, ok := strings.Cut(span, ",")
+	if !ok {
+		return "", b, "", bad
+	}
+	startLine, err1 := coverSpanLine(from)
+	endLine, err2 := coverSpanLine(to)
+	count, err3 := strconv.Atoi(fields[2])
+	if err1 != nil || err2 != nil || err3 != nil {
+		return "", b, "", bad
+	}
+	b.startLine, b.endLine, b.count = startLine, endLine, count
+	return file, b, span, nil
+}
+
+// coverSpanLine returns the line number from a "line.col" position in
+// a coverage profile block.
+func coverSpanLine(pos string) (uint, error) {
+	l, _, _ := strings.Cut(pos, ".")
+	v, err := strconv.ParseUint(l, 10, 32)
+	return uint(v), err
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import "cmd/compile/internal/ir"
+
+// deferAnalyzer looks for "defer" statements and calls to the
+// builtin "recover" within a function, and estimates whether the
+// function's defers are eligible to be open-coded (see the handling
+// of ODEFER in walk and ssagen). Since open-coding is decided after
+// escape analysis, the estimate is syntactic: a defer is assumed to
+// be ineligible if it is within a loop, or follows a label (which
+// may be the target of a backward "goto"), or if the function has
+// too many defers or too many returns and defers combined. Since the
+// node walk doesn't descend into closures, defers within nested
+// function literals are not counted.
+type deferAnalyzer struct {
+	fn        *ir.Func
+	ndefers   int
+	nreturns  int
+	loopDepth int
+	sawLabel  bool
+	inLoop    bool
+}
+
+// maxOpenDefers and maxOpenDeferExits mirror the limits on open-coded
+// defers imposed by walk (maxOpenDefers) and ssagen (the product of
+// the number of returns and the number of defers).
+const (
+	maxOpenDefers     = 8
+	maxOpenDeferExits = 15
+)
+
+func makeDeferAnalyzer(fn *ir.Func) *deferAnalyzer {
+	return &deferAnalyzer{
+		fn: fn,
+	}
+}
+
+func (da *deferAnalyzer) name() string {
+	return "defer"
+}
+
+func (da *deferAnalyzer) nodeVisitPre(n ir.Node) {
+	switch n.Op() {
+	case ir.OFOR, ir.ORANGE:
+		da.loopDepth++
+	case ir.OLABEL:
+		da.sawLabel = true
+	case ir.ORETURN:
+		da.nreturns++
+	case ir.ODEFER:
+		da.ndefers++
+		if da.loopDepth != 0 || da.sawLabel {
+			da.inLoop = true
+		}
+	}
+}
+
+func (da *deferAnalyzer) nodeVisitPost(n ir.Node) {
+	switch n.Op() {
+	case ir.OFOR, ir.ORANGE:
+		da.loopDepth--
+	}
+}
+
+// setResults transfers the defer and recover flags to 'fp'.
+func (da *deferAnalyzer) setResults(fp *FuncProps) {
+	ineligible := da.ndefers != 0 && (da.inLoop ||
+		da.ndefers > maxOpenDefers ||
+		da.nreturns*da.ndefers > maxOpenDeferExits)
+	recov := containsRecover(da.fn)
+	if debugTrace&debugTraceFuncFlags != 0 {
+		traceEvent("result", "analyzer", "defer", "func", da.fn.Sym().Name,
+			"defers", da.ndefers, "returns", da.nreturns, "inloop", da.inLoop,
+			"ineligible", ineligible, "recover", recov)
+	}
+	if da.ndefers != 0 {
+		fp.Flags |= FuncPropContainsDefer
+	}
+	if ineligible {
+		fp.Flags |= FuncPropOpenDeferIneligible
+	}
+	if recov {
+		fp.Flags |= FuncPropContainsRecover
+	}
+}
+
+// containsRecover reports whether 'fn' calls the builtin "recover",
+// either directly or from within a function literal that 'fn'
+// defers, as in
+//
+//	defer func() {
+//		if r := recover(); r != nil { ... }
+//	}()
+//
+// Calls to recover from closures that are not deferred by 'fn' are
+// not counted, since they have no effect on panics in 'fn'.
+func containsRecover(fn *ir.Func) bool {
+	var do func(n ir.Node) bool
+	do = func(n ir.Node) bool {
+		switch n.Op() {
+		case ir.ORECOVER, ir.ORECOVERFP:
+			return true
+		case ir.ODEFER:
+			ds := n.(*ir.GoDeferStmt)
+			if call, ok := ds.Call.(*ir.CallExpr); ok &&
+				call.X.Op() == ir.OCLOSURE {
+				clo := call.X.(*ir.ClosureExpr)
+				if ir.DoChildren(clo.Func, do) {
+					return true
+				}
+			}
+		}
+		return ir.DoChildren(n, do)
+	}
+	return ir.DoChildren(fn, do)
+}
@@ -8,8 +8,6 @@ import (
 	"cmd/compile/internal/base"
 	"cmd/compile/internal/ir"
 	"cmd/compile/internal/types"
-	"fmt"
-	"os"
 )
 
 // funcFlagsAnalyzer computes the "Flags" value for the FuncProps
@@ -40,6 +38,10 @@ func makeFuncFlagsAnalyzer(fn *ir.Func) *funcFlagsAnalyzer {
 	}
 }
 
+func (ffa *funcFlagsAnalyzer) name() string {
+	return "funcflags"
+}
+
 // setResults transfers func flag results to 'fp'.
 func (ffa *funcFlagsAnalyzer) setResults(fp *FuncProps) {
 	var rv FuncPropBits
@@ -63,7 +65,7 @@ func (ffa *funcFlagsAnalyzer) setResults(fp *FuncProps) {
 	if isMainMain(ffa.fn) {
 		rv &^= FuncPropNeverReturns
 	}
-	fp.Flags = rv
+	fp.Flags |= rv
 }
 
 func (ffa *funcFlagsAnalyzer) getstate(n ir.Node) pstate {
@@ -139,8 +141,8 @@ func (ffa *funcFlagsAnalyzer) stateForList(list ir.Nodes) pstate {
 		n := list[i]
 		psi := ffa.getstate(n)
 		if debugTrace&debugTraceFuncFlags != 0 {
-			fmt.Fprintf(os.Stderr, "=-= %v: stateForList n=%s ps=%s\n",
-				ir.Line(n), n.Op().String(), psi.String())
+			traceEvent("visit", "analyzer", "funcflags", "pos", ir.Line(n),
+				"op", n.Op(), "liststate", psi)
 		}
 		st = blockCombine(st, psi)
 	}
@@ -175,12 +177,72 @@ func isExitCall(n ir.Node) bool {
 		isWellKnownFunc(s, "runtime", "throw") {
 		return true
 	}
-	// FIXME: consult results of flags computation for
-	// previously analyzed Go functions, including props
-	// read from export data for functions in other packages.
+	// Consult the results of the flags computation for previously
+	// analyzed functions, including props read from export data for
+	// functions in other packages, so as to catch wrappers around
+	// panic or os.Exit (e.g. "fatalf" helpers).
+	if fn := name.Func; fn != nil {
+		if fp := propsForFunc(fn); fp != nil && fp.Flags&FuncPropNeverReturns != 0 {
+			return true
+		}
+	}
 	return false
 }
 
+// PropagateNeverReturns makes a fixed-point pass over the previously
+// analyzed functions in 'fns', marking as FuncPropNeverReturns any
+// function that (now) unconditionally calls a function known never
+// to return. Since functions are analyzed in bottom-up order, the
+// properties of callees are usually available when their callers
+// are analyzed, but this isn't the case for callees in the same
+// recursive cycle that happen to be analyzed after their callers.
+func PropagateNeverReturns(fns []*ir.Func) {
+	for changed := true; changed; {
+		changed = false
+		for _, fn := range fns {
+			fp := funcPropsTab[fn]
+			if fp == nil || fp.Flags&FuncPropNeverReturns != 0 {
+				continue
+			}
+			ffa := makeFuncFlagsAnalyzer(fn)
+			runAnalyzersOnFunction(fn, []propAnalyzer{ffa})
+			var nfp FuncProps
+			ffa.setResults(&nfp)
+			if nfp.Flags&FuncPropNeverReturns == 0 {
+				continue
+			}
+			fp.Flags |= FuncPropNeverReturns
+			if fn.Inl != nil && fn.Inl.Properties != "" {
+				fn.Inl.Properties = fp.SerializeToString()
+			}
+			changed = true
+		}
+	}
+}
+
+// MarkRecursive marks the previously analyzed functions in 'fns',
+// which form a recursive cycle (as determined by
+// ir.VisitFuncsBottomUp), with FuncPropRecursive. Closures are
+// skipped, since they appear in the list along with their enclosing
+// function whether or not they're part of the cycle. Note that this
+// can't be determined by looking at each function in isolation, so
+// it isn't done by any of the property analyzers.
+func MarkRecursive(fns []*ir.Func) {
+	for _, fn := range fns {
+		if fn.OClosure != nil {
+			continue
+		}
+		fp := funcPropsTab[fn]
+		if fp == nil || fp.Flags&FuncPropRecursive != 0 {
+			continue
+		}
+		fp.Flags |= FuncPropRecursive
+		if fn.Inl != nil && fn.Inl.Properties != "" {
+			fn.Inl.Properties = fp.SerializeToString()
+		}
+	}
+}
+
 // pessimize is called to record the fact that we saw something in the
 // function that renders it entirely impossible to analyze.
 func (ffa *funcFlagsAnalyzer) pessimize() {
@@ -203,8 +265,8 @@ func shouldVisit(n ir.Node) bool {
 // based on the state(s) of the node's children.
 func (ffa *funcFlagsAnalyzer) nodeVisitPost(n ir.Node) {
 	if debugTrace&debugTraceFuncFlags != 0 {
-		fmt.Fprintf(os.Stderr, "=+= nodevis %v %s should=%v\n",
-			ir.Line(n), n.Op().String(), shouldVisit(n))
+		traceEvent("visit", "analyzer", "funcflags", "pos", ir.Line(n),
+			"op", n.Op(), "should", shouldVisit(n))
 	}
 	if !shouldVisit(n) {
 		// invoke soft set, since node may be shared (e.g. ONAME)
@@ -328,8 +390,8 @@ func (ffa *funcFlagsAnalyzer) nodeVisitPost(n ir.Node) {
 			ir.Line(n), n.Op().String(), ir.FuncName(ffa.fn))
 	}
 	if debugTrace&debugTraceFuncFlags != 0 {
-		fmt.Fprintf(os.Stderr, "=-= %v: visit n=%s returns %s\n",
-			ir.Line(n), n.Op().String(), st.String())
+		traceEvent("visit", "analyzer", "funcflags", "pos", ir.Line(n),
+			"op", n.Op(), "state", st)
 	}
 	ffa.setstate(n, st)
 }
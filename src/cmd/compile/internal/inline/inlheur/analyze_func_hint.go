@@ -0,0 +1,49 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"cmd/compile/internal/ir"
+)
+
+// inlineHintAnalyzer transfers the hint given for a function with
+// the "//go:inlinehint hot|cold|prefer-inline" directive to its
+// properties (FuncPropHintHot, FuncPropHintCold or
+// FuncPropHintPreferInline), where the scoring picks it up. Like
+// coverageAnalyzer it doesn't look at the function body, and it is
+// only run for functions that have a hint.
+type inlineHintAnalyzer struct {
+	fn *ir.Func
+}
+
+func init() {
+	registerAnalyzer(190, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer {
+		if fn.InlineHint == ir.InlineHintNone {
+			return nil
+		}
+		return &inlineHintAnalyzer{fn: fn}
+	})
+}
+
+func (ha *inlineHintAnalyzer) name() string {
+	return "inlinehint"
+}
+
+func (ha *inlineHintAnalyzer) nodeVisitPre(n ir.Node) {
+}
+
+func (ha *inlineHintAnalyzer) nodeVisitPost(n ir.Node) {
+}
+
+func (ha *inlineHintAnalyzer) setResults(fp *FuncProps) {
+	switch ha.fn.InlineHint {
+	case ir.InlineHintHot:
+		fp.Flags |= FuncPropHintHot
+	case ir.InlineHintCold:
+		fp.Flags |= FuncPropHintCold
+	case ir.InlineHintPreferInline:
+		fp.Flags |= FuncPropHintPreferInline
+	}
+}
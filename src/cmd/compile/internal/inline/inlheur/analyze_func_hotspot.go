@@ -0,0 +1,111 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"fmt"
+	"path/filepath"
+)
+
+// hotspotAnalyzer locates the construct within a function that is
+// most likely to make the function expensive to inline or to call,
+// and records its source position. Candidate constructs are ranked
+// by kind: first operations that may block (channel operations,
+// "select" statements with no default case, and calls to well-known
+// blocking functions), then loops (with deeper loops ranked above
+// shallower ones), and finally allocations (make, new, composite
+// literals that are heap allocated, closures). Among candidates of
+// equal rank the first one encountered in the walk wins, which keeps
+// the result stable from run to run. Since the node walk doesn't
+// descend into closures, constructs in nested function literals are
+// not considered.
+type hotspotAnalyzer struct {
+	fn        *ir.Func
+	best      ir.Node
+	bestRank  int
+	loopDepth int
+	skip      map[ir.Node]bool
+}
+
+// Ranks for hotspot candidates; loops at depth d (starting at 1)
+// have rank hotspotLoop+d.
+const (
+	hotspotNone  = 0
+	hotspotAlloc = 1
+	hotspotLoop  = 2
+	hotspotBlock = 1 << 20
+)
+
+func makeHotspotAnalyzer(fn *ir.Func) *hotspotAnalyzer {
+	return &hotspotAnalyzer{
+		fn:   fn,
+		skip: make(map[ir.Node]bool),
+	}
+}
+
+func (ha *hotspotAnalyzer) name() string {
+	return "hotspot"
+}
+
+func (ha *hotspotAnalyzer) nodeVisitPre(n ir.Node) {
+	rank := hotspotNone
+	switch n.Op() {
+	case ir.OFOR, ir.ORANGE:
+		ha.loopDepth++
+		rank = hotspotLoop + ha.loopDepth
+		if n.Op() == ir.ORANGE {
+			if x := n.(*ir.RangeStmt).X; x.Type() != nil && x.Type().IsChan() {
+				rank = hotspotBlock
+			}
+		}
+	case ir.OSEND, ir.ORECV:
+		if !ha.skip[n] {
+			rank = hotspotBlock
+		}
+	case ir.OSELECT:
+		// The select itself is the interesting construct, not the
+		// channel operations in its cases.
+		sel := n.(*ir.SelectStmt)
+		for _, op := range selectCommOps(sel) {
+			ha.skip[op] = true
+		}
+		if !hasDefaultCase(sel) {
+			rank = hotspotBlock
+		}
+	case ir.OCALLFUNC:
+		if isCallTo(n.(*ir.CallExpr), blockingFuncs) {
+			rank = hotspotBlock
+		}
+	case ir.OMAKESLICE, ir.OMAKEMAP, ir.OMAKECHAN, ir.ONEW, ir.OPTRLIT,
+		ir.OSLICELIT, ir.OMAPLIT, ir.OCLOSURE:
+		rank = hotspotAlloc
+	}
+	if rank > ha.bestRank {
+		ha.best, ha.bestRank = n, rank
+	}
+}
+
+func (ha *hotspotAnalyzer) nodeVisitPost(n ir.Node) {
+	switch n.Op() {
+	case ir.OFOR, ir.ORANGE:
+		ha.loopDepth--
+	}
+}
+
+// setResults transfers the position of the hotspot (if any) to 'fp'.
+func (ha *hotspotAnalyzer) setResults(fp *FuncProps) {
+	if ha.best == nil {
+		return
+	}
+	p := base.Ctxt.InnermostPos(ha.best.Pos())
+	fp.Hotspot = fmt.Sprintf("%s:%d:%d",
+		filepath.Base(p.Filename()), p.Line(), p.Col())
+	if debugTrace&debugTraceFuncs != 0 {
+		traceEvent("result", "analyzer", "hotspot", "func", ha.fn.Sym().Name,
+			"op", ha.best.Op(), "pos", fp.Hotspot)
+	}
+}
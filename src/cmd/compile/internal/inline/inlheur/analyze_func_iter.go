@@ -0,0 +1,134 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/types"
+)
+
+// iterAnalyzer looks at the use of iterators (as in package iter)
+// within a function. It sets ParamYieldInLoop for a yield-style
+// param (see isYieldFunc) that is called within a loop, and
+// FuncPropRangeOverFunc if the function contains a range-over-func
+// loop. By the time we see it, such a loop has been rewritten into a
+// call to the iterator passing a function literal (the "F-range1"
+// closure, see compilerGenKind) that holds the loop body; the node
+// walk doesn't descend into closures, so we examine these bodies
+// ourselves, treating a call to a yield param within one as a call
+// in a loop (this is the common case for iterator adapters such as
+// "for v := range seq { if !yield(f(v)) { return } }"). As with the
+// other param flags, params that are reassigned or have their
+// address taken are not flagged.
+type iterAnalyzer struct {
+	fn *ir.Func
+	// paramTracker tracks only the yield params; the slots of the
+	// other params are nil.
+	paramTracker
+	loopDepth int
+	inLoop    map[*ir.Name]bool
+	rangeFunc bool
+}
+
+func makeIterAnalyzer(fn *ir.Func) *iterAnalyzer {
+	pt := makeParamTracker(fn)
+	for i, p := range pt.params {
+		if p != nil && !isYieldFunc(p.Type()) {
+			pt.params[i] = nil
+		}
+	}
+	return &iterAnalyzer{
+		fn:           fn,
+		paramTracker: pt,
+		inLoop:       make(map[*ir.Name]bool),
+	}
+}
+
+func (ia *iterAnalyzer) name() string {
+	return "iter"
+}
+
+// setResults adds ParamYieldInLoop to the param flags in 'fp', and
+// transfers the "range over func" flag.
+func (ia *iterAnalyzer) setResults(fp *FuncProps) {
+	if len(fp.ParamFlags) != len(ia.params) {
+		fp.ParamFlags = make([]ParamPropBits, len(ia.params))
+	}
+	for i, p := range ia.params {
+		if p != nil && ia.inLoop[p] && !ia.reassigned[p] {
+			fp.ParamFlags[i] |= ParamYieldInLoop
+		}
+	}
+	if ia.rangeFunc {
+		fp.Flags |= FuncPropRangeOverFunc
+	}
+	if debugTrace&debugTraceParams != 0 {
+		traceEvent("result", "analyzer", "iter", "func", ia.fn.Sym().Name,
+			"paramflags", fp.ParamFlags, "rangefunc", ia.rangeFunc)
+	}
+}
+
+func (ia *iterAnalyzer) nodeVisitPre(n ir.Node) {
+	ia.trackAssignment(n)
+	switch n.Op() {
+	case ir.OFOR, ir.ORANGE:
+		ia.loopDepth++
+	case ir.OCALLFUNC:
+		if p := ia.yieldParam(n.(*ir.CallExpr).X); p != nil && ia.loopDepth > 0 {
+			ia.inLoop[p] = true
+		}
+	case ir.OCLOSURE:
+		if clo := n.(*ir.ClosureExpr).Func; compilerGenKind(clo) == "rangefunc" {
+			ia.rangeFunc = true
+			ia.visitRangeBody(clo)
+		}
+	}
+}
+
+func (ia *iterAnalyzer) nodeVisitPost(n ir.Node) {
+	switch n.Op() {
+	case ir.OFOR, ir.ORANGE:
+		ia.loopDepth--
+	}
+}
+
+// visitRangeBody looks for calls to yield params within 'clo', the
+// body of a range-over-func loop, including those in the bodies of
+// range-over-func loops nested within it. Captured params appear
+// within the closure as closure vars, so we map them back to the
+// params they capture.
+func (ia *iterAnalyzer) visitRangeBody(clo *ir.Func) {
+	ir.VisitList(clo.Body, func(n ir.Node) {
+		switch n.Op() {
+		case ir.OCALLFUNC:
+			if p := ia.yieldParam(n.(*ir.CallExpr).X); p != nil {
+				ia.inLoop[p] = true
+			}
+		case ir.OCLOSURE:
+			if inner := n.(*ir.ClosureExpr).Func; compilerGenKind(inner) == "rangefunc" {
+				ia.visitRangeBody(inner)
+			}
+		}
+	})
+}
+
+// yieldParam returns the yield param of the function being analyzed
+// that 'n' refers to, possibly via a closure var (as within the body
+// of a range-over-func loop), or nil if 'n' is not a reference to
+// such a param.
+func (ia *iterAnalyzer) yieldParam(n ir.Node) *ir.Name {
+	if n == nil || n.Op() != ir.ONAME {
+		return nil
+	}
+	return ia.paramName(n.(*ir.Name).Canonical())
+}
+
+// isYieldFunc reports whether 't' is the type of a yield function as
+// passed to an iterator, i.e. a func type with a single bool result
+// (as in iter.Seq and iter.Seq2).
+func isYieldFunc(t *types.Type) bool {
+	return t.Kind() == types.TFUNC && t.NumResults() == 1 &&
+		t.Result(0).Type.IsBoolean()
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import "cmd/compile/internal/ir"
+
+// loopAnalyzer looks for params that determine the trip count of a
+// loop within the function, setting ParamFeedsLoopBound for them. A
+// param feeds a loop bound if it (or its length or capacity) is an
+// operand of a comparison in the condition of a "for" loop, or if it
+// is the value being ranged over by a "range" loop (other than one
+// over a channel). As with the other param flags, params that are
+// reassigned or have their address taken are not flagged, since the
+// value reaching the loop may not be the one passed in. Loops within
+// nested function literals are not examined.
+type loopAnalyzer struct {
+	fn *ir.Func
+	paramTracker
+	bounds map[*ir.Name]bool
+}
+
+func makeLoopAnalyzer(fn *ir.Func) *loopAnalyzer {
+	return &loopAnalyzer{
+		fn:           fn,
+		paramTracker: makeParamTracker(fn),
+		bounds:       make(map[*ir.Name]bool),
+	}
+}
+
+func (la *loopAnalyzer) name() string {
+	return "loops"
+}
+
+// setResults adds ParamFeedsLoopBound to the param flags in 'fp'.
+func (la *loopAnalyzer) setResults(fp *FuncProps) {
+	if len(fp.ParamFlags) != len(la.params) {
+		fp.ParamFlags = make([]ParamPropBits, len(la.params))
+	}
+	for i, p := range la.params {
+		if p != nil && la.bounds[p] && !la.reassigned[p] {
+			fp.ParamFlags[i] |= ParamFeedsLoopBound
+		}
+	}
+	if debugTrace&debugTraceParams != 0 {
+		traceEvent("result", "analyzer", "loops", "func", la.fn.Sym().Name,
+			"paramflags", fp.ParamFlags)
+	}
+}
+
+func (la *loopAnalyzer) nodeVisitPre(n ir.Node) {
+	la.trackAssignment(n)
+	switch n.Op() {
+	case ir.OFOR:
+		la.condBounds(n.(*ir.ForStmt).Cond)
+	case ir.ORANGE:
+		x := n.(*ir.RangeStmt).X
+		if p := la.paramName(x); p != nil && !p.Type().IsChan() {
+			la.bounds[p] = true
+		}
+	}
+}
+
+func (la *loopAnalyzer) nodeVisitPost(n ir.Node) {
+}
+
+// condBounds records the params that appear as operands (possibly
+// within a call to len or cap) of the comparisons making up the loop
+// condition 'cond'.
+func (la *loopAnalyzer) condBounds(cond ir.Node) {
+	if cond == nil {
+		return
+	}
+	switch cond.Op() {
+	case ir.OANDAND, ir.OOROR:
+		lo := cond.(*ir.LogicalExpr)
+		la.condBounds(lo.X)
+		la.condBounds(lo.Y)
+	case ir.ONOT:
+		la.condBounds(cond.(*ir.UnaryExpr).X)
+	case ir.OEQ, ir.ONE, ir.OLT, ir.OLE, ir.OGT, ir.OGE:
+		bin := cond.(*ir.BinaryExpr)
+		for _, opnd := range []ir.Node{bin.X, bin.Y} {
+			if opnd.Op() == ir.OLEN || opnd.Op() == ir.OCAP {
+				opnd = opnd.(*ir.UnaryExpr).X
+			}
+			if p := la.paramName(opnd); p != nil {
+				la.bounds[p] = true
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"cmd/compile/internal/ir"
+	"strings"
+)
+
+// lowLevelAnalyzer looks for low-level trickery within a function
+// that tends to defeat the compiler's analyses: pointer arithmetic
+// (unsafe.Add, or converting a uintptr back to an unsafe.Pointer),
+// calls to methods of reflect.Value, and use of //go:linkname,
+// either by the function itself or by a function that it calls.
+// Plain conversions to or from unsafe.Pointer don't count (they are
+// covered by FuncPropUsesUnsafe). Since the node walk doesn't descend
+// into closures, uses within nested function literals are not
+// counted.
+type lowLevelAnalyzer struct {
+	fn       *ir.Func
+	lowLevel bool
+}
+
+func makeLowLevelAnalyzer(fn *ir.Func) *lowLevelAnalyzer {
+	return &lowLevelAnalyzer{
+		fn:       fn,
+		lowLevel: fn.Sym() != nil && fn.Sym().Linkname != "",
+	}
+}
+
+func (la *lowLevelAnalyzer) name() string {
+	return "lowlevel"
+}
+
+func (la *lowLevelAnalyzer) nodeVisitPre(n ir.Node) {
+	if la.lowLevel {
+		return
+	}
+	switch n.Op() {
+	case ir.OUNSAFEADD:
+		la.lowLevel = true
+	case ir.OCONV, ir.OCONVNOP:
+		conv := n.(*ir.ConvExpr)
+		if conv.Type().IsUnsafePtr() &&
+			conv.X.Type() != nil && conv.X.Type().IsUintptr() {
+			la.lowLevel = true
+		}
+	case ir.OCALLFUNC:
+		call := n.(*ir.CallExpr)
+		la.lowLevel = isReflectValueCall(call) || isLinknameCall(call)
+	}
+}
+
+func (la *lowLevelAnalyzer) nodeVisitPost(n ir.Node) {
+}
+
+// setResults transfers the "low level" flag to 'fp'.
+func (la *lowLevelAnalyzer) setResults(fp *FuncProps) {
+	if debugTrace&debugTraceFuncFlags != 0 {
+		traceEvent("result", "analyzer", "lowlevel", "func", la.fn.Sym().Name,
+			"lowlevel", la.lowLevel)
+	}
+	if la.lowLevel {
+		fp.Flags |= FuncPropLowLevel
+	}
+}
+
+// isReflectValueCall reports whether 'call' is a direct call to a
+// method of reflect.Value.
+func isReflectValueCall(call *ir.CallExpr) bool {
+	if call.X.Op() != ir.OMETHEXPR {
+		return false
+	}
+	name := ir.MethodExprName(call.X)
+	if name == nil || name.Sym() == nil || name.Sym().Pkg.Path != "reflect" {
+		return false
+	}
+	s := name.Sym().Name
+	return strings.HasPrefix(s, "Value.") || strings.HasPrefix(s, "(*Value).")
+}
+
+// isLinknameCall reports whether 'call' is a direct call to a
+// function whose symbol is bound with //go:linkname (typically a
+// body-less declaration that pulls in a function from another
+// package, such as the runtime).
+func isLinknameCall(call *ir.CallExpr) bool {
+	if call.X.Op() != ir.ONAME {
+		return false
+	}
+	name := call.X.(*ir.Name)
+	return name.Class == ir.PFUNC && name.Sym() != nil && name.Sym().Linkname != ""
+}
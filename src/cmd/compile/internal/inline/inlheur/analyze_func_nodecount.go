@@ -0,0 +1,57 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import "cmd/compile/internal/ir"
+
+// nodeCountAnalyzer counts the nodes in a function body by category
+// (see NodeCounts). Since the node walk doesn't descend into
+// closures, nodes in nested function literals are not counted
+// (although the closure expression itself is).
+type nodeCountAnalyzer struct {
+	fn     *ir.Func
+	counts NodeCounts
+}
+
+func makeNodeCountAnalyzer(fn *ir.Func) *nodeCountAnalyzer {
+	return &nodeCountAnalyzer{
+		fn: fn,
+	}
+}
+
+func (nca *nodeCountAnalyzer) name() string {
+	return "nodecount"
+}
+
+func (nca *nodeCountAnalyzer) nodeVisitPre(n ir.Node) {
+	switch n.Op() {
+	case ir.OIF, ir.OFOR, ir.ORANGE, ir.OSWITCH, ir.OSELECT,
+		ir.OGOTO, ir.OBREAK, ir.OCONTINUE, ir.OFALL, ir.OLABEL,
+		ir.ORETURN, ir.OTAILCALL:
+		nca.counts.ControlFlow++
+		return
+	}
+	switch n.(type) {
+	case *ir.Func:
+		// the function itself
+	case ir.Stmt:
+		nca.counts.Stmts++
+	case ir.Expr:
+		nca.counts.Exprs++
+	}
+}
+
+func (nca *nodeCountAnalyzer) nodeVisitPost(n ir.Node) {
+}
+
+// setResults transfers the node counts to 'fp'.
+func (nca *nodeCountAnalyzer) setResults(fp *FuncProps) {
+	if debugTrace&debugTraceFuncs != 0 {
+		traceEvent("result", "analyzer", "nodecount", "func", nca.fn.Sym().Name,
+			"stmts", nca.counts.Stmts, "exprs", nca.counts.Exprs,
+			"controlflow", nca.counts.ControlFlow)
+	}
+	fp.NodeCounts = nca.counts
+}
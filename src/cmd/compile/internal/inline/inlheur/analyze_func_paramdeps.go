@@ -0,0 +1,193 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"cmd/compile/internal/ir"
+	"fmt"
+)
+
+// paramDepAnalyzer computes the "ResultAffectingParams" mask for the
+// FuncProps object we're computing, using a crude, flow-insensitive
+// backward reachability analysis. During the node walk we collect a
+// set of "sink" expressions (returned values, stored values and
+// addresses, call arguments, branch conditions, and so on) along
+// with a record of the expressions assigned to each local variable.
+// Once the walk is complete, any parameter reachable from a sink
+// (either directly or via chains of local assignments) is deemed to
+// affect the function's results or side effects. The analysis errs
+// on the side of marking a parameter as affecting results whenever
+// it can't be sure.
+type paramDepAnalyzer struct {
+	fn     *ir.Func
+	params []*ir.Name
+	sinks  []ir.Node
+	deps   map[*ir.Name][]ir.Node
+}
+
+func makeParamDepAnalyzer(fn *ir.Func) *paramDepAnalyzer {
+	var params []*ir.Name
+	for _, p := range fn.Type().RecvParams() {
+		var name *ir.Name
+		if p.Nname != nil {
+			name = p.Nname.(*ir.Name)
+		}
+		params = append(params, name)
+	}
+	return &paramDepAnalyzer{
+		fn:     fn,
+		params: params,
+		deps:   make(map[*ir.Name][]ir.Node),
+	}
+}
+
+func (pda *paramDepAnalyzer) name() string {
+	return "paramdeps"
+}
+
+// setResults computes the set of params reachable from the sinks
+// collected during the walk and transfers the result to 'fp'.
+func (pda *paramDepAnalyzer) setResults(fp *FuncProps) {
+	reached := make(map[*ir.Name]bool)
+	work := pda.sinks
+	for len(work) != 0 {
+		n := work[len(work)-1]
+		work = work[:len(work)-1]
+		for _, name := range namesReferenced(n) {
+			if reached[name] {
+				continue
+			}
+			reached[name] = true
+			work = append(work, pda.deps[name]...)
+		}
+	}
+	var mask uint64
+	for i, p := range pda.params {
+		if i >= 64 {
+			break
+		}
+		if p != nil && reached[p] {
+			mask |= 1 << i
+		}
+	}
+	if debugTrace&debugTraceParams != 0 {
+		traceEvent("result", "analyzer", "paramdeps", "func", pda.fn.Sym().Name,
+			"mask", fmt.Sprintf("%b", mask))
+	}
+	fp.ResultAffectingParams = mask
+}
+
+func (pda *paramDepAnalyzer) nodeVisitPost(n ir.Node) {
+}
+
+func (pda *paramDepAnalyzer) nodeVisitPre(n ir.Node) {
+	switch n.Op() {
+	case ir.ORETURN:
+		for _, r := range n.(*ir.ReturnStmt).Results {
+			pda.sink(r)
+		}
+	case ir.OAS:
+		as := n.(*ir.AssignStmt)
+		if as.Y != nil {
+			pda.assign(as.X, as.Y)
+		}
+	case ir.OASOP:
+		as := n.(*ir.AssignOpStmt)
+		pda.assign(as.X, as.Y)
+	case ir.OAS2, ir.OAS2FUNC, ir.OAS2DOTTYPE, ir.OAS2MAPR, ir.OAS2RECV:
+		as := n.(*ir.AssignListStmt)
+		for _, lhs := range as.Lhs {
+			for _, rhs := range as.Rhs {
+				pda.assign(lhs, rhs)
+			}
+		}
+	case ir.ORANGE:
+		rs := n.(*ir.RangeStmt)
+		pda.sink(rs.X)
+		if rs.Key != nil {
+			pda.assign(rs.Key, rs.X)
+		}
+		if rs.Value != nil {
+			pda.assign(rs.Value, rs.X)
+		}
+	case ir.OIF:
+		pda.sink(n.(*ir.IfStmt).Cond)
+	case ir.OFOR:
+		if cond := n.(*ir.ForStmt).Cond; cond != nil {
+			pda.sink(cond)
+		}
+	case ir.OSWITCH:
+		if tag := n.(*ir.SwitchStmt).Tag; tag != nil {
+			pda.sink(tag)
+		}
+	case ir.OSEND:
+		// Sending on or closing a channel is a store through
+		// the channel, visible to its other users.
+		ss := n.(*ir.SendStmt)
+		pda.sink(ss.Chan)
+		pda.sink(ss.Value)
+	case ir.OCLOSE:
+		pda.sink(n.(*ir.UnaryExpr).X)
+	case ir.OCALLFUNC, ir.OCALLINTER, ir.OPANIC,
+		ir.OPRINT, ir.OPRINTN, ir.OCOPY, ir.ODELETE, ir.OCLEAR,
+		ir.OCLOSURE, ir.OADDR:
+		// Calls may have side effects involving their arguments,
+		// closures may modify captured variables, and taking the
+		// address of a variable allows it to be modified
+		// indirectly, so treat all of these as sinks.
+		pda.sink(n)
+	}
+}
+
+// sink records 'n' as an expression whose value affects the results
+// or side effects of the function.
+func (pda *paramDepAnalyzer) sink(n ir.Node) {
+	pda.sinks = append(pda.sinks, n)
+}
+
+// assign records an assignment of 'rhs' to 'lhs'. Assignments to
+// local variables (including params) are recorded as dependencies;
+// any other assignment (to a global, through a pointer, into a
+// named result, and so on) is treated as a sink.
+func (pda *paramDepAnalyzer) assign(lhs, rhs ir.Node) {
+	if ir.IsBlank(lhs) {
+		return
+	}
+	if lhs.Op() == ir.ONAME {
+		name := lhs.(*ir.Name)
+		if (name.Class == ir.PAUTO || name.Class == ir.PPARAM) &&
+			!name.Addrtaken() && !name.IsClosureVar() {
+			pda.deps[name] = append(pda.deps[name], rhs)
+			return
+		}
+	}
+	pda.sink(lhs)
+	pda.sink(rhs)
+}
+
+// namesReferenced returns the names of the local variables (and
+// params) referenced within 'n', including those captured by any
+// closures within 'n'.
+func namesReferenced(n ir.Node) []*ir.Name {
+	var rv []*ir.Name
+	var do func(n ir.Node) bool
+	do = func(n ir.Node) bool {
+		switch n.Op() {
+		case ir.ONAME:
+			name := n.(*ir.Name)
+			if name.IsClosureVar() {
+				name = name.Canonical()
+			}
+			rv = append(rv, name)
+		case ir.OCLOSURE:
+			for _, cv := range n.(*ir.ClosureExpr).Func.ClosureVars {
+				rv = append(rv, cv.Canonical())
+			}
+		}
+		return ir.DoChildren(n, do)
+	}
+	do(n)
+	return rv
+}
@@ -0,0 +1,387 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import "cmd/compile/internal/ir"
+
+// paramsAnalyzer computes the ParamFlags for the FuncProps object
+// we're computing. At the moment it looks for the following
+// properties: ParamFeedsBoundsCheck, set for a slice or string param
+// that is indexed by a non-constant expression (and never reassigned)
+// within the function; ParamFeedsFormatString, set for a param passed
+// directly as the format string of a printf-style call;
+// ParamFeedsInterfaceMethodCall/ParamMayFeedInterfaceMethodCall, set
+// for an interface param that is the receiver of a method call, at
+// the top level of the function or nested within some control
+// construct (if, switch, loop and so on) respectively; and
+// ParamFeedsIndirectCall/ParamMayFeedIndirectCall, likewise set for a
+// func-typed param that is called; ParamFeedsIfOrSwitch/
+// ParamMayFeedIfOrSwitch, likewise set for a param that appears in a
+// simple "if" condition or "switch" tag or case expression (see
+// isSimpleExpr); ParamFeedsTypeAssert, set for an interface param
+// that is the operand of a type assertion or type switch;
+// ParamFeedsDivOrShift, set for an integer param that is the divisor
+// of an integer division or modulus, or the amount of a shift; and
+// ParamNeverRead, set for a param that is never referenced other than
+// as the target of an assignment.
+// Since the node walk doesn't descend into closures, operations in
+// nested function literals are not counted, except that a param
+// captured by a closure is considered to be read.
+type paramsAnalyzer struct {
+	fn *ir.Func
+	paramTracker
+	indexed  map[*ir.Name]bool
+	formats  map[*ir.Name]bool
+	asserted map[*ir.Name]bool
+	divisors map[*ir.Name]bool
+	// itfCalls records, for each param that is the receiver of an
+	// interface method call, whether there is such a call at the
+	// top level (true) or only nested ones (false).
+	itfCalls map[*ir.Name]bool
+	// indirectCalls is the same as itfCalls, but for params that
+	// are called.
+	indirectCalls map[*ir.Name]bool
+	// conds is the same as itfCalls, but for params that feed
+	// "if" or "switch" statements.
+	conds map[*ir.Name]bool
+	// refs counts the references to each param, and writes the
+	// ones that are the target of a plain assignment (which is
+	// not a read); a param is read if refs exceeds writes.
+	refs, writes map[*ir.Name]int
+	// condLevel is the number of control constructs enclosing the
+	// node currently being visited.
+	condLevel int
+}
+
+func makeParamsAnalyzer(fn *ir.Func) *paramsAnalyzer {
+	return &paramsAnalyzer{
+		fn:           fn,
+		paramTracker: makeParamTracker(fn),
+		indexed:      make(map[*ir.Name]bool),
+		formats:      make(map[*ir.Name]bool),
+		asserted:     make(map[*ir.Name]bool),
+		divisors:     make(map[*ir.Name]bool),
+		itfCalls:     make(map[*ir.Name]bool),
+
+		indirectCalls: make(map[*ir.Name]bool),
+		conds:         make(map[*ir.Name]bool),
+		refs:          make(map[*ir.Name]int),
+		writes:        make(map[*ir.Name]int),
+	}
+}
+
+func (pa *paramsAnalyzer) name() string {
+	return "params"
+}
+
+// setResults transfers the computed param flags to 'fp'.
+func (pa *paramsAnalyzer) setResults(fp *FuncProps) {
+	flags := make([]ParamPropBits, len(pa.params))
+	for i, p := range pa.params {
+		if pa.neverRead(p) {
+			flags[i] |= ParamNeverRead
+		}
+		if p == nil || pa.reassigned[p] {
+			continue
+		}
+		if pa.indexed[p] {
+			flags[i] |= ParamFeedsBoundsCheck
+		}
+		if pa.formats[p] {
+			flags[i] |= ParamFeedsFormatString
+		}
+		if pa.asserted[p] {
+			flags[i] |= ParamFeedsTypeAssert
+		}
+		if pa.divisors[p] {
+			flags[i] |= ParamFeedsDivOrShift
+		}
+		if top, ok := pa.itfCalls[p]; ok {
+			if top {
+				flags[i] |= ParamFeedsInterfaceMethodCall
+			} else {
+				flags[i] |= ParamMayFeedInterfaceMethodCall
+			}
+		}
+		if top, ok := pa.indirectCalls[p]; ok {
+			if top {
+				flags[i] |= ParamFeedsIndirectCall
+			} else {
+				flags[i] |= ParamMayFeedIndirectCall
+			}
+		}
+		if top, ok := pa.conds[p]; ok {
+			if top {
+				flags[i] |= ParamFeedsIfOrSwitch
+			} else {
+				flags[i] |= ParamMayFeedIfOrSwitch
+			}
+		}
+	}
+	if debugTrace&debugTraceParams != 0 {
+		traceEvent("result", "analyzer", "params", "func", pa.fn.Sym().Name,
+			"paramflags", flags)
+	}
+	fp.ParamFlags = flags
+}
+
+func (pa *paramsAnalyzer) nodeVisitPre(n ir.Node) {
+	pa.trackAssignment(n)
+	switch n.Op() {
+	case ir.ONAME:
+		if p := pa.paramName(n); p != nil {
+			pa.refs[p]++
+		}
+	case ir.OCLOSURE:
+		for _, cv := range n.(*ir.ClosureExpr).Func.ClosureVars {
+			if p := pa.paramName(cv.Outer); p != nil {
+				pa.refs[p]++
+			}
+		}
+	case ir.ORANGE:
+		rs := n.(*ir.RangeStmt)
+		pa.written(rs.Key)
+		pa.written(rs.Value)
+	case ir.OINDEX:
+		ix := n.(*ir.IndexExpr)
+		if ir.IsConstNode(ix.Index) {
+			return
+		}
+		if p := pa.paramName(ix.X); p != nil &&
+			(p.Type().IsSlice() || p.Type().IsString()) {
+			pa.indexed[p] = true
+		}
+	case ir.OCALLFUNC:
+		call := n.(*ir.CallExpr)
+		if format := formatArg(call); format != nil {
+			if p := pa.paramName(format); p != nil {
+				pa.formats[p] = true
+			}
+		}
+		if p := pa.paramName(call.X); p != nil {
+			pa.indirectCalls[p] = pa.indirectCalls[p] || pa.condLevel == 0
+		}
+	case ir.OCALLINTER:
+		sel := n.(*ir.CallExpr).X.(*ir.SelectorExpr)
+		if p := pa.paramName(sel.X); p != nil {
+			pa.itfCalls[p] = pa.itfCalls[p] || pa.condLevel == 0
+		}
+	case ir.OAS:
+		pa.written(n.(*ir.AssignStmt).X)
+	case ir.OASOP:
+		as := n.(*ir.AssignOpStmt)
+		pa.divOrShift(as.AsOp, as.X, as.Y)
+	case ir.ODIV, ir.OMOD, ir.OLSH, ir.ORSH:
+		n := n.(*ir.BinaryExpr)
+		pa.divOrShift(n.Op(), n.X, n.Y)
+	case ir.OAS2, ir.OAS2FUNC, ir.OAS2DOTTYPE, ir.OAS2MAPR, ir.OAS2RECV:
+		for _, lhs := range n.(*ir.AssignListStmt).Lhs {
+			pa.written(lhs)
+		}
+	case ir.ODOTTYPE, ir.ODOTTYPE2:
+		pa.typeAsserted(n.(*ir.TypeAssertExpr).X)
+	case ir.OIF:
+		pa.condition(n.(*ir.IfStmt).Cond)
+	case ir.OSWITCH:
+		sw := n.(*ir.SwitchStmt)
+		if sw.Tag != nil && sw.Tag.Op() == ir.OTYPESW {
+			pa.typeAsserted(sw.Tag.(*ir.TypeSwitchGuard).X)
+		} else if sw.Tag != nil {
+			pa.condition(sw.Tag)
+		}
+		for _, cas := range sw.Cases {
+			for _, e := range cas.List {
+				pa.condition(e)
+			}
+		}
+	}
+	if isConditional(n) {
+		pa.condLevel++
+	}
+}
+
+func (pa *paramsAnalyzer) nodeVisitPost(n ir.Node) {
+	if isConditional(n) {
+		pa.condLevel--
+	}
+}
+
+// isConditional reports whether 'n' is a control construct, parts of
+// which may execute conditionally (or more than once).
+func isConditional(n ir.Node) bool {
+	switch n.Op() {
+	case ir.OIF, ir.OFOR, ir.ORANGE, ir.OSWITCH, ir.OSELECT,
+		ir.OANDAND, ir.OOROR:
+		return true
+	}
+	return false
+}
+
+// typeAsserted records 'x', the operand of a type assertion or type
+// switch, if it is one of our params.
+func (pa *paramsAnalyzer) typeAsserted(x ir.Node) {
+	if p := pa.paramName(x); p != nil && p.Type().IsInterface() {
+		pa.asserted[p] = true
+	}
+}
+
+// divOrShift records the params that feed 'y', the divisor or shift
+// amount of the binary operation 'op' on 'x' and 'y' (or of the
+// corresponding assignment operation), provided that the operation
+// is an integer division or modulus, or a shift.
+func (pa *paramsAnalyzer) divOrShift(op ir.Op, x, y ir.Node) {
+	switch op {
+	case ir.ODIV, ir.OMOD:
+		if x.Type() == nil || !x.Type().IsInteger() {
+			return
+		}
+	case ir.OLSH, ir.ORSH:
+	default:
+		return
+	}
+	for y.Op() == ir.OCONV || y.Op() == ir.OCONVNOP {
+		y = y.(*ir.ConvExpr).X
+	}
+	if p := pa.paramName(y); p != nil && p.Type().IsInteger() {
+		pa.divisors[p] = true
+	}
+}
+
+// condition records the params referenced by 'cond', the condition
+// of an "if" statement or the tag or a case expression of a "switch"
+// statement, provided that it is a simple expression. This is called
+// before the statement itself is counted in condLevel.
+func (pa *paramsAnalyzer) condition(cond ir.Node) {
+	var refs []*ir.Name
+	if !pa.isSimpleExpr(cond, &refs) {
+		return
+	}
+	for _, p := range refs {
+		pa.conds[p] = pa.conds[p] || pa.condLevel == 0
+	}
+}
+
+// isSimpleExpr reports whether 'n' is a "simple" expression, built
+// only from constants and references to our params using conversions,
+// "len" and "cap", and unary and binary operators (but not calls, or
+// anything else that might have side effects or depend on memory).
+// If the call site passes constants for the params in a simple
+// condition, the condition can be folded once the callee is inlined,
+// and the branches not taken eliminated. The params referenced are
+// appended to 'refs'.
+func (pa *paramsAnalyzer) isSimpleExpr(n ir.Node, refs *[]*ir.Name) bool {
+	switch n.Op() {
+	case ir.OLITERAL, ir.ONIL:
+		return true
+	case ir.ONAME:
+		p := pa.paramName(n)
+		if p == nil {
+			return false
+		}
+		*refs = append(*refs, p)
+		return true
+	case ir.OCONV, ir.OCONVNOP:
+		return pa.isSimpleExpr(n.(*ir.ConvExpr).X, refs)
+	case ir.ONOT, ir.ONEG, ir.OBITNOT, ir.OPLUS, ir.OLEN, ir.OCAP:
+		return pa.isSimpleExpr(n.(*ir.UnaryExpr).X, refs)
+	case ir.OANDAND, ir.OOROR:
+		n := n.(*ir.LogicalExpr)
+		return pa.isSimpleExpr(n.X, refs) && pa.isSimpleExpr(n.Y, refs)
+	case ir.OEQ, ir.ONE, ir.OLT, ir.OLE, ir.OGT, ir.OGE,
+		ir.OADD, ir.OSUB, ir.OMUL, ir.OOR, ir.OXOR, ir.OAND,
+		ir.OANDNOT, ir.OLSH, ir.ORSH:
+		n := n.(*ir.BinaryExpr)
+		return pa.isSimpleExpr(n.X, refs) && pa.isSimpleExpr(n.Y, refs)
+	}
+	return false
+}
+
+// written records a plain assignment to 'n' (one that doesn't read
+// it), if it is one of our params.
+func (pa *paramsAnalyzer) written(n ir.Node) {
+	if p := pa.paramName(n); p != nil {
+		pa.writes[p]++
+	}
+}
+
+// neverRead reports whether the param 'p' (nil if the param is
+// unnamed) is never read. A function with an empty body is assumed
+// to read all of its params, since it may be implemented in assembly.
+func (pa *paramsAnalyzer) neverRead(p *ir.Name) bool {
+	if len(pa.fn.Body) == 0 {
+		return false
+	}
+	if p == nil || p.Sym() == nil || p.Sym().IsBlank() {
+		return true
+	}
+	return pa.refs[p] <= pa.writes[p]
+}
+
+// paramTracker holds the state shared by the analyzers that compute
+// param flags, which embed it: the params of the function being
+// analyzed (receiver first, with nil for unnamed ones), and the set
+// of those that are reassigned or have their address taken. Such
+// params are generally not flagged, since the value reaching the
+// operation of interest may not be the one passed in.
+type paramTracker struct {
+	params     []*ir.Name
+	reassigned map[*ir.Name]bool
+}
+
+func makeParamTracker(fn *ir.Func) paramTracker {
+	var params []*ir.Name
+	for _, p := range fn.Type().RecvParams() {
+		var name *ir.Name
+		if p.Nname != nil {
+			name = p.Nname.(*ir.Name)
+		}
+		params = append(params, name)
+	}
+	return paramTracker{
+		params:     params,
+		reassigned: make(map[*ir.Name]bool),
+	}
+}
+
+// trackAssignment records the params reassigned by 'n', if it is an
+// assignment, or whose address it takes. Analyzers embedding a
+// paramTracker call this for each node they visit.
+func (pt *paramTracker) trackAssignment(n ir.Node) {
+	switch n.Op() {
+	case ir.OAS:
+		pt.assigned(n.(*ir.AssignStmt).X)
+	case ir.OASOP:
+		pt.assigned(n.(*ir.AssignOpStmt).X)
+	case ir.OAS2, ir.OAS2FUNC, ir.OAS2DOTTYPE, ir.OAS2MAPR, ir.OAS2RECV:
+		for _, lhs := range n.(*ir.AssignListStmt).Lhs {
+			pt.assigned(lhs)
+		}
+	case ir.OADDR:
+		pt.assigned(n.(*ir.AddrExpr).X)
+	}
+}
+
+// assigned records an assignment to (or the taking of the address
+// of) 'n', if it is one of our params.
+func (pt *paramTracker) assigned(n ir.Node) {
+	if p := pt.paramName(n); p != nil {
+		pt.reassigned[p] = true
+	}
+}
+
+// paramName returns the param of the function being analyzed that
+// 'n' refers to, or nil if 'n' is not a reference to a param.
+func (pt *paramTracker) paramName(n ir.Node) *ir.Name {
+	if n == nil || n.Op() != ir.ONAME {
+		return nil
+	}
+	name := n.(*ir.Name)
+	for _, p := range pt.params {
+		if p != nil && p == name {
+			return p
+		}
+	}
+	return nil
+}
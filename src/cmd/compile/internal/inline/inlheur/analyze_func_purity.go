@@ -0,0 +1,154 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import "cmd/compile/internal/ir"
+
+// purityAnalyzer determines whether a function is free of side
+// effects. The analysis is very conservative: a function is deemed
+// impure if it assigns to anything other than its own local
+// variables (or the fields and array elements thereof), performs any
+// channel operation, I/O (print), or other operation with effects
+// visible outside the function (copy, append, delete, clear, panic,
+// go, defer and so on), or calls any function not itself known to be
+// pure, which includes all indirect and interface calls, calls to
+// functions in packages compiled without the heuristics, and all
+// calls into the synchronization packages (see syncPkgs). In practice
+// this means that only leaf-ish functions will typically qualify.
+// Reads of memory outside the function (through pointers, or of
+// globals) and allocations are permitted. Since the node walk doesn't
+// descend into closures, the bodies of nested function literals are
+// not examined, but calls to them are indirect and so make the
+// function impure.
+type purityAnalyzer struct {
+	fn     *ir.Func
+	impure ir.Node // first impure node found, if any
+}
+
+func makePurityAnalyzer(fn *ir.Func) *purityAnalyzer {
+	return &purityAnalyzer{
+		fn: fn,
+	}
+}
+
+func (pa *purityAnalyzer) name() string {
+	return "purity"
+}
+
+func (pa *purityAnalyzer) nodeVisitPre(n ir.Node) {
+	if pa.impure != nil {
+		return
+	}
+	if !pa.isPureNode(n) {
+		pa.impure = n
+	}
+}
+
+func (pa *purityAnalyzer) nodeVisitPost(n ir.Node) {
+}
+
+// setResults transfers the purity flag to 'fp'.
+func (pa *purityAnalyzer) setResults(fp *FuncProps) {
+	if debugTrace&debugTraceFuncFlags != 0 {
+		if pa.impure != nil {
+			traceEvent("result", "analyzer", "purity", "func", pa.fn.Sym().Name,
+				"pure", false, "impureop", pa.impure.Op())
+		} else {
+			traceEvent("result", "analyzer", "purity", "func", pa.fn.Sym().Name,
+				"pure", true)
+		}
+	}
+	if pa.impure == nil {
+		fp.Flags |= FuncPropIsPure
+	}
+}
+
+// isPureNode reports whether node 'n' (not including its children)
+// is free of side effects.
+func (pa *purityAnalyzer) isPureNode(n ir.Node) bool {
+	switch n.Op() {
+	case ir.OSEND, ir.ORECV, ir.OSELECT, ir.OCLOSE,
+		ir.OPRINT, ir.OPRINTN, ir.OPANIC, ir.ORECOVER, ir.ORECOVERFP,
+		ir.OGO, ir.ODEFER, ir.OCOPY, ir.OAPPEND, ir.ODELETE, ir.OCLEAR,
+		ir.OCALLINTER:
+		return false
+	case ir.OCALLFUNC:
+		callee := staticCallee(n.(*ir.CallExpr))
+		if callee == nil {
+			return false
+		}
+		if s := callee.Sym(); s != nil && s.Pkg != nil && syncPkgs[s.Pkg.Path] {
+			return false
+		}
+		if callee == pa.fn {
+			// Recursion doesn't introduce any new effects.
+			return true
+		}
+		fp := propsForFunc(callee)
+		return fp != nil && fp.Flags&FuncPropIsPure != 0
+	case ir.ORANGE:
+		rs := n.(*ir.RangeStmt)
+		if rs.X.Type() != nil && rs.X.Type().IsChan() {
+			return false
+		}
+		return isLocalStore(rs.Key) && isLocalStore(rs.Value)
+	case ir.OAS:
+		return isLocalStore(n.(*ir.AssignStmt).X)
+	case ir.OASOP:
+		return isLocalStore(n.(*ir.AssignOpStmt).X)
+	case ir.OAS2, ir.OAS2FUNC, ir.OAS2DOTTYPE, ir.OAS2MAPR, ir.OAS2RECV:
+		for _, lhs := range n.(*ir.AssignListStmt).Lhs {
+			if !isLocalStore(lhs) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isLocalStore reports whether a store to 'n' (which may be nil, as
+// for the key of a "for range" loop without one) modifies only local
+// state: 'n' must be a local variable (including params and results)
+// whose address is not taken and that is not captured from an
+// enclosing function, or a field or array element of such a
+// variable.
+func isLocalStore(n ir.Node) bool {
+	for n != nil {
+		switch n.Op() {
+		case ir.ONAME:
+			if ir.IsBlank(n) {
+				return true
+			}
+			name := n.(*ir.Name)
+			switch name.Class {
+			case ir.PAUTO, ir.PPARAM, ir.PPARAMOUT:
+				return !name.Addrtaken() && !name.IsClosureVar()
+			}
+			return false
+		case ir.ODOT:
+			n = n.(*ir.SelectorExpr).X
+		case ir.OINDEX:
+			ix := n.(*ir.IndexExpr)
+			if !ix.X.Type().IsArray() {
+				return false
+			}
+			n = ix.X
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// syncPkgs is the set of packages whose functions and methods
+// synchronize with other goroutines. Calls into these packages are
+// always treated as impure, even where the callee's own properties
+// would say otherwise (for example an atomic load, whose body, if it
+// has one, reads only through its pointer param).
+var syncPkgs = map[string]bool{
+	"sync":                    true,
+	"sync/atomic":             true,
+	"runtime/internal/atomic": true,
+}
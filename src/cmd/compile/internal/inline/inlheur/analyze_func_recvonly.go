@@ -0,0 +1,123 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import "cmd/compile/internal/ir"
+
+// recvOnlyAnalyzer looks for accessor-like methods: methods that
+// access the fields of their receiver, and otherwise touch only their
+// params and local variables. Such a method must not make any calls
+// (direct, indirect or interface), refer to any package-level
+// variables or functions, perform any channel operations, I/O,
+// panics, go or defer statements, or contain function literals, and
+// any memory it reads or writes through a pointer, slice or map must
+// be reached from the receiver. Since the node walk doesn't descend
+// into closures, the presence of a function literal is enough to
+// disqualify the method.
+type recvOnlyAnalyzer struct {
+	fn        *ir.Func
+	recv      *ir.Name
+	fieldRefs int
+	other     ir.Node // first disqualifying node found, if any
+}
+
+func makeRecvOnlyAnalyzer(fn *ir.Func) *recvOnlyAnalyzer {
+	ra := &recvOnlyAnalyzer{
+		fn: fn,
+	}
+	if r := fn.Type().Recv(); r != nil {
+		if nn, ok := r.Nname.(*ir.Name); ok && !ir.IsBlank(nn) {
+			ra.recv = nn
+		}
+	}
+	return ra
+}
+
+func (ra *recvOnlyAnalyzer) name() string {
+	return "recvonly"
+}
+
+func (ra *recvOnlyAnalyzer) nodeVisitPre(n ir.Node) {
+	if ra.recv == nil || ra.other != nil {
+		return
+	}
+	switch n.Op() {
+	case ir.OCALLFUNC, ir.OCALLMETH, ir.OCALLINTER, ir.OCLOSURE,
+		ir.OMETHVALUE, ir.OMETHEXPR,
+		ir.OSEND, ir.ORECV, ir.OSELECT, ir.OCLOSE,
+		ir.OPRINT, ir.OPRINTN, ir.OPANIC, ir.ORECOVER, ir.ORECOVERFP,
+		ir.OGO, ir.ODEFER:
+		ra.other = n
+	case ir.ONAME:
+		switch n.(*ir.Name).Class {
+		case ir.PEXTERN, ir.PFUNC:
+			ra.other = n
+		}
+	case ir.ODOT, ir.ODOTPTR:
+		if ra.fromRecv(n.(*ir.SelectorExpr).X) {
+			ra.fieldRefs++
+		} else if n.Op() == ir.ODOTPTR {
+			ra.other = n
+		}
+	case ir.ODEREF:
+		if !ra.fromRecv(n.(*ir.StarExpr).X) {
+			ra.other = n
+		}
+	case ir.OINDEX:
+		ix := n.(*ir.IndexExpr)
+		if !ix.X.Type().IsArray() && !ix.X.Type().IsString() && !ra.fromRecv(ix.X) {
+			ra.other = n
+		}
+	case ir.OINDEXMAP:
+		if !ra.fromRecv(n.(*ir.IndexExpr).X) {
+			ra.other = n
+		}
+	}
+}
+
+func (ra *recvOnlyAnalyzer) nodeVisitPost(n ir.Node) {
+}
+
+// fromRecv reports whether 'n' is the receiver, or is reached from it
+// via a chain of field selections, indexing operations and pointer
+// dereferences.
+func (ra *recvOnlyAnalyzer) fromRecv(n ir.Node) bool {
+	for {
+		n = ir.StaticValue(n)
+		switch n.Op() {
+		case ir.ONAME:
+			return n == ra.recv
+		case ir.ODOT, ir.ODOTPTR:
+			n = n.(*ir.SelectorExpr).X
+		case ir.OINDEX, ir.OINDEXMAP:
+			n = n.(*ir.IndexExpr).X
+		case ir.ODEREF:
+			n = n.(*ir.StarExpr).X
+		case ir.OADDR:
+			n = n.(*ir.AddrExpr).X
+		case ir.OCONVNOP:
+			n = n.(*ir.ConvExpr).X
+		default:
+			return false
+		}
+	}
+}
+
+// setResults transfers the "receiver only" flag to 'fp'.
+func (ra *recvOnlyAnalyzer) setResults(fp *FuncProps) {
+	recvOnly := ra.recv != nil && ra.other == nil && ra.fieldRefs != 0
+	if debugTrace&debugTraceFuncFlags != 0 {
+		if ra.other != nil {
+			traceEvent("result", "analyzer", "recvonly", "func", ra.fn.Sym().Name,
+				"recvonly", false, "otherop", ra.other.Op())
+		} else {
+			traceEvent("result", "analyzer", "recvonly", "func", ra.fn.Sym().Name,
+				"recvonly", recvOnly)
+		}
+	}
+	if recvOnly {
+		fp.Flags |= FuncPropReceiverOnly
+	}
+}
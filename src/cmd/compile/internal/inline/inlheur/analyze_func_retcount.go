@@ -0,0 +1,49 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import "cmd/compile/internal/ir"
+
+// returnCountAnalyzer counts the "return" statements in a function
+// and determines whether the function has a single return at the
+// tail of its body (as opposed to early exits). Since the node walk
+// doesn't descend into closures, returns in nested function literals
+// are not counted.
+type returnCountAnalyzer struct {
+	fn       *ir.Func
+	nreturns int
+}
+
+func makeReturnCountAnalyzer(fn *ir.Func) *returnCountAnalyzer {
+	return &returnCountAnalyzer{
+		fn: fn,
+	}
+}
+
+func (rca *returnCountAnalyzer) name() string {
+	return "retcount"
+}
+
+func (rca *returnCountAnalyzer) nodeVisitPre(n ir.Node) {
+	if n.Op() == ir.ORETURN {
+		rca.nreturns++
+	}
+}
+
+func (rca *returnCountAnalyzer) nodeVisitPost(n ir.Node) {
+}
+
+// setResults transfers the return count and tail return status
+// to 'fp'.
+func (rca *returnCountAnalyzer) setResults(fp *FuncProps) {
+	body := rca.fn.Body
+	tail := len(body) != 0 && body[len(body)-1].Op() == ir.ORETURN
+	fp.NumReturns = rca.nreturns
+	fp.SingleTailReturn = rca.nreturns == 1 && tail
+	if debugTrace&debugTraceResults != 0 {
+		traceEvent("result", "analyzer", "retcount", "func", rca.fn.Sym().Name,
+			"returns", rca.nreturns, "tail", tail)
+	}
+}
@@ -6,10 +6,9 @@ package inlheur
 
 import (
 	"cmd/compile/internal/ir"
-	"fmt"
+	"cmd/compile/internal/types"
 	"go/constant"
 	"go/token"
-	"os"
 )
 
 // returnsAnalyzer stores state information for the process of
@@ -17,28 +16,45 @@ import (
 // function, as part of inline heuristics synthesis.
 type returnsAnalyzer struct {
 	fname     string
+	fn        *ir.Func
 	props     []ResultPropBits
 	values    []resultVal
+	named     []*ir.Name
 	canInline func(*ir.Func)
+	// freshVars holds the local variables that hold fresh
+	// allocations (see freshAllocVars), computed on demand.
+	freshVars map[*ir.Name]bool
+	// lateWrites records, for each result slot, whether the named
+	// result may be written after a return statement has set it
+	// (see namedResultsWrittenLate), computed on demand.
+	lateWrites []bool
 }
 
 // resultVal captures information about a specific result returned from
 // the function we're analyzing; we are interested in cases where
 // the func always returns the same constant, or always returns
-// the same function, etc. This container stores info on a the specific
-// scenarios we're looking for.
+// the same function or global variable, etc. This container stores
+// info on a the specific scenarios we're looking for.
 type resultVal struct {
-	lit   constant.Value
-	fn    *ir.Name
-	fnClo bool
-	top   bool
+	lit    constant.Value
+	fn     *ir.Name
+	fnClo  bool
+	global *ir.Name
+	top    bool
+	// fresh is set if each value returned so far is a fresh
+	// allocation (see ResultFreshAlloc).
+	fresh bool
 }
 
 func makeResultsAnalyzer(fn *ir.Func, canInline func(*ir.Func)) *returnsAnalyzer {
 	results := fn.Type().Results()
 	props := make([]ResultPropBits, len(results))
 	vals := make([]resultVal, len(results))
+	named := make([]*ir.Name, len(results))
 	for i := range results {
+		if nn, ok := results[i].Nname.(*ir.Name); ok && !ir.IsBlank(nn) {
+			named[i] = nn
+		}
 		rt := results[i].Type
 		if !rt.IsScalar() && !rt.HasNil() {
 			// existing properties not applicable here (for things
@@ -51,12 +67,18 @@ func makeResultsAnalyzer(fn *ir.Func, canInline func(*ir.Func)) *returnsAnalyzer
 		vals[i].top = true
 	}
 	return &returnsAnalyzer{
+		fn:        fn,
 		props:     props,
 		values:    vals,
+		named:     named,
 		canInline: canInline,
 	}
 }
 
+func (ra *returnsAnalyzer) name() string {
+	return "returns"
+}
+
 // setResults transfers the calculated result properties for this
 // function to 'fp'.
 func (ra *returnsAnalyzer) setResults(fp *FuncProps) {
@@ -78,12 +100,44 @@ func (ra *returnsAnalyzer) setResults(fp *FuncProps) {
 			}
 		}
 	}
+	// A named result that may be written after the return (by a
+	// deferred call) doesn't necessarily hold the value returned,
+	// so nothing is known about it.
+	for i := range ra.props {
+		if ra.writtenLate(i) {
+			ra.props[i] = ResultNoInfo
+		}
+	}
+	// Mark the error results that are always nil.
+	results := ra.fn.Type().Results()
+	for i := range ra.props {
+		if ra.props[i] == ResultAlwaysSameConstant && ra.values[i].lit == nil &&
+			isErrorType(results[i].Type) {
+			ra.props[i] |= ResultErrorAlwaysNil
+		}
+	}
+	// Mark the allocated results that are never leaked.
+	for i := range ra.props {
+		if ra.props[i] == ResultIsAllocatedMem && ra.values[i].fresh {
+			ra.props[i] |= ResultFreshAlloc
+		}
+	}
+	// Mark the results that all callers throw away.
+	if dead := discardedResults(ra.fn); dead != 0 {
+		for i := range ra.props {
+			if i < 64 && dead&(1<<i) != 0 {
+				ra.props[i] |= ResultDiscardedByCallers
+			}
+		}
+	}
 	fp.ResultFlags = ra.props
 }
 
 func (ra *returnsAnalyzer) pessimize() {
 	for i := range ra.props {
 		ra.props[i] = ResultNoInfo
+		ra.values[i].top = false
+		ra.values[i].fresh = false
 	}
 }
 
@@ -98,19 +152,26 @@ func (ra *returnsAnalyzer) nodeVisitPost(n ir.Node) {
 		return
 	}
 	if debugTrace&debugTraceResults != 0 {
-		fmt.Fprintf(os.Stderr, "=+= returns nodevis %v %s\n",
-			ir.Line(n), n.Op().String())
+		traceEvent("visit", "analyzer", "returns", "pos", ir.Line(n),
+			"op", n.Op())
 	}
 
-	// No support currently for named results, so if we see an empty
-	// "return" stmt, be conservative.
+	// Each result slot is analyzed separately, so that (for example)
+	// the error result of a function returning (T, error) can be
+	// characterized even if nothing is known about the T result.
 	rs := n.(*ir.ReturnStmt)
-	if len(rs.Results) != len(ra.values) {
+	switch {
+	case len(rs.Results) == len(ra.values):
+		for i, r := range rs.Results {
+			ra.analyzeResult(i, r, rs)
+		}
+	case len(rs.Results) == 0:
+		// A bare "return" from a function with named results.
+		for i := range ra.values {
+			ra.analyzeNamedResult(i)
+		}
+	default:
 		ra.pessimize()
-		return
-	}
-	for i, r := range rs.Results {
-		ra.analyzeResult(i, r)
 	}
 }
 
@@ -143,22 +204,176 @@ func isFuncName(n ir.Node) (*ir.Name, bool, bool) {
 // applies a dataflow "meet" operation to combine this result with any
 // previous result (for the given return slot) that we've already
 // processed.
-func (ra *returnsAnalyzer) analyzeResult(ii int, n ir.Node) {
-	isAllocMem := isAllocatedMem(n)
-	isConcConvItf := isConcreteConvIface(n)
-	lit, isConst := isLiteral(n)
-	rfunc, isFunc, isClo := isFuncName(n)
-	curp := ra.props[ii]
-	newp := ResultNoInfo
-	var newlit constant.Value
-	var newfunc *ir.Name
+func (ra *returnsAnalyzer) analyzeResult(ii int, n ir.Node, rs *ir.ReturnStmt) {
+	var rv resultObs
+	if call, idx, ok := multiValueCallResult(n, rs.Results[0].Init()); ok {
+		// The value comes from a call with multiple results (as
+		// with "return f()", which the front end rewrites to
+		// assign the results of f to temporaries). If the
+		// properties of f are available, the result properties
+		// that don't depend on a specific value carry over from
+		// the corresponding result of f.
+		if callee := staticCallee(call); callee != nil {
+			if fp := propsForFunc(callee); fp != nil && idx < len(fp.ResultFlags) {
+				rv.isAllocMem = fp.ResultFlags[idx]&ResultIsAllocatedMem != 0
+				rv.isFresh = fp.ResultFlags[idx]&ResultFreshAlloc != 0
+				rv.isConcConvItf = fp.ResultFlags[idx]&ResultIsConcreteTypeConvertedToInterface != 0
+				// An error result that is always nil is a known value.
+				rv.isConst = fp.ResultFlags[idx]&ResultErrorAlwaysNil != 0
+			}
+		}
+		ra.meetResult(ii, rv)
+		return
+	}
+	rv.isAllocMem = isAllocatedMem(n)
+	rv.isFresh = ra.isFreshAlloc(n)
+	rv.isConcConvItf = isConcreteConvIface(n)
+	rv.lit, rv.isConst = isLiteral(n)
+	if !rv.isConst {
+		rv.isConst = isNilErrorCall(n)
+	}
+	rv.rfunc, rv.isFunc, rv.isClo = isFuncName(n)
+	rv.global, rv.isGlobal = isGlobalVar(n)
 
 	if debugTrace&debugTraceResults != 0 {
-		fmt.Fprintf(os.Stderr, "=-= %v: analyzeResult n=%s ismem=%v isconcconv=%v isconst=%v isfunc=%v isclo=%v\n", ir.Line(n), n.Op().String(), isAllocMem, isConcConvItf, isConst, isFunc, isClo)
+		traceEvent("visit", "analyzer", "returns", "pos", ir.Line(n),
+			"op", n.Op(), "result", ii, "ismem", rv.isAllocMem, "isfresh", rv.isFresh,
+			"isconcconv", rv.isConcConvItf, "isconst", rv.isConst,
+			"isfunc", rv.isFunc, "isclo", rv.isClo, "isglobal", rv.isGlobal)
+	}
+	ra.meetResult(ii, rv)
+}
+
+// resultObs records what we can tell about a value returned in a
+// given result slot by a particular return statement.
+type resultObs struct {
+	isAllocMem    bool
+	isFresh       bool
+	isConcConvItf bool
+	isConst       bool
+	isFunc        bool
+	isClo         bool
+	isGlobal      bool
+	lit           constant.Value
+	rfunc         *ir.Name
+	global        *ir.Name
+}
+
+// analyzeNamedResult handles the 'ii'th result of a bare "return"
+// statement, which returns the current value of the corresponding
+// named result. If the named result is never assigned (including by
+// a deferred call after the return, see namedResultsWrittenLate),
+// the value returned is the zero value for its type; otherwise (or if
+// the result is unnamed or blank) nothing is known about it.
+func (ra *returnsAnalyzer) analyzeNamedResult(ii int) {
+	var rv resultObs
+	if name := ra.named[ii]; name != nil && !ir.Reassigned(name) && !ra.writtenLate(ii) {
+		rv.lit, rv.isConst = zeroLiteral(name.Type())
+	}
+	ra.meetResult(ii, rv)
+}
+
+// writtenLate reports whether the named result in slot 'ii' may be
+// written after a return statement has set it (see
+// namedResultsWrittenLate).
+func (ra *returnsAnalyzer) writtenLate(ii int) bool {
+	if ra.named[ii] == nil {
+		return false
+	}
+	if ra.lateWrites == nil {
+		ra.lateWrites = namedResultsWrittenLate(ra.fn, ra.named)
+	}
+	return ra.lateWrites[ii]
+}
+
+// namedResultsWrittenLate returns, for each of the named results
+// 'named' of 'fn' (nil for a slot with an unnamed or blank result),
+// whether the result may be written after a return statement has
+// set it, in which case the value returned isn't necessarily the one
+// in the return statement. This is conservatively assumed for all
+// named results if 'fn' contains a "defer" (whose deferred call may
+// be passed the address of a result, as well as being a closure),
+// and otherwise for a result that is assigned (or has its address
+// taken) within a function literal nested in 'fn', as in:
+//
+//	defer func() {
+//		if r := recover(); r != nil {
+//			err = ErrX
+//		}
+//	}()
+func namedResultsWrittenLate(fn *ir.Func, named []*ir.Name) []bool {
+	late := make([]bool, len(named))
+	if ir.Any(fn, func(n ir.Node) bool { return n.Op() == ir.ODEFER }) {
+		for i, name := range named {
+			late[i] = name != nil
+		}
+		return late
+	}
+	mark := func(x ir.Node) {
+		if x == nil {
+			return
+		}
+		name, ok := ir.OuterValue(x).(*ir.Name)
+		if !ok {
+			return
+		}
+		for i, r := range named {
+			if r != nil && name.Canonical() == r {
+				late[i] = true
+			}
+		}
+	}
+	var visitClosure func(clo *ir.Func)
+	visitClosure = func(clo *ir.Func) {
+		ir.VisitList(clo.Body, func(n ir.Node) {
+			switch n.Op() {
+			case ir.OAS:
+				mark(n.(*ir.AssignStmt).X)
+			case ir.OAS2, ir.OAS2FUNC, ir.OAS2MAPR, ir.OAS2DOTTYPE, ir.OAS2RECV, ir.OSELRECV2:
+				for _, lhs := range n.(*ir.AssignListStmt).Lhs {
+					mark(lhs)
+				}
+			case ir.OASOP:
+				mark(n.(*ir.AssignOpStmt).X)
+			case ir.OADDR:
+				mark(n.(*ir.AddrExpr).X)
+			case ir.ORANGE:
+				rs := n.(*ir.RangeStmt)
+				mark(rs.Key)
+				mark(rs.Value)
+			case ir.OCLOSURE:
+				visitClosure(n.(*ir.ClosureExpr).Func)
+			}
+		})
+	}
+	ir.VisitList(fn.Body, func(n ir.Node) {
+		if n.Op() == ir.OCLOSURE {
+			visitClosure(n.(*ir.ClosureExpr).Func)
+		}
+	})
+	return late
+}
+
+// meetResult applies a dataflow "meet" operation to combine the
+// observation 'rv' for a value returned in result slot 'ii' with any
+// previous observations for that slot.
+func (ra *returnsAnalyzer) meetResult(ii int, rv resultObs) {
+	if !ra.values[ii].top && ra.props[ii] == ResultNoInfo {
+		// Already at the bottom of the lattice.
+		return
 	}
+	isAllocMem, isConcConvItf := rv.isAllocMem, rv.isConcConvItf
+	lit, isConst := rv.lit, rv.isConst
+	rfunc, isFunc, isClo := rv.rfunc, rv.isFunc, rv.isClo
+	global, isGlobal := rv.global, rv.isGlobal
+	curp := ra.props[ii]
+	newp := ResultNoInfo
+	var newlit constant.Value
+	var newfunc, newglobal *ir.Name
 
 	if ra.values[ii].top {
 		ra.values[ii].top = false
+		ra.values[ii].fresh = rv.isFresh
 		// this is the first return we've seen; record
 		// whatever properties it has.
 		switch {
@@ -172,19 +387,23 @@ func (ra *returnsAnalyzer) analyzeResult(ii int, n ir.Node) {
 		case isConst:
 			newp = ResultAlwaysSameConstant
 			newlit = lit
+		case isGlobal:
+			newp = ResultAlwaysSameGlobal
+			newglobal = global
 		}
 	} else {
 		// this is not the first return we've seen; apply
 		// what amounts of a "meet" operator to combine
 		// the properties we see here with what we saw on
 		// the previous returns.
+		ra.values[ii].fresh = ra.values[ii].fresh && rv.isFresh
 		switch curp {
 		case ResultIsAllocatedMem:
-			if isAllocatedMem(n) {
+			if isAllocMem {
 				newp = ResultIsAllocatedMem
 			}
 		case ResultIsConcreteTypeConvertedToInterface:
-			if isConcreteConvIface(n) {
+			if isConcConvItf {
 				newp = ResultIsConcreteTypeConvertedToInterface
 			}
 		case ResultAlwaysSameConstant:
@@ -197,16 +416,22 @@ func (ra *returnsAnalyzer) analyzeResult(ii int, n ir.Node) {
 				newp = ResultAlwaysSameFunc
 				newfunc = rfunc
 			}
+		case ResultAlwaysSameGlobal:
+			if isGlobal && global == ra.values[ii].global {
+				newp = ResultAlwaysSameGlobal
+				newglobal = global
+			}
 		}
 	}
 	ra.values[ii].fn = newfunc
 	ra.values[ii].fnClo = isClo
+	ra.values[ii].global = newglobal
 	ra.values[ii].lit = newlit
 	ra.props[ii] = newp
 
 	if debugTrace&debugTraceResults != 0 {
-		fmt.Fprintf(os.Stderr, "=-= %v: analyzeResult newp=%s\n",
-			ir.Line(n), newp)
+		traceEvent("result", "analyzer", "returns", "result", ii,
+			"props", newp)
 	}
 
 }
@@ -220,6 +445,165 @@ func isAllocatedMem(n ir.Node) bool {
 	return false
 }
 
+// isFreshAlloc reports whether 'n', a value returned by the function
+// being analyzed, is a pointer to memory allocated by "new" or
+// "&T{...}", either directly or via a local variable that holds a
+// fresh allocation (see freshAllocVars).
+func (ra *returnsAnalyzer) isFreshAlloc(n ir.Node) bool {
+	for n.Op() == ir.OCONVNOP {
+		n = n.(*ir.ConvExpr).X
+	}
+	switch n.Op() {
+	case ir.ONEW, ir.OPTRLIT:
+		return true
+	case ir.ONAME:
+		if ra.freshVars == nil {
+			ra.freshVars = freshAllocVars(ra.fn)
+		}
+		return ra.freshVars[n.(*ir.Name).Canonical()]
+	}
+	return false
+}
+
+// freshAllocVars returns the set of local variables in 'fn' that are
+// assigned once, from "new" or "&T{...}", and through which the
+// memory allocated can't leak. As with noEscapeCalls, this is a
+// syntactic approximation of escape analysis: the variable must not
+// have its address taken or be captured by a closure, and each use
+// of it must read or write through it without taking the address of
+// the memory it points to (see usedSafely), or return it.
+func freshAllocVars(fn *ir.Func) map[*ir.Name]bool {
+	vars := make(map[*ir.Name]bool)
+	bad := make(map[*ir.Name]bool)
+	addressed := make(map[ir.Node]bool)
+	var visit func(n, parent ir.Node, inClosure bool)
+	visit = func(n, parent ir.Node, inClosure bool) {
+		if n == nil {
+			return
+		}
+		switch n.Op() {
+		case ir.OCLOSURE:
+			clo := n.(*ir.ClosureExpr)
+			ir.DoChildren(clo.Func, func(c ir.Node) bool {
+				visit(c, n, true)
+				return false
+			})
+			return
+		case ir.OADDR:
+			addressed[addrBase(n.(*ir.AddrExpr).X)] = true
+		case ir.ONAME:
+			name := n.(*ir.Name).Canonical()
+			if inClosure || !(usedSafely(n, parent) || parent.Op() == ir.ORETURN) {
+				bad[name] = true
+			}
+			return
+		case ir.OAS:
+			as := n.(*ir.AssignStmt)
+			if !inClosure && as.X != nil && as.X.Op() == ir.ONAME &&
+				as.Y != nil && (as.Y.Op() == ir.ONEW || as.Y.Op() == ir.OPTRLIT) {
+				name := as.X.(*ir.Name)
+				if name.Class == ir.PAUTO && !name.Addrtaken() && !ir.Reassigned(name) {
+					vars[name] = true
+					// The assignment is not a use of the variable.
+					for _, c := range as.Init() {
+						visit(c, n, inClosure)
+					}
+					visit(as.Y, n, inClosure)
+					return
+				}
+			}
+		}
+		ir.DoChildren(n, func(c ir.Node) bool {
+			visit(c, n, inClosure)
+			return false
+		})
+	}
+	ir.DoChildren(fn, func(c ir.Node) bool {
+		visit(c, fn, false)
+		return false
+	})
+	for name := range vars {
+		if bad[name] || addressed[name] {
+			delete(vars, name)
+		}
+	}
+	return vars
+}
+
+// multiValueCallResult returns the call and result index that
+// produced the value of 'n', a result of a return statement, if it
+// comes from one of the results of a call with multiple results.
+// The front end assigns the results of such a call to temporaries
+// (see noder's reader.multiExpr) in an OAS2FUNC that is placed in
+// the init list of the expression for the first result; here 'inits'
+// is that init list. This covers "return f()", along with code of
+// the form
+//
+//	v, err := f()
+//	return v, err
+//
+// as long as 'v' and 'err' are not reassigned.
+func multiValueCallResult(n ir.Node, inits ir.Nodes) (*ir.CallExpr, int, bool) {
+	for n.Op() == ir.OCONVNOP {
+		n = n.(*ir.ConvExpr).X
+	}
+	if n.Op() != ir.ONAME {
+		return nil, 0, false
+	}
+	name := n.(*ir.Name)
+	if name.Class != ir.PAUTO {
+		return nil, 0, false
+	}
+	if !name.AutoTemp() {
+		// Follow a user variable defined by an assignment from the
+		// temporaries back to the call.
+		if name.Defn == nil || name.Defn.Op() != ir.OAS2 || ir.Reassigned(name) {
+			return nil, 0, false
+		}
+		as := name.Defn.(*ir.AssignListStmt)
+		for i, lhs := range as.Lhs {
+			if lhs == name && len(as.Rhs) == len(as.Lhs) {
+				return multiValueCallResult(as.Rhs[i], as.Rhs[0].Init())
+			}
+		}
+		return nil, 0, false
+	}
+	for _, init := range inits {
+		if init.Op() != ir.OAS2FUNC {
+			continue
+		}
+		as := init.(*ir.AssignListStmt)
+		call, ok := as.Rhs[0].(*ir.CallExpr)
+		if !ok || call.Op() != ir.OCALLFUNC {
+			continue
+		}
+		for i, lhs := range as.Lhs {
+			if lhs == name {
+				return call, i, true
+			}
+		}
+	}
+	return nil, 0, false
+}
+
+// zeroLiteral returns the zero value for type 't' in the form
+// returned by isLiteral, along with a boolean indicating success.
+func zeroLiteral(t *types.Type) (constant.Value, bool) {
+	switch {
+	case t.HasNil():
+		return nil, true
+	case t.IsBoolean():
+		return constant.MakeBool(false), true
+	case t.IsInteger():
+		return constant.MakeInt64(0), true
+	case t.IsFloat():
+		return constant.MakeFloat64(0), true
+	case t.IsString():
+		return constant.MakeString(""), true
+	}
+	return nil, false
+}
+
 func isLiteral(n ir.Node) (constant.Value, bool) {
 	sv := ir.StaticValue(n)
 	switch sv.Op() {
@@ -231,6 +615,23 @@ func isLiteral(n ir.Node) (constant.Value, bool) {
 	return nil, false
 }
 
+// isNilErrorCall reports whether 'n' is a call (possibly via a local
+// variable that is assigned only once) to a function with a single
+// error result that is always nil (see ResultErrorAlwaysNil).
+func isNilErrorCall(n ir.Node) bool {
+	sv := ir.StaticValue(n)
+	if sv.Op() != ir.OCALLFUNC {
+		return false
+	}
+	callee := staticCallee(sv.(*ir.CallExpr))
+	if callee == nil {
+		return false
+	}
+	fp := propsForFunc(callee)
+	return fp != nil && len(fp.ResultFlags) == 1 &&
+		fp.ResultFlags[0]&ResultErrorAlwaysNil != 0
+}
+
 // isSameLiteral checks to see if 'v1' and 'v2' correspond to the same
 // literal value, or if they are both nil.
 func isSameLiteral(v1, v2 constant.Value) bool {
@@ -251,6 +652,19 @@ func isConcreteConvIface(n ir.Node) bool {
 	return !sv.(*ir.ConvExpr).X.Type().IsInterface()
 }
 
+// isGlobalVar returns the package-level variable that 'n' refers to,
+// along with a boolean indicating success.
+func isGlobalVar(n ir.Node) (*ir.Name, bool) {
+	if n.Op() != ir.ONAME {
+		return nil, false
+	}
+	name := n.(*ir.Name)
+	if name.Class != ir.PEXTERN {
+		return nil, false
+	}
+	return name, true
+}
+
 func isSameFuncName(v1, v2 *ir.Name) bool {
 	// NB: there are a few corner cases where pointer equality
 	// doesn't work here, but this should be good enough for
@@ -0,0 +1,689 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"cmd/compile/internal/ir"
+	"strings"
+)
+
+// shapeAnalyzer looks for functions whose bodies match one of a
+// small number of well-known "shapes" (for example, a lock-free CAS
+// retry loop) and sets the corresponding function flags. Unlike the
+// other analyzers, it doesn't need to accumulate any state during the
+// node walk; each shape check is applied to the function body as a
+// whole once the walk is complete.
+type shapeAnalyzer struct {
+	fn *ir.Func
+}
+
+func makeShapeAnalyzer(fn *ir.Func) *shapeAnalyzer {
+	return &shapeAnalyzer{
+		fn: fn,
+	}
+}
+
+func (sa *shapeAnalyzer) nodeVisitPre(n ir.Node) {
+}
+
+func (sa *shapeAnalyzer) nodeVisitPost(n ir.Node) {
+}
+
+func (sa *shapeAnalyzer) name() string {
+	return "shapes"
+}
+
+// setResults transfers any shape-related function flags to 'fp'.
+func (sa *shapeAnalyzer) setResults(fp *FuncProps) {
+	var rv FuncPropBits
+	if isCASLoop(sa.fn) {
+		rv |= FuncPropCASLoop
+	}
+	if wrapper, trivial := isWrapper(sa.fn); wrapper {
+		rv |= FuncPropIsWrapper
+		if trivial {
+			rv |= FuncPropTrivialWrapper
+		}
+	}
+	if isTailRecursive(sa.fn) {
+		rv |= FuncPropTailRecursive
+	}
+	if isSyscallWrapper(sa.fn) {
+		rv |= FuncPropSyscallWrapper
+	}
+	if isArrayConstructor(sa.fn) {
+		rv |= FuncPropArrayConstructor
+	}
+	if isEndianConv(sa.fn) {
+		rv |= FuncPropEndianConv
+	}
+	if isFormatWrapper(sa.fn) {
+		rv |= FuncPropFormatWrapper
+	}
+	if isStraightLine(sa.fn) {
+		rv |= FuncPropStraightLine
+	}
+	if isLeaf(sa.fn) {
+		rv |= FuncPropIsLeaf
+	} else if isNearlyLeaf(sa.fn) {
+		rv |= FuncPropNearlyLeaf
+	}
+	if debugTrace&debugTraceFuncFlags != 0 {
+		traceEvent("result", "analyzer", "shapes", "func", sa.fn.Sym().Name,
+			"flags", rv)
+	}
+	fp.Flags |= rv
+}
+
+// stmtsNoDcl returns the statements in 'list' minus any declarations
+// (ODCL nodes, or zeroing assignments of the form "var x T"), which
+// are not interesting from a shape perspective.
+func stmtsNoDcl(list ir.Nodes) []ir.Node {
+	var rv []ir.Node
+	for _, n := range list {
+		if n.Op() == ir.ODCL {
+			continue
+		}
+		if n.Op() == ir.OAS && n.(*ir.AssignStmt).Y == nil {
+			continue
+		}
+		rv = append(rv, n)
+	}
+	return rv
+}
+
+// isCASLoop reports whether the body of 'fn' is a single
+// unconditional "for" loop that performs an atomic load followed by
+// an "if" whose condition is an atomic compare-and-swap and whose
+// body exits the loop, for example:
+//
+//	func incr(p *int32) {
+//		for {
+//			old := atomic.LoadInt32(p)
+//			if atomic.CompareAndSwapInt32(p, old, old+1) {
+//				return
+//			}
+//		}
+//	}
+//
+// A trailing "return" after the loop is permitted, so as to allow
+// for loops that exit via "break".
+func isCASLoop(fn *ir.Func) bool {
+	stmts := stmtsNoDcl(fn.Body)
+	switch {
+	case len(stmts) == 1:
+	case len(stmts) == 2 && stmts[1].Op() == ir.ORETURN:
+	default:
+		return false
+	}
+	if stmts[0].Op() != ir.OFOR {
+		return false
+	}
+	loop := stmts[0].(*ir.ForStmt)
+	if loop.Cond != nil || loop.Post != nil {
+		return false
+	}
+	sawLoad, sawCAS := false, false
+	for _, s := range stmtsNoDcl(loop.Body) {
+		switch s.Op() {
+		case ir.OAS:
+			as := s.(*ir.AssignStmt)
+			if as.Y == nil || !isAtomicCall(as.Y, "Load") {
+				return false
+			}
+			sawLoad = true
+		case ir.OIF:
+			ifs := s.(*ir.IfStmt)
+			if !sawLoad || sawCAS || len(ifs.Else) != 0 {
+				return false
+			}
+			if !isAtomicCall(ifs.Cond, "CompareAndSwap", "Cas") {
+				return false
+			}
+			if len(ifs.Body) == 0 || !exitsLoop(ifs.Body[len(ifs.Body)-1]) {
+				return false
+			}
+			sawCAS = true
+		default:
+			return false
+		}
+	}
+	return sawLoad && sawCAS
+}
+
+// exitsLoop reports whether 'n' is an unlabeled "break" or a
+// "return" statement.
+func exitsLoop(n ir.Node) bool {
+	switch n.Op() {
+	case ir.ORETURN:
+		return true
+	case ir.OBREAK:
+		return n.(*ir.BranchStmt).Label == nil
+	}
+	return false
+}
+
+// isAtomicCall reports whether 'n' is a call to a function or method
+// from one of the atomic packages whose name begins with one of
+// the prefixes in 'prefixes' (e.g. "Load" matches both
+// atomic.LoadInt32 and atomic.(*Int64).Load).
+func isAtomicCall(n ir.Node, prefixes ...string) bool {
+	if n.Op() != ir.OCALLFUNC {
+		return false
+	}
+	call := n.(*ir.CallExpr)
+	var name *ir.Name
+	switch call.X.Op() {
+	case ir.ONAME:
+		name = call.X.(*ir.Name)
+		if name.Class != ir.PFUNC {
+			return false
+		}
+	case ir.OMETHEXPR:
+		name = ir.MethodExprName(call.X)
+	}
+	if name == nil || name.Sym() == nil {
+		return false
+	}
+	s := name.Sym()
+	if s.Pkg.Path != "sync/atomic" && s.Pkg.Path != "runtime/internal/atomic" {
+		return false
+	}
+	fname := s.Name
+	if i := strings.LastIndex(fname, "."); i >= 0 {
+		// method, e.g. "(*Int64).Load"
+		fname = fname[i+1:]
+	}
+	for _, p := range prefixes {
+		if strings.HasPrefix(fname, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWrapper reports whether 'fn' is a simple forwarding wrapper,
+// meaning that its body consists of a single call to a statically
+// known function (either as an expression statement, or as the sole
+// result of a "return" statement), where the arguments passed are
+// exactly the parameters of 'fn' (including the receiver, if any) in
+// order. Variadic forwarding ("g(args...)") is allowed, as is the
+// insertion of a single constant argument at any position. Examples:
+//
+//	func F(a, b int) int { return g(a, b) }
+//	func V(args ...any) { h(args...) }
+//	func C(x int) int { return k(x, 3) }
+//
+// The second result reports whether the wrapper is trivial, meaning
+// that it passes its parameters and nothing else (F and V above, but
+// not C).
+func isWrapper(fn *ir.Func) (wrapper, trivial bool) {
+	stmts := stmtsNoDcl(fn.Body)
+	if len(stmts) != 1 {
+		return false, false
+	}
+	var call *ir.CallExpr
+	switch s := stmts[0]; s.Op() {
+	case ir.OCALLFUNC:
+		if fn.Type().NumResults() != 0 {
+			return false, false
+		}
+		call = s.(*ir.CallExpr)
+	case ir.ORETURN:
+		rs := s.(*ir.ReturnStmt)
+		if len(rs.Results) != 1 || rs.Results[0].Op() != ir.OCALLFUNC {
+			return false, false
+		}
+		call = rs.Results[0].(*ir.CallExpr)
+		if call.X.Type().NumResults() != fn.Type().NumResults() {
+			return false, false
+		}
+	default:
+		return false, false
+	}
+	if len(call.Init()) != 0 {
+		return false, false
+	}
+	if call.X.Op() != ir.OMETHEXPR {
+		if call.X.Op() != ir.ONAME || call.X.(*ir.Name).Class != ir.PFUNC {
+			return false, false
+		}
+	}
+
+	// Walk the args, matching them up against the params.
+	params := fn.Type().RecvParams()
+	if call.IsDDD != fn.Type().IsVariadic() {
+		return false, false
+	}
+	pidx, sawConst := 0, false
+	for _, arg := range call.Args {
+		for arg.Op() == ir.OCONVNOP {
+			arg = arg.(*ir.ConvExpr).X
+		}
+		if pidx < len(params) && arg.Op() == ir.ONAME &&
+			arg.(*ir.Name) == params[pidx].Nname {
+			pidx++
+			continue
+		}
+		if !sawConst && (arg.Op() == ir.OLITERAL || arg.Op() == ir.ONIL) {
+			sawConst = true
+			continue
+		}
+		return false, false
+	}
+	if pidx != len(params) {
+		return false, false
+	}
+	return true, !sawConst
+}
+
+// isTailRecursive reports whether 'fn' contains a "return" statement
+// whose sole result is a direct call to 'fn' itself. Calls made from
+// closures nested within 'fn' are not considered.
+func isTailRecursive(fn *ir.Func) bool {
+	return ir.Any(fn, func(n ir.Node) bool {
+		call := tailCall(n)
+		return call != nil && staticCallee(call) == fn
+	})
+}
+
+// isSyscallWrapper reports whether 'fn' is a thin wrapper around a
+// system call, with some error translation, for example:
+//
+//	func open(p string) (*File, error) {
+//		fd, err := syscall.Open(p, syscall.O_RDONLY, 0)
+//		if err != nil {
+//			return nil, &PathError{Op: "open", Path: p, Err: err}
+//		}
+//		return &File{fd}, nil
+//	}
+//
+// Specifically, the body must consist of an assignment whose
+// right-hand side is a call to a function in the syscall package,
+// followed by one or more "if" statements (with no "else") that test
+// one of the assigned variables and then return, followed by a final
+// "return" statement.
+func isSyscallWrapper(fn *ir.Func) bool {
+	stmts := stmtsNoDcl(fn.Body)
+	if len(stmts) < 3 || stmts[len(stmts)-1].Op() != ir.ORETURN {
+		return false
+	}
+	var lhs []ir.Node
+	switch s := stmts[0]; s.Op() {
+	case ir.OAS:
+		as := s.(*ir.AssignStmt)
+		if as.Y == nil || !isSyscallCall(as.Y) {
+			return false
+		}
+		lhs = []ir.Node{as.X}
+	case ir.OAS2FUNC:
+		as := s.(*ir.AssignListStmt)
+		if !isSyscallCall(as.Rhs[0]) {
+			return false
+		}
+		lhs = as.Lhs
+	case ir.OAS2:
+		// The front end may rewrite "a, b := f()" into an OAS2
+		// whose right-hand side values are (converted) temporaries
+		// assigned by an OAS2FUNC tucked away in an init list.
+		as := s.(*ir.AssignListStmt)
+		if !hasSyscallAssignInit(as.Rhs[0]) {
+			return false
+		}
+		lhs = as.Lhs
+	default:
+		return false
+	}
+	for _, s := range stmts[1 : len(stmts)-1] {
+		if s.Op() != ir.OIF {
+			return false
+		}
+		ifs := s.(*ir.IfStmt)
+		if len(ifs.Else) != 0 || len(ifs.Body) != 1 ||
+			ifs.Body[0].Op() != ir.ORETURN {
+			return false
+		}
+		if ifs.Cond.Op() != ir.ONE && ifs.Cond.Op() != ir.OEQ {
+			return false
+		}
+		cmp := ifs.Cond.(*ir.BinaryExpr)
+		if !isAssignedBy(cmp.X, lhs) && !isAssignedBy(cmp.Y, lhs) {
+			return false
+		}
+	}
+	return true
+}
+
+// isSyscallCall reports whether 'n' is a direct call to a function
+// in the syscall package (or one of the internal/syscall packages).
+func isSyscallCall(n ir.Node) bool {
+	if n.Op() != ir.OCALLFUNC {
+		return false
+	}
+	call := n.(*ir.CallExpr)
+	if call.X.Op() != ir.ONAME {
+		return false
+	}
+	name := call.X.(*ir.Name)
+	if name.Class != ir.PFUNC || name.Sym() == nil {
+		return false
+	}
+	path := name.Sym().Pkg.Path
+	return path == "syscall" || strings.HasPrefix(path, "internal/syscall/")
+}
+
+// hasSyscallAssignInit reports whether 'n' (or the expression
+// wrapped by 'n', if it is a no-op conversion) has an init statement
+// that assigns the results of a syscall call (see isSyscallCall).
+func hasSyscallAssignInit(n ir.Node) bool {
+	for {
+		for _, in := range n.Init() {
+			if in.Op() == ir.OAS2FUNC &&
+				isSyscallCall(in.(*ir.AssignListStmt).Rhs[0]) {
+				return true
+			}
+		}
+		if n.Op() != ir.OCONVNOP {
+			return false
+		}
+		n = n.(*ir.ConvExpr).X
+	}
+}
+
+// isAssignedBy reports whether 'n' is a (non-blank) name appearing
+// in 'lhs'.
+func isAssignedBy(n ir.Node, lhs []ir.Node) bool {
+	if n.Op() != ir.ONAME || ir.IsBlank(n) {
+		return false
+	}
+	for _, l := range lhs {
+		if l == n {
+			return true
+		}
+	}
+	return false
+}
+
+// isArrayConstructor reports whether the body of 'fn' consists of a
+// single "return" statement whose result is an array (not slice)
+// composite literal, for example:
+//
+//	func rgba(r, g, b, a byte) [4]byte { return [4]byte{r, g, b, a} }
+//
+// Unlike slice literals, array literals don't require a heap
+// allocation, and once inlined the array can often be kept in
+// registers or on the caller's stack.
+func isArrayConstructor(fn *ir.Func) bool {
+	stmts := stmtsNoDcl(fn.Body)
+	if len(stmts) != 1 || stmts[0].Op() != ir.ORETURN {
+		return false
+	}
+	rs := stmts[0].(*ir.ReturnStmt)
+	return len(rs.Results) == 1 && rs.Results[0].Op() == ir.OARRAYLIT
+}
+
+// isEndianConv reports whether the body of 'fn' consists of a single
+// byte order conversion from the encoding/binary package applied to
+// the params of 'fn', either as the sole result of a "return"
+// statement (possibly with a numeric conversion of the result) or as
+// an expression statement, for example:
+//
+//	func u32(b []byte) uint32 { return binary.LittleEndian.Uint32(b) }
+//	func put16(b []byte, v uint16) { binary.BigEndian.PutUint16(b[2:], v) }
+//
+// The arguments must be params, constants, or slices or indexings of
+// params by constants and params.
+func isEndianConv(fn *ir.Func) bool {
+	stmts := stmtsNoDcl(fn.Body)
+	if len(stmts) != 1 {
+		return false
+	}
+	var n ir.Node
+	switch s := stmts[0]; s.Op() {
+	case ir.OCALLFUNC:
+		n = s
+	case ir.ORETURN:
+		rs := s.(*ir.ReturnStmt)
+		if len(rs.Results) != 1 {
+			return false
+		}
+		n = rs.Results[0]
+		for n.Op() == ir.OCONV || n.Op() == ir.OCONVNOP {
+			n = n.(*ir.ConvExpr).X
+		}
+	default:
+		return false
+	}
+	if n.Op() != ir.OCALLFUNC {
+		return false
+	}
+	call := n.(*ir.CallExpr)
+	if len(call.Init()) != 0 || !isByteOrderMethod(call.X) {
+		return false
+	}
+	sawParam := false
+	for _, arg := range call.Args {
+		switch isParamDerived(fn, arg) {
+		case paramDerivedNo:
+			// The receiver (e.g. binary.LittleEndian) may appear as
+			// the first argument of a method expression call.
+			if call.X.Op() != ir.OMETHEXPR || arg != call.Args[0] {
+				return false
+			}
+		case paramDerivedYes:
+			sawParam = true
+		}
+	}
+	return sawParam
+}
+
+// isByteOrderMethod reports whether 'n' refers to one of the
+// fixed-size integer conversion methods (Uint32, PutUint16,
+// AppendUint64 and so on) of a byte order type from the
+// encoding/binary package.
+func isByteOrderMethod(n ir.Node) bool {
+	if n.Op() != ir.OMETHEXPR && n.Op() != ir.ODOTMETH {
+		return false
+	}
+	name := ir.MethodExprName(n)
+	if name == nil || name.Sym() == nil {
+		return false
+	}
+	s := name.Sym()
+	if s.Pkg.Path != "encoding/binary" {
+		return false
+	}
+	mname := s.Name
+	if i := strings.LastIndex(mname, "."); i >= 0 {
+		mname = mname[i+1:]
+	}
+	for _, p := range []string{"Uint", "PutUint", "AppendUint"} {
+		if strings.HasPrefix(mname, p) {
+			return true
+		}
+	}
+	return false
+}
+
+type paramDerived int
+
+const (
+	paramDerivedNo    paramDerived = iota // not derived from params
+	paramDerivedConst                     // constant
+	paramDerivedYes                       // derived from params
+)
+
+// isParamDerived classifies expression 'n' within 'fn' as either a
+// constant, a param of 'fn' (possibly converted, or sliced or indexed
+// by constants and params), or something else.
+func isParamDerived(fn *ir.Func, n ir.Node) paramDerived {
+	switch n.Op() {
+	case ir.OLITERAL, ir.ONIL:
+		return paramDerivedConst
+	case ir.ONAME:
+		if n.(*ir.Name).Class == ir.PPARAM && n.(*ir.Name).Curfn == fn {
+			return paramDerivedYes
+		}
+	case ir.OCONV, ir.OCONVNOP:
+		return isParamDerived(fn, n.(*ir.ConvExpr).X)
+	case ir.OINDEX:
+		ix := n.(*ir.IndexExpr)
+		if isParamDerived(fn, ix.Index) != paramDerivedNo &&
+			isParamDerived(fn, ix.X) == paramDerivedYes {
+			return paramDerivedYes
+		}
+	case ir.OSLICE, ir.OSLICEARR:
+		sl := n.(*ir.SliceExpr)
+		for _, b := range []ir.Node{sl.Low, sl.High, sl.Max} {
+			if b != nil && isParamDerived(fn, b) == paramDerivedNo {
+				return paramDerivedNo
+			}
+		}
+		if isParamDerived(fn, sl.X) == paramDerivedYes {
+			return paramDerivedYes
+		}
+	}
+	return paramDerivedNo
+}
+
+// isFormatWrapper reports whether the body of 'fn' consists of a
+// single printf-style formatting call (see formatArg), either as an
+// expression statement or as the sole result of a "return"
+// statement, where the format string is either a constant or a
+// param of 'fn', for example:
+//
+//	func errorf(format string, args ...any) error {
+//		return fmt.Errorf(format, args...)
+//	}
+//
+// The restriction on the format string means that whether or not it
+// is constant can be determined at each call site.
+func isFormatWrapper(fn *ir.Func) bool {
+	stmts := stmtsNoDcl(fn.Body)
+	if len(stmts) != 1 {
+		return false
+	}
+	var n ir.Node
+	switch s := stmts[0]; s.Op() {
+	case ir.OCALLFUNC:
+		n = s
+	case ir.ORETURN:
+		rs := s.(*ir.ReturnStmt)
+		if len(rs.Results) != 1 {
+			return false
+		}
+		n = rs.Results[0]
+		for n.Op() == ir.OCONV || n.Op() == ir.OCONVNOP {
+			n = n.(*ir.ConvExpr).X
+		}
+	default:
+		return false
+	}
+	if n.Op() != ir.OCALLFUNC {
+		return false
+	}
+	format := formatArg(n.(*ir.CallExpr))
+	if format == nil {
+		return false
+	}
+	if format.Op() == ir.ONAME {
+		return format.(*ir.Name).Class == ir.PPARAM
+	}
+	return ir.IsConstNode(format)
+}
+
+// formatArg returns the format string argument of 'call' (with any
+// no-op conversions removed) if it is a direct call to one of the
+// printf-style functions from the fmt package (Sprintf, Fprintf,
+// Errorf and so on), or nil otherwise. In all such functions the
+// format string is the param immediately preceding the final
+// variadic "...any" param.
+func formatArg(call *ir.CallExpr) ir.Node {
+	if call.X.Op() != ir.ONAME {
+		return nil
+	}
+	name := call.X.(*ir.Name)
+	if name.Class != ir.PFUNC || name.Sym() == nil ||
+		name.Sym().Pkg.Path != "fmt" ||
+		!strings.HasSuffix(name.Sym().Name, "f") {
+		return nil
+	}
+	typ := name.Type()
+	np := typ.NumParams()
+	if !typ.IsVariadic() || np < 2 || len(call.Args) < np-1 {
+		return nil
+	}
+	if !typ.Param(np - 2).Type.IsString() {
+		return nil
+	}
+	format := call.Args[np-2]
+	for format.Op() == ir.OCONVNOP {
+		format = format.(*ir.ConvExpr).X
+	}
+	return format
+}
+
+// isStraightLine reports whether the body of 'fn' (not including any
+// nested closures) is free of control flow, meaning that it contains
+// no "if", "for", "range", "switch" or "select" statements, gotos, or
+// labels. Note that "return" statements are permitted; since any
+// statement following a "return" in the same block is unreachable,
+// such a function still has a single basic block.
+func isStraightLine(fn *ir.Func) bool {
+	return !ir.Any(fn, func(n ir.Node) bool {
+		switch n.Op() {
+		case ir.OIF, ir.OFOR, ir.ORANGE, ir.OSWITCH, ir.OSELECT,
+			ir.OGOTO, ir.OLABEL:
+			return true
+		}
+		return false
+	})
+}
+
+// isLeaf reports whether 'fn' makes no calls, apart from calls to
+// intrinsics (see FuncPropIsLeaf).
+func isLeaf(fn *ir.Func) bool {
+	return !ir.Any(fn, func(n ir.Node) bool {
+		switch n.Op() {
+		case ir.OCALLFUNC:
+			return !ir.IsIntrinsicCall(n.(*ir.CallExpr))
+		case ir.OCALLINTER, ir.OCALLMETH:
+			return true
+		}
+		return false
+	})
+}
+
+// maxNearlyLeafCalleeCost is the largest inline cost of a callee that
+// a nearly leaf function may call (see FuncPropNearlyLeaf).
+const maxNearlyLeafCalleeCost = 20
+
+// isNearlyLeaf reports whether each call made by 'fn' (apart from
+// calls to intrinsics) is a direct call to an inlinable leaf function
+// whose inline cost is at most maxNearlyLeafCalleeCost. This looks
+// only one level down, at the properties already computed for the
+// callees: those in the same package are analyzed before their
+// callers, and those imported come with their export data.
+func isNearlyLeaf(fn *ir.Func) bool {
+	return !ir.Any(fn, func(n ir.Node) bool {
+		switch n.Op() {
+		case ir.OCALLFUNC:
+			call := n.(*ir.CallExpr)
+			if ir.IsIntrinsicCall(call) {
+				return false
+			}
+			callee := staticCallee(call)
+			if callee == nil || callee == fn || callee.Inl == nil ||
+				callee.Inl.Cost > maxNearlyLeafCalleeCost {
+				return true
+			}
+			fp := propsForFunc(callee)
+			return fp == nil || fp.Flags&FuncPropIsLeaf == 0
+		case ir.OCALLINTER, ir.OCALLMETH:
+			return true
+		}
+		return false
+	})
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import "cmd/compile/internal/ir"
+
+// unsafeAnalyzer looks for uses of package unsafe within a function:
+// conversions to or from unsafe.Pointer, and calls to the unsafe
+// builtins that manipulate pointers (unsafe.Add, unsafe.Slice and so
+// on). Note that unsafe.Sizeof, unsafe.Alignof and unsafe.Offsetof
+// are evaluated to constants by the type checker, and so are not
+// (and needn't be) detected. Since the node walk doesn't descend into
+// closures, uses within nested function literals are not counted.
+type unsafeAnalyzer struct {
+	fn         *ir.Func
+	usesUnsafe bool
+}
+
+func makeUnsafeAnalyzer(fn *ir.Func) *unsafeAnalyzer {
+	return &unsafeAnalyzer{
+		fn: fn,
+	}
+}
+
+func (ua *unsafeAnalyzer) name() string {
+	return "unsafe"
+}
+
+func (ua *unsafeAnalyzer) nodeVisitPre(n ir.Node) {
+	if ua.usesUnsafe {
+		return
+	}
+	switch n.Op() {
+	case ir.OUNSAFEADD, ir.OUNSAFESLICE, ir.OUNSAFESLICEDATA,
+		ir.OUNSAFESTRING, ir.OUNSAFESTRINGDATA:
+		ua.usesUnsafe = true
+	case ir.OCONV, ir.OCONVNOP:
+		conv := n.(*ir.ConvExpr)
+		if conv.Type().IsUnsafePtr() ||
+			(conv.X.Type() != nil && conv.X.Type().IsUnsafePtr()) {
+			ua.usesUnsafe = true
+		}
+	}
+}
+
+func (ua *unsafeAnalyzer) nodeVisitPost(n ir.Node) {
+}
+
+// setResults transfers the "uses unsafe" flag to 'fp'.
+func (ua *unsafeAnalyzer) setResults(fp *FuncProps) {
+	if debugTrace&debugTraceFuncFlags != 0 {
+		traceEvent("result", "analyzer", "unsafe", "func", ua.fn.Sym().Name,
+			"usesunsafe", ua.usesUnsafe)
+	}
+	if ua.usesUnsafe {
+		fp.Flags |= FuncPropUsesUnsafe
+	}
+}
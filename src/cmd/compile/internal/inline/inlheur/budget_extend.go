@@ -0,0 +1,117 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"strconv"
+	"strings"
+)
+
+// This file implements the "-d=inlbudgetextend=flag:amount/..."
+// command line flag, which raises the inline budget by 'amount' for
+// calls whose callee has the named property flag (a function flag
+// such as FuncPropIsLeaf, or a param or result flag such as
+// ParamFeedsIfOrSwitch, in which case at least one param or result
+// must have it). Unlike a change to the global budget, this allows
+// trialing budget relief targeted at the kinds of functions that the
+// heuristics suggest are worth inlining. If several of the named
+// flags apply to a callee, the largest of their amounts is used.
+// Callees marked FuncPropCoverageCold are never given an extension.
+
+// budgetExtension is a single entry in the -d=inlbudgetextend list.
+// Only one of the func, param and result flags is nonzero.
+type budgetExtension struct {
+	funcFlag   FuncPropBits
+	paramFlag  ParamPropBits
+	resultFlag ResultPropBits
+	amount     int32
+}
+
+// budgetExtendSpec is the value of -d=inlbudgetextend that
+// budgetExtensions was parsed from.
+var (
+	budgetExtendSpec string
+	budgetExtensions []budgetExtension
+)
+
+// parseBudgetExtendSpec parses 'spec', a slash-separated list of
+// "flag:amount" entries, setting up budgetExtensions.
+func parseBudgetExtendSpec(spec string) {
+	if spec == budgetExtendSpec {
+		return
+	}
+	budgetExtendSpec = spec
+	budgetExtensions = nil
+	for _, ent := range strings.Split(spec, "/") {
+		name, amt, ok := strings.Cut(ent, ":")
+		if !ok {
+			base.Fatalf("invalid -d=inlbudgetextend entry %q: want flag:amount", ent)
+		}
+		v, err := strconv.ParseInt(amt, 10, 32)
+		if err != nil || v <= 0 {
+			base.Fatalf("invalid -d=inlbudgetextend entry %q: bad amount", ent)
+		}
+		ext := budgetExtension{amount: int32(v)}
+		if b, ok := propBitByName[FuncPropBits](name); ok {
+			ext.funcFlag = b
+		} else if b, ok := propBitByName[ParamPropBits](name); ok {
+			ext.paramFlag = b
+		} else if b, ok := propBitByName[ResultPropBits](name); ok {
+			ext.resultFlag = b
+		} else {
+			base.Fatalf("invalid -d=inlbudgetextend entry %q: unknown property flag", ent)
+		}
+		budgetExtensions = append(budgetExtensions, ext)
+	}
+}
+
+// BudgetExtension returns the amount by which the inline budget
+// should be raised for calls to 'callee' under the
+// -d=inlbudgetextend command line flag, or zero if the flag isn't in
+// effect or none of the flags it names apply to the callee (or the
+// callee's properties are unknown).
+func BudgetExtension(callee *ir.Func) int32 {
+	spec := base.Debug.InlBudgetExtend
+	if spec == "" {
+		return 0
+	}
+	parseBudgetExtendSpec(spec)
+	fp := propsForFunc(callee)
+	if fp == nil || fp.Flags&FuncPropCoverageCold != 0 {
+		// Callees that the coverage profile shows were never
+		// executed don't get any relief.
+		return 0
+	}
+	var extra int32
+	for _, ext := range budgetExtensions {
+		if ext.amount > extra && ext.appliesTo(fp) {
+			extra = ext.amount
+		}
+	}
+	if extra != 0 && debugTrace&debugTraceScoring != 0 {
+		traceEvent("budgetextend", "func", callee.Sym().Name, "extra", extra)
+	}
+	return extra
+}
+
+// appliesTo reports whether the flag named by 'ext' is set in 'fp'.
+func (ext budgetExtension) appliesTo(fp *FuncProps) bool {
+	if fp.Flags&ext.funcFlag != 0 {
+		return true
+	}
+	for _, pf := range fp.ParamFlags {
+		if pf&ext.paramFlag != 0 {
+			return true
+		}
+	}
+	for _, rf := range fp.ResultFlags {
+		if rf&ext.resultFlag != 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,338 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CallSite records useful information about a potentially inlinable
+// (direct) function call. "Callee" is the target of the call, "Call"
+// is the ir node corresponding to the call itself, "ID" is a numeric
+// ID for the site within its containing function, and "Flags" holds
+// properties of the call site itself (as opposed to the callee).
+// "RangedArgs" is a mask of the callee param slots (receiver first,
+// if any) whose argument is the slice being ranged over by a loop
+// enclosing the call (see CallSiteInRangeOverArg), and "ConstArgs" is
+// a mask of the param slots whose argument is a constant. "Score" and
+// "ScoreMask" record the score most recently computed for the call
+// site by GetCallSiteScore, and the adjustments that went into it.
+type CallSite struct {
+	Callee     *ir.Func
+	Call       *ir.CallExpr
+	ID         uint
+	Flags      CSPropBits
+	RangedArgs uint64
+	ConstArgs  uint64
+	Score      int
+	ScoreMask  scoreAdjustTyp
+}
+
+// CallSiteProps holds the properties of a call site computed by the
+// call site analyzers (see callSiteAnalyzer), which are transferred
+// to the fields of the same names in its CallSite.
+type CallSiteProps struct {
+	Flags      CSPropBits
+	RangedArgs uint64
+	ConstArgs  uint64
+}
+
+// CallSiteTab is a table of call sites, keyed by call expr.
+// Ideally it would be nice to key the table by src.XPos, but
+// this results in collisions for calls on very long lines (the
+// front end saturates column numbers at 255). We also wind up
+// with many calls that share the same auto-generated pos.
+type CallSiteTab map[*ir.CallExpr]*CallSite
+
+type CSPropBits uint32
+
+const (
+	// Call is in tail position, meaning that its results are
+	// returned directly by the containing function.
+	CallSiteTailPos CSPropBits = 1 << iota
+	// Result of the call feeds directly into the length or
+	// capacity argument of a "make" for a slice or map.
+	CallSiteFeedsMakeSize
+	// Call is within a "for i := range s" loop over a slice s, and
+	// both s and the loop index i are passed as arguments.
+	CallSiteInRangeOverArg
+	// Call is within the body of a "for" or "range" loop (or in
+	// the condition or post statement of a "for" loop).
+	CallSiteInLoop
+	// Call is within a block that unconditionally ends in a panic
+	// or a call to os.Exit, meaning that it is on an error path
+	// that is unlikely to be executed.
+	CallSiteOnPanicPath
+	// Result of the call feeds directly (possibly via a local
+	// variable that is assigned only once) into the condition of an
+	// "if" statement or the tag of a "switch" statement.
+	CallSiteResultFeedsCond
+	// Result of the call (a single pointer, slice, map or channel)
+	// doesn't escape the caller: it is only dereferenced, indexed,
+	// ranged over, passed to len or cap, or compared, either
+	// directly or via a local variable that is assigned only once.
+	CallSiteResultNoEscape
+	// Call is on a path that is executed at most once per process:
+	// it is within a package initialization function or a function
+	// literal passed to sync.Once.Do (or sync.OnceFunc and the like),
+	// or it is a call to set up or parse command line flags using
+	// package flag.
+	CallSiteCold
+	// Call is on an error handling path: it is within the body of
+	// an "if err != nil" statement (or the else branch of "if err
+	// == nil"), or within a branch of a conditional statement that
+	// ends by returning a non-nil error. Such paths are unlikely to
+	// be executed often.
+	CallSiteOnErrorPath
+	// An error result of the call feeds directly (possibly via a
+	// local variable that is assigned only once) into an "if err !=
+	// nil" or "if err == nil" check.
+	CallSiteErrorChecked
+	// Call is within a "select" statement, either in one of its
+	// case bodies or in the channel operation of a case.
+	CallSiteInSelect
+	// Call is within an event loop: a "for" or "range" loop whose
+	// body contains a "select" statement (at its top level), as in
+	// "for { select { ... } }". The code in such loops tends to be
+	// executed over and over for the lifetime of a long-running
+	// goroutine, so calls in it are likely to be hot.
+	CallSiteInEventLoop
+	// Every argument of the call (not counting the receiver of a
+	// method call) is a constant, modulo conversions, and there is
+	// at least one. See ConstArgs for the individual arguments.
+	CallSiteAllArgsConst
+	// Call passes a function literal as one of its arguments (as in
+	// "seq(func(v int) bool { ... })", which is also what a
+	// range-over-func loop becomes once rewritten).
+	CallSitePassesFuncLit
+)
+
+// callSiteInfo summarizes a CallSite for the purposes of a function
+// properties dump, where the ir nodes for the call and callee are not
+// available. Here 'pos' is the position of the call in the form
+// "file:line:col" (with the file's base name only). If 'scored' is
+// set, 'score' is the score computed for the call site from the
+// callee's inline cost, and 'adjs' the adjustments that went into it
+// (see dumpCallSiteScore).
+type callSiteInfo struct {
+	id     uint
+	pos    string
+	flags  CSPropBits
+	callee string
+	scored bool
+	score  int
+	adjs   scoreAdjustTyp
+}
+
+// callSiteInfos returns summaries of the call sites in 'cstab',
+// ordered by ID. The call sites are not yet scored (see
+// scoreCallSiteInfos).
+func callSiteInfos(cstab CallSiteTab) []callSiteInfo {
+	sites := make([]callSiteInfo, 0, len(cstab))
+	for _, cs := range cstab {
+		p := base.Ctxt.InnermostPos(cs.Call.Pos())
+		sites = append(sites, callSiteInfo{
+			id: cs.ID,
+			pos: fmt.Sprintf("%s:%d:%d",
+				filepath.Base(p.Filename()), p.Line(), p.Col()),
+			flags:  cs.Flags,
+			callee: cs.Callee.Sym().Name,
+		})
+	}
+	sort.Slice(sites, func(i, j int) bool {
+		return sites[i].id < sites[j].id
+	})
+	return sites
+}
+
+// scoreCallSiteInfos fills in the scores of the summaries 'sites' (as
+// returned by callSiteInfos) of the call sites in 'cstab' whose
+// callee is inlinable. This is done only once the inlinability and
+// properties of all of the callees within the package are known,
+// which is not yet the case for callees in the same strongly
+// connected component of the call graph as the caller when the
+// summaries are created.
+func scoreCallSiteInfos(sites []callSiteInfo, cstab CallSiteTab) {
+	for _, cs := range cstab {
+		if cs.Callee.Inl == nil {
+			continue
+		}
+		for i := range sites {
+			if sites[i].id == cs.ID {
+				sites[i].scored = true
+				sites[i].score, sites[i].adjs = dumpCallSiteScore(cs)
+				break
+			}
+		}
+	}
+}
+
+// dumpCallSiteScore returns the score for call site 'cs' (whose
+// callee must be inlinable) for a function properties dump, along
+// with the adjustments that went into it. The score is computed from
+// the callee's inline cost and properties as GetCallSiteScore would,
+// except that profile hotness and any policy hook are not taken into
+// account, so that the result depends only on the code being
+// compiled.
+func dumpCallSiteScore(cs *CallSite) (int, scoreAdjustTyp) {
+	fp := propsForFunc(cs.Callee)
+	score, mask := int(cs.Callee.Inl.Cost), scoreAdjustTyp(0)
+	if fp != nil {
+		score, mask = computeFuncScore(fp, score)
+	}
+	return computeCallSiteScore(cs, fp, score, mask)
+}
+
+// callSitesToString renders 'sites' in human-readable form for
+// inclusion in a function properties dump, with each line prefixed by
+// 'prefix'. Each call site appears on a line of its own, giving the
+// ID, position, flags ("0" if none) and callee name, followed (for a
+// scored call site) by the score and adjustments ("0" if none), as in
+// "score=37 adj=tailCallAdj|leafAdj". The result is empty if there
+// are no call sites.
+func callSitesToString(sites []callSiteInfo, prefix string) string {
+	if len(sites) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s%s\n", prefix, callSitesTag)
+	for _, cs := range sites {
+		fmt.Fprintf(&sb, "%s  %d %s %s %s",
+			prefix, cs.id, cs.pos, csFlagsString(cs.flags), cs.callee)
+		if cs.scored {
+			adjs := "0"
+			if cs.adjs != 0 {
+				adjs = cs.adjs.String()
+			}
+			fmt.Fprintf(&sb, " score=%d adj=%s", cs.score, adjs)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// csFlagsString returns 'flags' in the form used for call sites in
+// a function properties dump.
+func csFlagsString(flags CSPropBits) string {
+	if flags == 0 {
+		return "0"
+	}
+	return flags.String()
+}
+
+// parseCallSiteInfo parses a single call site line as produced by
+// callSitesToString (minus the prefix). The score and adjustments are
+// optional, for compatibility with older dumps that lack them.
+func parseCallSiteInfo(line string) (callSiteInfo, error) {
+	var cs callSiteInfo
+	line = strings.TrimSpace(line)
+	rest := line
+	if i := strings.LastIndex(rest, " score="); i >= 0 {
+		sf, af, ok := strings.Cut(rest[i+1:], " ")
+		if !ok {
+			return cs, fmt.Errorf("malformed call site %q", line)
+		}
+		if err := parseCallSiteScore(&cs, sf, af); err != nil {
+			return cs, fmt.Errorf("malformed call site %q: %v", line, err)
+		}
+		rest = rest[:i]
+	}
+	fields := strings.SplitN(rest, " ", 4)
+	if len(fields) != 4 {
+		return cs, fmt.Errorf("malformed call site %q", line)
+	}
+	if _, err := fmt.Sscanf(fields[0], "%d", &cs.id); err != nil {
+		return cs, fmt.Errorf("malformed call site %q: %v", line, err)
+	}
+	cs.pos = fields[1]
+	flags, err := parseCSPropBits(fields[2])
+	if err != nil {
+		return cs, fmt.Errorf("malformed call site %q: %v", line, err)
+	}
+	cs.flags = flags
+	cs.callee = fields[3]
+	return cs, nil
+}
+
+// parseCallSiteScore parses the score field 'sf' ("score=N") and the
+// adjustments field 'af' ("adj=...") of a call site line into 'cs'.
+func parseCallSiteScore(cs *callSiteInfo, sf, af string) error {
+	v, ok := strings.CutPrefix(sf, "score=")
+	if !ok {
+		return fmt.Errorf("bad score %q", sf)
+	}
+	score, err := strconv.Atoi(v)
+	if err != nil {
+		return fmt.Errorf("bad score %q", sf)
+	}
+	v, ok = strings.CutPrefix(af, "adj=")
+	if !ok {
+		return fmt.Errorf("bad adjustments %q", af)
+	}
+	adjs, err := parseScoreAdjustTyp(v)
+	if err != nil {
+		return err
+	}
+	cs.scored, cs.score, cs.adjs = true, score, adjs
+	return nil
+}
+
+// parseScoreAdjustTyp parses a set of score adjustments in the form
+// produced by scoreAdjustTyp.String (names separated by "|"), or "0"
+// for none.
+func parseScoreAdjustTyp(s string) (scoreAdjustTyp, error) {
+	var mask scoreAdjustTyp
+	if s == "0" {
+		return mask, nil
+	}
+	for _, name := range strings.Split(s, "|") {
+		found := false
+		for typ := range adjValues {
+			if typ.String() == name {
+				mask |= typ
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("unknown score adjustment %q", name)
+		}
+	}
+	return mask, nil
+}
+
+// parseCSPropBits parses a set of call site flags in the form
+// produced by CSPropBits.String (names separated by "|"). An empty
+// string or "0" denotes no flags.
+func parseCSPropBits(s string) (CSPropBits, error) {
+	var flags CSPropBits
+	if s == "" || s == "0" {
+		return flags, nil
+	}
+	for _, name := range strings.Split(s, "|") {
+		found := false
+		for i := 0; i < 32; i++ {
+			if bit := CSPropBits(1 << i); bit.String() == name {
+				flags |= bit
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("unknown flag %q", name)
+		}
+	}
+	return flags, nil
+}
+
+// callSitesTag is the header line for the call sites section of a
+// function's entry in the function properties dump.
+const callSitesTag = "CallSites"
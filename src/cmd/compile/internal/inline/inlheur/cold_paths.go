@@ -0,0 +1,107 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/typecheck"
+	"strings"
+)
+
+// This file contains support for recognizing call sites that are on
+// "cold" paths executed at most once per process, namely package
+// initialization, the functions passed to sync.Once and friends, and
+// the setup of command line flags (see CallSiteCold). Inlining at
+// such call sites has little payoff, so the budget is better spent
+// elsewhere.
+
+// isInitFunc reports whether 'fn' is a package initialization
+// function, either one declared with "func init" in the source (which
+// the front end renames to "init.0", "init.1" and so on) or the one
+// synthesized to initialize package-level variables. Closures created
+// within these functions are not included, since they may be stored
+// away and called later on.
+func isInitFunc(fn *ir.Func) bool {
+	if fn.OClosure != nil {
+		return false
+	}
+	return fn.IsPackageInit() || strings.HasPrefix(fn.Sym().Name, "init.")
+}
+
+// onceFuncs is the set of functions and methods in package sync that
+// invoke the function passed to them at most once.
+var onceFuncs = map[string]bool{
+	"(*Once).Do": true,
+	"OnceFunc":   true,
+	"OnceValue":  true,
+	"OnceValues": true,
+}
+
+// isOnceCall reports whether 'call' is a direct call to one of the
+// functions in 'onceFuncs'.
+func isOnceCall(call *ir.CallExpr) bool {
+	callee := staticCallee(call)
+	if callee == nil || callee.Sym() == nil || callee.Sym().Pkg == nil ||
+		callee.Sym().Pkg.Path != "sync" {
+		return false
+	}
+	// Strip the type arguments from instantiations of OnceValue and
+	// OnceValues.
+	name, _, _ := strings.Cut(callee.Sym().Name, "[")
+	return onceFuncs[name]
+}
+
+// isFlagSetupCall reports whether 'callee' is a function or method
+// from package flag, such as flag.String or (*flag.FlagSet).Parse.
+func isFlagSetupCall(callee *ir.Func) bool {
+	s := callee.Sym()
+	return s != nil && s.Pkg != nil && s.Pkg.Path == "flag"
+}
+
+// onceClosures is the set of function literals in the current package
+// that are passed directly to one of the functions in 'onceFuncs',
+// computed on demand by isOnceClosure.
+var onceClosures map[*ir.Func]bool
+
+// isOnceClosure reports whether 'fn' is a function literal passed
+// directly to one of the functions in 'onceFuncs', as in
+//
+//	once.Do(func() { ... })
+//
+// Since a closure doesn't record the function that encloses it, the
+// first call walks all the functions in the package to find such
+// closures.
+func isOnceClosure(fn *ir.Func) bool {
+	if fn.OClosure == nil || typecheck.Target == nil {
+		return false
+	}
+	if onceClosures == nil {
+		onceClosures = make(map[*ir.Func]bool)
+		var do func(n ir.Node) bool
+		do = func(n ir.Node) bool {
+			switch n.Op() {
+			case ir.OCLOSURE:
+				ir.DoChildren(n.(*ir.ClosureExpr).Func, do)
+			case ir.OCALLFUNC:
+				call := n.(*ir.CallExpr)
+				if isOnceCall(call) {
+					for _, arg := range call.Args {
+						for arg.Op() == ir.OCONVNOP {
+							arg = arg.(*ir.ConvExpr).X
+						}
+						if arg.Op() == ir.OCLOSURE {
+							onceClosures[arg.(*ir.ClosureExpr).Func] = true
+						}
+					}
+				}
+			}
+			return ir.DoChildren(n, do)
+		}
+		for _, f := range typecheck.Target.Funcs {
+			ir.DoChildren(f, do)
+		}
+	}
+	return onceClosures[fn]
+}
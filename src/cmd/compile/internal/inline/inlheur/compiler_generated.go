@@ -0,0 +1,177 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"strings"
+)
+
+// This file recognizes functions generated by the compiler (as
+// opposed to written by the user), which are marked with
+// FuncPropCompilerGenerated, and which may be left out of the
+// function properties dump with "-d=dumpinlskipgen". The kinds of
+// generated function recognized are:
+//
+//	eq         a type's equality function (".eq.T")
+//	hash       a type's hash function (".hash.T")
+//	deferwrap  the wrapper for the call in a "defer" statement
+//	gowrap     the wrapper for the call in a "go" statement
+//	rangefunc  the body of a range-over-func loop ("F-range1")
+//	instwrap   a wrapper that calls the shaped implementation of a
+//	           generic function or method instantiation
+//	wrapper    any other wrapper, such as a method value or promoted
+//	           method wrapper
+//
+// The predicate used to decide whether a function is generated can be
+// replaced with SetCompilerGeneratedFunc.
+
+// compilerGenKinds lists the kinds of generated function returned by
+// compilerGenKind.
+var compilerGenKinds = []string{"eq", "hash", "deferwrap", "gowrap", "rangefunc", "instwrap", "wrapper"}
+
+// compilerGenKind returns the kind of compiler-generated function
+// that 'fn' is (one of compilerGenKinds), or "" if it doesn't look
+// like a generated function.
+func compilerGenKind(fn *ir.Func) string {
+	if fn.Nname == nil || fn.Sym() == nil {
+		return ""
+	}
+	name := fn.Sym().Name
+	switch {
+	case strings.HasPrefix(name, ".eq."):
+		return "eq"
+	case strings.HasPrefix(name, ".hash."):
+		return "hash"
+	}
+	last := name[strings.LastIndex(name, ".")+1:]
+	switch {
+	case strings.HasPrefix(last, "deferwrap"):
+		return "deferwrap"
+	case strings.HasPrefix(last, "gowrap"):
+		return "gowrap"
+	case strings.Contains(last, "-range"):
+		return "rangefunc"
+	}
+	if fn.Wrapper() {
+		if strings.Contains(name, "[") {
+			return "instwrap"
+		}
+		return "wrapper"
+	}
+	return ""
+}
+
+// isCompilerGenerated is the default predicate for deciding whether
+// a function was generated by the compiler.
+func isCompilerGenerated(fn *ir.Func) bool {
+	return compilerGenKind(fn) != ""
+}
+
+// compilerGenerated, if non-nil, replaces isCompilerGenerated. See
+// SetCompilerGeneratedFunc.
+var compilerGenerated func(fn *ir.Func) bool
+
+// SetCompilerGeneratedFunc installs 'pred' as the predicate used to
+// decide whether a function was generated by the compiler, and so
+// should be marked with FuncPropCompilerGenerated. This allows tools
+// to treat other functions (such as those produced by code
+// generators) the same way. Passing nil restores the default
+// predicate, which recognizes the kinds of functions listed in
+// compilerGenKinds.
+func SetCompilerGeneratedFunc(pred func(fn *ir.Func) bool) {
+	compilerGenerated = pred
+}
+
+// funcIsCompilerGenerated reports whether 'fn' should be marked with
+// FuncPropCompilerGenerated.
+func funcIsCompilerGenerated(fn *ir.Func) bool {
+	if compilerGenerated != nil {
+		return compilerGenerated(fn)
+	}
+	return isCompilerGenerated(fn)
+}
+
+// compilerGenAnalyzer marks compiler-generated functions with
+// FuncPropCompilerGenerated. Like inlineHintAnalyzer it doesn't look
+// at the function body, and it is only run for functions that are
+// generated.
+type compilerGenAnalyzer struct{}
+
+func init() {
+	registerAnalyzer(195, func(fn *ir.Func, _ func(*ir.Func)) propAnalyzer {
+		if !funcIsCompilerGenerated(fn) {
+			return nil
+		}
+		return compilerGenAnalyzer{}
+	})
+}
+
+func (compilerGenAnalyzer) name() string {
+	return "compilergen"
+}
+
+func (compilerGenAnalyzer) nodeVisitPre(n ir.Node) {
+}
+
+func (compilerGenAnalyzer) nodeVisitPost(n ir.Node) {
+}
+
+func (compilerGenAnalyzer) setResults(fp *FuncProps) {
+	fp.Flags |= FuncPropCompilerGenerated
+}
+
+// dumpSkipGen holds the kinds of generated function to be left out
+// of the function properties dump, parsed from "-d=dumpinlskipgen"
+// on first use; the key "all" stands for every function for which
+// funcIsCompilerGenerated returns true.
+var dumpSkipGen map[string]bool
+
+// skipGeneratedInDump returns true if 'fn' is a compiler-generated
+// function that should be left out of the function properties dump.
+func skipGeneratedInDump(fn *ir.Func) bool {
+	if dumpSkipGen == nil {
+		dumpSkipGen = parseSkipGen(base.Debug.DumpInlSkipGen)
+	}
+	if dumpSkipGen["all"] {
+		return funcIsCompilerGenerated(fn)
+	}
+	k := compilerGenKind(fn)
+	return k != "" && dumpSkipGen[k]
+}
+
+// parseSkipGen parses the value of the "-d=dumpinlskipgen" command
+// line flag, a slash-separated list of kinds of generated functions
+// (see compilerGenKinds), or "all", or "none". An empty value selects
+// the equality functions only.
+func parseSkipGen(spec string) map[string]bool {
+	m := make(map[string]bool)
+	switch spec {
+	case "":
+		m["eq"] = true
+		return m
+	case "none":
+		return m
+	}
+	for _, k := range strings.Split(spec, "/") {
+		if k != "all" && !validGenKind(k) {
+			base.Fatalf("unknown kind of generated function %q in -d=dumpinlskipgen=%s (want all, none, or some of %s)",
+				k, spec, strings.Join(compilerGenKinds, "/"))
+		}
+		m[k] = true
+	}
+	return m
+}
+
+// validGenKind reports whether 'k' is one of compilerGenKinds.
+func validGenKind(k string) bool {
+	for _, kind := range compilerGenKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/types"
+	"cmd/internal/src"
+	"strings"
+	"testing"
+)
+
+func TestCompilerGenKind(t *testing.T) {
+	pkg := types.NewPkg("p", "p")
+	testcases := []struct {
+		name    string
+		wrapper bool
+		want    string
+	}{
+		{"F", false, ""},
+		{"F.func1", false, ""},
+		{".eq.[4]int", false, "eq"},
+		{".hash.p.T", false, "hash"},
+		{"F.deferwrap1", true, "deferwrap"},
+		{"F.func1.gowrap2", true, "gowrap"},
+		{"F-range1", false, "rangefunc"},
+		{"G[int]", true, "instwrap"},
+		{"T.M-fm", true, "wrapper"},
+	}
+	for _, tc := range testcases {
+		fn := ir.NewFunc(src.NoXPos, src.NoXPos, pkg.Lookup(tc.name), nil)
+		fn.SetWrapper(tc.wrapper)
+		if got := compilerGenKind(fn); got != tc.want {
+			t.Errorf("compilerGenKind(%s): got %q, want %q", tc.name, got, tc.want)
+		}
+		if got := isCompilerGenerated(fn); got != (tc.want != "") {
+			t.Errorf("isCompilerGenerated(%s): got %v", tc.name, got)
+		}
+	}
+}
+
+func TestSkipGeneratedInDump(t *testing.T) {
+	saved := dumpSkipGen
+	defer func() { dumpSkipGen = saved }()
+	defer SetCompilerGeneratedFunc(nil)
+
+	pkg := types.NewPkg("p", "p")
+	mk := func(name string) *ir.Func {
+		return ir.NewFunc(src.NoXPos, src.NoXPos, pkg.Lookup(name),
+			types.NewSignature(nil, nil, nil))
+	}
+	eq, hash, user := mk(".eq.[4]int"), mk(".hash.p.T"), mk("zz_generated_F")
+
+	testcases := []struct {
+		spec string
+		want string // the functions skipped
+	}{
+		{"", ".eq.[4]int"},
+		{"none", ""},
+		{"hash", ".hash.p.T"},
+		{"eq/hash", ".eq.[4]int .hash.p.T"},
+		{"all", ".eq.[4]int .hash.p.T"},
+	}
+	for _, tc := range testcases {
+		dumpSkipGen = parseSkipGen(tc.spec)
+		var skipped []string
+		for _, fn := range []*ir.Func{eq, hash, user} {
+			if skipGeneratedInDump(fn) {
+				skipped = append(skipped, fn.Sym().Name)
+			}
+		}
+		if got := strings.Join(skipped, " "); got != tc.want {
+			t.Errorf("spec %q: skipped %q, want %q", tc.spec, got, tc.want)
+		}
+	}
+
+	// A custom predicate decides which functions are marked, and
+	// which are skipped with "all".
+	SetCompilerGeneratedFunc(func(fn *ir.Func) bool {
+		return strings.HasPrefix(fn.Sym().Name, "zz_generated_")
+	})
+	for _, fn := range []*ir.Func{user, eq} {
+		marked := false
+		for _, a := range makeAnalyzers(fn, nil) {
+			if _, ok := a.(compilerGenAnalyzer); ok {
+				marked = true
+			}
+		}
+		if want := fn == user; marked != want {
+			t.Errorf("custom predicate: %s marked %v, want %v", fn.Sym().Name, marked, want)
+		}
+	}
+	dumpSkipGen = parseSkipGen("all")
+	if !skipGeneratedInDump(user) || skipGeneratedInDump(eq) {
+		t.Errorf("custom predicate with \"all\": wrong functions skipped")
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/typecheck"
+	"cmd/compile/internal/types"
+	"sync"
+)
+
+// This file contains a cross-function pass that determines, for each
+// function in the package, which of its results are discarded by
+// every one of its callers (see ResultDiscardedByCallers). When such
+// a function is inlined, dead code elimination can delete the work
+// done to compute those results.
+//
+// The pass examines the bodies of all the functions in the package
+// once, before any inlining has taken place, since inlining rewrites
+// calls into assignments that no longer show whether the results
+// are used. Only unexported functions (not methods or closures) are
+// considered, since other functions may have callers we can't see,
+// and functions that are referenced as values are excluded for the
+// same reason.
+
+var (
+	deadResultsOnce sync.Once
+	deadResults     map[*ir.Func]uint64
+)
+
+// discardedResults returns a mask of the results of 'fn' (bit i for
+// result i) that are discarded at every call to 'fn' in the package,
+// or zero if 'fn' has no callers or may have callers we can't see.
+func discardedResults(fn *ir.Func) uint64 {
+	deadResultsOnce.Do(func() {
+		if typecheck.Target != nil {
+			deadResults = computeDiscardedResults(typecheck.Target.Funcs)
+		}
+	})
+	return deadResults[fn]
+}
+
+// computeDiscardedResults examines the calls made by the functions in
+// 'fns' and returns a map from callee to the mask of its results that
+// are discarded by all of the calls.
+func computeDiscardedResults(fns []*ir.Func) map[*ir.Func]uint64 {
+	type callUse struct {
+		call   *ir.CallExpr
+		parent ir.Node
+		callee *ir.Func
+	}
+	var calls []callUse
+	escapes := make(map[*ir.Func]bool)
+	// blankTemps holds the temporaries introduced by the front end
+	// for the results of a multi-value call that are then assigned
+	// to the blank identifier, as in "x, _ := f()". Since the
+	// assignment to blank follows the call, the uses of the results
+	// are only examined once the walk is complete.
+	blankTemps := make(map[*ir.Name]bool)
+
+	var visit func(n, parent ir.Node)
+	visit = func(n, parent ir.Node) {
+		switch n.Op() {
+		case ir.ONAME:
+			name := n.(*ir.Name)
+			if name.Class == ir.PFUNC && name.Func != nil {
+				if call, ok := parent.(*ir.CallExpr); !ok || call.X != n {
+					escapes[name.Func] = true
+				}
+			}
+		case ir.OCLOSURE:
+			// Calls made by a closure are calls in the package like
+			// any other.
+			clo := n.(*ir.ClosureExpr)
+			ir.DoChildren(clo.Func, func(c ir.Node) bool {
+				visit(c, clo.Func)
+				return false
+			})
+		case ir.OAS2:
+			as := n.(*ir.AssignListStmt)
+			if len(as.Lhs) == len(as.Rhs) {
+				for i, lhs := range as.Lhs {
+					if !ir.IsBlank(lhs) {
+						continue
+					}
+					rhs := as.Rhs[i]
+					for rhs.Op() == ir.OCONVNOP {
+						rhs = rhs.(*ir.ConvExpr).X
+					}
+					if tmp, ok := rhs.(*ir.Name); ok && tmp.AutoTemp() {
+						blankTemps[tmp] = true
+					}
+				}
+			}
+		case ir.OCALLFUNC:
+			call := n.(*ir.CallExpr)
+			if callee := staticCallee(call); callee != nil {
+				calls = append(calls, callUse{call, parent, callee})
+			}
+		}
+		ir.DoChildren(n, func(c ir.Node) bool {
+			visit(c, n)
+			return false
+		})
+	}
+	for _, fn := range fns {
+		ir.DoChildren(fn, func(c ir.Node) bool {
+			visit(c, fn)
+			return false
+		})
+	}
+
+	used := make(map[*ir.Func]uint64)
+	for _, cu := range calls {
+		used[cu.callee] |= usedResults(cu.call, cu.parent, blankTemps)
+	}
+	rv := make(map[*ir.Func]uint64)
+	for callee, u := range used {
+		if escapes[callee] || callee.OClosure != nil ||
+			callee.Type().Recv() != nil || types.IsExported(callee.Sym().Name) {
+			continue
+		}
+		nres := callee.Type().NumResults()
+		if nres == 0 || nres > 64 {
+			continue
+		}
+		all := uint64(1)<<nres - 1
+		if mask := all &^ u; mask != 0 {
+			rv[callee] = mask
+		}
+	}
+	return rv
+}
+
+// usedResults returns a mask of the results of 'call' that are (or
+// may be) used, given that it appears as a child of 'parent'. Here
+// 'blankTemps' is the set of temporaries that are only assigned to
+// the blank identifier.
+func usedResults(call *ir.CallExpr, parent ir.Node, blankTemps map[*ir.Name]bool) uint64 {
+	const all = ^uint64(0)
+	switch parent.Op() {
+	case ir.OAS:
+		as := parent.(*ir.AssignStmt)
+		if as.Y == call && (as.X == nil || ir.IsBlank(as.X)) {
+			return 0
+		}
+		return all
+	case ir.OAS2FUNC:
+		as := parent.(*ir.AssignListStmt)
+		if len(as.Rhs) != 1 || as.Rhs[0] != call {
+			return all
+		}
+		var mask uint64
+		for i, lhs := range as.Lhs {
+			if ir.IsBlank(lhs) {
+				continue
+			}
+			if tmp, ok := lhs.(*ir.Name); ok && blankTemps[tmp] {
+				continue
+			}
+			if i < 64 {
+				mask |= 1 << i
+			}
+		}
+		return mask
+	case ir.ODEFER, ir.OGO:
+		return 0
+	case ir.OBLOCK, ir.ODCLFUNC, ir.OLABEL:
+		return 0
+	case ir.OIF:
+		if parent.(*ir.IfStmt).Cond == call {
+			return all
+		}
+		return 0
+	case ir.OFOR:
+		if parent.(*ir.ForStmt).Cond == call {
+			return all
+		}
+		return 0
+	case ir.ORANGE:
+		if parent.(*ir.RangeStmt).X == call {
+			return all
+		}
+		return 0
+	case ir.OSWITCH:
+		if parent.(*ir.SwitchStmt).Tag == call {
+			return all
+		}
+		return 0
+	case ir.OCASE:
+		if cc, ok := parent.(*ir.CaseClause); ok {
+			for _, n := range cc.List {
+				if n == call {
+					return all
+				}
+			}
+		}
+		return 0
+	}
+	return all
+}
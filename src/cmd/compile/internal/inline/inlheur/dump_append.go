@@ -0,0 +1,74 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"cmd/compile/internal/base"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// This file contains support for appending the function properties
+// dumps of several packages to the same file ("-d=dumpinlpropsappend=1"),
+// so that a single dump can describe a whole program when the flag
+// is passed to every compilation in a build (for example with
+// "go build -gcflags=all=-d=dumpinlfuncprops=...,dumpinlpropsappend=1").
+// Rather than truncating the dump file, each compilation appends a
+// section for its package, consisting of a package line giving the
+// import path and build ID (if any) of the package, the usual file
+// preamble, and the entries for the package's functions:
+//
+//	// Package example.com/p abcdef0123
+//	// DO NOT EDIT (use 'go test -v -update-expected' instead.)
+//	// ...
+//	// <endfilepreamble>
+//	// p.go F 10 0 1 6
+//	// ...
+//
+// Since the go command may run several compilations at once, the
+// section for a package is accumulated in memory and appended to
+// the file with a single write. Append mode is supported only for
+// the default dump format.
+
+// packageTag is the tag for the package line that begins the
+// section for each package in an appended dump.
+const packageTag = "Package"
+
+// dumpPackagePreamble writes out the package line for the package
+// being compiled, as part of an appended function properties dump.
+func dumpPackagePreamble(w io.Writer) {
+	writePackageLine(w, base.Ctxt.Pkgpath, base.Flag.BuildID)
+}
+
+// writePackageLine writes out a package line for the package with
+// import path 'pkg' and build ID 'buildID' (which may be empty).
+func writePackageLine(w io.Writer, pkg, buildID string) {
+	if buildID == "" {
+		fmt.Fprintf(w, "// %s %s\n", packageTag, pkg)
+	} else {
+		fmt.Fprintf(w, "// %s %s %s\n", packageTag, pkg, buildID)
+	}
+}
+
+// parsePackageLine parses the contents 'line' of a package line from
+// an appended dump (without the leading "// "), returning the import
+// path and build ID of the package. The last result is false if the
+// line is not a package line.
+func parsePackageLine(line string) (pkg, buildID string, ok bool) {
+	rest, ok := strings.CutPrefix(line, packageTag+" ")
+	if !ok {
+		return "", "", false
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return "", "", false
+	}
+	pkg = fields[0]
+	if len(fields) > 1 {
+		buildID = fields[1]
+	}
+	return pkg, buildID, true
+}
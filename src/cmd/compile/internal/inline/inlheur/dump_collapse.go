@@ -0,0 +1,150 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"fmt"
+	"strings"
+)
+
+// This file contains support for collapsing the instantiations of a
+// generic function into a single entry in the function properties
+// dump (see "-d=dumpinlpropscollapse=1"). Ordinarily each
+// instantiation gets an entry of its own, distinguished only by its
+// name and by the "idx" field of the preamble, since they all share
+// the same definition line.
+//
+// Instantiations are grouped by their origin function, with the type
+// arguments replaced by "[shape]" for the shaped instantiations that
+// hold the actual code, and by "[...]" for the instantiations that
+// simply forward to a shaped instantiation, passing a dictionary, so
+// that "Max[go.shape.int]" and "Max[go.shape.string]" are collapsed
+// to "Max[shape]", and "Max[int]" and "Max[string]" to "Max[...]".
+// The first instantiation recorded is used as the representative of
+// its group, and the entry records the number of instantiations,
+// along with the names of any whose properties or call sites differ
+// from those of the representative:
+//
+//	// Instantiations 3
+//	// DisagreeingInstantiations
+//	//   Max[go.shape.string]
+
+// instantiationsTag and disagreeTag are the tags for the
+// instantiation count and the section listing the disagreeing
+// instantiations in a collapsed dump entry.
+const (
+	instantiationsTag = "Instantiations"
+	disagreeTag       = "DisagreeingInstantiations"
+)
+
+// collapseInstantiations returns the dump entries in 'sl', all of
+// which originate from the same source file, with the instantiations
+// of each generic function collapsed into a single entry, along with
+// the counts of the resulting entries defined on each line.
+func collapseInstantiations(sl []fnInlHeur) ([]fnInlHeur, lineCounts) {
+	var rv []fnInlHeur
+	atline := make(lineCounts)
+	groups := make(map[string]int)
+	sigs := make(map[string]string)
+	for _, e := range sl {
+		name := originName(e.fname)
+		if name == e.fname {
+			e.idx = atline.add(e.file, e.line)
+			rv = append(rv, e)
+			continue
+		}
+		key := fmt.Sprintf("%d:%s", e.line, name)
+		sig := entrySignature(&e)
+		i, ok := groups[key]
+		if !ok {
+			groups[key] = len(rv)
+			sigs[key] = sig
+			e.fname = name
+			e.ninst = 1
+			e.csites = collapseCallSites(e.csites)
+			e.idx = atline.add(e.file, e.line)
+			rv = append(rv, e)
+			continue
+		}
+		rv[i].ninst++
+		if sig != sigs[key] {
+			rv[i].disagree = append(rv[i].disagree, e.fname)
+		}
+	}
+	return rv, atline
+}
+
+// entrySignature returns a string summarizing the properties and
+// call sites of 'e', for the purpose of checking whether two
+// instantiations of a generic function agree. The names of callees
+// are collapsed, so that (for example) the calls made by Max[int] and
+// Max[string] to Max[go.shape.int] and Max[go.shape.string] match.
+func entrySignature(e *fnInlHeur) string {
+	return e.props.ToString("") + callSitesToString(collapseCallSites(e.csites), "")
+}
+
+// collapseCallSites returns a copy of 'sites' in which the callee
+// names are collapsed as with originName.
+func collapseCallSites(sites []callSiteInfo) []callSiteInfo {
+	var rv []callSiteInfo
+	for _, cs := range sites {
+		cs.callee = originName(cs.callee)
+		rv = append(rv, cs)
+	}
+	return rv
+}
+
+// originName returns the name of function 'fname' with each
+// (outermost) bracketed list of type arguments replaced by "[shape]"
+// if it contains shape types, or "[...]" otherwise. For example,
+// "(*List[go.shape.int]).Push" becomes "(*List[shape]).Push". Names
+// without type arguments are returned unchanged.
+func originName(fname string) string {
+	if !strings.Contains(fname, "[") {
+		return fname
+	}
+	var sb strings.Builder
+	depth, start := 0, 0
+	for i := 0; i < len(fname); i++ {
+		switch fname[i] {
+		case '[':
+			if depth == 0 {
+				sb.WriteString(fname[start:i])
+				start = i
+			}
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				if strings.Contains(fname[start:i], "go.shape.") {
+					sb.WriteString("[shape]")
+				} else {
+					sb.WriteString("[...]")
+				}
+				start = i + 1
+			}
+		}
+	}
+	sb.WriteString(fname[start:])
+	return sb.String()
+}
+
+// instantiationsToString returns the lines recording the number of
+// instantiations (and any disagreeing instantiations) for a collapsed
+// dump entry 'fih', or an empty string if 'fih' is not collapsed.
+func instantiationsToString(fih *fnInlHeur, prefix string) string {
+	if fih.ninst == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s%s %d\n", prefix, instantiationsTag, fih.ninst)
+	if len(fih.disagree) != 0 {
+		fmt.Fprintf(&sb, "%s%s\n", prefix, disagreeTag)
+		for _, name := range fih.disagree {
+			fmt.Fprintf(&sb, "%s  %s\n", prefix, name)
+		}
+	}
+	return sb.String()
+}
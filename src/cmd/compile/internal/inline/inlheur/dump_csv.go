@@ -0,0 +1,112 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// This file contains support for writing the function properties
+// dump in CSV form (selected with a spec of the form
+// "-d=dumpinlfuncprops=file:csv" or "file:pattern:csv"), for loading
+// into spreadsheets and data analysis tools. The dump consists of a
+// header row naming the columns (see csvDumpHeader), followed by one
+// row per function, in the same order as in the default format.
+// Flags are written using their symbolic names, separated by "|";
+// for the per-param and per-result flags, the entries for each param
+// or result are separated by ";". ResultAffectingParams is written as
+// a space-separated list of param indices.
+
+// dumpCSV is set if the function properties dump is to be written
+// as CSV (see parseDumpSpec).
+var dumpCSV bool
+
+// csvDumpHeader holds the column names for a CSV dump.
+var csvDumpHeader = []string{
+	"file", "fname", "line", "col",
+	"Flags", "ParamFlags", "ResultFlags", "ResultAffectingParams",
+	"NumReturns", "SingleTailReturn", "MaxCallArgs", "NumCalls",
+	"Stmts", "Exprs", "ControlFlow", "Hotspot",
+	"CapturedVars", "CapturedByRef", "ClosureEscapes",
+}
+
+// dumpCSVPreamble writes out the header row of a CSV dump.
+func dumpCSVPreamble(w io.Writer) error {
+	return writeCSVRecord(w, csvDumpHeader)
+}
+
+// emitCSVDumpGroup writes out the function property dump entries in
+// 'sl', all of which originate from the same source file, as rows of
+// a CSV dump.
+func emitCSVDumpGroup(w io.Writer, sl []fnInlHeur) error {
+	for _, e := range sl {
+		if err := writeCSVRecord(w, csvRecord(&e)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// csvRecord returns the columns of the CSV dump row for entry 'e'.
+func csvRecord(e *fnInlHeur) []string {
+	fp := e.props
+	var rap []string
+	for i := 0; i < 64; i++ {
+		if fp.ResultAffectingParams&(1<<i) != 0 {
+			rap = append(rap, strconv.Itoa(i))
+		}
+	}
+	flags := ""
+	if fp.Flags != 0 {
+		flags = fp.Flags.String()
+	}
+	return []string{
+		e.file,
+		e.fname,
+		strconv.FormatUint(uint64(e.line), 10),
+		strconv.FormatUint(uint64(e.col), 10),
+		flags,
+		csvFlagSlice(fp.ParamFlags),
+		csvFlagSlice(fp.ResultFlags),
+		strings.Join(rap, " "),
+		strconv.Itoa(fp.NumReturns),
+		strconv.FormatBool(fp.SingleTailReturn),
+		strconv.Itoa(fp.MaxCallArgs),
+		strconv.Itoa(fp.NumCalls),
+		strconv.Itoa(fp.NodeCounts.Stmts),
+		strconv.Itoa(fp.NodeCounts.Exprs),
+		strconv.Itoa(fp.NodeCounts.ControlFlow),
+		fp.Hotspot,
+		strconv.Itoa(fp.Captures.Vars),
+		strconv.Itoa(fp.Captures.ByRef),
+		strconv.FormatBool(fp.Captures.Escapes),
+	}
+}
+
+// csvFlagSlice returns the CSV form of the per-param or per-result
+// flags in 'sl'.
+func csvFlagSlice[T interface {
+	~uint32
+	String() string
+}](sl []T) string {
+	strs := make([]string, len(sl))
+	for i, f := range sl {
+		strs[i] = f.String()
+	}
+	return strings.Join(strs, ";")
+}
+
+// writeCSVRecord writes out 'record' as a single row of a CSV dump.
+func writeCSVRecord(w io.Writer, record []string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"fmt"
+)
+
+// This file implements the "-d=inlheurdumpir=flag" command line flag,
+// which dumps the IR (via ir.Dump) of each function for which the
+// named property flag is computed, as an aid to diagnosing an
+// analyzer that sets a flag where it shouldn't. The flag may be a
+// function flag (such as FuncPropNeverReturns), in which case it must
+// be set for the function as a whole, or a param or result flag (such
+// as ParamFeedsLoopBound), in which case it must be set for at least
+// one param or result. The IR is dumped as the properties are
+// computed, before any overrides (see props_override.go) are applied
+// and before the properties are refined for recursive functions.
+
+// dumpIRSpec is the value of -d=inlheurdumpir that was
+// parsed from, and dumpIRFuncFlag, dumpIRParamFlag and
+// dumpIRResultFlag the flag it names (only one of which is nonzero).
+var (
+	dumpIRSpec       string
+	dumpIRFuncFlag   FuncPropBits
+	dumpIRParamFlag  ParamPropBits
+	dumpIRResultFlag ResultPropBits
+)
+
+// parseDumpIRSpec parses 'spec', the name of a function, param or
+// result property flag, setting up dumpIRFuncFlag and friends.
+func parseDumpIRSpec(spec string) {
+	if spec == dumpIRSpec {
+		return
+	}
+	dumpIRSpec = spec
+	dumpIRFuncFlag, dumpIRParamFlag, dumpIRResultFlag = 0, 0, 0
+	if b, ok := propBitByName[FuncPropBits](spec); ok {
+		dumpIRFuncFlag = b
+	} else if b, ok := propBitByName[ParamPropBits](spec); ok {
+		dumpIRParamFlag = b
+	} else if b, ok := propBitByName[ResultPropBits](spec); ok {
+		dumpIRResultFlag = b
+	} else {
+		base.Fatalf("invalid -d=inlheurdumpir=%s: unknown property flag", spec)
+	}
+}
+
+// maybeDumpIR dumps the IR of function 'fn' if its properties 'fp'
+// include the flag named by the -d=inlheurdumpir command line flag.
+func maybeDumpIR(fn *ir.Func, fp *FuncProps) {
+	spec := base.Debug.InlHeurDumpIR
+	if spec == "" {
+		return
+	}
+	parseDumpIRSpec(spec)
+	if !hasDumpIRFlag(fp) {
+		return
+	}
+	ir.Dump(fmt.Sprintf("inlheur %s (%s):", spec, ir.Line(fn)), fn)
+}
+
+// hasDumpIRFlag reports whether 'fp' includes the flag named by the
+// -d=inlheurdumpir command line flag.
+func hasDumpIRFlag(fp *FuncProps) bool {
+	if fp.Flags&dumpIRFuncFlag != 0 {
+		return true
+	}
+	for _, pf := range fp.ParamFlags {
+		if pf&dumpIRParamFlag != 0 {
+			return true
+		}
+	}
+	for _, rf := range fp.ResultFlags {
+		if rf&dumpIRResultFlag != 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// This file contains support for writing the function properties
+// dump as a single JSON document (selected with a spec of the form
+// "-d=dumpinlfuncprops=file:json" or "file:pattern:json"), for use
+// by external tools. The document is an array with one object per
+// function, of the form
+//
+//	{
+//	  "file": "foo.go",
+//	  "fname": "T_mumble",
+//	  "line": 35,
+//	  "props": { ... },
+//	  "callsites": [
+//	    { "id": 0, "pos": "foo.go:37:9", "flags": "CallSiteTailPos", "callee": "bar",
+//	      "score": 37, "adjustments": "tailCallAdj" },
+//	    ...
+//	  ]
+//	}
+//
+// where "props" is the JSON encoding of the function's FuncProps, and
+// "flags" is empty for a call site with no flags set. The "score" and
+// "adjustments" fields are present only for call sites with an
+// inlinable callee, and "adjustments" is empty if none were applied. Entries appear
+// in the same order as in the default format. Entries for collapsed
+// generic functions (see dump_collapse.go) also have fields
+// "instantiations" and (if any disagree) "disagree".
+
+// jsonDumpEntry is the JSON form of a function's dump entry.
+type jsonDumpEntry struct {
+	File      string         `json:"file"`
+	Fname     string         `json:"fname"`
+	Line      uint           `json:"line"`
+	Props     *FuncProps     `json:"props"`
+	CallSites []jsonCallSite `json:"callsites"`
+	Instances int            `json:"instantiations,omitempty"`
+	Disagree  []string       `json:"disagree,omitempty"`
+}
+
+// jsonCallSite is the JSON form of a call site within a dump entry.
+type jsonCallSite struct {
+	ID          uint    `json:"id"`
+	Pos         string  `json:"pos"`
+	Flags       string  `json:"flags"`
+	Callee      string  `json:"callee"`
+	Score       *int    `json:"score,omitempty"`
+	Adjustments *string `json:"adjustments,omitempty"`
+}
+
+// dumpJSON is set if the function properties dump is to be written
+// as JSON (see parseDumpSpec).
+var dumpJSON bool
+
+// dumpJSONCount is the number of entries written so far to a JSON
+// function properties dump.
+var dumpJSONCount int
+
+// dumpJSONPreamble and dumpJSONPostamble write out the start and end
+// of the array making up a JSON function properties dump.
+func dumpJSONPreamble(w io.Writer) {
+	io.WriteString(w, "[")
+}
+
+func dumpJSONPostamble(w io.Writer) {
+	io.WriteString(w, "\n]\n")
+}
+
+// emitJSONDumpGroup writes out the function property dump entries in
+// 'sl', all of which originate from the same source file, as elements
+// of the array making up a JSON dump.
+func emitJSONDumpGroup(w io.Writer, sl []fnInlHeur) error {
+	for _, e := range sl {
+		data, err := jsonEntryData(&e)
+		if err != nil {
+			return err
+		}
+		if err := writeJSONEntry(w, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonEntryData returns the JSON encoding of dump entry 'e'.
+func jsonEntryData(e *fnInlHeur) ([]byte, error) {
+	je := jsonDumpEntry{
+		File:      e.file,
+		Fname:     e.fname,
+		Line:      e.line,
+		Props:     e.props,
+		CallSites: []jsonCallSite{},
+		Instances: e.ninst,
+		Disagree:  e.disagree,
+	}
+	for _, cs := range e.csites {
+		jcs := jsonCallSite{
+			ID:     cs.id,
+			Pos:    cs.pos,
+			Flags:  cs.flags.String(),
+			Callee: cs.callee,
+		}
+		if cs.scored {
+			score, adjs := cs.score, ""
+			if cs.adjs != 0 {
+				adjs = cs.adjs.String()
+			}
+			jcs.Score, jcs.Adjustments = &score, &adjs
+		}
+		je.CallSites = append(je.CallSites, jcs)
+	}
+	return json.Marshal(je)
+}
+
+// writeJSONEntry writes out the encoded dump entry 'data' as the next
+// element of the array making up a JSON dump.
+func writeJSONEntry(w io.Writer, data []byte) error {
+	sep := ",\n"
+	if dumpJSONCount == 0 {
+		sep = "\n"
+	}
+	dumpJSONCount++
+	if _, err := io.WriteString(w, sep); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
@@ -0,0 +1,583 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// dumpReader is a helper for parsing a function properties dump, as
+// written by the "-d=dumpinlfuncprops=..." command line flag.
+type dumpReader struct {
+	s  *bufio.Scanner
+	p  string
+	ln int
+	// pkg and buildID identify the package whose entries are being
+	// read, for an appended dump (see dump_append.go).
+	pkg, buildID string
+	// version is the schema version of the dump (or of the section
+	// for the package being read, in an appended dump).
+	version int
+}
+
+// parseDump reads in the contents of a function properties dump
+// from 'r' (with 'name' used in error messages). It breaks the dump
+// down into separate sections by function, then deserializes each
+// func section into a fnInlHeur object and returns a slice of those
+// objects. Note that the 'fn' and 'cstab' fields of the returned
+// objects are not populated. For an appended dump containing the
+// sections for several packages, the entries from all of the
+// sections are returned, with their 'pkg' and 'buildID' fields
+// filled in.
+//
+// A dump with a newer schema version than dumpSchemaVersion is
+// rejected. A dump with an older version is migrated: the properties
+// are decoded leniently, so that fields that were added since are
+// left with their zero values, and fields that were removed since are
+// ignored. Each entry records the version it was read with, so that
+// callers that need an up-to-date dump (such as the comparison with
+// the expected results in testdata/props) can check it.
+func parseDump(r io.Reader, name string) ([]fnInlHeur, error) {
+	dr := &dumpReader{
+		s:  bufio.NewScanner(r),
+		p:  name,
+		ln: 1,
+	}
+	if !dr.scan() {
+		if err := dr.s.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("malformed dump %s, missing preamble delimiter", name)
+	}
+	if err := dr.readPreamble(); err != nil {
+		return nil, err
+	}
+	res := []fnInlHeur{}
+	for {
+		dentry, err := dr.readEntry()
+		if err != nil {
+			return nil, err
+		}
+		if dentry.fname == "" {
+			break
+		}
+		res = append(res, dentry)
+	}
+	return res, nil
+}
+
+// readPreamble consumes the header comment of a dump (or of the
+// section for a package, in an appended dump), starting with the
+// current line, up to and including the preamble delimiter, picking
+// out the package line and schema version if there are any.
+func (dr *dumpReader) readPreamble() error {
+	dr.version = 1
+	for {
+		line, err := dr.curLine()
+		if err != nil {
+			return err
+		}
+		if line == preambleDelimiter {
+			return nil
+		}
+		if pkg, buildID, ok := parsePackageLine(line); ok {
+			dr.pkg, dr.buildID = pkg, buildID
+		}
+		if v, ok := strings.CutPrefix(line, schemaVersionTag+" "); ok {
+			if _, err := fmt.Sscanf(v, "%d", &dr.version); err != nil {
+				return fmt.Errorf("malformed schema version %s:%d: %s", dr.p, dr.ln, line)
+			}
+			if dr.version > dumpSchemaVersion {
+				return fmt.Errorf("dump %s has schema version %d, newer than the latest supported version %d",
+					dr.p, dr.version, dumpSchemaVersion)
+			}
+		}
+		if !dr.scan() {
+			if err := dr.s.Err(); err != nil {
+				return err
+			}
+			return fmt.Errorf("malformed dump %s, missing preamble delimiter", dr.p)
+		}
+	}
+}
+
+func (dr *dumpReader) scan() bool {
+	v := dr.s.Scan()
+	if v {
+		dr.ln++
+	}
+	return v
+}
+
+func (dr *dumpReader) curLine() (string, error) {
+	res := strings.TrimSpace(dr.s.Text())
+	if !strings.HasPrefix(res, "// ") {
+		return "", fmt.Errorf("malformed line %s:%d, no comment: %s", dr.p, dr.ln, res)
+	}
+	return res[3:], nil
+}
+
+// nextLine advances to the next line and returns its contents,
+// returning an error if there are no more lines.
+func (dr *dumpReader) nextLine() (string, error) {
+	if !dr.scan() {
+		if err := dr.s.Err(); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("malformed dump %s, unexpected EOF at line %d", dr.p, dr.ln)
+	}
+	return dr.curLine()
+}
+
+// readEntry reads a single function's worth of material from
+// a function properties dump. It deserializes the json for the func
+// properties and returns the resulting properties and function name.
+// EOF is signaled by a return with an empty function name (and no
+// error).
+func (dr *dumpReader) readEntry() (fnInlHeur, error) {
+	var fih fnInlHeur
+	if !dr.scan() {
+		return fih, dr.s.Err()
+	}
+	// first line contains info about function: file/name/line/col,
+	// once past the start of any sections for further packages in an
+	// appended dump (which may contain no entries).
+	info, err := dr.curLine()
+	if err != nil {
+		return fih, err
+	}
+	for {
+		if _, _, ok := parsePackageLine(info); !ok {
+			break
+		}
+		if err := dr.readPreamble(); err != nil {
+			return fih, err
+		}
+		if !dr.scan() {
+			return fih, dr.s.Err()
+		}
+		if info, err = dr.curLine(); err != nil {
+			return fih, err
+		}
+	}
+	fih.pkg, fih.buildID, fih.version = dr.pkg, dr.buildID, dr.version
+	chunks := strings.Fields(info)
+	if len(chunks) < 3 {
+		return fih, fmt.Errorf("malformed function preamble %s:%d: %s", dr.p, dr.ln, info)
+	}
+	fih.file = chunks[0]
+	fih.fname = chunks[1]
+	if _, err := fmt.Sscanf(chunks[2], "%d", &fih.line); err != nil {
+		return fih, err
+	}
+	// column is an optional trailing field (older dumps lack it)
+	if len(chunks) > 5 {
+		if _, err := fmt.Sscanf(chunks[5], "%d", &fih.col); err != nil {
+			return fih, err
+		}
+	}
+	// consume comments until and including delimiter, picking
+	// out any specialization hints and call sites along the way.
+	section := ""
+	for {
+		line, err := dr.nextLine()
+		if err != nil {
+			return fih, err
+		}
+		if line == comDelimiter {
+			break
+		}
+		if line == specHintsTag || line == callSitesTag || line == disagreeTag {
+			section = line
+			continue
+		}
+		if n, ok := strings.CutPrefix(line, instantiationsTag+" "); ok {
+			if _, err := fmt.Sscanf(n, "%d", &fih.ninst); err != nil {
+				return fih, err
+			}
+			section = ""
+			continue
+		}
+		if !strings.HasPrefix(line, "  ") {
+			section = ""
+			continue
+		}
+		switch section {
+		case specHintsTag:
+			h, err := parseSpecHint(line)
+			if err != nil {
+				return fih, err
+			}
+			fih.hints = append(fih.hints, h)
+		case callSitesTag:
+			cs, err := parseCallSiteInfo(line)
+			if err != nil {
+				return fih, err
+			}
+			fih.csites = append(fih.csites, cs)
+		case disagreeTag:
+			fih.disagree = append(fih.disagree, strings.TrimSpace(line))
+		}
+	}
+
+	// Consume JSON for encoded props, up to and including the
+	// delimiter. The JSON is normally written on a single line, but
+	// may span several lines if indented (see -d=dumpinlpropsindent).
+	var sb strings.Builder
+	for {
+		line, err := dr.nextLine()
+		if err != nil {
+			return fih, err
+		}
+		if line == fnDelimiter {
+			break
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	if sb.Len() == 0 {
+		return fih, fmt.Errorf("malformed dump %q, missing props for %s", dr.p, fih.fname)
+	}
+	fp := &FuncProps{}
+	dec := json.NewDecoder(strings.NewReader(sb.String()))
+	if dr.version == dumpSchemaVersion {
+		// The properties must match the current schema exactly.
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(fp); err != nil {
+		return fih, fmt.Errorf("%s: decoding props for %s (schema version %d): %v",
+			dr.p, fih.fname, dr.version, err)
+	}
+	fih.props = fp
+
+	return fih, nil
+}
+
+// MergeDumps reads in the function properties dumps in 'inputs'
+// (for example, dumps produced by separate compilations in a
+// distributed build) and writes a single combined dump to 'out'.
+// Entries that appear in more than one input are written only once;
+// an error is returned if the properties for such an entry are not
+// the same in each input. If the inputs include appended dumps
+// describing several packages (see dump_append.go), the output has
+// a section for each package, in order of import path.
+func MergeDumps(inputs []io.Reader, out io.Writer) error {
+	seen := make(map[dumpEntryKey]int)
+	var all []fnInlHeur
+	for i, r := range inputs {
+		name := fmt.Sprintf("input %d", i)
+		entries, err := parseDump(r, name)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			k := keyOf(&e)
+			if j, ok := seen[k]; ok {
+				if !sameDumpEntry(&all[j], &e) {
+					return fmt.Errorf("%s: conflicting properties for %s (%s:%d)",
+						name, e.fname, e.file, e.line)
+				}
+				continue
+			}
+			seen[k] = len(all)
+			all = append(all, e)
+		}
+	}
+
+	// Group by package, then by file (with files in sorted order,
+	// since the inputs may have seen them in different orders), and
+	// write out as with emitDumpToFile, keeping the entries for each
+	// file in the order in which they were first seen.
+	type pkgKey struct{ pkg, buildID string }
+	byPkg := make(map[pkgKey]map[string][]fnInlHeur)
+	atline := make(map[pkgKey]lineCounts)
+	for _, e := range all {
+		k := pkgKey{e.pkg, e.buildID}
+		if byPkg[k] == nil {
+			byPkg[k] = make(map[string][]fnInlHeur)
+			atline[k] = make(lineCounts)
+		}
+		e.idx = atline[k].add(e.file, e.line)
+		byPkg[k][e.file] = append(byPkg[k][e.file], e)
+	}
+	pkgs := make([]pkgKey, 0, len(byPkg))
+	for k := range byPkg {
+		pkgs = append(pkgs, k)
+	}
+	sort.Slice(pkgs, func(i, j int) bool {
+		if pkgs[i].pkg != pkgs[j].pkg {
+			return pkgs[i].pkg < pkgs[j].pkg
+		}
+		return pkgs[i].buildID < pkgs[j].buildID
+	})
+	if len(pkgs) == 0 {
+		dumpFilePreamble(out)
+	}
+	for _, k := range pkgs {
+		byFile := byPkg[k]
+		files := make([]string, 0, len(byFile))
+		for file := range byFile {
+			files = append(files, file)
+		}
+		sort.Strings(files)
+		if k.pkg != "" {
+			writePackageLine(out, k.pkg, k.buildID)
+		}
+		dumpFilePreamble(out)
+		for _, file := range files {
+			emitDumpGroup(out, byFile[file], atline[k])
+		}
+	}
+	return nil
+}
+
+// sameDumpEntry returns true if dump entries 'e1' and 'e2' have
+// the same properties, hints and call sites.
+func sameDumpEntry(e1, e2 *fnInlHeur) bool {
+	j1, err1 := json.Marshal(e1.props)
+	j2, err2 := json.Marshal(e2.props)
+	if err1 != nil || err2 != nil || string(j1) != string(j2) {
+		return false
+	}
+	return specHintsToString(e1.hints, "") == specHintsToString(e2.hints, "") &&
+		callSitesToString(e1.csites, "") == callSitesToString(e2.csites, "")
+}
+
+// dumpEntryKey uniquely identifies a function within a function
+// properties dump.
+type dumpEntryKey struct {
+	pkg, file, fname string
+	line, col        uint
+}
+
+func keyOf(e *fnInlHeur) dumpEntryKey {
+	return dumpEntryKey{e.pkg, e.file, e.fname, e.line, e.col}
+}
+
+// DiffDumps compares the function properties dump read from 'oldR'
+// against the one read from 'newR' (for example, dumps produced
+// before and after a change to the heuristics) and writes a report to
+// 'out'. Functions are matched up by package (for appended dumps),
+// file, name, line and column. For each function present in both
+// dumps, the report lists the FuncProps fields whose values differ,
+// if any, along with any call sites that were added, removed or had
+// their flags changed; functions
+// present in only one of the two dumps are reported as added or
+// removed. Other than call sites, only the encoded properties are
+// compared, not the human-readable comments that precede them.
+// Entries in the report are ordered by package and file, then line,
+// column and name.
+func DiffDumps(oldR, newR io.Reader, out io.Writer) error {
+	olds, err := parseDump(oldR, "old")
+	if err != nil {
+		return err
+	}
+	news, err := parseDump(newR, "new")
+	if err != nil {
+		return err
+	}
+	oldm := make(map[dumpEntryKey]*fnInlHeur)
+	for i := range olds {
+		oldm[keyOf(&olds[i])] = &olds[i]
+	}
+	newm := make(map[dumpEntryKey]*fnInlHeur)
+	for i := range news {
+		newm[keyOf(&news[i])] = &news[i]
+	}
+	keys := make([]dumpEntryKey, 0, len(oldm)+len(newm))
+	for k := range oldm {
+		keys = append(keys, k)
+	}
+	for k := range newm {
+		if oldm[k] == nil {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ki, kj := keys[i], keys[j]
+		if ki.pkg != kj.pkg {
+			return ki.pkg < kj.pkg
+		}
+		if ki.file != kj.file {
+			return ki.file < kj.file
+		}
+		if ki.line != kj.line {
+			return ki.line < kj.line
+		}
+		if ki.col != kj.col {
+			return ki.col < kj.col
+		}
+		return ki.fname < kj.fname
+	})
+
+	for _, k := range keys {
+		where := fmt.Sprintf("%s:%d:%d %s", k.file, k.line, k.col, k.fname)
+		if k.pkg != "" {
+			where = k.pkg + " " + where
+		}
+		oe, ne := oldm[k], newm[k]
+		switch {
+		case oe == nil:
+			fmt.Fprintf(out, "added: %s\n", where)
+		case ne == nil:
+			fmt.Fprintf(out, "removed: %s\n", where)
+		default:
+			changes, err := diffFuncProps(oe.props, ne.props)
+			if err != nil {
+				return fmt.Errorf("%s: %v", where, err)
+			}
+			changes = append(changes, diffCallSites(oe.csites, ne.csites)...)
+			if len(changes) == 0 {
+				continue
+			}
+			fmt.Fprintf(out, "changed: %s\n", where)
+			for _, c := range changes {
+				fmt.Fprintf(out, "\t%s\n", c)
+			}
+		}
+	}
+	return nil
+}
+
+// diffFuncProps returns a description of each field whose value
+// differs between 'fp1' and 'fp2', in the form "Field: old -> new",
+// with values shown in their JSON encoding. For the flags fields,
+// the description is followed by the names of the flags that were
+// set ("+") or cleared ("-").
+func diffFuncProps(fp1, fp2 *FuncProps) ([]string, error) {
+	m1, err := propsFields(fp1)
+	if err != nil {
+		return nil, err
+	}
+	m2, err := propsFields(fp2)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]string, 0, len(m1))
+	for f := range m1 {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	var rv []string
+	for _, f := range fields {
+		if bytes.Equal(m1[f], m2[f]) {
+			continue
+		}
+		desc := fmt.Sprintf("%s: %s -> %s", f, m1[f], m2[f])
+		var delta string
+		switch f {
+		case "Flags":
+			delta = bitsDelta(fp1.Flags, fp2.Flags)
+		case "ParamFlags":
+			delta = sliceBitsDelta(fp1.ParamFlags, fp2.ParamFlags)
+		case "ResultFlags":
+			delta = sliceBitsDelta(fp1.ResultFlags, fp2.ResultFlags)
+		}
+		if delta != "" {
+			desc += " (" + delta + ")"
+		}
+		rv = append(rv, desc)
+	}
+	return rv, nil
+}
+
+// propBits is the set of flag types whose changes are reported
+// symbolically by DiffDumps.
+type propBits interface {
+	~uint32 | ~uint64
+	String() string
+}
+
+// bitsDelta returns a description of the flags that differ between
+// 'b1' and 'b2', for example "+FuncPropIsPure -FuncPropMayBlock".
+func bitsDelta[T propBits](b1, b2 T) string {
+	var parts []string
+	for bit := T(1); bit != 0; bit <<= 1 {
+		switch {
+		case b1&bit == 0 && b2&bit != 0:
+			parts = append(parts, "+"+bit.String())
+		case b1&bit != 0 && b2&bit == 0:
+			parts = append(parts, "-"+bit.String())
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// sliceBitsDelta returns a description of the per-element flag
+// changes between 'sl1' and 'sl2', in the form "N: delta" for each
+// element N that differs, separated by "; ". If the slices have
+// different lengths (for example because the signature of the
+// function changed) no description is returned.
+func sliceBitsDelta[T propBits](sl1, sl2 []T) string {
+	if len(sl1) != len(sl2) {
+		return ""
+	}
+	var parts []string
+	for i := range sl1 {
+		if sl1[i] != sl2[i] {
+			parts = append(parts, fmt.Sprintf("%d: %s", i, bitsDelta(sl1[i], sl2[i])))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// diffCallSites returns a description of the differences between
+// call sites 'cs1' and 'cs2', matched up by position and callee.
+// Call sites present in only one of the two are reported as added
+// or removed; for the others, changes to the flags are reported,
+// along with changes to the score where both have one.
+func diffCallSites(cs1, cs2 []callSiteInfo) []string {
+	type csKey struct{ pos, callee string }
+	m2 := make(map[csKey]callSiteInfo, len(cs2))
+	for _, cs := range cs2 {
+		m2[csKey{cs.pos, cs.callee}] = cs
+	}
+	var rv []string
+	seen := make(map[csKey]bool, len(cs1))
+	for _, cs := range cs1 {
+		k := csKey{cs.pos, cs.callee}
+		seen[k] = true
+		ncs, ok := m2[k]
+		if !ok {
+			rv = append(rv, fmt.Sprintf("CallSite %s %s: removed", cs.pos, cs.callee))
+			continue
+		}
+		if ncs.flags != cs.flags {
+			rv = append(rv, fmt.Sprintf("CallSite %s %s: %s -> %s (%s)",
+				cs.pos, cs.callee, csFlagsString(cs.flags), csFlagsString(ncs.flags),
+				bitsDelta(cs.flags, ncs.flags)))
+		}
+		if cs.scored && ncs.scored && ncs.score != cs.score {
+			rv = append(rv, fmt.Sprintf("CallSite %s %s: score %d -> %d",
+				cs.pos, cs.callee, cs.score, ncs.score))
+		}
+	}
+	for _, cs := range cs2 {
+		if !seen[csKey{cs.pos, cs.callee}] {
+			rv = append(rv, fmt.Sprintf("CallSite %s %s: added", cs.pos, cs.callee))
+		}
+	}
+	return rv
+}
+
+// propsFields returns the JSON encoding of each field of 'fp', keyed
+// by field name.
+func propsFields(fp *FuncProps) (map[string]json.RawMessage, error) {
+	b, err := json.Marshal(fp)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,281 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+)
+
+// This file implements the "-d=dumpinlcallsitescores=file" table,
+// which lists every call site scored by the inline heuristics (see
+// GetCallSiteScore), sorted by position, along with the callee's
+// unadjusted inline cost, each of the adjustments that were applied
+// and the final score. For example:
+//
+//	pos          caller  callee  cost  adjustments              score
+//	foo.go:12:9  F       p.g     57    wrapper:-20,tailCall:-5  32
+//	foo.go:19:3  F       p.h     80    none                     80
+//
+// If a policy hook (see SetInlinePolicyHook) replaced the score, the
+// adjustments end with "policy". A call site scored more than once
+// is listed with its last score. Since call sites are only scored
+// when the heuristics are in use, the table is empty unless
+// -d=inlheuristics is also given.
+//
+// With "-d=dumpinlcallsitesource=1", the table has an additional
+// "source" column giving the text of the source line containing each
+// call site (with leading and trailing space removed), so that the
+// table can be reviewed without cross-referencing the source files.
+// The column is left empty for call sites whose source line can't be
+// read.
+//
+// With "-d=dumpinlchangedonly=1", the table is restricted to the
+// call sites whose inlining decision was changed by the heuristics,
+// that is, those that were inlined although the callee's unadjusted
+// cost exceeds the threshold, or not inlined although it doesn't
+// (as in the -d=inlheuroutcomes summary). The table then has two
+// additional columns, giving the threshold and whether the
+// heuristics enabled or suppressed inlining. Call sites for which
+// the inliner made no decision based on cost are omitted.
+
+// callSiteScore records the score computed for a call site.
+type callSiteScore struct {
+	path           string // file name as given to the compiler
+	file           string
+	line, col      uint
+	caller, callee string
+	cost, score    int
+	adjs           string
+	src            string
+
+	// The inliner's decision for the call site, if it made one
+	// based on cost (see RecordCallSiteDecision).
+	decided   bool
+	inlined   bool
+	threshold int
+}
+
+// callSiteScores holds the scores recorded so far, or nil if they
+// are not being collected (the default); see EnableCallSiteScoreDump.
+var callSiteScores map[*CallSite]*callSiteScore
+
+// EnableCallSiteScoreDump turns on the recording of call site scores
+// for the "-d=dumpinlcallsitescores" command line flag. It should be
+// called before any scoring takes place.
+func EnableCallSiteScoreDump() {
+	callSiteScores = make(map[*CallSite]*callSiteScore)
+}
+
+// recordCallSiteScore records the score 'score' computed for call
+// site 'cs' in 'caller', where 'cost' is the callee's unadjusted
+// cost and 'mask' the adjustments that were applied.
+func recordCallSiteScore(caller *ir.Func, cs *CallSite, cost, score int, mask scoreAdjustTyp) {
+	p := base.Ctxt.InnermostPos(cs.Call.Pos())
+	e := &callSiteScore{
+		path:   p.Filename(),
+		file:   filepath.Base(p.Filename()),
+		line:   p.Line(),
+		col:    p.Col(),
+		caller: ir.FuncName(caller),
+		callee: ir.PkgFuncName(cs.Callee),
+		cost:   cost,
+		score:  score,
+	}
+	var sb strings.Builder
+	sum := cost
+	for m := mask; m != 0; m &= m - 1 {
+		typ := scoreAdjustTyp(1) << bits.TrailingZeros64(uint64(m))
+		val := adjValue(typ)
+		sum += val
+		if sb.Len() != 0 {
+			sb.WriteByte(',')
+		}
+		fmt.Fprintf(&sb, "%s:%+d", adjDisplayName(typ), val)
+	}
+	if sum != score {
+		if sb.Len() != 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString("policy")
+	}
+	e.adjs = sb.String()
+	if e.adjs == "" {
+		e.adjs = "none"
+	}
+	callSiteScores[cs] = e
+}
+
+// RecordCallSiteDecision records the inliner's decision for the call
+// 'call' in 'caller', for the "-d=dumpinlchangedonly" table:
+// 'inlined' is the decision made by comparing the call's score
+// against 'threshold'. It should be called after GetCallSiteScore.
+func RecordCallSiteDecision(caller *ir.Func, call *ir.CallExpr, inlined bool, threshold int32) {
+	if callSiteScores == nil {
+		return
+	}
+	e := callSiteScores[lookupCallSite(caller, call)]
+	if e == nil {
+		return
+	}
+	e.decided, e.inlined, e.threshold = true, inlined, int(threshold)
+}
+
+// changed reports whether the decision recorded for the call site
+// differs from the one that would have been made using the callee's
+// unadjusted cost.
+func (e *callSiteScore) changed() bool {
+	return e.decided && (e.cost <= e.threshold) != e.inlined
+}
+
+// changedCallSiteScores returns the entries in 'entries' whose
+// decision was changed by the heuristics.
+func changedCallSiteScores(entries []*callSiteScore) []*callSiteScore {
+	var rv []*callSiteScore
+	for _, e := range entries {
+		if e.changed() {
+			rv = append(rv, e)
+		}
+	}
+	return rv
+}
+
+// adjDisplayName returns the name of adjustment 'typ' as shown in
+// the call site score table, namely its scoreAdjustTyp constant
+// without the "Adj" suffix.
+func adjDisplayName(typ scoreAdjustTyp) string {
+	return strings.TrimSuffix(typ.String(), "Adj")
+}
+
+// maskAdjustments returns the adjustments in 'mask', each in the
+// form "name:+N" where N is its value, in the order of their bits.
+func maskAdjustments(mask scoreAdjustTyp) []string {
+	var adjs []string
+	for m := mask; m != 0; m &= m - 1 {
+		typ := scoreAdjustTyp(1) << bits.TrailingZeros64(uint64(m))
+		adjs = append(adjs, fmt.Sprintf("%s:%+d", adjDisplayName(typ), adjValue(typ)))
+	}
+	return adjs
+}
+
+// DumpCallSiteScores writes the call site scores recorded so far to
+// the file 'path'.
+func DumpCallSiteScores(path string) {
+	entries := make([]*callSiteScore, 0, len(callSiteScores))
+	for _, e := range callSiteScores {
+		entries = append(entries, e)
+	}
+	sortCallSiteScores(entries)
+	withDecision := base.Debug.DumpInlChangedOnly != 0
+	if withDecision {
+		entries = changedCallSiteScores(entries)
+	}
+	withSrc := base.Debug.DumpInlCallSiteSource != 0
+	if withSrc {
+		addCallSiteSource(entries)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		base.Fatalf("opening call site score dump file %q: %v", path, err)
+	}
+	w := bufio.NewWriter(f)
+	writeCallSiteScores(w, entries, withSrc, withDecision)
+	if err := w.Flush(); err != nil {
+		base.Fatalf("writing call site score dump file %q: %v", path, err)
+	}
+	if err := f.Close(); err != nil {
+		base.Fatalf("closing call site score dump file %q: %v", path, err)
+	}
+	callSiteScores = nil
+}
+
+// sortCallSiteScores sorts 'entries' by position, then by caller and
+// callee (a call within a closure that has been inlined may appear
+// in more than one caller).
+func sortCallSiteScores(entries []*callSiteScore) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.file != b.file {
+			return a.file < b.file
+		}
+		if a.line != b.line {
+			return a.line < b.line
+		}
+		if a.col != b.col {
+			return a.col < b.col
+		}
+		if a.caller != b.caller {
+			return a.caller < b.caller
+		}
+		return a.callee < b.callee
+	})
+}
+
+// writeCallSiteScores writes the table of 'entries' to 'w', including
+// the threshold and decision columns if 'withDecision' is set and the
+// source column if 'withSrc' is set.
+func writeCallSiteScores(w io.Writer, entries []*callSiteScore, withSrc, withDecision bool) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "pos\tcaller\tcallee\tcost\tadjustments\tscore")
+	if withDecision {
+		fmt.Fprintf(tw, "\tthreshold\tdecision")
+	}
+	if withSrc {
+		fmt.Fprintf(tw, "\tsource")
+	}
+	fmt.Fprintf(tw, "\n")
+	for _, e := range entries {
+		fmt.Fprintf(tw, "%s:%d:%d\t%s\t%s\t%d\t%s\t%d", e.file, e.line,
+			e.col, e.caller, e.callee, e.cost, e.adjs, e.score)
+		if withDecision {
+			decision := "suppressed"
+			if e.inlined {
+				decision = "enabled"
+			}
+			fmt.Fprintf(tw, "\t%d\t%s", e.threshold, decision)
+		}
+		if withSrc && e.src != "" {
+			fmt.Fprintf(tw, "\t%s", e.src)
+		}
+		fmt.Fprintf(tw, "\n")
+	}
+	tw.Flush()
+}
+
+// addCallSiteSource fills in the source line text of each of the call
+// sites in 'entries', reading each source file at most once.
+func addCallSiteSource(entries []*callSiteScore) {
+	files := make(map[string][]string)
+	for _, e := range entries {
+		lines, ok := files[e.path]
+		if !ok {
+			if data, err := os.ReadFile(e.path); err == nil {
+				lines = strings.Split(string(data), "\n")
+			}
+			files[e.path] = lines
+		}
+		if e.line >= 1 && e.line <= uint(len(lines)) {
+			e.src = sourceSnippet(lines[e.line-1])
+		}
+	}
+}
+
+// sourceSnippet returns source line 'line' in the form shown in the
+// source column of the call site score table: with leading and
+// trailing space removed, and any tabs within it replaced by spaces
+// (since the table is laid out with tabs).
+func sourceSnippet(line string) string {
+	return strings.ReplaceAll(strings.TrimSpace(line), "\t", " ")
+}
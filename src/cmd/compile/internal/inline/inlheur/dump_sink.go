@@ -0,0 +1,93 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+)
+
+// This file contains support for writing the function properties
+// dump to destinations other than a single file. The file part of
+// the dump spec (see parseDumpSpec) may name several destinations
+// separated by "+" (commas can't be used, since they separate -d
+// options), each of which receives a complete copy of the dump. A
+// destination of "-" denotes the standard output, and "-stderr" the
+// standard error; for example "-d=dumpinlfuncprops=props.txt+-stderr"
+// writes the dump both to props.txt and to the standard error, where
+// it is captured along with the rest of the build log.
+
+// dumpSinkSep separates the destinations in a dump spec.
+const dumpSinkSep = "+"
+
+// dumpSink is the destination of the function properties dump.
+type dumpSink struct {
+	w     io.Writer
+	files []*os.File    // files to be closed when the dump is done
+	buf   *bytes.Buffer // in append mode, output not yet written
+}
+
+// openDumpSink opens the destinations listed in 'dumpfile', creating
+// any files. Files are truncated, unless 'appending' is set, in which
+// case the output is held in memory until the sink is closed, then
+// appended to each file with a single write (see dump_append.go).
+func openDumpSink(dumpfile string, appending bool) (*dumpSink, error) {
+	ds := &dumpSink{}
+	mode := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if appending {
+		mode = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+		ds.buf = new(bytes.Buffer)
+	}
+	var ws []io.Writer
+	for _, dest := range strings.Split(dumpfile, dumpSinkSep) {
+		switch dest {
+		case "-":
+			ws = append(ws, os.Stdout)
+		case "-stderr":
+			ws = append(ws, os.Stderr)
+		default:
+			f, err := os.OpenFile(dest, mode, 0644)
+			if err != nil {
+				ds.Close()
+				return nil, err
+			}
+			ds.files = append(ds.files, f)
+			ws = append(ws, f)
+		}
+	}
+	if len(ws) == 1 {
+		ds.w = ws[0]
+	} else {
+		ds.w = io.MultiWriter(ws...)
+	}
+	return ds, nil
+}
+
+func (ds *dumpSink) Write(p []byte) (int, error) {
+	if ds.buf != nil {
+		return ds.buf.Write(p)
+	}
+	return ds.w.Write(p)
+}
+
+// Close writes out any buffered output, then closes any files
+// written by 'ds' (but not the standard output or error), returning
+// the first error encountered.
+func (ds *dumpSink) Close() error {
+	var rerr error
+	if ds.buf != nil && ds.w != nil {
+		_, rerr = ds.w.Write(ds.buf.Bytes())
+		ds.buf = nil
+	}
+	for _, f := range ds.files {
+		if err := f.Close(); err != nil && rerr == nil {
+			rerr = err
+		}
+	}
+	ds.files = nil
+	return rerr
+}
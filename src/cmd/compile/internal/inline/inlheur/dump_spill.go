@@ -0,0 +1,140 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"bytes"
+	"io"
+	"os"
+)
+
+// This file contains support for streaming the function properties
+// dump (see StreamFuncPropsDump). Rather than holding on to the dump
+// entries for the whole package (along with the call site tables and
+// IR they refer to), entries are encoded as soon as they are final
+// and appended to a temporary "spill" file, keeping only a small
+// index record for each one in memory. When the dump is finalized,
+// the encoded entries are copied from the spill file into the dump in
+// the same order as in the default buffered mode.
+
+// spillWriter accumulates encoded function properties dump entries
+// in a temporary file.
+type spillWriter struct {
+	f     *os.File
+	off   int64
+	index []spillRecord
+	buf   bytes.Buffer
+}
+
+// spillRecord identifies a dump entry written to the spill file.
+// For the default format, the spilled data excludes the first line
+// of the function preamble, since that depends on the other entries
+// from the same source file.
+type spillRecord struct {
+	file, fname string
+	line, col   uint
+	idx         uint
+	off, len    int64
+}
+
+// newSpillWriter creates a spillWriter backed by a new temporary
+// file. The file is created lazily, on the first call to add.
+func newSpillWriter() *spillWriter {
+	return &spillWriter{}
+}
+
+// add encodes dump entry 'e' and appends it to the spill file.
+func (sw *spillWriter) add(e *fnInlHeur) error {
+	if sw.f == nil {
+		f, err := os.CreateTemp("", "inlprops*.spill")
+		if err != nil {
+			return err
+		}
+		sw.f = f
+	}
+	sw.buf.Reset()
+	switch {
+	case dumpJSON:
+		data, err := jsonEntryData(e)
+		if err != nil {
+			return err
+		}
+		sw.buf.Write(data)
+	case dumpCSV:
+		if err := writeCSVRecord(&sw.buf, csvRecord(e)); err != nil {
+			return err
+		}
+	default:
+		if err := dumpFnBody(&sw.buf, e); err != nil {
+			return err
+		}
+	}
+	n, err := sw.f.Write(sw.buf.Bytes())
+	if err != nil {
+		return err
+	}
+	sw.index = append(sw.index, spillRecord{
+		file:  e.file,
+		fname: e.fname,
+		line:  e.line,
+		col:   e.col,
+		idx:   e.idx,
+		off:   sw.off,
+		len:   int64(n),
+	})
+	sw.off += int64(n)
+	return nil
+}
+
+// finish writes out the spilled entries to 'w', grouped by source
+// file (with files in the order in which they were first seen) and
+// in the order in which they were spilled within each file, then
+// removes the spill file.
+func (sw *spillWriter) finish(w io.Writer) error {
+	if sw.f == nil {
+		return nil
+	}
+	defer func() {
+		sw.f.Close()
+		os.Remove(sw.f.Name())
+		sw.f = nil
+	}()
+	for _, recs := range groupByFile(sw.index, func(r *spillRecord) string { return r.file }) {
+		if err := sw.writeGroup(w, recs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeGroup writes out the spilled entries in 'recs', all of which
+// originate from the same source file.
+func (sw *spillWriter) writeGroup(w io.Writer, recs []spillRecord) error {
+	for i := range recs {
+		r := &recs[i]
+		data := make([]byte, r.len)
+		if _, err := sw.f.ReadAt(data, r.off); err != nil {
+			return err
+		}
+		switch {
+		case dumpJSON:
+			if err := writeJSONEntry(w, data); err != nil {
+				return err
+			}
+			continue
+		case dumpCSV:
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+			continue
+		}
+		dumpFnHeader(w, r.file, r.fname, r.line, r.idx,
+			dumpBuffer.atline.count(r.file, r.line), r.col)
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import "cmd/compile/internal/ir"
+
+// This file implements an optional second analysis pass over the
+// package's inlinable functions (enabled with "-d=inlheurescapepass=1"),
+// which runs after escape analysis and refines the properties
+// computed before it using its results. Since all inlining within the
+// package is complete by the time escape analysis runs, there are no
+// call sites left to re-score here; instead the refined properties
+// are recorded in the export data, where they are used when scoring
+// calls to the functions from importing packages.
+//
+// At present the only refinement is to FuncPropAllocates, which the
+// alloc analyzer (see allocAnalyzer) sets on the assumption that any
+// allocation may wind up on the heap. If escape analysis shows that
+// none of the function's allocations escape to the heap, the flag is
+// cleared, since inlining the function won't save any heap
+// allocations.
+
+// RefineAfterEscape runs the second analysis pass over the functions
+// in 'fns', which must have been through escape analysis.
+func RefineAfterEscape(fns []*ir.Func) {
+	for _, fn := range fns {
+		if fn.Inl == nil {
+			continue
+		}
+		fp := propsForFunc(fn)
+		if fp == nil || fp.Flags&FuncPropAllocates == 0 || heapAllocates(fn) {
+			continue
+		}
+		// The properties may be shared (see propsCache), so make a
+		// copy rather than updating them in place.
+		nfp := *fp
+		nfp.Flags &^= FuncPropAllocates
+		funcPropsMu.Lock()
+		funcPropsTab[fn] = &nfp
+		funcPropsMu.Unlock()
+		fn.Inl.Properties = nfp.SerializeToString()
+		if debugTrace&debugTraceResults != 0 {
+			traceEvent("result", "analyzer", "escape", "func", fn.Sym().Name,
+				"allocates", false)
+		}
+	}
+}
+
+// heapAllocates reports whether any of the allocations in 'fn' (not
+// counting those in nested function literals) may be on the heap
+// according to escape analysis. Appends are assumed to allocate on
+// the heap, since escape analysis doesn't track the growth of the
+// slice.
+func heapAllocates(fn *ir.Func) bool {
+	var do func(ir.Node) bool
+	do = func(n ir.Node) bool {
+		switch n.Op() {
+		case ir.OCLOSURE:
+			return false
+		case ir.OAPPEND:
+			return true
+		case ir.ONEW, ir.OMAKESLICE, ir.OMAKESLICECOPY, ir.OMAKEMAP,
+			ir.OMAKECHAN, ir.OPTRLIT, ir.OSLICELIT, ir.OMAPLIT:
+			if n.Esc() != ir.EscNone {
+				return true
+			}
+		}
+		return ir.DoChildren(n, do)
+	}
+	return ir.DoChildren(fn, do)
+}
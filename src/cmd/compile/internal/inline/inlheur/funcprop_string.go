@@ -22,9 +22,81 @@ func (fp *FuncProps) ToString(prefix string) string {
 		prefix, "ParamFlags")
 	flagSliceToSB[ResultPropBits](&sb, fp.ResultFlags,
 		prefix, "ResultFlags")
+	if fp.ResultAffectingParams != 0 {
+		fmt.Fprintf(&sb, "%sResultAffectingParams", prefix)
+		for i := 0; i < 64; i++ {
+			if fp.ResultAffectingParams&(1<<i) != 0 {
+				fmt.Fprintf(&sb, " %d", i)
+			}
+		}
+		sb.WriteString("\n")
+	}
+	if fp.NumReturns != 0 {
+		fmt.Fprintf(&sb, "%sNumReturns %d\n", prefix, fp.NumReturns)
+	}
+	if fp.SingleTailReturn {
+		fmt.Fprintf(&sb, "%sSingleTailReturn\n", prefix)
+	}
+	if fp.NumCalls != 0 {
+		fmt.Fprintf(&sb, "%sMaxCallArgs %d\n", prefix, fp.MaxCallArgs)
+		fmt.Fprintf(&sb, "%sNumCalls %d\n", prefix, fp.NumCalls)
+	}
+	if nc := fp.NodeCounts; nc != (NodeCounts{}) {
+		fmt.Fprintf(&sb, "%sNodeCounts stmts=%d exprs=%d control=%d\n",
+			prefix, nc.Stmts, nc.Exprs, nc.ControlFlow)
+	}
+	if fp.Hotspot != "" {
+		fmt.Fprintf(&sb, "%sHotspot %s\n", prefix, fp.Hotspot)
+	}
+	if c := fp.Captures; c != (ClosureCaptures{}) {
+		fmt.Fprintf(&sb, "%sCaptures vars=%d byref=%d", prefix, c.Vars, c.ByRef)
+		if c.Escapes {
+			sb.WriteString(" escapes")
+		}
+		sb.WriteString("\n")
+	}
 	return sb.String()
 }
 
+// Summary returns a single-line description of the most salient of
+// the properties in 'fp' (the function flags and the non-zero param
+// and result flags), as printed in the "-m=3" diagnostics, or "none"
+// if there are none.
+func (fp *FuncProps) Summary() string {
+	var parts []string
+	if fp.Flags != 0 {
+		parts = append(parts, "flags="+fp.Flags.String())
+	}
+	if s := flagSliceSummary[ParamPropBits](fp.ParamFlags); s != "" {
+		parts = append(parts, "params="+s)
+	}
+	if s := flagSliceSummary[ResultPropBits](fp.ResultFlags); s != "" {
+		parts = append(parts, "results="+s)
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, " ")
+}
+
+// flagSliceSummary returns the non-zero entries of 'sl' in the form
+// "[i:flags ...]", or the empty string if there are none.
+func flagSliceSummary[T interface {
+	~uint32
+	String() string
+}](sl []T) string {
+	var items []string
+	for i, e := range sl {
+		if e != 0 {
+			items = append(items, fmt.Sprintf("%d:%s", i, e.String()))
+		}
+	}
+	if len(items) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(items, " ") + "]"
+}
+
 func flagSliceToSB[T interface {
 	~uint32
 	String() string
@@ -15,16 +15,86 @@ func _() {
 	// An "invalid array index" compiler error signifies that the constant values have changed.
 	// Re-run the stringer command to generate them again.
 	var x [1]struct{}
+	_ = x[concurrencyFlags-3932160]
 	_ = x[FuncPropNeverReturns-1]
+	_ = x[FuncPropCASLoop-2]
+	_ = x[FuncPropIsWrapper-4]
+	_ = x[FuncPropTailRecursive-8]
+	_ = x[FuncPropSyscallWrapper-16]
+	_ = x[FuncPropContainsRecover-32]
+	_ = x[FuncPropArrayConstructor-64]
+	_ = x[FuncPropMayBlock-128]
+	_ = x[FuncPropEndianConv-256]
+	_ = x[FuncPropFormatWrapper-512]
+	_ = x[FuncPropUsesUnsafe-1024]
+	_ = x[FuncPropIsPure-2048]
+	_ = x[FuncPropStraightLine-4096]
+	_ = x[FuncPropContainsDefer-8192]
+	_ = x[FuncPropOpenDeferIneligible-16384]
+	_ = x[FuncPropAllocates-32768]
+	_ = x[FuncPropRecursive-65536]
+	_ = x[FuncPropIsLeaf-131072]
+	_ = x[FuncPropSpawnsGoroutine-262144]
+	_ = x[FuncPropUsesChannels-524288]
+	_ = x[FuncPropUsesSelect-1048576]
+	_ = x[FuncPropUsesMutex-2097152]
+	_ = x[FuncPropConcatDominated-4194304]
+	_ = x[FuncPropTrivialWrapper-8388608]
+	_ = x[FuncPropExternalImpl-16777216]
+	_ = x[FuncPropCoverageCold-33554432]
+	_ = x[FuncPropReceiverOnly-67108864]
+	_ = x[FuncPropTooLarge-134217728]
+	_ = x[FuncPropHintHot-268435456]
+	_ = x[FuncPropHintCold-536870912]
+	_ = x[FuncPropHintPreferInline-1073741824]
+	_ = x[FuncPropNearlyLeaf-2147483648]
+	_ = x[FuncPropCompilerGenerated-4294967296]
+	_ = x[FuncPropLowLevel-8589934592]
+	_ = x[FuncPropRangeOverFunc-17179869184]
 }
 
 var _FuncPropBits_value = [...]uint64{
-	0x1, /* FuncPropNeverReturns */
+	0x3c0000,    /* concurrencyFlags */
+	0x1,         /* FuncPropNeverReturns */
+	0x2,         /* FuncPropCASLoop */
+	0x4,         /* FuncPropIsWrapper */
+	0x8,         /* FuncPropTailRecursive */
+	0x10,        /* FuncPropSyscallWrapper */
+	0x20,        /* FuncPropContainsRecover */
+	0x40,        /* FuncPropArrayConstructor */
+	0x80,        /* FuncPropMayBlock */
+	0x100,       /* FuncPropEndianConv */
+	0x200,       /* FuncPropFormatWrapper */
+	0x400,       /* FuncPropUsesUnsafe */
+	0x800,       /* FuncPropIsPure */
+	0x1000,      /* FuncPropStraightLine */
+	0x2000,      /* FuncPropContainsDefer */
+	0x4000,      /* FuncPropOpenDeferIneligible */
+	0x8000,      /* FuncPropAllocates */
+	0x10000,     /* FuncPropRecursive */
+	0x20000,     /* FuncPropIsLeaf */
+	0x40000,     /* FuncPropSpawnsGoroutine */
+	0x80000,     /* FuncPropUsesChannels */
+	0x100000,    /* FuncPropUsesSelect */
+	0x200000,    /* FuncPropUsesMutex */
+	0x400000,    /* FuncPropConcatDominated */
+	0x800000,    /* FuncPropTrivialWrapper */
+	0x1000000,   /* FuncPropExternalImpl */
+	0x2000000,   /* FuncPropCoverageCold */
+	0x4000000,   /* FuncPropReceiverOnly */
+	0x8000000,   /* FuncPropTooLarge */
+	0x10000000,  /* FuncPropHintHot */
+	0x20000000,  /* FuncPropHintCold */
+	0x40000000,  /* FuncPropHintPreferInline */
+	0x80000000,  /* FuncPropNearlyLeaf */
+	0x100000000, /* FuncPropCompilerGenerated */
+	0x200000000, /* FuncPropLowLevel */
+	0x400000000, /* FuncPropRangeOverFunc */
 }
 
-const _FuncPropBits_name = "FuncPropNeverReturns"
+const _FuncPropBits_name = "concurrencyFlagsFuncPropNeverReturnsFuncPropCASLoopFuncPropIsWrapperFuncPropTailRecursiveFuncPropSyscallWrapperFuncPropContainsRecoverFuncPropArrayConstructorFuncPropMayBlockFuncPropEndianConvFuncPropFormatWrapperFuncPropUsesUnsafeFuncPropIsPureFuncPropStraightLineFuncPropContainsDeferFuncPropOpenDeferIneligibleFuncPropAllocatesFuncPropRecursiveFuncPropIsLeafFuncPropSpawnsGoroutineFuncPropUsesChannelsFuncPropUsesSelectFuncPropUsesMutexFuncPropConcatDominatedFuncPropTrivialWrapperFuncPropExternalImplFuncPropCoverageColdFuncPropReceiverOnlyFuncPropTooLargeFuncPropHintHotFuncPropHintColdFuncPropHintPreferInlineFuncPropNearlyLeafFuncPropCompilerGeneratedFuncPropLowLevelFuncPropRangeOverFunc"
 
-var _FuncPropBits_index = [...]uint8{0, 20}
+var _FuncPropBits_index = [...]uint16{0, 16, 36, 51, 68, 89, 111, 134, 158, 174, 192, 213, 231, 245, 265, 286, 313, 330, 347, 361, 384, 404, 422, 439, 462, 484, 504, 524, 544, 560, 575, 591, 615, 633, 658, 674, 695}
 
 func (i FuncPropBits) String() string {
 	var b bytes.Buffer
@@ -5,21 +5,26 @@
 package inlheur
 
 import (
-	"bufio"
+	"bytes"
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/inline/golden"
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/types"
+	"cmd/internal/src"
+	"encoding/csv"
 	"encoding/json"
-	"flag"
 	"fmt"
 	"internal/testenv"
+	"io"
 	"os"
 	"path/filepath"
+	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 	"testing"
-	"time"
 )
 
-var remasterflag = flag.Bool("update-expected", false, "if true, generate updated golden results in testcases for all props tests")
-
 func TestFuncProperties(t *testing.T) {
 	td := t.TempDir()
 	//td = "/tmp/qqq"
@@ -35,10 +40,10 @@ func TestFuncProperties(t *testing.T) {
 	// to building a fresh compiler on the fly, or using some other
 	// scheme.
 
-	testcases := []string{"funcflags", "returns"}
+	testcases := []string{"funcflags", "returns", "params", "shapes", "callsites"}
 
 	for _, tc := range testcases {
-		dumpfile, err := gatherPropsDumpForFile(t, tc, td)
+		dumpfile, err := gatherPropsDumpForFile(t, tc, td, "")
 		if err != nil {
 			t.Fatalf("dumping func props for %q: error %v", tc, err)
 		}
@@ -47,17 +52,13 @@ func TestFuncProperties(t *testing.T) {
 		if derr != nil {
 			t.Fatalf("reading func prop dump: %v", derr)
 		}
-		if *remasterflag {
+		sortBySource(dentries)
+		if *golden.Update {
 			updateExpected(t, tc, dentries)
 			continue
 		}
-		// Generate expected dump.
-		epath, gerr := genExpected(td, tc)
-		if gerr != nil {
-			t.Fatalf("generating expected func prop dump: %v", gerr)
-		}
 		// Read in the expected result entries.
-		eentries, eerr := readDump(t, epath)
+		eentries, eerr := readExpected(tc)
 		if eerr != nil {
 			t.Fatalf("reading expected func prop dump: %v", eerr)
 		}
@@ -86,252 +87,933 @@ func TestFuncProperties(t *testing.T) {
 	}
 }
 
-func propBitsToString[T interface{ String() string }](sl []T) string {
-	var sb strings.Builder
-	for i, f := range sl {
-		fmt.Fprintf(&sb, "%d: %s\n", i, f.String())
+// TestStreamingDump verifies that streaming the function properties
+// dump (spilling entries to a temporary file as they are computed)
+// produces the same entries, in the same order, as the default
+// buffered mode.
+func TestStreamingDump(t *testing.T) {
+	td := t.TempDir()
+	testenv.MustHaveGoBuild(t)
+
+	for _, tc := range []string{"funcflags", "returns"} {
+		var dumps [2][]fnInlHeur
+		for i, extra := range []string{"", "dumpinlpropsstream=1"} {
+			dumpfile, err := gatherPropsDumpForFile(t, tc, td, extra)
+			if err != nil {
+				t.Fatalf("dumping func props for %q: error %v", tc, err)
+			}
+			if dumps[i], err = readDump(t, dumpfile); err != nil {
+				t.Fatalf("reading func prop dump: %v", err)
+			}
+		}
+		if len(dumps[0]) != len(dumps[1]) {
+			t.Fatalf("testcase %s: buffered dump has %d entries, streaming dump has %d", tc, len(dumps[0]), len(dumps[1]))
+		}
+		for i := range dumps[0] {
+			be, se := &dumps[0][i], &dumps[1][i]
+			if be.fname != se.fname || be.line != se.line || be.col != se.col {
+				t.Errorf("testcase %s: entry %d: buffered dump has %s:%d:%d, streaming dump has %s:%d:%d", tc, i, be.fname, be.line, be.col, se.fname, se.line, se.col)
+				continue
+			}
+			// Specialization hints are not available in streaming
+			// mode, so don't compare them.
+			be.hints = nil
+			compareEntries(t, tc, se, be)
+		}
 	}
-	return sb.String()
 }
 
-func compareEntries(t *testing.T, tc string, dentry *fnInlHeur, eentry *fnInlHeur) {
-	dfp := dentry.props
-	efp := eentry.props
-	dfn := dentry.fname
+// TestIncrementalProps verifies that computing function properties
+// incrementally via a FuncPropsBuilder (as done for the dump with
+// "-d=dumpinlpropsincr=1") produces the same results as the
+// default batch analysis.
+func TestIncrementalProps(t *testing.T) {
+	td := t.TempDir()
+	testenv.MustHaveGoBuild(t)
 
-	// Compare function flags.
-	if dfp.Flags != efp.Flags {
-		t.Errorf("testcase %s: Flags mismatch for %q: got %s, wanted %s",
-			tc, dfn, dfp.Flags.String(), efp.Flags.String())
+	for _, tc := range []string{"funcflags", "returns", "shapes"} {
+		var dumps [2][]fnInlHeur
+		for i, extra := range []string{"", "dumpinlpropsincr=1"} {
+			dumpfile, err := gatherPropsDumpForFile(t, tc, td, extra)
+			if err != nil {
+				t.Fatalf("dumping func props for %q: error %v", tc, err)
+			}
+			if dumps[i], err = readDump(t, dumpfile); err != nil {
+				t.Fatalf("reading func prop dump: %v", err)
+			}
+		}
+		if len(dumps[0]) != len(dumps[1]) {
+			t.Fatalf("testcase %s: batch dump has %d entries, incremental dump has %d", tc, len(dumps[0]), len(dumps[1]))
+		}
+		for i := range dumps[0] {
+			be, ie := &dumps[0][i], &dumps[1][i]
+			if be.fname != ie.fname || be.line != ie.line || be.col != ie.col {
+				t.Errorf("testcase %s: entry %d: batch dump has %s:%d:%d, incremental dump has %s:%d:%d", tc, i, be.fname, be.line, be.col, ie.fname, ie.line, ie.col)
+				continue
+			}
+			compareEntries(t, tc, ie, be)
+		}
 	}
-	// Compare returns
-	rgot := propBitsToString[ResultPropBits](dfp.ResultFlags)
-	rwant := propBitsToString[ResultPropBits](efp.ResultFlags)
-	if rgot != rwant {
-		t.Errorf("Results mismatch for %q: got:\n%swant:\n%s",
-			dfn, rgot, rwant)
+}
+
+// TestParallelProps verifies that computing function properties in
+// parallel ("-d=inlpropsworkers=N") produces the same results as
+// the default serial analysis.
+func TestParallelProps(t *testing.T) {
+	td := t.TempDir()
+	testenv.MustHaveGoBuild(t)
+
+	for _, tc := range []string{"callsites", "funcflags", "params", "returns"} {
+		var dumps [2][]fnInlHeur
+		for i, extra := range []string{"", "inlpropsworkers=4"} {
+			dumpfile, err := gatherPropsDumpForFile(t, tc, td, extra)
+			if err != nil {
+				t.Fatalf("dumping func props for %q: error %v", tc, err)
+			}
+			if dumps[i], err = readDump(t, dumpfile); err != nil {
+				t.Fatalf("reading func prop dump: %v", err)
+			}
+			// Functions are analyzed (and so recorded) in a
+			// different order when analyzed as a single batch.
+			sortBySource(dumps[i])
+		}
+		if len(dumps[0]) != len(dumps[1]) {
+			t.Fatalf("testcase %s: serial dump has %d entries, parallel dump has %d", tc, len(dumps[0]), len(dumps[1]))
+		}
+		for i := range dumps[0] {
+			se, pe := &dumps[0][i], &dumps[1][i]
+			if se.fname != pe.fname || se.line != pe.line || se.col != pe.col {
+				t.Errorf("testcase %s: entry %d: serial dump has %s:%d:%d, parallel dump has %s:%d:%d", tc, i, se.fname, se.line, se.col, pe.fname, pe.line, pe.col)
+				continue
+			}
+			compareEntries(t, tc, pe, se)
+		}
 	}
-	// Compare receiver + params.
-	pgot := propBitsToString[ParamPropBits](dfp.ParamFlags)
-	pwant := propBitsToString[ParamPropBits](efp.ParamFlags)
-	if pgot != pwant {
-		t.Errorf("Params mismatch for %q: got:\n%swant:\n%s",
-			dfn, pgot, pwant)
+}
+
+// TestVerifyProps checks that the analyzers produce the same results
+// when run twice over each of the test cases ("-d=inlheurverify=1"),
+// with and without the heuristics (which analyze functions through
+// a different path).
+func TestVerifyProps(t *testing.T) {
+	td := t.TempDir()
+	testenv.MustHaveGoBuild(t)
+
+	for _, tc := range []string{"callsites", "funcflags", "params", "returns", "shapes"} {
+		for _, extra := range []string{"inlheurverify=1", "inlheurverify=1,inlheuristics=1"} {
+			if _, err := gatherPropsDumpForFile(t, tc, td, extra); err != nil {
+				t.Errorf("testcase %s with %s: error %v", tc, extra, err)
+			}
+		}
 	}
 }
 
-type dumpReader struct {
-	s  *bufio.Scanner
-	t  *testing.T
-	p  string
-	ln int
+// TestDumpIR verifies that "-d=inlheurdumpir=flag" dumps the IR of the
+// functions for which the named flag is set, and only those.
+func TestDumpIR(t *testing.T) {
+	td := t.TempDir()
+	testenv.MustHaveGoBuild(t)
+
+	outpath := filepath.Join(td, "funcflags.a")
+	cmd := testenv.Command(t, testenv.GoToolPath(t), "build",
+		"-gcflags=-d=inlheurdumpir=FuncPropNeverReturns,inlheuristics=1",
+		"-o", outpath, "testdata/props/funcflags.go")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("build failed: %v\n%s", err, out)
+	}
+	dumped := regexp.MustCompile(`(?m)^inlheur FuncPropNeverReturns \(.*\):\n\.\s+DCLFUNC \w+\.(\S+)`)
+	got := make(map[string]bool)
+	for _, m := range dumped.FindAllStringSubmatch(string(out), -1) {
+		got[m[1]] = true
+	}
+	if !got["T_simple"] {
+		t.Errorf("IR of T_simple not dumped; output:\n%s", out)
+	}
+	if got["T_feeds_if_simple"] {
+		t.Errorf("IR of T_feeds_if_simple unexpectedly dumped")
+	}
 }
 
-// readDump reads in the contents of a dump file produced
-// by the "-d=dumpinlfuncprops=..." command line flag by the Go
-// compiler. It breaks the dump down into separate sections
-// by function, then deserializes each func section into a
-// fnInlHeur object and returns a slice of those objects.
-func readDump(t *testing.T, path string) ([]fnInlHeur, error) {
-	content, err := os.ReadFile(path)
+// TestIndentedDump verifies that a dump written with the embedded
+// JSON indented ("-d=dumpinlpropsindent=1") can be read back in, and
+// has the same entries as the default compact form.
+func TestIndentedDump(t *testing.T) {
+	td := t.TempDir()
+	testenv.MustHaveGoBuild(t)
+
+	for _, tc := range []string{"funcflags", "shapes"} {
+		var dumps [2][]fnInlHeur
+		for i, extra := range []string{"", "dumpinlpropsindent=1"} {
+			dumpfile, err := gatherPropsDumpForFile(t, tc, td, extra)
+			if err != nil {
+				t.Fatalf("dumping func props for %q: error %v", tc, err)
+			}
+			if i == 1 {
+				content, err := os.ReadFile(dumpfile)
+				if err != nil {
+					t.Fatalf("reading func prop dump: %v", err)
+				}
+				if !strings.Contains(string(content), "\n//   \"Flags\": ") {
+					t.Errorf("testcase %s: dump does not appear to be indented", tc)
+				}
+			}
+			if dumps[i], err = readDump(t, dumpfile); err != nil {
+				t.Fatalf("reading func prop dump: %v", err)
+			}
+		}
+		if len(dumps[0]) != len(dumps[1]) {
+			t.Fatalf("testcase %s: compact dump has %d entries, indented dump has %d", tc, len(dumps[0]), len(dumps[1]))
+		}
+		for i := range dumps[0] {
+			ce, ie := &dumps[0][i], &dumps[1][i]
+			if ce.fname != ie.fname || ce.line != ie.line || ce.col != ie.col {
+				t.Errorf("testcase %s: entry %d: compact dump has %s:%d:%d, indented dump has %s:%d:%d", tc, i, ce.fname, ce.line, ce.col, ie.fname, ie.line, ie.col)
+				continue
+			}
+			compareEntries(t, tc, ie, ce)
+		}
+	}
+}
+
+// TestJSONDump verifies that a function properties dump written as
+// JSON ("-d=dumpinlfuncprops=file:json") is a well-formed JSON
+// document with the same entries as the default format.
+func TestJSONDump(t *testing.T) {
+	td := t.TempDir()
+	testenv.MustHaveGoBuild(t)
+
+	for _, tc := range []string{"funcflags", "callsites"} {
+		dumpfile, err := gatherPropsDumpForFile(t, tc, td, "")
+		if err != nil {
+			t.Fatalf("dumping func props for %q: error %v", tc, err)
+		}
+		tentries, err := readDump(t, dumpfile)
+		if err != nil {
+			t.Fatalf("reading func prop dump: %v", err)
+		}
+		jdumpfile, err := gatherPropsDump(t, tc, td, "json", "")
+		if err != nil {
+			t.Fatalf("dumping func props for %q: error %v", tc, err)
+		}
+		content, err := os.ReadFile(jdumpfile)
+		if err != nil {
+			t.Fatalf("reading func prop dump: %v", err)
+		}
+		var jentries []jsonDumpEntry
+		if err := json.Unmarshal(content, &jentries); err != nil {
+			t.Fatalf("testcase %s: malformed JSON dump: %v", tc, err)
+		}
+		if len(jentries) != len(tentries) {
+			t.Fatalf("testcase %s: text dump has %d entries, JSON dump has %d", tc, len(tentries), len(jentries))
+		}
+		for i, je := range jentries {
+			te := &tentries[i]
+			if je.File != te.file || je.Fname != te.fname || je.Line != te.line {
+				t.Errorf("testcase %s: entry %d: text dump has %s %s:%d, JSON dump has %s %s:%d", tc, i, te.fname, te.file, te.line, je.Fname, je.File, je.Line)
+				continue
+			}
+			// Compare everything but the hints, which JSON
+			// dumps don't include.
+			fih := fnInlHeur{fname: je.Fname, props: je.Props, hints: te.hints}
+			for _, cs := range je.CallSites {
+				flags, err := parseCSPropBits(cs.Flags)
+				if err != nil {
+					t.Fatalf("testcase %s: %s: %v", tc, je.Fname, err)
+				}
+				si := callSiteInfo{
+					id:     cs.ID,
+					pos:    cs.Pos,
+					flags:  flags,
+					callee: cs.Callee,
+				}
+				if cs.Score != nil {
+					adjs := "0"
+					if cs.Adjustments != nil && *cs.Adjustments != "" {
+						adjs = *cs.Adjustments
+					}
+					if err := parseCallSiteScore(&si, fmt.Sprintf("score=%d", *cs.Score), "adj="+adjs); err != nil {
+						t.Fatalf("testcase %s: %s: %v", tc, je.Fname, err)
+					}
+				}
+				fih.csites = append(fih.csites, si)
+			}
+			compareEntries(t, tc, &fih, te)
+		}
+	}
+}
+
+// TestCSVDump verifies that the CSV form of the function properties
+// dump ("-d=dumpinlfuncprops=file:csv") has a row for each entry in
+// the default dump, with matching properties, in both buffered and
+// streaming modes.
+func TestCSVDump(t *testing.T) {
+	td := t.TempDir()
+	testenv.MustHaveGoBuild(t)
+
+	for _, tc := range []string{"funcflags", "params"} {
+		dumpfile, err := gatherPropsDumpForFile(t, tc, td, "")
+		if err != nil {
+			t.Fatalf("dumping func props for %q: error %v", tc, err)
+		}
+		tentries, err := readDump(t, dumpfile)
+		if err != nil {
+			t.Fatalf("reading func prop dump: %v", err)
+		}
+		var contents []string
+		for _, extra := range []string{"", "dumpinlpropsstream=1"} {
+			cdumpfile, err := gatherPropsDump(t, tc, td, "csv", extra)
+			if err != nil {
+				t.Fatalf("dumping func props for %q: error %v", tc, err)
+			}
+			content, err := os.ReadFile(cdumpfile)
+			if err != nil {
+				t.Fatalf("reading func prop dump: %v", err)
+			}
+			contents = append(contents, string(content))
+		}
+		if contents[0] != contents[1] {
+			t.Errorf("testcase %s: buffered and streaming CSV dumps differ:\n%s\n---\n%s", tc, contents[0], contents[1])
+		}
+		rows, err := csv.NewReader(strings.NewReader(contents[0])).ReadAll()
+		if err != nil {
+			t.Fatalf("testcase %s: malformed CSV dump: %v", tc, err)
+		}
+		if len(rows) == 0 || strings.Join(rows[0], ",") != strings.Join(csvDumpHeader, ",") {
+			t.Fatalf("testcase %s: missing or bad CSV header", tc)
+		}
+		rows = rows[1:]
+		if len(rows) != len(tentries) {
+			t.Fatalf("testcase %s: text dump has %d entries, CSV dump has %d", tc, len(tentries), len(rows))
+		}
+		for i, row := range rows {
+			want := csvRecord(&tentries[i])
+			if strings.Join(row, ",") != strings.Join(want, ",") {
+				t.Errorf("testcase %s: row %d: got %q, want %q", tc, i, row, want)
+			}
+		}
+	}
+}
+
+// TestDumpFilter verifies that a function name pattern passed via
+// "-d=dumpinlfuncprops=file:pattern" restricts the dump to matching
+// functions (and their closures), as does a list of function names.
+func TestDumpFilter(t *testing.T) {
+	td := t.TempDir()
+	testenv.MustHaveGoBuild(t)
+
+	for _, extra := range []string{"", "dumpinlpropsstream=1"} {
+		dumpfile, err := gatherPropsDump(t, "funcflags", td, "^T_(recov|defer_)", extra)
+		if err != nil {
+			t.Fatalf("dumping func props: error %v", err)
+		}
+		entries, err := readDump(t, dumpfile)
+		if err != nil {
+			t.Fatalf("reading func prop dump: %v", err)
+		}
+		var got []string
+		for _, e := range entries {
+			got = append(got, e.fname)
+		}
+		want := []string{"T_recov", "T_defer_recover", "T_defer_recover.func1",
+			"T_defer_norecover", "T_defer_norecover.func1"}
+		if strings.Join(got, " ") != strings.Join(want, " ") {
+			t.Errorf("filtered dump (%q): got %v, want %v", extra, got, want)
+		}
+	}
+
+	// A slash-separated list of names selects exactly those
+	// functions (and their closures).
+	dumpfile, err := gatherPropsDump(t, "funcflags", td, "T_recov/T_defer_norecover", "")
 	if err != nil {
-		return nil, err
+		t.Fatalf("dumping func props: error %v", err)
+	}
+	entries, err := readDump(t, dumpfile)
+	if err != nil {
+		t.Fatalf("reading func prop dump: %v", err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.fname)
 	}
-	dr := &dumpReader{
-		s:  bufio.NewScanner(strings.NewReader(string(content))),
-		t:  t,
-		p:  path,
-		ln: 1,
+	want := []string{"T_recov", "T_defer_norecover", "T_defer_norecover.func1"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("name list dump: got %v, want %v", got, want)
 	}
-	// consume header comment until preamble delimiter.
-	found := false
-	for dr.scan() {
-		if dr.curLine() == preambleDelimiter {
-			found = true
-			break
+}
+
+// TestMergeDumps verifies that MergeDumps combines the entries from
+// several dumps (including duplicates) into a single dump, and that
+// it rejects duplicate entries whose properties disagree.
+func TestMergeDumps(t *testing.T) {
+	td := t.TempDir()
+	testenv.MustHaveGoBuild(t)
+
+	var contents []string
+	var want []fnInlHeur
+	for _, tc := range []string{"funcflags", "returns"} {
+		dumpfile, err := gatherPropsDumpForFile(t, tc, td, "")
+		if err != nil {
+			t.Fatalf("dumping func props for %q: error %v", tc, err)
 		}
+		content, err := os.ReadFile(dumpfile)
+		if err != nil {
+			t.Fatalf("reading func prop dump: %v", err)
+		}
+		entries, err := readDump(t, dumpfile)
+		if err != nil {
+			t.Fatalf("reading func prop dump: %v", err)
+		}
+		contents = append(contents, string(content))
+		want = append(want, entries...)
+	}
+
+	merge := func(inputs ...string) ([]fnInlHeur, error) {
+		var readers []io.Reader
+		for _, in := range inputs {
+			readers = append(readers, strings.NewReader(in))
+		}
+		var sb strings.Builder
+		if err := MergeDumps(readers, &sb); err != nil {
+			return nil, err
+		}
+		return parseDump(strings.NewReader(sb.String()), "merged")
+	}
+
+	// Merge both dumps, with the second one repeated.
+	got, err := merge(contents[0], contents[1], contents[1])
+	if err != nil {
+		t.Fatalf("MergeDumps: %v", err)
 	}
-	if !found {
-		return nil, fmt.Errorf("malformed testcase file %s, missing preamble delimiter", path)
+	if len(got) != len(want) {
+		t.Fatalf("merged dump has %d entries, want %d", len(got), len(want))
 	}
-	res := []fnInlHeur{}
-	for {
-		dentry, err := dr.readEntry()
+	for i := range got {
+		if got[i].fname != want[i].fname || got[i].file != want[i].file {
+			t.Errorf("entry %d: got %s:%s, want %s:%s", i,
+				got[i].file, got[i].fname, want[i].file, want[i].fname)
+			continue
+		}
+		compareEntries(t, "merged", &got[i], &want[i])
+	}
+
+	// Tweak the flags for the first entry in one copy, which should
+	// produce a conflict.
+	flre := regexp.MustCompile(`"Flags":\[[^\]]*\]`)
+	loc := flre.FindStringIndex(contents[0])
+	if loc == nil {
+		t.Fatalf("can't locate flags in dump")
+	}
+	bad := contents[0][:loc[0]] + `"Flags":["FuncPropUsesUnsafe"]` + contents[0][loc[1]:]
+	if _, err := merge(contents[0], bad); err == nil {
+		t.Errorf("MergeDumps: no error for conflicting entries")
+	}
+}
+
+func TestDiffDumps(t *testing.T) {
+	td := t.TempDir()
+	testenv.MustHaveGoBuild(t)
+
+	var contents []string
+	for _, tc := range []string{"funcflags", "returns"} {
+		dumpfile, err := gatherPropsDumpForFile(t, tc, td, "")
+		if err != nil {
+			t.Fatalf("dumping func props for %q: error %v", tc, err)
+		}
+		content, err := os.ReadFile(dumpfile)
 		if err != nil {
 			t.Fatalf("reading func prop dump: %v", err)
 		}
-		if dentry.fname == "" {
-			break
+		contents = append(contents, string(content))
+	}
+	diff := func(old, new string) string {
+		var sb strings.Builder
+		if err := DiffDumps(strings.NewReader(old), strings.NewReader(new), &sb); err != nil {
+			t.Fatalf("DiffDumps: %v", err)
+		}
+		return sb.String()
+	}
+
+	// A dump compared against itself should produce no report.
+	if got := diff(contents[0], contents[0]); got != "" {
+		t.Errorf("diff of identical dumps: got\n%s", got)
+	}
+
+	// Tweak the encoded flags for the first entry, along with the
+	// human-readable comments (which should be ignored).
+	flre := regexp.MustCompile(`"Flags":(\[[^\]]*\])`)
+	loc := flre.FindStringSubmatchIndex(contents[0])
+	if loc == nil {
+		t.Fatalf("can't locate flags in dump")
+	}
+	oldFlags := contents[0][loc[2]:loc[3]]
+	tweaked := contents[0][:loc[0]] + `"Flags":["FuncPropUsesUnsafe"]` + contents[0][loc[1]:]
+	tweaked = strings.Replace(tweaked, "// Flags ", "// Flags (edited) ", -1)
+	entries, err := parseDump(strings.NewReader(contents[0]), "funcflags")
+	if err != nil {
+		t.Fatalf("reading func prop dump: %v", err)
+	}
+	e := entries[0]
+	want := fmt.Sprintf("changed: %s:%d:%d %s\n\tFlags: %s -> [\"FuncPropUsesUnsafe\"] (%s)\n",
+		e.file, e.line, e.col, e.fname, oldFlags,
+		bitsDelta(e.props.Flags, FuncPropUsesUnsafe))
+	if got := diff(contents[0], tweaked); got != want {
+		t.Errorf("diff of tweaked dump: got\n%s\nwant\n%s", got, want)
+	}
+
+	// Clearing the flags of a call site should be reported as well.
+	csre := regexp.MustCompile(`(?m)^//   \d+ (\S+) (CallSite\w+) (\S+)( score=.*)?$`)
+	m := csre.FindStringSubmatchIndex(contents[0])
+	if m == nil {
+		t.Fatalf("can't locate call site with flags in dump")
+	}
+	pos, flags, callee := contents[0][m[2]:m[3]], contents[0][m[4]:m[5]], contents[0][m[6]:m[7]]
+	tweaked = contents[0][:m[4]] + "0" + contents[0][m[5]:]
+	wantcs := fmt.Sprintf("\tCallSite %s %s: %s -> 0 (-%s)\n", pos, callee, flags, flags)
+	if got := diff(contents[0], tweaked); !strings.HasPrefix(got, "changed: ") || !strings.HasSuffix(got, wantcs) {
+		t.Errorf("diff of dump with tweaked call site: got\n%s\nwant suffix\n%s", got, wantcs)
+	}
+
+	// Diffing against a dump with an additional file should report
+	// only added functions, and the reverse only removed functions.
+	var merged strings.Builder
+	if err := MergeDumps([]io.Reader{strings.NewReader(contents[0]),
+		strings.NewReader(contents[1])}, &merged); err != nil {
+		t.Fatalf("MergeDumps: %v", err)
+	}
+	for _, tc := range []struct {
+		old, new, prefix string
+	}{
+		{contents[0], merged.String(), "added: returns.go:"},
+		{merged.String(), contents[0], "removed: returns.go:"},
+	} {
+		got := diff(tc.old, tc.new)
+		if got == "" {
+			t.Errorf("expected %q lines, got empty diff", tc.prefix)
+		}
+		for _, line := range strings.Split(strings.TrimSpace(got), "\n") {
+			if !strings.HasPrefix(line, tc.prefix) {
+				t.Errorf("unexpected diff line %q, want prefix %q", line, tc.prefix)
+			}
 		}
-		res = append(res, dentry)
 	}
-	return res, nil
 }
 
-func (dr *dumpReader) scan() bool {
-	v := dr.s.Scan()
-	if v {
-		dr.ln++
+// TestDumpSinks verifies that a function properties dump can be
+// written to several destinations at once, including the standard
+// error, and that each gets the same entries.
+func TestDumpSinks(t *testing.T) {
+	td := t.TempDir()
+	testenv.MustHaveGoBuild(t)
+
+	dumpfile, err := gatherPropsDumpForFile(t, "params", td, "")
+	if err != nil {
+		t.Fatalf("dumping func props: error %v", err)
+	}
+	want, err := readDump(t, dumpfile)
+	if err != nil {
+		t.Fatalf("reading func prop dump: %v", err)
+	}
+
+	f1 := golden.UniquePath(td, "params", ".1.dump.txt")
+	f2 := golden.UniquePath(td, "params", ".2.dump.txt")
+	dflags := "-d=dumpinlfuncprops=" + f1 + "+" + f2 + "+-stderr"
+	cmd := testenv.Command(t, testenv.GoToolPath(t), "build",
+		"-gcflags="+dflags, "-o", filepath.Join(td, "params.a"),
+		"testdata/props/params.go")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("build failed: %v\n%s", err, out)
+	}
+	// Skip the package header line written by the go command.
+	i := bytes.Index(out, []byte("// DO NOT EDIT"))
+	if i < 0 {
+		t.Fatalf("no dump in build output:\n%s", out)
+	}
+	check := func(what string, got []fnInlHeur) {
+		if len(got) != len(want) {
+			t.Errorf("%s: got %d entries, want %d", what, len(got), len(want))
+			return
+		}
+		for i := range got {
+			compareEntries(t, what, &got[i], &want[i])
+		}
+	}
+	got, err := parseDump(bytes.NewReader(out[i:]), "stderr")
+	if err != nil {
+		t.Fatalf("reading func prop dump from stderr: %v", err)
+	}
+	check("stderr", got)
+	for _, f := range []string{f1, f2} {
+		got, err := readDump(t, f)
+		if err != nil {
+			t.Fatalf("reading func prop dump: %v", err)
+		}
+		check(f, got)
 	}
-	return v
 }
 
-func (dr *dumpReader) curLine() string {
-	res := strings.TrimSpace(dr.s.Text())
-	if !strings.HasPrefix(res, "// ") {
-		dr.t.Fatalf("malformed line %s:%d, no comment: %s", dr.p, dr.ln, res)
+// TestAppendDump verifies that with "-d=dumpinlpropsappend=1" the
+// dumps for several packages accumulate in a single file, each with
+// its own package preamble, and that the combined file can be read
+// back and merged.
+func TestAppendDump(t *testing.T) {
+	td := t.TempDir()
+	testenv.MustHaveGoBuild(t)
+
+	testcases := []string{"funcflags", "returns"}
+	var want []fnInlHeur
+	for _, tc := range testcases {
+		dumpfile, err := gatherPropsDumpForFile(t, tc, td, "")
+		if err != nil {
+			t.Fatalf("dumping func props for %q: error %v", tc, err)
+		}
+		entries, err := readDump(t, dumpfile)
+		if err != nil {
+			t.Fatalf("reading func prop dump: %v", err)
+		}
+		want = append(want, entries...)
+	}
+
+	dumpfile := golden.UniquePath(td, "append", ".dump.txt")
+	for _, tc := range testcases {
+		dflags := "-d=dumpinlfuncprops=" + dumpfile + ",dumpinlpropsappend=1"
+		if err := golden.Build(t, "testdata/props/"+tc+".go", filepath.Join(td, tc+".a"), dflags); err != nil {
+			t.Fatalf("dumping func props for %q: error %v", tc, err)
+		}
+	}
+	got, err := readDump(t, dumpfile)
+	if err != nil {
+		t.Fatalf("reading appended func prop dump: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("appended dump has %d entries, want %d", len(got), len(want))
+	}
+	for i := range got {
+		if got[i].pkg != "command-line-arguments" {
+			t.Errorf("entry %d (%s): got package %q", i, got[i].fname, got[i].pkg)
+		}
+		compareEntries(t, "append", &got[i], &want[i])
+	}
+
+	// Merging the appended dump with itself should preserve the
+	// package information.
+	content, err := os.ReadFile(dumpfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var merged strings.Builder
+	if err := MergeDumps([]io.Reader{bytes.NewReader(content), bytes.NewReader(content)}, &merged); err != nil {
+		t.Fatalf("MergeDumps: %v", err)
+	}
+	mentries, err := parseDump(strings.NewReader(merged.String()), "merged")
+	if err != nil {
+		t.Fatalf("reading merged dump: %v", err)
+	}
+	if len(mentries) != len(got) {
+		t.Fatalf("merged dump has %d entries, want %d", len(mentries), len(got))
+	}
+	// Sections are ordered by import path and build ID in the
+	// merged dump, so compare without regard to order.
+	seen := make(map[dumpEntryKey]string)
+	for i := range got {
+		seen[keyOf(&got[i])] = got[i].buildID
+	}
+	for i := range mentries {
+		me := &mentries[i]
+		if id, ok := seen[keyOf(me)]; !ok || id != me.buildID {
+			t.Errorf("merged entry %s (package %s %s) not in appended dump", me.fname, me.pkg, me.buildID)
+		}
 	}
-	return res[3:]
 }
 
-// readObjBlob reads in a series of commented lines until
-// it hits a delimiter, then returns the contents of the comments.
-func (dr *dumpReader) readObjBlob(delim string) (string, error) {
-	var sb strings.Builder
-	foundDelim := false
-	for dr.scan() {
-		line := dr.curLine()
-		if delim == line {
-			foundDelim = true
-			break
+// TestParseDump verifies that ParseDump reads back the same entries
+// and properties as were written to a function properties dump.
+// TestParseCallSiteInfo verifies that call site lines, with or without
+// a score, survive a round trip through callSitesToString and
+// parseCallSiteInfo.
+func TestParseCallSiteInfo(t *testing.T) {
+	sites := []callSiteInfo{
+		{id: 0, pos: "a.go:3:9", flags: CallSiteTailPos, callee: "F",
+			scored: true, score: 37, adjs: tailCallAdj | leafAdj},
+		{id: 1, pos: "a.go:4:2", callee: "G[struct { x int }]",
+			scored: true, score: -5},
+		{id: 2, pos: "a.go:5:2", flags: CallSiteInLoop, callee: "H"},
+	}
+	lines := strings.Split(strings.TrimSpace(callSitesToString(sites, "")), "\n")
+	if len(lines) != len(sites)+1 || lines[0] != callSitesTag {
+		t.Fatalf("malformed call sites table:\n%s", strings.Join(lines, "\n"))
+	}
+	for i, line := range lines[1:] {
+		cs, err := parseCallSiteInfo(line)
+		if err != nil {
+			t.Fatalf("parsing %q: %v", line, err)
+		}
+		if cs != sites[i] {
+			t.Errorf("parsing %q: got %+v, want %+v", line, cs, sites[i])
+		}
+	}
+	for _, line := range []string{
+		"0 a.go:3:9 0 F score=x adj=0",
+		"0 a.go:3:9 0 F score=3 adj=bogusAdj",
+		"0 a.go:3:9 0 F score=3",
+	} {
+		if _, err := parseCallSiteInfo(line); err == nil {
+			t.Errorf("parsing %q: no error", line)
 		}
-		sb.WriteString(line + "\n")
 	}
-	if err := dr.s.Err(); err != nil {
-		return "", err
+}
+
+func TestParseDump(t *testing.T) {
+	td := t.TempDir()
+	testenv.MustHaveGoBuild(t)
+
+	dumpfile, err := gatherPropsDumpForFile(t, "params", td, "")
+	if err != nil {
+		t.Fatalf("dumping func props: error %v", err)
+	}
+	want, err := readDump(t, dumpfile)
+	if err != nil {
+		t.Fatalf("reading func prop dump: %v", err)
+	}
+	f, err := os.Open(dumpfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	got, err := ParseDump(f)
+	if err != nil {
+		t.Fatalf("ParseDump: %v", err)
 	}
-	if !foundDelim {
-		return "", fmt.Errorf("malformed input %s, missing delimiter %q",
-			dr.p, delim)
+	if len(got) != len(want) {
+		t.Fatalf("ParseDump returned %d entries, want %d", len(got), len(want))
+	}
+	for i, de := range got {
+		we := &want[i]
+		if de.File != we.file || de.Func != we.fname || de.Line != we.line || de.Col != we.col {
+			t.Errorf("entry %d: got %s %s:%d:%d, want %s %s:%d:%d", i,
+				de.Func, de.File, de.Line, de.Col, we.fname, we.file, we.line, we.col)
+			continue
+		}
+		if !fpeq(*de.Props, *we.props) {
+			t.Errorf("entry %d (%s): got props:\n%swant:\n%s", i, de.Func, de.Props, we.props)
+		}
 	}
-	return sb.String(), nil
 }
 
-// readEntry reads a single function's worth of material from
-// a file produced by the "-d=dumpinlfuncprops=..." command line
-// flag. It deserializes the json for the func properties and
-// returns the resulting properties and function name. EOF is
-// signaled by a nil FuncProps return (with no error
-func (dr *dumpReader) readEntry() (fnInlHeur, error) {
-	var fih fnInlHeur
-	if !dr.scan() {
-		return fih, nil
+func TestDumpSchemaVersion(t *testing.T) {
+	mkdump := func(version string, props string) string {
+		var sb strings.Builder
+		sb.WriteString("// header\n")
+		if version != "" {
+			fmt.Fprintf(&sb, "// %s %s\n", schemaVersionTag, version)
+		}
+		fmt.Fprintf(&sb, "// %s\n", preambleDelimiter)
+		fmt.Fprintf(&sb, "// x.go T_f 3 0 1 6\n// %s\n// %s\n// %s\n",
+			comDelimiter, props, fnDelimiter)
+		return sb.String()
 	}
-	// first line contains info about function: file/name/line
-	info := dr.curLine()
-	chunks := strings.Fields(info)
-	fih.file = chunks[0]
-	fih.fname = chunks[1]
-	if _, err := fmt.Sscanf(chunks[2], "%d", &fih.line); err != nil {
-		return fih, err
+	cur := fmt.Sprint(dumpSchemaVersion)
+	stale := `{"Flags":["FuncPropNeverReturns"],"Removed":1}`
+	testcases := []struct {
+		version, props string
+		want           int    // version of the entry read back
+		err            string // expected error substring, if any
+	}{
+		{"", stale, 1, ""},
+		{"1", stale, 1, ""},
+		{cur, `{"Flags":["FuncPropNeverReturns"]}`, dumpSchemaVersion, ""},
+		{cur, stale, 0, `unknown field "Removed"`},
+		{fmt.Sprint(dumpSchemaVersion + 1), `{}`, 0, "newer than the latest supported"},
+		{"x", `{}`, 0, "malformed schema version"},
 	}
-	// consume comments until and including delimiter
-	for {
-		if !dr.scan() {
-			break
+	for _, tc := range testcases {
+		entries, err := parseDump(strings.NewReader(mkdump(tc.version, tc.props)), "x")
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("version %q props %s: got error %v, want %q", tc.version, tc.props, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("version %q props %s: unexpected error %v", tc.version, tc.props, err)
+			continue
+		}
+		if len(entries) != 1 {
+			t.Fatalf("version %q: got %d entries, want 1", tc.version, len(entries))
+		}
+		e := entries[0]
+		if e.version != tc.want {
+			t.Errorf("version %q: got entry version %d, want %d", tc.version, e.version, tc.want)
 		}
-		if dr.curLine() == comDelimiter {
-			break
+		if e.props.Flags != FuncPropNeverReturns {
+			t.Errorf("version %q: got flags %v, want %v", tc.version, e.props.Flags, FuncPropNeverReturns)
 		}
 	}
+}
 
-	// Consume JSON for encoded props.
-	dr.scan()
-	line := dr.curLine()
-	fp := &FuncProps{}
-	if err := json.Unmarshal([]byte(line), fp); err != nil {
-		return fih, err
+func propBitsToString[T interface{ String() string }](sl []T) string {
+	var sb strings.Builder
+	for i, f := range sl {
+		fmt.Fprintf(&sb, "%d: %s\n", i, f.String())
 	}
-	fih.props = fp
+	return sb.String()
+}
 
-	// Consume delimiter.
-	dr.scan()
-	line = dr.curLine()
-	if line != fnDelimiter {
-		return fih, fmt.Errorf("malformed testcase file %q, missing delimiter %q", dr.p, fnDelimiter)
+func compareEntries(t *testing.T, tc string, dentry *fnInlHeur, eentry *fnInlHeur) {
+	dfp := dentry.props
+	efp := eentry.props
+	dfn := dentry.fname
+
+	// Compare function flags.
+	if dfp.Flags != efp.Flags {
+		t.Errorf("testcase %s: Flags mismatch for %q: got %s, wanted %s",
+			tc, dfn, dfp.Flags.String(), efp.Flags.String())
+	}
+	// Compare returns
+	rgot := propBitsToString[ResultPropBits](dfp.ResultFlags)
+	rwant := propBitsToString[ResultPropBits](efp.ResultFlags)
+	if rgot != rwant {
+		t.Errorf("Results mismatch for %q: got:\n%swant:\n%s",
+			dfn, rgot, rwant)
+	}
+	// Compare receiver + params.
+	pgot := propBitsToString[ParamPropBits](dfp.ParamFlags)
+	pwant := propBitsToString[ParamPropBits](efp.ParamFlags)
+	if pgot != pwant {
+		t.Errorf("Params mismatch for %q: got:\n%swant:\n%s",
+			dfn, pgot, pwant)
+	}
+	// Compare result-affecting params.
+	if dfp.ResultAffectingParams != efp.ResultAffectingParams {
+		t.Errorf("testcase %s: ResultAffectingParams mismatch for %q: got %b, wanted %b",
+			tc, dfn, dfp.ResultAffectingParams, efp.ResultAffectingParams)
+	}
+	// Compare return counts.
+	if dfp.NumReturns != efp.NumReturns ||
+		dfp.SingleTailReturn != efp.SingleTailReturn {
+		t.Errorf("testcase %s: returns mismatch for %q: got %d/%v, wanted %d/%v",
+			tc, dfn, dfp.NumReturns, dfp.SingleTailReturn,
+			efp.NumReturns, efp.SingleTailReturn)
+	}
+	// Compare call argument counts.
+	if dfp.MaxCallArgs != efp.MaxCallArgs || dfp.NumCalls != efp.NumCalls {
+		t.Errorf("testcase %s: call counts mismatch for %q: got %d/%d, wanted %d/%d",
+			tc, dfn, dfp.MaxCallArgs, dfp.NumCalls,
+			efp.MaxCallArgs, efp.NumCalls)
+	}
+	// Compare node counts.
+	if dfp.NodeCounts != efp.NodeCounts {
+		t.Errorf("testcase %s: node counts mismatch for %q: got %+v, wanted %+v",
+			tc, dfn, dfp.NodeCounts, efp.NodeCounts)
+	}
+	// Compare hotspots.
+	if dfp.Hotspot != efp.Hotspot {
+		t.Errorf("testcase %s: hotspot mismatch for %q: got %q, wanted %q",
+			tc, dfn, dfp.Hotspot, efp.Hotspot)
+	}
+	// Compare closure captures.
+	if dfp.Captures != efp.Captures {
+		t.Errorf("testcase %s: captures mismatch for %q: got %+v, wanted %+v",
+			tc, dfn, dfp.Captures, efp.Captures)
+	}
+	// Compare specialization hints.
+	hgot := specHintsToString(dentry.hints, "")
+	hwant := specHintsToString(eentry.hints, "")
+	if hgot != hwant {
+		t.Errorf("Specialization hints mismatch for %q: got:\n%swant:\n%s",
+			dfn, hgot, hwant)
 	}
+	// Compare call sites.
+	cgot := callSitesToString(dentry.csites, "")
+	cwant := callSitesToString(eentry.csites, "")
+	if cgot != cwant {
+		t.Errorf("Call sites mismatch for %q: got:\n%swant:\n%s",
+			dfn, cgot, cwant)
+	}
+}
 
-	return fih, nil
+// sortBySource sorts the dump entries in 'sl', which are in the order
+// in which the compiler recorded them, by source position (definition
+// line and column, then name), which is the order of the expected
+// results in a testcase file.
+func sortBySource(sl []fnInlHeur) {
+	sort.SliceStable(sl, func(i, j int) bool {
+		a, b := &sl[i], &sl[j]
+		if a.line != b.line {
+			return a.line < b.line
+		}
+		if a.col != b.col {
+			return a.col < b.col
+		}
+		return a.fname < b.fname
+	})
+}
+
+// readDump reads in the contents of a dump file produced
+// by the "-d=dumpinlfuncprops=..." command line flag by the Go
+// compiler (see parseDump).
+func readDump(t *testing.T, path string) ([]fnInlHeur, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseDump(f, path)
 }
 
 // gatherPropsDumpForFile builds the specified testcase 'testcase' from
 // testdata/props passing the "-d=dumpinlfuncprops=..." compiler option,
 // to produce a properties dump, then returns the path of the newly
-// created file. NB: we can't use "go tool compile" here, since
-// some of the test cases import stdlib packages (such as "os").
-// This means using "go build", which is problematic since the
-// Go command can potentially cache the results of the compile step,
-// causing the test to fail when being run interactively. E.g.
-//
-//	$ rm -f dump.txt
-//	$ go build -o foo.a -gcflags=-d=dumpinlfuncprops=dump.txt foo.go
-//	$ rm -f dump.txt foo.a
-//	$ go build -o foo.a -gcflags=-d=dumpinlfuncprops=dump.txt foo.go
-//	$ ls foo.a dump.txt > /dev/null
-//	ls : cannot access 'dump.txt': No such file or directory
-//	$
+// created file. The dump file is given a unique name, so as to
+// defeat the go command's build cache (see golden.UniquePath).
 //
-// For this reason, pick a unique filename for the dump, so as to
-// defeat the caching.
-func gatherPropsDumpForFile(t *testing.T, testcase string, td string) (string, error) {
+// Additional debug settings can be passed in 'extra'
+// (e.g. "dumpinlpropsstream=1").
+func gatherPropsDumpForFile(t *testing.T, testcase string, td string, extra string) (string, error) {
+	t.Helper()
+	return gatherPropsDump(t, testcase, td, "", extra)
+}
+
+// gatherPropsDump is the same as gatherPropsDumpForFile, but also
+// allows a function name pattern 'pattern' to be passed as part of
+// the "-d=dumpinlfuncprops=..." option.
+func gatherPropsDump(t *testing.T, testcase, td, pattern, extra string) (string, error) {
 	t.Helper()
 	gopath := "testdata/props/" + testcase + ".go"
 	outpath := filepath.Join(td, testcase+".a")
-	salt := fmt.Sprintf(".p%dt%d", os.Getpid(), time.Now().UnixNano())
-	dumpfile := filepath.Join(td, testcase+salt+".dump.txt")
-	run := []string{testenv.GoToolPath(t), "build",
-		"-gcflags=-d=dumpinlfuncprops=" + dumpfile, "-o", outpath, gopath}
-	out, err := testenv.Command(t, run[0], run[1:]...).CombinedOutput()
-	if strings.TrimSpace(string(out)) != "" {
-		t.Logf("%s", out)
+	dumpfile := golden.UniquePath(td, testcase, ".dump.txt")
+	dflags := "-d=dumpinlfuncprops=" + dumpfile
+	if pattern != "" {
+		dflags += ":" + pattern
 	}
+	if extra != "" {
+		dflags += "," + extra
+	}
+	err := golden.Build(t, gopath, outpath, dflags)
 	return dumpfile, err
 }
 
-// genExpected reads in a given Go testcase file, strips out all the
-// unindented (column 0) commands, writes them out to a new file, and
-// returns the path of that new file. By picking out just the comments
-// from the Go file we wind up with something that resembles the
-// output from a "-d=dumpinlfuncprops=..." compilation.
-func genExpected(td string, testcase string) (string, error) {
-	epath := filepath.Join(td, testcase+".expected")
-	outf, err := os.OpenFile(epath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+// readExpected reads in the expected results for testcase
+// 'testcase', namely the unindented (column 0) comments in its Go
+// file, which resemble the output from a "-d=dumpinlfuncprops=..."
+// compilation.
+func readExpected(testcase string) ([]fnInlHeur, error) {
+	gf, err := golden.Read("testdata/props/" + testcase + ".go")
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	gopath := "testdata/props/" + testcase + ".go"
-	content, err := os.ReadFile(gopath)
+	entries, err := parseDump(strings.NewReader(gf.Expected()), gf.Path)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	lines := strings.Split(string(content), "\n")
-	for _, line := range lines[3:] {
-		if !strings.HasPrefix(line, "// ") {
-			continue
+	for _, e := range entries {
+		if e.version != dumpSchemaVersion {
+			return nil, fmt.Errorf("%s has dump schema version %d, want %d; remaster it with -update-expected",
+				gf.Path, e.version, dumpSchemaVersion)
 		}
-		fmt.Fprintf(outf, "%s\n", line)
-	}
-	if err := outf.Close(); err != nil {
-		return "", err
-	}
-	return epath, nil
-}
-
-type upexState struct {
-	dentries   []fnInlHeur
-	newgolines []string
-	atline     map[uint]uint
-}
-
-func mkUpexState(dentries []fnInlHeur) *upexState {
-	atline := make(map[uint]uint)
-	for _, e := range dentries {
-		atline[e.line] = atline[e.line] + 1
-	}
-	return &upexState{
-		dentries: dentries,
-		atline:   atline,
 	}
+	return entries, nil
 }
 
 // updateExpected takes a given Go testcase file X.go and writes out a
@@ -346,45 +1028,31 @@ func mkUpexState(dentries []fnInlHeur) *upexState {
 func updateExpected(t *testing.T, testcase string, dentries []fnInlHeur) {
 	nd := len(dentries)
 
-	ues := mkUpexState(dentries)
-
-	gopath := "testdata/props/" + testcase + ".go"
-	newgopath := "testdata/props/" + testcase + ".go.new"
-
-	// Read the existing Go file.
-	content, err := os.ReadFile(gopath)
-	if err != nil {
-		t.Fatalf("opening %s: %v", gopath, err)
+	atline := make(map[uint]uint)
+	for _, e := range dentries {
+		atline[e.line] = atline[e.line] + 1
 	}
-	golines := strings.Split(string(content), "\n")
 
-	// Preserve copyright.
-	ues.newgolines = append(ues.newgolines, golines[:4]...)
-	if !strings.HasPrefix(golines[0], "// Copyright") {
-		t.Fatalf("missing copyright from existing testcase")
+	gf, err := golden.Read("testdata/props/" + testcase + ".go")
+	if err != nil {
+		t.Fatalf("reading testcase: %v", err)
 	}
-	golines = golines[4:]
 
 	clore := regexp.MustCompile(`.+\.func\d+[\.\d]*$`)
 
+	var entry []string
 	emitFunc := func(e *fnInlHeur, instance, atl uint) {
 		var sb strings.Builder
 		dumpFnPreamble(&sb, e, instance, atl)
-		ues.newgolines = append(ues.newgolines,
+		entry = append(entry,
 			strings.Split(strings.TrimSpace(sb.String()), "\n")...)
 	}
 
-	// Write file preamble with "DO NOT EDIT" message and such.
-	var sb strings.Builder
-	dumpFilePreamble(&sb)
-	ues.newgolines = append(ues.newgolines,
-		strings.Split(strings.TrimSpace(sb.String()), "\n")...)
-
 	// Helper to add a clump of functions to the output file.
 	processClump := func(idx int, emit bool) int {
 		// Process func itself, plus anything else defined
 		// on the same line
-		atl := ues.atline[dentries[idx].line]
+		atl := atline[dentries[idx].line]
 		for k := uint(0); k < atl; k++ {
 			if emit {
 				emitFunc(&dentries[idx], k, atl)
@@ -392,13 +1060,11 @@ func updateExpected(t *testing.T, testcase string, dentries []fnInlHeur) {
 			idx++
 		}
 		// now process any closures it contains
-		ncl := 0
 		for idx < nd {
 			nfn := dentries[idx].fname
 			if !clore.MatchString(nfn) {
 				break
 			}
-			ncl++
 			if emit {
 				emitFunc(&dentries[idx], 0, 1)
 			}
@@ -407,42 +1073,28 @@ func updateExpected(t *testing.T, testcase string, dentries []fnInlHeur) {
 		return idx
 	}
 
-	didx := 0
-	for _, line := range golines {
-		if strings.HasPrefix(line, "func ") {
-
-			// We have a function definition.
-			// Pick out the corresponding entry or entries in the dump
-			// and emit if interesting (or skip if not).
-			dentry := dentries[didx]
-			emit := interestingToCompare(dentry.fname)
-			didx = processClump(didx, emit)
-		}
+	// File preamble with "DO NOT EDIT" message and such.
+	var sb strings.Builder
+	dumpFilePreamble(&sb)
+	preamble := strings.Split(strings.TrimSpace(sb.String()), "\n")
 
-		// Consume all existing comments.
-		if strings.HasPrefix(line, "//") {
-			continue
-		}
-		ues.newgolines = append(ues.newgolines, line)
-	}
+	didx := 0
+	content := gf.Rewrite(preamble, func(string) []string {
+		// We have a function definition. Pick out the
+		// corresponding entry or entries in the dump and emit if
+		// interesting (or skip if not).
+		entry = nil
+		dentry := dentries[didx]
+		emit := interestingToCompare(dentry.fname)
+		didx = processClump(didx, emit)
+		return entry
+	})
 
 	if didx != nd {
 		t.Logf("didx=%d wanted %d", didx, nd)
 	}
 
-	// Open new Go file and write contents.
-	of, err := os.OpenFile(newgopath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
-	if err != nil {
-		t.Fatalf("opening %s: %v", newgopath, err)
-	}
-	fmt.Fprintf(of, "%s", strings.Join(ues.newgolines, "\n"))
-	if err := of.Close(); err != nil {
-		t.Fatalf("closing %s: %v", newgopath, err)
-	}
-
-	t.Logf("update-expected: emitted updated file %s", newgopath)
-	t.Logf("please compare the two files, then overwrite %s with %s\n",
-		gopath, newgopath)
+	gf.WriteNew(t, content)
 }
 
 // interestingToCompare returns TRUE if we want to compare results
@@ -460,3 +1112,294 @@ func interestingToCompare(fname string) bool {
 	}
 	return false
 }
+
+// TestPropsCache verifies that computed properties are memoized by
+// function symbol, and that blank functions are not cached.
+func TestPropsCache(t *testing.T) {
+	savedCache, savedHits, savedMisses := propsCache, propsCacheHits, propsCacheMisses
+	defer func() {
+		propsCache, propsCacheHits, propsCacheMisses = savedCache, savedHits, savedMisses
+	}()
+	propsCache, propsCacheHits, propsCacheMisses = nil, 0, 0
+
+	pkg := types.NewPkg("p", "p")
+	fn := ir.NewFunc(src.NoXPos, src.NoXPos, pkg.Lookup("f"), nil)
+	blank := ir.NewFunc(src.NoXPos, src.NoXPos, pkg.Lookup("_"), nil)
+	fp := &FuncProps{Flags: FuncPropIsPure}
+
+	if _, ok := lookupPropsCache(fn); ok {
+		t.Errorf("unexpected cache hit for %v", fn)
+	}
+	addPropsCache(fn, fp)
+	addPropsCache(blank, fp)
+	if got, ok := lookupPropsCache(fn); !ok || got != fp {
+		t.Errorf("lookup of %v: got %v, %v, want cached props", fn, got, ok)
+	}
+	if _, ok := lookupPropsCache(blank); ok {
+		t.Errorf("unexpected cache hit for blank function")
+	}
+	if propsCacheHits != 1 || propsCacheMisses != 1 {
+		t.Errorf("got %d hits %d misses, want 1 hit 1 miss", propsCacheHits, propsCacheMisses)
+	}
+}
+
+// TestFuncPropsFor verifies that FuncPropsFor returns the properties
+// recorded for a function in this package, decodes those recorded
+// in the export data for an imported function, and reports when no
+// properties are available.
+func TestFuncPropsFor(t *testing.T) {
+	saved := funcPropsTab
+	defer func() { funcPropsTab = saved }()
+	funcPropsTab = nil
+
+	pkg := types.NewPkg("p", "p")
+	local := ir.NewFunc(src.NoXPos, src.NoXPos, pkg.Lookup("local"), nil)
+	imported := ir.NewFunc(src.NoXPos, src.NoXPos, pkg.Lookup("imported"), nil)
+	unknown := ir.NewFunc(src.NoXPos, src.NoXPos, pkg.Lookup("unknown"), nil)
+
+	lfp := &FuncProps{Flags: FuncPropNeverReturns}
+	funcPropsTab = map[*ir.Func]*FuncProps{local: lfp}
+	ifp := &FuncProps{Flags: FuncPropIsPure, ParamFlags: []ParamPropBits{ParamFeedsIfOrSwitch}}
+	imported.Inl = &ir.Inline{Properties: ifp.SerializeToString()}
+
+	if got, ok := FuncPropsFor(local); !ok || got != lfp {
+		t.Errorf("FuncPropsFor(local): got %v, %v, want recorded props", got, ok)
+	}
+	if got, ok := FuncPropsFor(imported); !ok || got.String() != ifp.String() {
+		t.Errorf("FuncPropsFor(imported): got %v, %v, want %v", got, ok, ifp)
+	}
+	if got, ok := FuncPropsFor(unknown); ok || got != nil {
+		t.Errorf("FuncPropsFor(unknown): got %v, %v, want nil, false", got, ok)
+	}
+}
+
+// TestRefineAfterEscape verifies that the post-escape-analysis pass
+// clears FuncPropAllocates for a function whose allocations don't
+// escape to the heap (updating the properties recorded for export),
+// and leaves it alone otherwise.
+func TestRefineAfterEscape(t *testing.T) {
+	saved := funcPropsTab
+	defer func() { funcPropsTab = saved }()
+
+	pkg := types.NewPkg("p", "p")
+	for _, esc := range []uint16{ir.EscNone, ir.EscHeap} {
+		fn := ir.NewFunc(src.NoXPos, src.NoXPos, pkg.Lookup("f"), nil)
+		alloc := ir.NewUnaryExpr(src.NoXPos, ir.ONEW, nil)
+		alloc.SetEsc(esc)
+		fn.Body = []ir.Node{alloc}
+		fn.Inl = &ir.Inline{}
+		fp := &FuncProps{Flags: FuncPropAllocates | FuncPropIsLeaf}
+		funcPropsTab = map[*ir.Func]*FuncProps{fn: fp}
+
+		RefineAfterEscape([]*ir.Func{fn})
+
+		want := fp.Flags
+		if esc == ir.EscNone {
+			want = FuncPropIsLeaf
+		}
+		if got := funcPropsTab[fn].Flags; got != want {
+			t.Errorf("esc=%d: got flags %v, want %v", esc, got, want)
+		}
+		if fp.Flags != FuncPropAllocates|FuncPropIsLeaf {
+			t.Errorf("esc=%d: original props modified", esc)
+		}
+		if esc == ir.EscNone {
+			if got := DeserializeFromString(fn.Inl.Properties).Flags; got != want {
+				t.Errorf("esc=%d: got exported flags %v, want %v", esc, got, want)
+			}
+		}
+	}
+}
+
+func TestExternalImplProps(t *testing.T) {
+	pkg := types.NewPkg("p", "p")
+	fn := ir.NewFunc(src.NoXPos, src.NoXPos, pkg.Lookup("asmfn"),
+		types.NewSignature(nil, nil, nil))
+	fp := analyzeFunc(fn, nil)
+	if fp.Flags != FuncPropExternalImpl {
+		t.Errorf("got flags %v, want %v", fp.Flags, FuncPropExternalImpl)
+	}
+	if fp2 := computeFuncPropsIncremental(fn, nil); !reflect.DeepEqual(fp, fp2) {
+		t.Errorf("incremental props differ: got %+v, want %+v", *fp2, *fp)
+	}
+}
+
+func TestTooLargeProps(t *testing.T) {
+	saved := base.Debug.InlHeurMaxNodes
+	defer func() { base.Debug.InlHeurMaxNodes = saved }()
+	pkg := types.NewPkg("p", "p")
+	fn := ir.NewFunc(src.NoXPos, src.NoXPos, pkg.Lookup("big"),
+		types.NewSignature(nil, []*types.Field{types.NewField(src.NoXPos, nil, types.NewStruct(nil))}, nil))
+	// Five nodes, counting the function itself.
+	for i := 0; i < 4; i++ {
+		fn.Body.Append(ir.NewBlockStmt(src.NoXPos, nil))
+	}
+	for _, tc := range []struct {
+		limit int
+		want  bool
+	}{{0, false}, {4, true}, {5, false}} {
+		base.Debug.InlHeurMaxNodes = tc.limit
+		if got := tooLargeToAnalyze(fn); got != tc.want {
+			t.Errorf("limit %d: got %v, want %v", tc.limit, got, tc.want)
+		}
+	}
+	base.Debug.InlHeurMaxNodes = 4
+	fp := analyzeFunc(fn, nil)
+	if fp.Flags != FuncPropTooLarge || len(fp.ParamFlags) != 1 {
+		t.Errorf("got props %+v, want only FuncPropTooLarge with one param", *fp)
+	}
+}
+
+// namedAnalyzer is a do-nothing property analyzer, for testing the
+// analyzer registry.
+type namedAnalyzer string
+
+func (a namedAnalyzer) nodeVisitPre(n ir.Node)   {}
+func (a namedAnalyzer) nodeVisitPost(n ir.Node)  {}
+func (a namedAnalyzer) setResults(fp *FuncProps) {}
+func (a namedAnalyzer) name() string             { return string(a) }
+
+func TestRegisterAnalyzer(t *testing.T) {
+	saved := analyzerRegistry
+	defer func() { analyzerRegistry = saved }()
+	analyzerRegistry = nil
+
+	named := func(name string) analyzerFactory {
+		return func(*ir.Func, func(*ir.Func)) propAnalyzer { return namedAnalyzer(name) }
+	}
+	registerAnalyzer(20, named("b"))
+	registerAnalyzer(10, named("a"))
+	registerAnalyzer(20, named("c"))
+	registerAnalyzer(15, func(*ir.Func, func(*ir.Func)) propAnalyzer { return nil })
+	registerAnalyzer(5, named("z"))
+
+	var names []string
+	for _, a := range makeAnalyzers(nil, nil) {
+		names = append(names, a.name())
+	}
+	if got, want := strings.Join(names, " "), "z a b c"; got != want {
+		t.Errorf("got analyzers %q, want %q", got, want)
+	}
+}
+
+func TestReanalyzeFuncs(t *testing.T) {
+	saved, savedCache := funcPropsTab, propsCache
+	defer func() { funcPropsTab, propsCache = saved, savedCache }()
+
+	pkg := types.NewPkg("p", "p")
+	fn := ir.NewFunc(src.NoXPos, src.NoXPos, pkg.Lookup("f"),
+		types.NewSignature(nil, nil, nil))
+	fn.Body = []ir.Node{ir.NewBlockStmt(src.NoXPos, nil)}
+	fn.Inl = &ir.Inline{}
+	stale := &FuncProps{Flags: FuncPropRecursive | FuncPropMayBlock}
+	funcPropsTab = map[*ir.Func]*FuncProps{fn: stale}
+	propsCache = nil
+	addPropsCache(fn, stale)
+
+	ReanalyzeFuncs([]*ir.Func{fn}, nil)
+
+	fp := funcPropsTab[fn]
+	if fp.Flags&FuncPropMayBlock != 0 {
+		t.Errorf("stale flag survived reanalysis: %v", fp.Flags)
+	}
+	if fp.Flags&FuncPropRecursive == 0 {
+		t.Errorf("cross-function flag not carried over: %v", fp.Flags)
+	}
+	if cfp, _ := lookupPropsCache(fn); cfp != fp {
+		t.Errorf("props cache not updated")
+	}
+	if got := DeserializeFromString(fn.Inl.Properties).Flags; got != fp.Flags {
+		t.Errorf("got exported flags %v, want %v", got, fp.Flags)
+	}
+}
+
+func TestCollapseInstantiations(t *testing.T) {
+	for _, tc := range []struct{ name, want string }{
+		{"Use", "Use"},
+		{"Max[int]", "Max[...]"},
+		{"Max[go.shape.int]", "Max[shape]"},
+		{"(*List[go.shape.int]).Push", "(*List[shape]).Push"},
+		{"Map[go.shape.[]int,go.shape.map[string]int].func1", "Map[shape].func1"},
+	} {
+		if got := originName(tc.name); got != tc.want {
+			t.Errorf("originName(%q): got %q, want %q", tc.name, got, tc.want)
+		}
+	}
+
+	shape := &FuncProps{Flags: FuncPropIsPure, NumReturns: 2}
+	odd := &FuncProps{Flags: FuncPropIsPure, NumReturns: 1}
+	wrapper := &FuncProps{Flags: FuncPropStraightLine, NumReturns: 1}
+	entry := func(name string, line uint, fp *FuncProps, callee string) fnInlHeur {
+		e := fnInlHeur{fname: name, file: "g.go", line: line, col: 6, props: fp}
+		if callee != "" {
+			e.csites = []callSiteInfo{{pos: "g.go:3:6", flags: CallSiteTailPos, callee: callee}}
+		}
+		return e
+	}
+	sl := []fnInlHeur{
+		entry("Max[go.shape.float64]", 3, shape, ""),
+		entry("Max[string]", 3, wrapper, "Max[go.shape.string]"),
+		entry("Max[go.shape.string]", 3, odd, ""),
+		entry("Max[int]", 3, wrapper, "Max[go.shape.int]"),
+		entry("Max[go.shape.int]", 3, shape, ""),
+		entry("Use", 15, wrapper, ""),
+	}
+	got, atline := collapseInstantiations(sl)
+	var sb strings.Builder
+	for _, e := range got {
+		fmt.Fprintf(&sb, "%s %d %v;", e.fname, e.ninst, e.disagree)
+	}
+	want := "Max[shape] 3 [Max[go.shape.string]];Max[...] 2 [];Use 0 [];"
+	if sb.String() != want {
+		t.Fatalf("collapseInstantiations: got %q, want %q", sb.String(), want)
+	}
+	if callee := got[1].csites[0].callee; callee != "Max[shape]" {
+		t.Errorf("collapsed callee: got %q, want %q", callee, "Max[shape]")
+	}
+	if got[0].idx != 0 || got[1].idx != 1 || atline.count("g.go", 3) != 2 {
+		t.Errorf("collapsed idx/atl: got %d %d/%d, want 0 1/2",
+			got[0].idx, got[1].idx, atline.count("g.go", 3))
+	}
+
+	// The instantiation info should survive a trip through the text
+	// format.
+	var buf bytes.Buffer
+	dumpFilePreamble(&buf)
+	for i := range got {
+		if err := dumpFnPreamble(&buf, &got[i], 0, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	back, err := parseDump(&buf, "collapsed")
+	if err != nil {
+		t.Fatalf("parseDump: %v", err)
+	}
+	for i := range got {
+		if back[i].ninst != got[i].ninst ||
+			fmt.Sprint(back[i].disagree) != fmt.Sprint(got[i].disagree) {
+			t.Errorf("entry %s: read back %d %v, want %d %v", got[i].fname,
+				back[i].ninst, back[i].disagree, got[i].ninst, got[i].disagree)
+		}
+	}
+}
+
+// TestClosureEscapesUnknown checks that a closure for which
+// FindEscapingClosures has no answer (such as one copied into
+// another function by inlining) is treated as escaping.
+func TestClosureEscapesUnknown(t *testing.T) {
+	saved := escapingClosures
+	defer func() { escapingClosures = saved }()
+
+	local, copied := new(ir.Func), new(ir.Func)
+	escapingClosures = map[*ir.Func]bool{local: false}
+	if closureEscapes(local) {
+		t.Errorf("closure found not to escape reported as escaping")
+	}
+	if !closureEscapes(copied) {
+		t.Errorf("unknown closure reported as not escaping")
+	}
+	escapingClosures = nil
+	if !closureEscapes(local) {
+		t.Errorf("closure reported as not escaping before FindEscapingClosures")
+	}
+}
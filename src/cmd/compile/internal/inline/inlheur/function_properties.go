@@ -28,19 +28,215 @@ package inlheur
 // of specific results. Note that 'ParamFlags' includes and entry for
 // the receiver if applicable, and does include etries for blank
 // params; for a function such as "func foo(_ int, b byte, _ float32)"
-// the length of ParamFlags will be 3.
+// the length of ParamFlags will be 3. 'ResultAffectingParams' is a
+// mask with bit i set if the param in slot i of ParamFlags may
+// influence the function's results or side effects (params beyond
+// slot 63 are not represented). 'NumReturns' is the number of
+// "return" statements in the function (not counting any in nested
+// closures), and 'SingleTailReturn' is set if there is exactly one
+// such statement and it is the final statement of the function body.
+// 'MaxCallArgs' is the largest number of arguments passed by any call
+// made by the function (again not counting nested closures), and
+// 'NumCalls' is the total number of such calls. 'NodeCounts' gives a
+// coarse breakdown of the nodes in the function body by category.
+// 'Hotspot' is the source position ("file:line:col") of the construct
+// within the function that is most likely to make it expensive to
+// inline or call, or the empty string if there is no such construct
+// (see hotspotAnalyzer for details). 'Captures' describes the
+// variables captured by a closure, and is zero for other functions.
 type FuncProps struct {
-	Flags       FuncPropBits
-	ParamFlags  []ParamPropBits // slot 0 receiver if applicable
-	ResultFlags []ResultPropBits
+	Flags                 FuncPropBits
+	ParamFlags            []ParamPropBits // slot 0 receiver if applicable
+	ResultFlags           []ResultPropBits
+	ResultAffectingParams uint64
+	NumReturns            int
+	SingleTailReturn      bool
+	MaxCallArgs           int
+	NumCalls              int
+	NodeCounts            NodeCounts
+	Hotspot               string
+	Captures              ClosureCaptures
 }
 
-type FuncPropBits uint32
+// NodeCounts holds counts of the nodes within a function body (not
+// including the bodies of nested closures), broken down into
+// statements, expressions, and control flow statements (branches,
+// loops, labels, returns and jumps, which are not included in
+// 'Stmts'). It provides a coarse structural measure of the size of
+// the function, independent of the inliner's cost model.
+type NodeCounts struct {
+	Stmts       int
+	Exprs       int
+	ControlFlow int
+}
+
+// ClosureCaptures describes the variables captured by a closure (see
+// capturesAnalyzer): 'Vars' is the number of captured variables, and
+// 'ByRef' the number of them that are captured by reference (because
+// they are reassigned, have their address taken or are too large to
+// copy), so that the closure and its enclosing function share them
+// through memory. 'Escapes' is set if the closure may outlive the
+// call of its enclosing function, in which case the closure and any
+// variables it captures by reference must be heap allocated.
+type ClosureCaptures struct {
+	Vars    int
+	ByRef   int
+	Escapes bool
+}
+
+type FuncPropBits uint64
 
 const (
 	// Function always panics or invokes os.Exit() or a func that does
 	// likewise.
 	FuncPropNeverReturns FuncPropBits = 1 << iota
+	// Function body consists of a single lock-free compare-and-swap
+	// retry loop, e.g. "for { old := atomic.Load(p); if
+	// atomic.CompareAndSwap(p, old, f(old)) { break } }".
+	FuncPropCASLoop
+	// Function is a simple forwarding wrapper: its body consists of a
+	// single call to another function whose arguments are exactly the
+	// function's own parameters in order (possibly with the addition
+	// of a single constant argument), the results of which are
+	// returned unmodified.
+	FuncPropIsWrapper
+	// Function contains at least one call to itself in tail position
+	// (e.g. "return f(n-1, acc*n)").
+	FuncPropTailRecursive
+	// Function is a thin wrapper around a call into the syscall
+	// package, consisting of the call itself, one or more "if err !=
+	// nil { return ... }" error translation checks, and a final
+	// return.
+	FuncPropSyscallWrapper
+	// Function calls the builtin "recover", either directly or from
+	// a function literal that it defers.
+	FuncPropContainsRecover
+	// Function body consists of a single "return" of a fixed-size
+	// array composite literal (e.g. "return [4]byte{r, g, b, a}").
+	FuncPropArrayConstructor
+	// Function may block, e.g. on a channel operation, a "select"
+	// with no default case, or a call to a well-known blocking
+	// function such as sync.(*Mutex).Lock or time.Sleep.
+	FuncPropMayBlock
+	// Function does nothing more than a single byte order conversion
+	// via encoding/binary over its params (e.g. "return
+	// binary.LittleEndian.Uint32(b)").
+	FuncPropEndianConv
+	// Function does nothing more than make a single printf-style
+	// formatting call from the fmt package (e.g. "return
+	// fmt.Sprintf(format, args...)").
+	FuncPropFormatWrapper
+	// Function uses package unsafe to convert to or from
+	// unsafe.Pointer, or calls one of unsafe.Add, unsafe.Slice,
+	// unsafe.SliceData, unsafe.String or unsafe.StringData.
+	FuncPropUsesUnsafe
+	// Function is (conservatively) known to be free of side effects:
+	// it writes only to its own local variables, performs no I/O or
+	// channel operations, and calls only functions that are
+	// themselves known to be pure.
+	FuncPropIsPure
+	// Function body is a single basic block: it contains no
+	// branches, loops, or labels (although it may contain any number
+	// of statements).
+	FuncPropStraightLine
+	// Function contains at least one "defer" statement (not
+	// counting those in nested function literals).
+	FuncPropContainsDefer
+	// Function contains a "defer" that is likely to prevent the
+	// use of open-coded defers, e.g. one within a loop, or more
+	// defers than can be open-coded; such defers are handled
+	// through the (much slower) runtime defer mechanism.
+	FuncPropOpenDeferIneligible
+	// Function unconditionally allocates memory that may be placed
+	// on the heap, via "new", "make", "append", or a composite
+	// literal such as "&T{...}" or "[]T{...}". If the allocation
+	// doesn't escape the caller once the function is inlined, it can
+	// be moved to the stack.
+	FuncPropAllocates
+	// Function calls itself, directly or as part of a cycle of
+	// mutually recursive functions within the package (see
+	// MarkRecursive).
+	FuncPropRecursive
+	// Function makes no calls (not counting calls in nested function
+	// literals, calls that the back end implements as intrinsics,
+	// or uses of builtins such as "append" and "panic"). Leaf
+	// functions are typically the cheapest to inline, since nothing
+	// else comes along with them.
+	FuncPropIsLeaf
+	// Function contains a "go" statement.
+	FuncPropSpawnsGoroutine
+	// Function sends on or receives from a channel (including
+	// ranging over a channel, and within a "select").
+	FuncPropUsesChannels
+	// Function contains a "select" statement.
+	FuncPropUsesSelect
+	// Function locks or unlocks a sync.Mutex or sync.RWMutex.
+	FuncPropUsesMutex
+	// Most of the function body is given over to string
+	// concatenation, appending to byte slices, or fmt-style
+	// formatting, and at least one param feeds into it (see
+	// ParamFeedsStringConcat).
+	FuncPropConcatDominated
+	// Function is a trivial forwarding wrapper: it is a wrapper (see
+	// FuncPropIsWrapper) that passes exactly its own parameters,
+	// with no added constant argument. Calling such a function costs
+	// about as much as inlining it, so it should be inlined wherever
+	// it is inlinable at all.
+	FuncPropTrivialWrapper
+	// Function has no body in Go: it is implemented in assembly, or
+	// provided by another package via //go:linkname. The analyzers
+	// are not run for such functions, so none of the other
+	// properties are set, and the absence of a property means that
+	// nothing is known rather than that it doesn't hold.
+	FuncPropExternalImpl
+	// Function was never executed according to the coverage profile
+	// supplied with -d=inlcoverprofile (see coverageAnalyzer), so
+	// inlining it is unlikely to pay off.
+	FuncPropCoverageCold
+	// Function is an accessor-like method: it reads or writes the
+	// fields of its receiver, and otherwise uses only its params and
+	// locals, with no calls, no references to globals, and no
+	// accesses through pointers not reached from the receiver (see
+	// recvOnlyAnalyzer).
+	FuncPropReceiverOnly
+	// Function has more IR nodes than the limit set with
+	// -d=inlheurmaxnodes, so the analyzers were not run for it. As
+	// with FuncPropExternalImpl, none of the other properties are
+	// set. Such functions are far too large to ever be inlined, so
+	// analyzing them would only waste compile time.
+	FuncPropTooLarge
+	// Function is marked "//go:inlinehint hot": its author expects
+	// it to be called often (see inlineHintAnalyzer).
+	FuncPropHintHot
+	// Function is marked "//go:inlinehint cold": its author expects
+	// it to be called rarely.
+	FuncPropHintCold
+	// Function is marked "//go:inlinehint prefer-inline": its author
+	// asks for it to be inlined wherever the budget allows.
+	FuncPropHintPreferInline
+	// Function is not a leaf (see FuncPropIsLeaf), but each of the
+	// calls it makes is a direct call to a small inlinable leaf
+	// function, within the package or imported. Once those calls
+	// are inlined, the function is in effect a leaf, so it is
+	// scored like one.
+	FuncPropNearlyLeaf
+	// Function was generated by the compiler rather than written by
+	// the user: a type's equality or hash function, a wrapper for
+	// the call in a "go" or "defer" statement, the body of a
+	// range-over-func loop, or a method or instantiation wrapper
+	// (see compilerGenKind). Such functions are left out of the
+	// function properties dump by default (see
+	// "-d=dumpinlskipgen").
+	FuncPropCompilerGenerated
+	// Function does low-level pointer arithmetic (unsafe.Add, or a
+	// conversion of a uintptr back to an unsafe.Pointer), calls a
+	// method of reflect.Value, or is itself bound with //go:linkname
+	// or calls a function that is. Such code is opaque to escape
+	// analysis and other optimizations, so inlining it seldom pays.
+	FuncPropLowLevel
+	// Function contains a range-over-func loop ("for x := range
+	// seq", where seq is an iterator function such as an iter.Seq).
+	FuncPropRangeOverFunc
 )
 
 type ParamPropBits uint32
@@ -77,6 +273,64 @@ const (
 	// classification below), where the if/switch is
 	// conditional/nested.
 	ParamMayFeedIfOrSwitch
+
+	// Parameter is a slice or string that is indexed by a
+	// non-constant expression, meaning that the function performs a
+	// bounds check against it. If the function is called in a loop
+	// over the same slice, inlining may allow the caller to hoist or
+	// eliminate the check.
+	ParamFeedsBoundsCheck
+
+	// Parameter value feeds unmodified into the format string
+	// argument of a printf-style formatting call from the fmt
+	// package (assumes parameter is of string type).
+	ParamFeedsFormatString
+
+	// Parameter value feeds unmodified into the condition of a "for"
+	// loop (possibly via len or cap), or is the value ranged over by
+	// a "range" loop, meaning that it determines the trip count of
+	// the loop. If the call site passes a small constant, inlining
+	// may allow the loop to be unrolled or eliminated.
+	ParamFeedsLoopBound
+
+	// Parameter is never read: it is blank or unnamed, or is only
+	// ever assigned to. Uses within branches that the front end has
+	// discarded (such as "if debug { ... }" with a constant false
+	// "debug") don't count, since they're not in the IR. Once the
+	// function is inlined, the argument passed for the param need
+	// not be materialized.
+	ParamNeverRead
+
+	// Parameter value (a string or byte slice) feeds unmodified into
+	// a string concatenation, an append of its bytes to a byte
+	// slice, or a formatting call from the fmt package. If the call
+	// site passes a constant string, inlining may allow much of the
+	// work to be constant folded.
+	ParamFeedsStringConcat
+
+	// Parameter value feeds unmodified into a type assertion or type
+	// switch (assumes parameter is of interface type). If the call
+	// site passes a value of concrete type, inlining lets the
+	// assertion be resolved at compile time, and any method calls
+	// on the asserted value devirtualized.
+	ParamFeedsTypeAssert
+
+	// Parameter value (of integer type) feeds unmodified, or via a
+	// conversion, into the divisor of an integer division or
+	// modulus, or the shift amount of a shift. If the call site
+	// passes a constant (particularly a power of two), inlining
+	// lets the division be strength-reduced to a multiply, shift or
+	// mask, and the shift's range check be dropped.
+	ParamFeedsDivOrShift
+
+	// Parameter is a yield function (a func with a single bool
+	// result, as passed to an iter.Seq iterator) that is called
+	// within a loop, or within the body of a range-over-func loop.
+	// If the call site passes a function literal, inlining the
+	// callee puts the literal's body in the loop, where it can in
+	// turn be inlined, flattening a chain of iterators into a plain
+	// loop.
+	ParamYieldInLoop
 )
 
 type ResultPropBits uint32
@@ -95,4 +349,31 @@ const (
 	ResultAlwaysSameFunc
 	// Result is always the same (potentially) inlinable function or closure.
 	ResultAlwaysSameInlinableFunc
+	// Result is always the value of the same package-level variable
+	// (for example a sentinel error such as io.EOF).
+	ResultAlwaysSameGlobal
+	// Result is discarded at every call to the function in the
+	// package, and the function has no callers outside the package.
+	ResultDiscardedByCallers
+	// Result is of type error and is nil on every return path
+	// (including via the results of a call with the same property).
+	// Once the function is inlined, a caller's "if err != nil"
+	// check of the result can be deleted along with its body.
+	ResultErrorAlwaysNil
+	// Result is always a pointer to memory allocated by the function
+	// itself (with "new" or "&T{...}") that the function doesn't
+	// otherwise leak. Once the function is inlined, the allocation
+	// can be placed on the stack if the caller doesn't let the
+	// result escape either.
+	ResultFreshAlloc
 )
+
+// A note on "simple" expressions: the ParamFeedsIfOrSwitch and
+// ParamMayFeedIfOrSwitch flags are set only for params that appear in
+// an "if" condition, or a "switch" tag or case expression, that is
+// built entirely from params and constants using conversions, "len",
+// "cap" and the unary and binary operators (for example "x < 10 &&
+// !verbose", but not "f(x) < 10" or "x < y" where y is a local
+// variable). When the caller passes constants for the params in such
+// an expression, the expression can be constant folded once the
+// callee is inlined, and the branches not taken eliminated.
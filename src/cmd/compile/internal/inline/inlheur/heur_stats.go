@@ -0,0 +1,120 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"fmt"
+	"io"
+	"runtime/metrics"
+	"sort"
+	"time"
+)
+
+// analysisStats holds the statistics collected for the
+// "-d=inlheurstats" command line flag, which are intended to help
+// quantify the compile time cost of the inline heuristics. Here
+// 'funcs' is the number of functions analyzed (not counting cache
+// hits), 'nodeVisits' the number of IR nodes visited by the property
+// analyzers (a node visited by N analyzers counts N times),
+// 'analyzerTime' the time spent in each analyzer, keyed by analyzer
+// name, and 'analyzerCalls' the number of functions each analyzer
+// was run on (an analyzer that declines to look at a function isn't
+// counted). 'allocs' and 'allocBytes' are the number and total size
+// of the heap allocations made while analyzing functions.
+// 'dumpEntries' is the number of entries added to the function
+// properties dump buffer, and 'dumpPeak' the largest number of them
+// held in the buffer at any one time. Since collecting the stats
+// forces functions to be analyzed serially (see analyzeBatch), no
+// locking is needed.
+type analysisStats struct {
+	funcs         int
+	nodeVisits    int64
+	analyzerTime  map[string]time.Duration
+	analyzerCalls map[string]int
+	allocs        uint64
+	allocBytes    uint64
+	dumpEntries   int
+	dumpPeak      int
+}
+
+// heurStats holds the statistics collected so far, or nil if they
+// are not being collected (the default); see EnableHeurStats.
+var heurStats *analysisStats
+
+// EnableHeurStats turns on the collection of analysis statistics for
+// the "-d=inlheurstats" command line flag, along with the score
+// adjustment counters (see EnableScoreStats) if they're not already
+// being collected. It should be called before any analysis or scoring
+// takes place.
+func EnableHeurStats() {
+	heurStats = &analysisStats{
+		analyzerTime:  make(map[string]time.Duration),
+		analyzerCalls: make(map[string]int),
+	}
+	if scoreStats == nil {
+		EnableScoreStats()
+	}
+}
+
+// heapAllocMetrics holds the samples read by heapAllocs.
+var heapAllocMetrics = []metrics.Sample{
+	{Name: "/gc/heap/allocs:objects"},
+	{Name: "/gc/heap/allocs:bytes"},
+}
+
+// heapAllocs returns the cumulative number and total size of the
+// heap allocations made by the compiler so far. Unlike
+// runtime.ReadMemStats, reading these doesn't stop the world, so it
+// is cheap enough to do for each function analyzed. The price is
+// that small allocations are only counted when the runtime's
+// per-thread allocation cache is refilled, so the counts for a
+// single function are approximate; the totals over a package are
+// close enough to compare analyzers.
+func heapAllocs() (objects, bytes uint64) {
+	metrics.Read(heapAllocMetrics)
+	return heapAllocMetrics[0].Value.Uint64(), heapAllocMetrics[1].Value.Uint64()
+}
+
+// noteDumpEntry records the addition of an entry to the function
+// properties dump buffer.
+func (s *analysisStats) noteDumpEntry() {
+	s.dumpEntries++
+	n := len(dumpBuffer.entries)
+	if n > s.dumpPeak {
+		s.dumpPeak = n
+	}
+}
+
+// DumpHeurStats writes the analysis statistics collected so far for
+// package 'pkg' to 'w', followed by the score adjustment counters
+// (see DumpScoreStats). It does nothing if statistics are not being
+// collected.
+func DumpHeurStats(w io.Writer, pkg string) {
+	s := heurStats
+	if s == nil {
+		return
+	}
+	fmt.Fprintf(w, "inline heuristics stats for package %s:\n", pkg)
+	fmt.Fprintf(w, "  functions analyzed %d\n", s.funcs)
+	fmt.Fprintf(w, "  node visits %d\n", s.nodeVisits)
+	fmt.Fprintf(w, "  heap allocations %d (%d bytes)\n", s.allocs, s.allocBytes)
+	if s.dumpEntries != 0 {
+		fmt.Fprintf(w, "  dump entries %d (peak buffered %d)\n",
+			s.dumpEntries, s.dumpPeak)
+	}
+	names := make([]string, 0, len(s.analyzerTime))
+	var total time.Duration
+	for name, d := range s.analyzerTime {
+		names = append(names, name)
+		total += d
+	}
+	sort.Strings(names)
+	fmt.Fprintf(w, "  analyzer time %v\n", total)
+	for _, name := range names {
+		fmt.Fprintf(w, "    %-12s %v (%d functions)\n", name, s.analyzerTime[name],
+			s.analyzerCalls[name])
+	}
+	DumpScoreStats(w)
+}
@@ -0,0 +1,189 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"bufio"
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/types"
+	"cmd/internal/src"
+	"fmt"
+	"internal/testenv"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// heurCounters is a snapshot of the analysis statistics collected
+// for "-d=inlheurstats" (see analysisStats), for use by tests and
+// benchmarks. It can be obtained either in process, with
+// readHeurCounters, or from the output of a compilation, with
+// parseHeurCounters.
+type heurCounters struct {
+	funcs         int
+	nodeVisits    int64
+	allocs        uint64
+	allocBytes    uint64
+	analyzerTime  time.Duration
+	analyzerCalls map[string]int
+}
+
+// readHeurCounters returns a snapshot of the statistics collected so
+// far in this process. Statistics must have been enabled with
+// EnableHeurStats.
+func readHeurCounters() heurCounters {
+	s := heurStats
+	c := heurCounters{
+		funcs:         s.funcs,
+		nodeVisits:    s.nodeVisits,
+		allocs:        s.allocs,
+		allocBytes:    s.allocBytes,
+		analyzerCalls: make(map[string]int),
+	}
+	for name, d := range s.analyzerTime {
+		c.analyzerTime += d
+		c.analyzerCalls[name] = s.analyzerCalls[name]
+	}
+	return c
+}
+
+// parseHeurCounters parses the statistics written by DumpHeurStats
+// for a single package in 'out', the output of a compilation with
+// "-d=inlheurstats=1".
+func parseHeurCounters(out string) (heurCounters, error) {
+	c := heurCounters{analyzerCalls: make(map[string]int)}
+	found := false
+	inAnalyzers := false
+	sc := bufio.NewScanner(strings.NewReader(out))
+	for sc.Scan() {
+		line := sc.Text()
+		var err error
+		switch {
+		case strings.HasPrefix(line, "inline heuristics stats for package "):
+			found = true
+		case !found:
+		case strings.HasPrefix(line, "  functions analyzed "):
+			_, err = fmt.Sscanf(line, "  functions analyzed %d", &c.funcs)
+		case strings.HasPrefix(line, "  node visits "):
+			_, err = fmt.Sscanf(line, "  node visits %d", &c.nodeVisits)
+		case strings.HasPrefix(line, "  heap allocations "):
+			_, err = fmt.Sscanf(line, "  heap allocations %d (%d bytes)", &c.allocs, &c.allocBytes)
+		case strings.HasPrefix(line, "  analyzer time "):
+			c.analyzerTime, err = time.ParseDuration(strings.TrimPrefix(line, "  analyzer time "))
+			inAnalyzers = true
+		case inAnalyzers && strings.HasPrefix(line, "    "):
+			var name, dur string
+			var n int
+			if _, err = fmt.Sscanf(line, "%s %s (%d functions)", &name, &dur, &n); err == nil {
+				c.analyzerCalls[name] = n
+			}
+		default:
+			inAnalyzers = false
+		}
+		if err != nil {
+			return c, fmt.Errorf("parsing %q: %v", line, err)
+		}
+	}
+	if !found {
+		return c, fmt.Errorf("no inline heuristics stats in output")
+	}
+	return c, nil
+}
+
+func TestHeurStatsCounters(t *testing.T) {
+	savedHeur, savedScore := heurStats, scoreStats
+	defer func() { heurStats, scoreStats = savedHeur, savedScore }()
+	EnableHeurStats()
+
+	pkg := types.NewPkg("p", "p")
+	fn := ir.NewFunc(src.NoXPos, src.NoXPos, pkg.Lookup("f"),
+		types.NewSignature(nil, nil, nil))
+	fn.Body = []ir.Node{ir.NewBlockStmt(src.NoXPos, nil)}
+	analyzers := makeAnalyzers(fn, nil)
+
+	runAnalyzers(fn, nil)
+	runAnalyzers(fn, nil)
+
+	c := readHeurCounters()
+	if c.funcs != 2 {
+		t.Errorf("got %d functions analyzed, want 2", c.funcs)
+	}
+	// Each analyzer walks the function separately, visiting the
+	// function itself and its body (a single block).
+	if want := int64(2 * 2 * len(analyzers)); c.nodeVisits != want {
+		t.Errorf("got %d node visits, want %d", c.nodeVisits, want)
+	}
+	if len(c.analyzerCalls) != len(analyzers) {
+		t.Errorf("got calls for %d analyzers, want %d", len(c.analyzerCalls), len(analyzers))
+	}
+	for _, a := range analyzers {
+		if n := c.analyzerCalls[a.name()]; n != 2 {
+			t.Errorf("analyzer %s: got %d calls, want 2", a.name(), n)
+		}
+	}
+
+	// Check that the dump can be parsed back.
+	var sb strings.Builder
+	DumpHeurStats(&sb, "p")
+	pc, err := parseHeurCounters(sb.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pc.funcs != c.funcs || pc.nodeVisits != c.nodeVisits ||
+		pc.allocs != c.allocs || pc.allocBytes != c.allocBytes ||
+		len(pc.analyzerCalls) != len(c.analyzerCalls) {
+		t.Errorf("parsed counters %+v, want %+v", pc, c)
+	}
+}
+
+// BenchmarkAnalyzeProps measures the cost of the inline heuristics
+// analysis over the testdata/props corpus. Each iteration compiles one
+// file of the corpus with "-d=inlheuristics=1,inlheurstats=1"; along
+// with the time per compilation, the benchmark reports the analysis
+// statistics per compilation, so that the cost of a new analyzer can
+// be measured by comparing results with benchstat. As with
+// TestFuncProperties, this uses the installed compiler.
+func BenchmarkAnalyzeProps(b *testing.B) {
+	testenv.MustHaveGoBuild(b)
+	td := b.TempDir()
+	for _, tc := range []string{"funcflags", "returns", "params", "shapes", "callsites"} {
+		b.Run(tc, func(b *testing.B) {
+			gopath, err := filepath.Abs("testdata/props/" + tc + ".go")
+			if err != nil {
+				b.Fatal(err)
+			}
+			importcfg := filepath.Join(td, tc+".importcfg")
+			testenv.WriteImportcfg(b, importcfg, nil, gopath)
+			outpath := filepath.Join(td, tc+".o")
+			var total heurCounters
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				cmd := testenv.Command(b, testenv.GoToolPath(b), "tool", "compile",
+					"-p", "p", "-importcfg", importcfg, "-o", outpath,
+					"-d=inlheuristics=1,inlheurstats=1", gopath)
+				out, err := cmd.CombinedOutput()
+				if err != nil {
+					b.Fatalf("%v: %v\n%s", cmd, err, out)
+				}
+				c, err := parseHeurCounters(string(out))
+				if err != nil {
+					b.Fatal(err)
+				}
+				total.funcs += c.funcs
+				total.nodeVisits += c.nodeVisits
+				total.allocs += c.allocs
+				total.allocBytes += c.allocBytes
+				total.analyzerTime += c.analyzerTime
+			}
+			n := float64(b.N)
+			b.ReportMetric(float64(total.funcs)/n, "funcs/op")
+			b.ReportMetric(float64(total.nodeVisits)/n, "node-visits/op")
+			b.ReportMetric(float64(total.allocs)/n, "analysis-allocs/op")
+			b.ReportMetric(float64(total.allocBytes)/n, "analysis-B/op")
+			b.ReportMetric(float64(total.analyzerTime.Nanoseconds())/n, "analysis-ns/op")
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"fmt"
+
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/logopt"
+)
+
+// This file feeds the results of the inline heuristics into the
+// compiler's optimization log (the "-json=0,dir" command line flag;
+// see package logopt), alongside the inliner's own
+// "canInlineFunction" and "cannotInlineCall" records, so that build
+// systems can collect them from the standard stream rather than from
+// one of the heuristics' own dump files. Two kinds of record are
+// written:
+//
+//	code "inlheurFuncProps", at each analyzed function, with the
+//	message "props: <summary>" (see FuncProps.Summary);
+//
+//	code "inlheurCallSiteScore", at each scored call site, with the
+//	message "callee: p.g, cost: 57, score: 32, adjustments: <mask>".
+
+// logFuncProps writes the properties 'fp' computed for 'fn' to the
+// optimization log, if it is enabled.
+func logFuncProps(fn *ir.Func, fp *FuncProps) {
+	if !logopt.Enabled() {
+		return
+	}
+	logopt.LogOpt(fn.Pos(), "inlheurFuncProps", "inline", ir.FuncName(fn),
+		"props: "+fp.Summary())
+}
+
+// logCallSiteScore writes the score 'score' computed for call site
+// 'cs' in 'caller' to the optimization log, if it is enabled. Here
+// 'cost' is the callee's unadjusted cost and 'mask' the adjustments
+// that were applied.
+func logCallSiteScore(caller *ir.Func, cs *CallSite, cost int32, score int, mask scoreAdjustTyp) {
+	if !logopt.Enabled() {
+		return
+	}
+	logopt.LogOpt(cs.Call.Pos(), "inlheurCallSiteScore", "inline", ir.FuncName(caller),
+		fmt.Sprintf("callee: %s, cost: %d, score: %d, adjustments: %s",
+			ir.PkgFuncName(cs.Callee), cost, score, mask))
+}
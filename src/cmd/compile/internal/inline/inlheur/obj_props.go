@@ -0,0 +1,59 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"sort"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"cmd/internal/inlprops"
+	"cmd/internal/obj"
+	"cmd/internal/objabi"
+)
+
+// WriteObjProps records the properties computed for the functions in
+// 'fns' (and any closures within them) in a table in the object file
+// being written, for use by post-build tools; see package
+// cmd/internal/inlprops for the format and a reader. Functions that
+// weren't analyzed are omitted, and nothing is written if none were.
+func WriteObjProps(fns []*ir.Func) {
+	var entries []inlprops.Entry
+	seen := make(map[*ir.Func]bool)
+	add := func(fn *ir.Func) {
+		if seen[fn] {
+			return
+		}
+		seen[fn] = true
+		if fp := funcPropsTab[fn]; fp != nil {
+			entries = append(entries, inlprops.Entry{
+				Func:  ir.LinkFuncName(fn),
+				Props: fp.String(),
+			})
+		}
+	}
+	for _, fn := range fns {
+		add(fn)
+		ir.VisitFuncAndClosures(fn, func(n ir.Node) {
+			if clo, ok := n.(*ir.ClosureExpr); ok {
+				add(clo.Func)
+			}
+		})
+	}
+	if len(entries) == 0 {
+		return
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Func < entries[j].Func
+	})
+	s := base.Ctxt.Lookup(inlprops.SymPrefix + base.Ctxt.Pkgpath)
+	s.Type = objabi.SRODATA
+	// As with the DWARF compile unit info symbols, two package main
+	// archives may be linked together (for example when building
+	// tests), so allow dups.
+	s.Set(obj.AttrDuplicateOK, true)
+	s.P = inlprops.Encode(entries)
+	base.Ctxt.Data = append(base.Ctxt.Data, s)
+}
@@ -22,21 +22,37 @@ func _() {
 	_ = x[ParamMayFeedIndirectCall-16]
 	_ = x[ParamFeedsIfOrSwitch-32]
 	_ = x[ParamMayFeedIfOrSwitch-64]
+	_ = x[ParamFeedsBoundsCheck-128]
+	_ = x[ParamFeedsFormatString-256]
+	_ = x[ParamFeedsLoopBound-512]
+	_ = x[ParamNeverRead-1024]
+	_ = x[ParamFeedsStringConcat-2048]
+	_ = x[ParamFeedsTypeAssert-4096]
+	_ = x[ParamFeedsDivOrShift-8192]
+	_ = x[ParamYieldInLoop-16384]
 }
 
 var _ParamPropBits_value = [...]uint64{
-	0x0,  /* ParamNoInfo */
-	0x2,  /* ParamFeedsInterfaceMethodCall */
-	0x4,  /* ParamMayFeedInterfaceMethodCall */
-	0x8,  /* ParamFeedsIndirectCall */
-	0x10, /* ParamMayFeedIndirectCall */
-	0x20, /* ParamFeedsIfOrSwitch */
-	0x40, /* ParamMayFeedIfOrSwitch */
+	0x0,    /* ParamNoInfo */
+	0x2,    /* ParamFeedsInterfaceMethodCall */
+	0x4,    /* ParamMayFeedInterfaceMethodCall */
+	0x8,    /* ParamFeedsIndirectCall */
+	0x10,   /* ParamMayFeedIndirectCall */
+	0x20,   /* ParamFeedsIfOrSwitch */
+	0x40,   /* ParamMayFeedIfOrSwitch */
+	0x80,   /* ParamFeedsBoundsCheck */
+	0x100,  /* ParamFeedsFormatString */
+	0x200,  /* ParamFeedsLoopBound */
+	0x400,  /* ParamNeverRead */
+	0x800,  /* ParamFeedsStringConcat */
+	0x1000, /* ParamFeedsTypeAssert */
+	0x2000, /* ParamFeedsDivOrShift */
+	0x4000, /* ParamYieldInLoop */
 }
 
-const _ParamPropBits_name = "ParamNoInfoParamFeedsInterfaceMethodCallParamMayFeedInterfaceMethodCallParamFeedsIndirectCallParamMayFeedIndirectCallParamFeedsIfOrSwitchParamMayFeedIfOrSwitch"
+const _ParamPropBits_name = "ParamNoInfoParamFeedsInterfaceMethodCallParamMayFeedInterfaceMethodCallParamFeedsIndirectCallParamMayFeedIndirectCallParamFeedsIfOrSwitchParamMayFeedIfOrSwitchParamFeedsBoundsCheckParamFeedsFormatStringParamFeedsLoopBoundParamNeverReadParamFeedsStringConcatParamFeedsTypeAssertParamFeedsDivOrShiftParamYieldInLoop"
 
-var _ParamPropBits_index = [...]uint8{0, 11, 40, 71, 93, 117, 137, 159}
+var _ParamPropBits_index = [...]uint16{0, 11, 40, 71, 93, 117, 137, 159, 180, 202, 221, 235, 257, 277, 297, 313}
 
 func (i ParamPropBits) String() string {
 	var b bytes.Buffer
@@ -0,0 +1,75 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/types"
+	"fmt"
+	"io"
+)
+
+// propsCache memoizes the properties computed for functions, keyed
+// by function symbol, so that a function that is reached more than
+// once (for example a closure, which is analyzed both on its own and
+// when checking the inlinability of the function returning it, or a
+// function that is both dumped and analyzed for scoring) is only
+// analyzed once. It is only accessed serially.
+var propsCache map[*types.Sym]*FuncProps
+
+// propsCacheHits and propsCacheMisses count the lookups in
+// propsCache, for use with the "-d=inlpropscachestats" command line
+// flag.
+var propsCacheHits, propsCacheMisses int
+
+// propsCacheKey returns the key under which the properties of 'fn'
+// are cached, or nil if they can't be cached.
+func propsCacheKey(fn *ir.Func) *types.Sym {
+	sym := fn.Sym()
+	if sym == nil || sym.IsBlank() {
+		// Blank functions all share the same symbol.
+		return nil
+	}
+	return sym
+}
+
+// lookupPropsCache returns the cached properties for 'fn', if any.
+func lookupPropsCache(fn *ir.Func) (*FuncProps, bool) {
+	key := propsCacheKey(fn)
+	if key == nil {
+		return nil, false
+	}
+	fp, ok := propsCache[key]
+	if ok {
+		propsCacheHits++
+	} else {
+		propsCacheMisses++
+	}
+	return fp, ok
+}
+
+// addPropsCache records 'fp' as the properties of 'fn' in the cache.
+func addPropsCache(fn *ir.Func, fp *FuncProps) {
+	key := propsCacheKey(fn)
+	if key == nil {
+		return
+	}
+	if propsCache == nil {
+		propsCache = make(map[*types.Sym]*FuncProps)
+	}
+	propsCache[key] = fp
+}
+
+// DumpPropsCacheStats writes a summary of the function properties
+// cache lookups made so far to 'w'.
+func DumpPropsCacheStats(w io.Writer) {
+	total := propsCacheHits + propsCacheMisses
+	rate := 0.0
+	if total != 0 {
+		rate = 100 * float64(propsCacheHits) / float64(total)
+	}
+	fmt.Fprintf(w, "inline props cache: %d hits, %d misses (%.1f%% hit rate)\n",
+		propsCacheHits, propsCacheMisses, rate)
+}
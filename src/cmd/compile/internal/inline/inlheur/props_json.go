@@ -0,0 +1,143 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// This file contains the JSON encoding of the function property
+// flags, along with support for reading function properties back in
+// from their JSON encoding or from a complete dump. So that the JSON
+// written to a function properties dump can be understood by tools
+// other than the compiler, flags are encoded as arrays of flag names
+// rather than as integer bitmasks, for example
+//
+//	{"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[[],["ParamNeverRead"]],...}
+//
+// When decoding, a plain integer is accepted as well, for
+// compatibility with older dumps and with hand-written property
+// overrides (see props_override.go).
+
+func (b FuncPropBits) MarshalJSON() ([]byte, error)     { return marshalPropBits(b) }
+func (b *FuncPropBits) UnmarshalJSON(data []byte) error { return unmarshalPropBits(b, data) }
+
+func (b ParamPropBits) MarshalJSON() ([]byte, error)     { return marshalPropBits(b) }
+func (b *ParamPropBits) UnmarshalJSON(data []byte) error { return unmarshalPropBits(b, data) }
+
+func (b ResultPropBits) MarshalJSON() ([]byte, error)     { return marshalPropBits(b) }
+func (b *ResultPropBits) UnmarshalJSON(data []byte) error { return unmarshalPropBits(b, data) }
+
+// marshalPropBits returns the JSON encoding of 'b', namely an array
+// of the names of the flags set in it (empty if none are set). If
+// some flag has no name, 'b' is encoded as an integer instead, so
+// that nothing is lost.
+func marshalPropBits[T propBits](b T) ([]byte, error) {
+	names := []string{}
+	for bit := T(1); bit != 0; bit <<= 1 {
+		if b&bit == 0 {
+			continue
+		}
+		name := bit.String()
+		if strings.HasSuffix(name, ")") {
+			// unnamed, as in "FuncPropBits(0x8000000000000000)"
+			return json.Marshal(uint64(b))
+		}
+		names = append(names, name)
+	}
+	return json.Marshal(names)
+}
+
+// unmarshalPropBits decodes the JSON encoded flags 'data' into 'b'.
+// The flags may be given either as an array of flag names or as an
+// integer.
+func unmarshalPropBits[T propBits](b *T, data []byte) error {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		var v uint64
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		*b = T(v)
+		return nil
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	var flags T
+	for _, name := range names {
+		bit, ok := propBitByName[T](name)
+		if !ok {
+			return fmt.Errorf("unknown property flag %q", name)
+		}
+		flags |= bit
+	}
+	*b = flags
+	return nil
+}
+
+// propBitByName returns the single flag of type T whose name is
+// 'name'.
+func propBitByName[T propBits](name string) (T, bool) {
+	for bit := T(1); bit != 0; bit <<= 1 {
+		if bit.String() == name {
+			return bit, true
+		}
+	}
+	return 0, false
+}
+
+// ParseFuncProps decodes function properties from 'data', in the
+// JSON form written to a function properties dump.
+func ParseFuncProps(data []byte) (*FuncProps, error) {
+	fp := new(FuncProps)
+	if err := json.Unmarshal(data, fp); err != nil {
+		return nil, err
+	}
+	return fp, nil
+}
+
+// DumpEntry is an entry read from a function properties dump by
+// ParseDump, giving the properties of the function 'Func' defined at
+// 'File':'Line':'Col' (the column is zero for dumps that lack it).
+// For an appended dump describing several packages (see
+// dump_append.go), 'Package' and 'BuildID' identify the package
+// containing the function.
+type DumpEntry struct {
+	Package string
+	BuildID string
+	File    string
+	Func    string
+	Line    uint
+	Col     uint
+	Props   *FuncProps
+}
+
+// ParseDump reads in the function properties dump (as written by the
+// "-d=dumpinlfuncprops=..." command line flag in the default format)
+// from 'r', returning its entries in order.
+func ParseDump(r io.Reader) ([]DumpEntry, error) {
+	entries, err := parseDump(r, "dump")
+	if err != nil {
+		return nil, err
+	}
+	rv := make([]DumpEntry, 0, len(entries))
+	for _, e := range entries {
+		rv = append(rv, DumpEntry{
+			Package: e.pkg,
+			BuildID: e.buildID,
+			File:    e.file,
+			Func:    e.fname,
+			Line:    e.line,
+			Col:     e.col,
+			Props:   e.props,
+		})
+	}
+	return rv, nil
+}
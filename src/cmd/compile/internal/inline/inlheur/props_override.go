@@ -0,0 +1,156 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"bufio"
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// This file implements the "-d=inlpropsoverride=file" command line
+// flag, which forces the properties computed for selected functions
+// to given values, so as to see what the inliner would do if it knew
+// more (or less) about a function without having to modify either
+// the function or the compiler. Each line of the file names a
+// function by its package-qualified name (as in "-m" output) and
+// gives the properties to force, in one of two forms:
+//
+//	example.com/p.Fatal    NeverReturns
+//	example.com/p.hash     IsPure|StraightLine
+//	example.com/p.(*T).get {"ResultFlags": [4], "NumReturns": 1}
+//
+// In the first form the function flags are replaced by the listed
+// FuncPropBits (written with or without the "FuncProp" prefix, case
+// insensitively, or as "none" to clear them). In the second the rest
+// of the line is a JSON object whose fields replace the corresponding
+// fields of the computed FuncProps; fields not mentioned keep their
+// computed values. Blank lines and lines starting with "#" are
+// ignored. Since overrides are applied before the properties are
+// cached or serialized, they are also seen by importing packages.
+
+// propsOverride records the overrides for a single function: either
+// 'flags' replaces FuncProps.Flags, or 'js' (if non-nil) is a JSON
+// object to be decoded over the computed FuncProps.
+type propsOverride struct {
+	flags FuncPropBits
+	js    json.RawMessage
+}
+
+// propsOverrides maps the package-qualified names of functions to
+// their overrides, or is nil if no override file was given.
+var propsOverrides map[string]*propsOverride
+
+// SetPropsOverrides reads the function property overrides in the file
+// 'path', for use by subsequent property computations.
+func SetPropsOverrides(path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		base.Fatalf("opening inline properties override file %q: %v", path, err)
+	}
+	defer f.Close()
+	m, err := readPropsOverrides(f)
+	if err != nil {
+		base.Fatalf("reading inline properties override file %q: %v", path, err)
+	}
+	propsOverrides = m
+}
+
+// readPropsOverrides parses the override file contents read from 'r'.
+func readPropsOverrides(r io.Reader) (map[string]*propsOverride, error) {
+	m := make(map[string]*propsOverride)
+	s := bufio.NewScanner(r)
+	for lno := 1; s.Scan(); lno++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, spec, ok := strings.Cut(line, " ")
+		spec = strings.TrimSpace(spec)
+		if !ok || spec == "" {
+			return nil, fmt.Errorf("line %d: malformed entry %q, want function name followed by properties", lno, line)
+		}
+		if _, dup := m[name]; dup {
+			return nil, fmt.Errorf("line %d: duplicate entry for %s", lno, name)
+		}
+		ov := new(propsOverride)
+		if strings.HasPrefix(spec, "{") {
+			// Check the JSON up front, so that errors are reported
+			// whether or not the function is compiled.
+			var fp FuncProps
+			if err := json.Unmarshal([]byte(spec), &fp); err != nil {
+				return nil, fmt.Errorf("line %d: bad properties for %s: %v", lno, name, err)
+			}
+			ov.js = json.RawMessage(spec)
+		} else {
+			flags, err := parseFuncPropBits(spec)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: bad properties for %s: %v", lno, name, err)
+			}
+			ov.flags = flags
+		}
+		m[name] = ov
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// parseFuncPropBits parses a "|"-separated list of FuncPropBits names.
+func parseFuncPropBits(spec string) (FuncPropBits, error) {
+	if strings.EqualFold(spec, "none") {
+		return 0, nil
+	}
+	var flags FuncPropBits
+	for _, name := range strings.Split(spec, "|") {
+		bit, ok := funcPropBitByName(strings.TrimSpace(name))
+		if !ok {
+			return 0, fmt.Errorf("unknown function property %q", name)
+		}
+		flags |= bit
+	}
+	return flags, nil
+}
+
+// funcPropBitByName returns the FuncPropBits value with the name
+// 'name', which may omit the "FuncProp" prefix.
+func funcPropBitByName(name string) (FuncPropBits, bool) {
+	for _, v := range _FuncPropBits_value {
+		bit := FuncPropBits(v)
+		full := bit.String()
+		if strings.EqualFold(name, full) ||
+			strings.EqualFold(name, strings.TrimPrefix(full, "FuncProp")) {
+			return bit, true
+		}
+	}
+	return 0, false
+}
+
+// applyPropsOverride returns the properties of 'fn' with any override
+// for it applied; 'fp' itself is left unchanged. It should be called
+// on all freshly computed properties before they are cached.
+func applyPropsOverride(fn *ir.Func, fp *FuncProps) *FuncProps {
+	if propsOverrides == nil {
+		return fp
+	}
+	ov := propsOverrides[ir.PkgFuncName(fn)]
+	if ov == nil {
+		return fp
+	}
+	nfp := *fp
+	if ov.js == nil {
+		nfp.Flags = ov.flags
+	} else if err := json.Unmarshal(ov.js, &nfp); err != nil {
+		// Already checked when the file was read.
+		base.Fatalf("applying inline properties override for %v: %v", fn, err)
+	}
+	return &nfp
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestReadPropsOverrides(t *testing.T) {
+	const input = `
+# comment
+p.Fatal   NeverReturns
+p.hash    funcpropispure|StraightLine
+p.clear   none
+p.(*T).get {"ResultFlags": [4], "NumReturns": 1}
+`
+	m, err := readPropsOverrides(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m) != 4 {
+		t.Fatalf("got %d entries, want 4", len(m))
+	}
+	wantFlags := map[string]FuncPropBits{
+		"p.Fatal": FuncPropNeverReturns,
+		"p.hash":  FuncPropIsPure | FuncPropStraightLine,
+		"p.clear": 0,
+	}
+	for name, want := range wantFlags {
+		if ov := m[name]; ov == nil || ov.js != nil || ov.flags != want {
+			t.Errorf("%s: got %+v, want flags %s", name, ov, want)
+		}
+	}
+
+	// A JSON override replaces only the fields it mentions.
+	ov := m["p.(*T).get"]
+	if ov == nil || ov.js == nil {
+		t.Fatalf("p.(*T).get: missing JSON override")
+	}
+	fp := FuncProps{Flags: FuncPropIsWrapper, NumReturns: 3, NumCalls: 2}
+	if err := json.Unmarshal(ov.js, &fp); err != nil {
+		t.Fatal(err)
+	}
+	if fp.Flags != FuncPropIsWrapper || fp.NumReturns != 1 || fp.NumCalls != 2 ||
+		len(fp.ResultFlags) != 1 || fp.ResultFlags[0] != ResultIsConcreteTypeConvertedToInterface {
+		t.Errorf("p.(*T).get: got %+v after override", fp)
+	}
+
+	for _, bad := range []string{
+		"p.f",
+		"p.f Bogus",
+		"p.f NeverReturns\np.f IsPure",
+		`p.f {"NumReturns": "x"}`,
+	} {
+		if _, err := readPropsOverrides(strings.NewReader(bad)); err == nil {
+			t.Errorf("%q: no error", bad)
+		}
+	}
+}
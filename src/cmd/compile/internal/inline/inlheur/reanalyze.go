@@ -0,0 +1,84 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import "cmd/compile/internal/ir"
+
+// This file provides support for iterative inlining, in which the
+// inliner makes more than one pass over the package. Inlining
+// rewrites the bodies of the functions it inlines into (calls are
+// replaced by the callee bodies, constants propagate into them, and
+// so on), so the properties computed for those functions before the
+// first round no longer describe them. Before a later round scores
+// calls to such a function, its properties are discarded and
+// recomputed from its current body.
+//
+// Some properties are established by cross-function passes rather
+// than by the analyzers looking at a single body (see MarkRecursive
+// and PropagateNeverReturns). Inlining doesn't change whether these
+// hold, so they are carried over from the old properties rather than
+// recomputed.
+
+// crossFuncPropBits are the function flags set by cross-function
+// passes, which are carried over by ReanalyzeFuncs.
+const crossFuncPropBits = FuncPropRecursive | FuncPropNeverReturns
+
+// InvalidateFuncProps discards the properties recorded for the
+// functions in 'fns' (both in the table consulted by scoring and in
+// the props cache), so that they will be recomputed the next time the
+// functions are analyzed.
+func InvalidateFuncProps(fns []*ir.Func) {
+	funcPropsMu.Lock()
+	defer funcPropsMu.Unlock()
+	for _, fn := range fns {
+		delete(funcPropsTab, fn)
+		if key := propsCacheKey(fn); key != nil {
+			delete(propsCache, key)
+		}
+	}
+}
+
+// ReanalyzeFuncs recomputes the properties of the functions in 'fns',
+// whose bodies have been modified by a round of inlining, so that a
+// subsequent round scores calls to them using properties that reflect
+// their current bodies. As with AnalyzeFuncs, the functions should be
+// given in bottom-up order, and 'canInline' is a callback used to
+// check the inlinability of closures returned by the functions.
+// Functions that haven't been analyzed before are skipped.
+func ReanalyzeFuncs(fns []*ir.Func, canInline func(*ir.Func)) {
+	var todo []*ir.Func
+	carried := make(map[*ir.Func]FuncPropBits)
+	for _, fn := range fns {
+		fp, ok := funcPropsTab[fn]
+		if !ok {
+			continue
+		}
+		todo = append(todo, fn)
+		carried[fn] = fp.Flags & crossFuncPropBits
+	}
+	InvalidateFuncProps(todo)
+	AnalyzeFuncs(todo, canInline)
+	for _, fn := range todo {
+		fp := funcPropsTab[fn]
+		if fp == nil || fp.Flags&carried[fn] == carried[fn] {
+			continue
+		}
+		// The properties may be shared (see propsCache), so make a
+		// copy rather than updating them in place.
+		nfp := *fp
+		nfp.Flags |= carried[fn]
+		funcPropsTab[fn] = &nfp
+		addPropsCache(fn, &nfp)
+		if fn.Inl != nil {
+			fn.Inl.Properties = nfp.SerializeToString()
+		}
+	}
+	if debugTrace&debugTraceResults != 0 {
+		for _, fn := range todo {
+			traceEvent("result", "analyzer", "reanalyze", "func", fn.Sym().Name,
+				"props", funcPropsTab[fn].Summary())
+		}
+	}
+}
@@ -21,20 +21,28 @@ func _() {
 	_ = x[ResultAlwaysSameConstant-8]
 	_ = x[ResultAlwaysSameFunc-16]
 	_ = x[ResultAlwaysSameInlinableFunc-32]
+	_ = x[ResultAlwaysSameGlobal-64]
+	_ = x[ResultDiscardedByCallers-128]
+	_ = x[ResultErrorAlwaysNil-256]
+	_ = x[ResultFreshAlloc-512]
 }
 
 var _ResultPropBits_value = [...]uint64{
-	0x0,  /* ResultNoInfo */
-	0x2,  /* ResultIsAllocatedMem */
-	0x4,  /* ResultIsConcreteTypeConvertedToInterface */
-	0x8,  /* ResultAlwaysSameConstant */
-	0x10, /* ResultAlwaysSameFunc */
-	0x20, /* ResultAlwaysSameInlinableFunc */
+	0x0,   /* ResultNoInfo */
+	0x2,   /* ResultIsAllocatedMem */
+	0x4,   /* ResultIsConcreteTypeConvertedToInterface */
+	0x8,   /* ResultAlwaysSameConstant */
+	0x10,  /* ResultAlwaysSameFunc */
+	0x20,  /* ResultAlwaysSameInlinableFunc */
+	0x40,  /* ResultAlwaysSameGlobal */
+	0x80,  /* ResultDiscardedByCallers */
+	0x100, /* ResultErrorAlwaysNil */
+	0x200, /* ResultFreshAlloc */
 }
 
-const _ResultPropBits_name = "ResultNoInfoResultIsAllocatedMemResultIsConcreteTypeConvertedToInterfaceResultAlwaysSameConstantResultAlwaysSameFuncResultAlwaysSameInlinableFunc"
+const _ResultPropBits_name = "ResultNoInfoResultIsAllocatedMemResultIsConcreteTypeConvertedToInterfaceResultAlwaysSameConstantResultAlwaysSameFuncResultAlwaysSameInlinableFuncResultAlwaysSameGlobalResultDiscardedByCallersResultErrorAlwaysNilResultFreshAlloc"
 
-var _ResultPropBits_index = [...]uint8{0, 12, 32, 72, 96, 116, 145}
+var _ResultPropBits_index = [...]uint8{0, 12, 32, 72, 96, 116, 145, 167, 191, 211, 227}
 
 func (i ResultPropBits) String() string {
 	var b bytes.Buffer
@@ -0,0 +1,74 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"fmt"
+	"io"
+	"math/bits"
+	"sort"
+	"sync/atomic"
+)
+
+// adjStats holds counters recording how many times each type of
+// score adjustment has been applied, along with the cumulative
+// change in score from those adjustments. The counters are updated
+// atomically, since scoring may take place concurrently.
+type adjStats struct {
+	counts [64]atomic.Int64
+	deltas [64]atomic.Int64
+}
+
+// scoreStats holds the score adjustment counters, or nil if they
+// are not being collected (the default); see EnableScoreStats.
+var scoreStats *adjStats
+
+// EnableScoreStats turns on the collection of score adjustment
+// counters, for use with the "-d=inlscorestats" command line flag.
+// It should be called before any scoring takes place.
+func EnableScoreStats() {
+	scoreStats = new(adjStats)
+}
+
+// record notes an application of adjustment 'typ' with value 'val'.
+func (s *adjStats) record(typ scoreAdjustTyp, val int) {
+	i := bits.TrailingZeros64(uint64(typ))
+	s.counts[i].Add(1)
+	s.deltas[i].Add(int64(val))
+}
+
+// DumpScoreStats writes a summary of the score adjustments applied
+// so far to 'w', one line per adjustment type (sorted by name) with
+// the number of times it was applied and the cumulative change in
+// score. It does nothing if counters are not being collected.
+func DumpScoreStats(w io.Writer) {
+	if scoreStats == nil {
+		return
+	}
+	type entry struct {
+		name         string
+		count, delta int64
+	}
+	var entries []entry
+	for typ := range adjValues {
+		i := bits.TrailingZeros64(uint64(typ))
+		count := scoreStats.counts[i].Load()
+		if count == 0 {
+			continue
+		}
+		entries = append(entries, entry{
+			name:  typ.String(),
+			count: count,
+			delta: scoreStats.deltas[i].Load(),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].name < entries[j].name
+	})
+	fmt.Fprintf(w, "inlining score adjustments:\n")
+	for _, e := range entries {
+		fmt.Fprintf(w, "  %-20s count %d delta %d\n", e.name, e.count, e.delta)
+	}
+}
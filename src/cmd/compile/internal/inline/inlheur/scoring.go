@@ -0,0 +1,1019 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"fmt"
+	"go/constant"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// This file contains code to compute a "score" for a function based
+// on its properties, for use by the inliner when deciding whether
+// calls to the function should be inlined. Scores follow the same
+// convention as inline costs: the starting point is the inline cost
+// of the function, and each applicable heuristic adjusts the score
+// up (making inlining less likely) or down (making it more likely).
+
+// scoreAdjustTyp enumerates the various score adjustments that may be
+// applied by the heuristics. Each adjustment type is a single bit, so
+// that a set of applied adjustments can be recorded as a mask.
+type scoreAdjustTyp uint64
+
+const (
+	// Function is a CAS retry loop; inlining it replicates both the
+	// loop and the (relatively expensive) atomic operations into the
+	// caller, which may itself be looping.
+	casLoopAdj scoreAdjustTyp = 1 << iota
+	// Function is a simple forwarding wrapper; inlining it removes
+	// a call while adding very little code.
+	wrapperAdj
+	// Call site is in tail position (its results are returned
+	// directly by the caller), and the callee is not itself tail
+	// recursive. Inlining such a call doesn't extend the lifetime of
+	// any of the caller's state, and the caller's return sequence
+	// can be shared with the callee's.
+	tailCallAdj
+	// Function is a thin wrapper around a system call; its cost
+	// is dominated by the system call itself, so there is little
+	// to be gained from inlining it.
+	syscallWrapperAdj
+	// Call site result feeds the length or capacity of a "make",
+	// and the callee always returns the same constant; inlining
+	// lets the compiler see the constant size, which may allow the
+	// allocation to be done on the stack.
+	makeSizeConstAdj
+	// Function just builds and returns a fixed-size array; inlining
+	// lets the array be constructed in place in the caller.
+	arrayCtorAdj
+	// Function just performs a single byte order conversion via
+	// encoding/binary; once inlined, the conversion typically
+	// collapses into a single (possibly byte-swapped) load or store.
+	endianConvAdj
+	// Call site is in a range loop over a slice that is passed to
+	// the callee, and the callee indexes that slice; inlining lets
+	// the caller hoist (or eliminate) the bounds checks.
+	rangeBoundsCheckAdj
+	// Function is a printf-style formatting wrapper whose format
+	// string is constant at the call site; inlining exposes the
+	// constant format to vet-style checking and specialization.
+	formatConstAdj
+	// Function is a printf-style formatting wrapper whose format
+	// string is not constant at the call site; there is little to
+	// gain from inlining it, as the formatting (and allocation)
+	// dominates.
+	formatNonConstAdj
+	// Function makes calls with many arguments; each argument has
+	// to be materialized in the inlined body, so the inline cost
+	// tends to understate the true growth in code size.
+	wideCallsAdj
+	// Call site passes a small integer constant for a param that
+	// feeds a loop bound in the callee; once inlined, the loop may
+	// be unrolled or eliminated entirely.
+	loopBoundConstAdj
+	// Call site is hot according to the PGO profile supplied to the
+	// compiler (see SetProfileHotness).
+	hotCallSiteAdj
+	// Callee was never seen to be called in the PGO profile supplied
+	// to the compiler, so inlining it is unlikely to pay off.
+	coldCalleeAdj
+	// Call site passes a value of concrete type for an interface
+	// param that is the receiver of a method call at the top level
+	// of the callee; once inlined, the call can be devirtualized.
+	passConcreteToItfCallAdj
+	// As above, but the method call in the callee is nested within
+	// some control construct, so it may not execute.
+	passConcreteToNestedItfCallAdj
+	// Call site passes a function literal (or a named function, method
+	// expression or method value) for a func-typed param that is called
+	// at the top level of the callee; once inlined, the indirect call
+	// becomes a direct one, which can in turn be inlined.
+	passFuncToIndirectCallAdj
+	// As above, but the call in the callee is nested within some
+	// control construct, so it may not execute.
+	passFuncToNestedIndirectCallAdj
+	// Call site result feeds an "if" or "switch" condition, and the
+	// callee always returns the same constant or package-level
+	// variable; once inlined, the condition can often be folded.
+	resultFeedsCondAdj
+	// Call site result doesn't escape the caller, and the callee
+	// unconditionally allocates memory; once inlined, escape
+	// analysis may be able to place the allocation on the stack.
+	allocNoEscapeAdj
+	// Call site is on a cold path that runs at most once (package
+	// initialization, sync.Once, flag setup); inlining there wastes
+	// budget that is better spent on hot code.
+	coldCallSiteAdj
+	// Every result of the callee is discarded by all of its callers;
+	// once inlined, dead code elimination can delete the work done
+	// to compute the results.
+	deadResultAdj
+	// Function is a leaf (it makes no calls); inlining it removes
+	// the only call involved, and the inlined body can be optimized
+	// together with the caller without pulling in anything else.
+	// This also applies to nearly leaf functions (see
+	// FuncPropNearlyLeaf).
+	leafAdj
+	// Call site passes a constant, or a value that has to be
+	// computed, for a param that the callee never reads; once
+	// inlined, the argument need not be materialized, and its
+	// computation may be eliminated as dead code.
+	deadArgAdj
+	// Call site is in a loop, and the callee uses concurrency
+	// constructs (goroutines, channels, select or mutexes); these
+	// don't benefit from being inlined, and replicating them into
+	// loop bodies tends to bloat the hottest code.
+	concurrencyInLoopAdj
+	// Call site passes a constant string for a param that feeds
+	// string concatenation or formatting in a callee dominated by
+	// such work; once inlined, much of it can be constant folded.
+	constConcatArgAdj
+	// Call site passes a constant for a param that feeds a simple
+	// "if" or "switch" condition at the top level of the callee;
+	// once inlined, the condition can be folded and the branches
+	// not taken eliminated.
+	passConstToIfAdj
+	// As above, but the "if" or "switch" in the callee is nested
+	// within some other control construct, so it may not execute.
+	passConstToNestedIfAdj
+	// Function is a trivial forwarding wrapper (this replaces
+	// wrapperAdj); the bonus is large enough to bring the score of
+	// any function that is inlinable at all (whose cost is within the
+	// inline budget, 80 by default; see SetInlineBudget) down to zero
+	// or less.
+	trivialWrapperAdj
+	// Call site passes a value of concrete type (converted to
+	// interface) for a param that is the operand of a type assertion
+	// or type switch in the callee; once inlined, the assertion can
+	// be resolved statically.
+	passConcreteToTypeAssertAdj
+	// Call site is on an error handling path or a path that ends in
+	// a panic (see CallSiteOnErrorPath and CallSiteOnPanicPath);
+	// such paths rarely run, so inlining there (typically of error
+	// formatting helpers) wastes budget better spent on hot code.
+	errorPathAdj
+	// Callee was never executed according to the coverage profile
+	// supplied to the compiler (see FuncPropCoverageCold).
+	coverageColdAdj
+	// Call site passes a constant for a param that is the divisor
+	// of an integer division or modulus, or the amount of a shift,
+	// in the callee; once inlined, the operation can be strength
+	// reduced.
+	passConstToDivAdj
+	// As above, but the constant is a power of two, so a division
+	// or modulus becomes a shift or mask (this replaces
+	// passConstToDivAdj).
+	passPow2ToDivAdj
+	// Call site checks an error result of the callee against nil,
+	// and the callee's error result is always nil (see
+	// ResultErrorAlwaysNil); once inlined, the check and the code
+	// it guards can be deleted.
+	nilErrorCheckAdj
+	// Callee is marked with "//go:inlinehint hot", "cold" or
+	// "prefer-inline" (see FuncPropHintHot, FuncPropHintCold and
+	// FuncPropHintPreferInline). These apply the author's judgement
+	// where the heuristics have nothing better to go on; only
+	// //go:noinline overrides the heuristics outright.
+	hintHotAdj
+	hintColdAdj
+	hintPreferInlineAdj
+	// Call site result doesn't escape the caller, and the callee
+	// always returns freshly allocated memory that it doesn't leak
+	// (see ResultFreshAlloc); once inlined, the allocation can be
+	// placed on the stack. This replaces allocNoEscapeAdj, which
+	// is based on weaker evidence.
+	freshAllocAdj
+	// Call site is within an event loop (see CallSiteInEventLoop),
+	// and not on an error, panic or cold path; such calls are
+	// likely to be made over and over, so the call overhead adds up.
+	eventLoopAdj
+	// Call site passes constants for all of its arguments (see
+	// CallSiteAllArgsConst), and the callee has params that feed an
+	// "if" or "switch" condition, or a division or shift. This comes
+	// on top of passConstToIfAdj and the like: with every argument
+	// known, all such conditions and operations can be folded once
+	// inlined, rather than just the one that earned the adjustment.
+	allArgsConstAdj
+	// Callee does low-level pointer arithmetic, reflection or
+	// //go:linkname trickery (see FuncPropLowLevel); the compiler
+	// can't see through such code, so inlining it seldom unlocks
+	// further optimization.
+	lowLevelAdj
+	// Call site passes a function literal for a yield param that
+	// the callee calls in a loop (see ParamYieldInLoop); once both
+	// are inlined, a chain of iterators can be flattened into a
+	// plain loop. This replaces passFuncToIndirectCallAdj and
+	// passFuncToNestedIndirectCallAdj.
+	passFuncLitToYieldAdj
+)
+
+// wideCallArgs is the number of arguments at or above which a call
+// is considered "wide" for the purposes of wideCallsAdj.
+const wideCallArgs = 6
+
+// smallLoopBound is the largest constant loop bound considered small
+// for the purposes of loopBoundConstAdj.
+const smallLoopBound = 8
+
+// adjValues holds the default value for each score adjustment type.
+var adjValues = map[scoreAdjustTyp]int{
+	casLoopAdj:          15,
+	wrapperAdj:          -20,
+	tailCallAdj:         -5,
+	syscallWrapperAdj:   10,
+	makeSizeConstAdj:    -15,
+	arrayCtorAdj:        -10,
+	endianConvAdj:       -25,
+	rangeBoundsCheckAdj: -20,
+	formatConstAdj:      -10,
+	formatNonConstAdj:   10,
+	wideCallsAdj:        10,
+	loopBoundConstAdj:   -15,
+	hotCallSiteAdj:      -40,
+	coldCalleeAdj:       20,
+
+	passConcreteToItfCallAdj:       -20,
+	passConcreteToNestedItfCallAdj: -10,
+
+	passFuncToIndirectCallAdj:       -20,
+	passFuncToNestedIndirectCallAdj: -10,
+
+	resultFeedsCondAdj: -15,
+
+	allocNoEscapeAdj: -25,
+	coldCallSiteAdj:  25,
+
+	deadResultAdj: -20,
+
+	leafAdj: -10,
+
+	deadArgAdj: -10,
+
+	concurrencyInLoopAdj: 15,
+
+	constConcatArgAdj: -15,
+
+	passConstToIfAdj:       -20,
+	passConstToNestedIfAdj: -15,
+
+	trivialWrapperAdj: -80,
+
+	passConcreteToTypeAssertAdj: -15,
+
+	errorPathAdj: 20,
+
+	coverageColdAdj: 20,
+
+	passConstToDivAdj: -10,
+	passPow2ToDivAdj:  -20,
+
+	nilErrorCheckAdj: -25,
+
+	hintHotAdj:          -20,
+	hintColdAdj:         30,
+	hintPreferInlineAdj: -40,
+
+	freshAllocAdj: -35,
+
+	eventLoopAdj: -15,
+
+	allArgsConstAdj: -10,
+
+	lowLevelAdj: 10,
+
+	passFuncLitToYieldAdj: -30,
+}
+
+func adjValue(x scoreAdjustTyp) int {
+	if val, ok := adjValues[x]; ok {
+		return val
+	} else {
+		panic("internal error unregistered adjustment type")
+	}
+}
+
+// SetInlineBudget informs the heuristics that the inline budget for
+// the package being compiled is 'budget' rather than the default
+// (as with the -d=inlbudget flag), scaling the adjustments that are
+// sized relative to the budget accordingly. It should be called
+// before SetScoreAdjustments, so that explicit overrides win.
+func SetInlineBudget(budget int) {
+	adjValues[trivialWrapperAdj] = -budget
+}
+
+// SetScoreAdjustments overrides the values of the score adjustments
+// named in 'spec', a slash-separated list of name:value pairs (as
+// given by the -d=inlscoreadj flag; commas can't be used, since they
+// separate -d options). An adjustment is named by its scoreAdjustTyp
+// constant, without the "Adj" suffix and ignoring case; for example
+// "wrapper:-30/tailcall:0" makes the bonus for wrapper functions
+// larger and disables the one for tail calls.
+func SetScoreAdjustments(spec string) {
+	if err := parseScoreAdjustments(spec); err != nil {
+		base.Fatalf("invalid -d=inlscoreadj=%s: %v", spec, err)
+	}
+}
+
+// parseScoreAdjustments is a helper for SetScoreAdjustments. The
+// adjustments in 'spec' are applied only if they are all valid.
+func parseScoreAdjustments(spec string) error {
+	byName := make(map[string]scoreAdjustTyp, len(adjValues))
+	for typ := range adjValues {
+		byName[adjName(typ.String())] = typ
+	}
+	vals := make(map[scoreAdjustTyp]int)
+	for _, item := range strings.Split(spec, "/") {
+		name, val, ok := strings.Cut(item, ":")
+		if !ok {
+			return fmt.Errorf("malformed item %q, want name:value", item)
+		}
+		typ, ok := byName[adjName(name)]
+		if !ok {
+			return fmt.Errorf("unknown score adjustment %q", name)
+		}
+		v, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("bad value for score adjustment %q: %v", name, err)
+		}
+		vals[typ] = v
+	}
+	for typ, v := range vals {
+		adjValues[typ] = v
+	}
+	return nil
+}
+
+// adjName returns the canonical form of the score adjustment name
+// 'name' (lower case, with no "adj" suffix).
+func adjName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), "adj")
+}
+
+// adjustScore applies the adjustment 'typ' to 'score', returning the
+// new score along with an updated mask of the adjustments applied so
+// far. A given adjustment is applied at most once.
+func adjustScore(typ scoreAdjustTyp, score int, mask scoreAdjustTyp) (int, scoreAdjustTyp) {
+	if mask&typ != 0 {
+		return score, mask
+	}
+	val := adjValue(typ)
+	if scoreStats != nil {
+		scoreStats.record(typ, val)
+	}
+	return score + val, mask | typ
+}
+
+// computeFuncScore computes a score for a function with properties
+// 'fp' and inline cost 'cost', returning the score and a mask of the
+// adjustments that were applied.
+func computeFuncScore(fp *FuncProps, cost int) (int, scoreAdjustTyp) {
+	score := cost
+	var mask scoreAdjustTyp
+	if fp.Flags&FuncPropCASLoop != 0 {
+		score, mask = adjustScore(casLoopAdj, score, mask)
+	}
+	if fp.Flags&FuncPropTrivialWrapper != 0 {
+		score, mask = adjustScore(trivialWrapperAdj, score, mask)
+	} else if fp.Flags&FuncPropIsWrapper != 0 {
+		score, mask = adjustScore(wrapperAdj, score, mask)
+	}
+	if fp.Flags&FuncPropSyscallWrapper != 0 {
+		score, mask = adjustScore(syscallWrapperAdj, score, mask)
+	}
+	if fp.Flags&FuncPropArrayConstructor != 0 {
+		score, mask = adjustScore(arrayCtorAdj, score, mask)
+	}
+	if fp.Flags&FuncPropEndianConv != 0 {
+		score, mask = adjustScore(endianConvAdj, score, mask)
+	}
+	if fp.Flags&(FuncPropIsLeaf|FuncPropNearlyLeaf) != 0 {
+		score, mask = adjustScore(leafAdj, score, mask)
+	}
+	if fp.MaxCallArgs >= wideCallArgs {
+		score, mask = adjustScore(wideCallsAdj, score, mask)
+	}
+	if fp.Flags&FuncPropLowLevel != 0 {
+		score, mask = adjustScore(lowLevelAdj, score, mask)
+	}
+	if fp.Flags&FuncPropCoverageCold != 0 {
+		score, mask = adjustScore(coverageColdAdj, score, mask)
+	}
+	if fp.Flags&FuncPropHintHot != 0 {
+		score, mask = adjustScore(hintHotAdj, score, mask)
+	}
+	if fp.Flags&FuncPropHintCold != 0 {
+		score, mask = adjustScore(hintColdAdj, score, mask)
+	}
+	if fp.Flags&FuncPropHintPreferInline != 0 {
+		score, mask = adjustScore(hintPreferInlineAdj, score, mask)
+	}
+	return score, mask
+}
+
+// computeCallSiteScore applies call-site-specific adjustments to
+// 'score' (previously computed by computeFuncScore for the callee,
+// whose properties are 'fp') for the call site 'cs', returning the
+// new score and updated adjustment mask. Here 'fp' may be nil if the
+// callee's properties are not known.
+func computeCallSiteScore(cs *CallSite, fp *FuncProps, score int, mask scoreAdjustTyp) (int, scoreAdjustTyp) {
+	csflags := cs.Flags
+	if csflags&CallSiteTailPos != 0 {
+		// If the callee is tail recursive, inlining it only peels
+		// off the first level of the recursion, so we don't apply
+		// the adjustment in that case.
+		if fp == nil || fp.Flags&FuncPropTailRecursive == 0 {
+			score, mask = adjustScore(tailCallAdj, score, mask)
+		}
+	}
+	if csflags&CallSiteFeedsMakeSize != 0 && fp != nil &&
+		len(fp.ResultFlags) != 0 &&
+		fp.ResultFlags[0]&ResultAlwaysSameConstant != 0 {
+		score, mask = adjustScore(makeSizeConstAdj, score, mask)
+	}
+	if csflags&CallSiteResultFeedsCond != 0 && fp != nil &&
+		len(fp.ResultFlags) == 1 &&
+		fp.ResultFlags[0]&(ResultAlwaysSameConstant|ResultAlwaysSameGlobal) != 0 {
+		score, mask = adjustScore(resultFeedsCondAdj, score, mask)
+	}
+	if csflags&CallSiteErrorChecked != 0 && fp != nil && hasNilErrorResult(fp) {
+		score, mask = adjustScore(nilErrorCheckAdj, score, mask)
+	}
+	if csflags&CallSiteResultNoEscape != 0 && fp != nil {
+		if len(fp.ResultFlags) == 1 && fp.ResultFlags[0]&ResultFreshAlloc != 0 {
+			score, mask = adjustScore(freshAllocAdj, score, mask)
+		} else if fp.Flags&FuncPropAllocates != 0 {
+			score, mask = adjustScore(allocNoEscapeAdj, score, mask)
+		}
+	}
+	if csflags&CallSiteCold != 0 {
+		score, mask = adjustScore(coldCallSiteAdj, score, mask)
+	}
+	if csflags&(CallSiteOnErrorPath|CallSiteOnPanicPath) != 0 {
+		score, mask = adjustScore(errorPathAdj, score, mask)
+	}
+	if csflags&CallSiteInEventLoop != 0 &&
+		csflags&(CallSiteOnErrorPath|CallSiteOnPanicPath|CallSiteCold) == 0 {
+		score, mask = adjustScore(eventLoopAdj, score, mask)
+	}
+	if csflags&CallSiteInLoop != 0 && fp != nil &&
+		fp.Flags&concurrencyFlags != 0 {
+		score, mask = adjustScore(concurrencyInLoopAdj, score, mask)
+	}
+	if fp != nil && allResultsDiscarded(fp) {
+		score, mask = adjustScore(deadResultAdj, score, mask)
+	}
+	if fp != nil && fp.Flags&FuncPropFormatWrapper != 0 {
+		if formatIsConst(cs, fp) {
+			score, mask = adjustScore(formatConstAdj, score, mask)
+		} else {
+			score, mask = adjustScore(formatNonConstAdj, score, mask)
+		}
+	}
+	if csflags&CallSiteInRangeOverArg != 0 && fp != nil {
+		for i, pf := range fp.ParamFlags {
+			if i < 64 && cs.RangedArgs&(1<<i) != 0 &&
+				pf&ParamFeedsBoundsCheck != 0 {
+				score, mask = adjustScore(rangeBoundsCheckAdj, score, mask)
+				break
+			}
+		}
+	}
+	if fp != nil && passesDeadArg(cs, fp) {
+		score, mask = adjustScore(deadArgAdj, score, mask)
+	}
+	if fp != nil && smallConstLoopBound(cs, fp) {
+		score, mask = adjustScore(loopBoundConstAdj, score, mask)
+	}
+	if fp != nil && constConcatArg(cs, fp) {
+		score, mask = adjustScore(constConcatArgAdj, score, mask)
+	}
+	if fp != nil {
+		top, nested := concreteToItfCall(cs, fp)
+		if top {
+			score, mask = adjustScore(passConcreteToItfCallAdj, score, mask)
+		} else if nested {
+			score, mask = adjustScore(passConcreteToNestedItfCallAdj, score, mask)
+		}
+		top, nested = funcToIndirectCall(cs, fp)
+		if funcLitToYield(cs, fp) {
+			score, mask = adjustScore(passFuncLitToYieldAdj, score, mask)
+		} else if top {
+			score, mask = adjustScore(passFuncToIndirectCallAdj, score, mask)
+		} else if nested {
+			score, mask = adjustScore(passFuncToNestedIndirectCallAdj, score, mask)
+		}
+		if concreteToTypeAssert(cs, fp) {
+			score, mask = adjustScore(passConcreteToTypeAssertAdj, score, mask)
+		}
+		if pow2, ok := constToDivOrShift(cs, fp); pow2 {
+			score, mask = adjustScore(passPow2ToDivAdj, score, mask)
+		} else if ok {
+			score, mask = adjustScore(passConstToDivAdj, score, mask)
+		}
+		top, nested = constToIfOrSwitch(cs, fp)
+		if top {
+			score, mask = adjustScore(passConstToIfAdj, score, mask)
+		} else if nested {
+			score, mask = adjustScore(passConstToNestedIfAdj, score, mask)
+		}
+		if csflags&CallSiteAllArgsConst != 0 && hasFoldableParams(fp) {
+			score, mask = adjustScore(allArgsConstAdj, score, mask)
+		}
+	}
+	return score, mask
+}
+
+// hasFoldableParams reports whether any of the params of the function
+// whose properties are 'fp' feeds an "if" or "switch" condition, or a
+// division or shift, such that passing a constant for it allows
+// the condition or operation to be folded (see allArgsConstAdj).
+func hasFoldableParams(fp *FuncProps) bool {
+	for _, pf := range fp.ParamFlags {
+		if pf&(ParamFeedsIfOrSwitch|ParamMayFeedIfOrSwitch|ParamFeedsDivOrShift) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// hasNilErrorResult reports whether any of the results of the
+// function whose properties are 'fp' is an error that is always nil.
+func hasNilErrorResult(fp *FuncProps) bool {
+	for _, rf := range fp.ResultFlags {
+		if rf&ResultErrorAlwaysNil != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// concreteToItfCall reports whether call site 'cs' passes a value of
+// concrete type (converted to interface) for a param that feeds an
+// interface method call in the callee, whose properties are 'fp'.
+// The first result is set if the method call is at the top level of
+// the callee, the second if it is nested.
+func concreteToItfCall(cs *CallSite, fp *FuncProps) (top, nested bool) {
+	if cs.Call == nil {
+		return false, false
+	}
+	for i, pf := range fp.ParamFlags {
+		if pf&(ParamFeedsInterfaceMethodCall|ParamMayFeedInterfaceMethodCall) == 0 {
+			continue
+		}
+		j := i - argSlotOffset(cs.Call)
+		if j < 0 || j >= len(cs.Call.Args) || !isConcreteConvIface(cs.Call.Args[j]) {
+			continue
+		}
+		if pf&ParamFeedsInterfaceMethodCall != 0 {
+			top = true
+		} else {
+			nested = true
+		}
+	}
+	return top, nested
+}
+
+// funcToIndirectCall reports whether call site 'cs' passes a func
+// value with a statically known target (see isStaticFuncValue) for a
+// param that is called in the callee, whose properties are 'fp'. The
+// first result is set if the call is at the top level of the callee,
+// the second if it is nested.
+func funcToIndirectCall(cs *CallSite, fp *FuncProps) (top, nested bool) {
+	if cs.Call == nil {
+		return false, false
+	}
+	for i, pf := range fp.ParamFlags {
+		if pf&(ParamFeedsIndirectCall|ParamMayFeedIndirectCall) == 0 {
+			continue
+		}
+		j := i - argSlotOffset(cs.Call)
+		if j < 0 || j >= len(cs.Call.Args) {
+			continue
+		}
+		if !isStaticFuncValue(cs.Call.Args[j]) {
+			continue
+		}
+		if pf&ParamFeedsIndirectCall != 0 {
+			top = true
+		} else {
+			nested = true
+		}
+	}
+	return top, nested
+}
+
+// funcLitToYield reports whether call site 'cs' passes a function
+// literal for a yield param that is called in a loop in the callee,
+// whose properties are 'fp' (see ParamYieldInLoop).
+func funcLitToYield(cs *CallSite, fp *FuncProps) bool {
+	if cs.Flags&CallSitePassesFuncLit == 0 || cs.Call == nil {
+		return false
+	}
+	for i, pf := range fp.ParamFlags {
+		if pf&ParamYieldInLoop == 0 {
+			continue
+		}
+		j := i - argSlotOffset(cs.Call)
+		if j >= 0 && j < len(cs.Call.Args) && cs.Call.Args[j].Op() == ir.OCLOSURE {
+			return true
+		}
+	}
+	return false
+}
+
+// isStaticFuncValue reports whether 'n' is a func value whose target
+// is known statically: a named function, a function literal, a
+// method expression "T.M", or a method value "x.M" (see
+// methodValueName).
+func isStaticFuncValue(n ir.Node) bool {
+	if _, ok, _ := isFuncName(n); ok {
+		return true
+	}
+	sv := ir.StaticValue(n)
+	return sv.Op() == ir.OMETHVALUE && methodValueName(sv.(*ir.SelectorExpr)) != nil
+}
+
+// concreteToTypeAssert reports whether call site 'cs' passes a value
+// of concrete type (converted to interface) for a param that feeds a
+// type assertion or type switch in the callee, whose properties are
+// 'fp'.
+func concreteToTypeAssert(cs *CallSite, fp *FuncProps) bool {
+	if cs.Call == nil {
+		return false
+	}
+	for i, pf := range fp.ParamFlags {
+		if pf&ParamFeedsTypeAssert == 0 {
+			continue
+		}
+		j := i - argSlotOffset(cs.Call)
+		if j >= 0 && j < len(cs.Call.Args) && isConcreteConvIface(cs.Call.Args[j]) {
+			return true
+		}
+	}
+	return false
+}
+
+// constToDivOrShift reports whether call site 'cs' passes a constant
+// for a param that feeds a division, modulus or shift in the callee,
+// whose properties are 'fp'. The first result is set if one such
+// constant is a positive power of two.
+func constToDivOrShift(cs *CallSite, fp *FuncProps) (pow2, ok bool) {
+	for i, pf := range fp.ParamFlags {
+		if pf&ParamFeedsDivOrShift == 0 || i >= 64 || cs.ConstArgs&(1<<i) == 0 {
+			continue
+		}
+		ok = true
+		if cs.Call == nil {
+			continue
+		}
+		j := i - argSlotOffset(cs.Call)
+		if j < 0 || j >= len(cs.Call.Args) {
+			continue
+		}
+		arg := cs.Call.Args[j]
+		for arg.Op() == ir.OCONVNOP || arg.Op() == ir.OCONV {
+			arg = arg.(*ir.ConvExpr).X
+		}
+		if !ir.IsConst(arg, constant.Int) {
+			continue
+		}
+		if v, exact := constant.Uint64Val(arg.Val()); exact && v != 0 && v&(v-1) == 0 {
+			pow2 = true
+		}
+	}
+	return pow2, ok
+}
+
+// constToIfOrSwitch reports whether call site 'cs' passes a constant
+// for a param that feeds an "if" or "switch" condition in the callee,
+// whose properties are 'fp'. The first result is set if the condition
+// is at the top level of the callee, the second if it is nested.
+func constToIfOrSwitch(cs *CallSite, fp *FuncProps) (top, nested bool) {
+	for i, pf := range fp.ParamFlags {
+		if i >= 64 || cs.ConstArgs&(1<<i) == 0 {
+			continue
+		}
+		if pf&ParamFeedsIfOrSwitch != 0 {
+			top = true
+		} else if pf&ParamMayFeedIfOrSwitch != 0 {
+			nested = true
+		}
+	}
+	return top, nested
+}
+
+// allResultsDiscarded reports whether the callee whose properties are
+// 'fp' has results, and all of them are discarded by its callers.
+func allResultsDiscarded(fp *FuncProps) bool {
+	if len(fp.ResultFlags) == 0 {
+		return false
+	}
+	for _, rf := range fp.ResultFlags {
+		if rf&ResultDiscardedByCallers == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// passesDeadArg reports whether call site 'cs' passes a constant, or
+// an expression other than a simple variable reference, for a param
+// that the callee (whose properties are 'fp') never reads.
+func passesDeadArg(cs *CallSite, fp *FuncProps) bool {
+	if cs.Call == nil {
+		return false
+	}
+	for i, pf := range fp.ParamFlags {
+		if pf&ParamNeverRead == 0 {
+			continue
+		}
+		if i < 64 && cs.ConstArgs&(1<<i) != 0 {
+			return true
+		}
+		j := i - argSlotOffset(cs.Call)
+		if j < 0 || j >= len(cs.Call.Args) {
+			continue
+		}
+		arg := cs.Call.Args[j]
+		for arg.Op() == ir.OCONVNOP || arg.Op() == ir.OCONV {
+			arg = arg.(*ir.ConvExpr).X
+		}
+		if arg.Op() != ir.ONAME && arg.Op() != ir.ONIL {
+			return true
+		}
+	}
+	return false
+}
+
+// smallConstLoopBound reports whether call site 'cs' passes a small
+// non-negative integer constant (at most smallLoopBound) for a param
+// that feeds a loop bound in the callee, whose properties are 'fp'.
+func smallConstLoopBound(cs *CallSite, fp *FuncProps) bool {
+	for i, pf := range fp.ParamFlags {
+		if pf&ParamFeedsLoopBound == 0 || i >= 64 ||
+			cs.ConstArgs&(1<<i) == 0 || cs.Call == nil {
+			continue
+		}
+		j := i - argSlotOffset(cs.Call)
+		if j < 0 || j >= len(cs.Call.Args) {
+			continue
+		}
+		arg := cs.Call.Args[j]
+		for arg.Op() == ir.OCONVNOP || arg.Op() == ir.OCONV {
+			arg = arg.(*ir.ConvExpr).X
+		}
+		if !ir.IsConst(arg, constant.Int) {
+			continue
+		}
+		if v, ok := constant.Int64Val(arg.Val()); ok && v >= 0 && v <= smallLoopBound {
+			return true
+		}
+	}
+	return false
+}
+
+// constConcatArg reports whether call site 'cs' passes a constant
+// string for a param that feeds string concatenation or formatting
+// in the callee (whose properties are 'fp'), where the callee's cost
+// is dominated by such work. Format wrappers are left to
+// formatConstAdj and formatNonConstAdj.
+func constConcatArg(cs *CallSite, fp *FuncProps) bool {
+	if fp.Flags&FuncPropConcatDominated == 0 ||
+		fp.Flags&FuncPropFormatWrapper != 0 || cs.Call == nil {
+		return false
+	}
+	for i, pf := range fp.ParamFlags {
+		if pf&ParamFeedsStringConcat == 0 || i >= 64 ||
+			cs.ConstArgs&(1<<i) == 0 {
+			continue
+		}
+		j := i - argSlotOffset(cs.Call)
+		if j < 0 || j >= len(cs.Call.Args) {
+			continue
+		}
+		arg := cs.Call.Args[j]
+		for arg.Op() == ir.OCONVNOP || arg.Op() == ir.OCONV {
+			arg = arg.(*ir.ConvExpr).X
+		}
+		if ir.IsConst(arg, constant.String) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatIsConst reports whether the format string passed by the
+// format wrapper with properties 'fp' is constant for call site 'cs',
+// which is the case if the wrapper doesn't forward its format string
+// from a param (and so must use a constant one), or if every param it
+// forwards is passed a constant at 'cs'.
+func formatIsConst(cs *CallSite, fp *FuncProps) bool {
+	for i, pf := range fp.ParamFlags {
+		if pf&ParamFeedsFormatString == 0 {
+			continue
+		}
+		if i >= 64 || cs.ConstArgs&(1<<i) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// FuncScoreAdjustments returns the heuristics-adjusted inlining cost
+// for function 'fn' given its unadjusted inline cost 'cost', along
+// with the adjustments applied, each in the form "name:+N" as in the
+// -d=dumpinlcallsitescores table. If the policy hook (see
+// SetInlinePolicyHook) changed the score, the last entry is
+// "policy". The last return value is false if no properties were
+// recorded for 'fn' (for example if it was not analyzed via
+// AnalyzeFunc, and none were found in the export data), in which case
+// the original cost is returned. The original cost is also returned
+// if 'fn' is not selected by the -d=inlscorehash flag (see
+// scoreHashMatch).
+func FuncScoreAdjustments(fn *ir.Func, cost int32) (int32, []string, bool) {
+	fp := propsForFunc(fn)
+	if fp == nil {
+		return cost, nil, false
+	}
+	if !scoreHashMatch(fn) {
+		return cost, nil, true
+	}
+	score, mask := computeFuncScore(fp, int(cost))
+	adjs := maskAdjustments(mask)
+	if s := applyPolicyHook(fn, fp, score); s != score {
+		score = s
+		adjs = append(adjs, "policy")
+	}
+	if debugTrace&debugTraceScoring != 0 {
+		traceEvent("score", "func", fn.Sym().Name, "cost", cost,
+			"score", score, "adjustments", mask)
+	}
+	return int32(score), adjs, true
+}
+
+// GetCallSiteScore returns the heuristics-adjusted inlining cost for
+// the call 'call' from 'caller' to 'callee', given the callee's
+// unadjusted inline cost 'cost'. This includes both the adjustments
+// from FuncScoreAdjustments and those specific to the call site. The
+// second return value is false if neither the callee's properties nor
+// the call site could be located, in which case the original cost is
+// returned. The original cost is also returned if 'caller' is not
+// selected by the -d=inlscorehash flag (see scoreHashMatch).
+func GetCallSiteScore(caller *ir.Func, call *ir.CallExpr, callee *ir.Func, cost int32) (int32, bool) {
+	fp := propsForFunc(callee)
+	if !scoreHashMatch(caller) {
+		return cost, fp != nil
+	}
+	score, mask := int(cost), scoreAdjustTyp(0)
+	if fp != nil {
+		score, mask = computeFuncScore(fp, score)
+	}
+	cs := lookupCallSite(caller, call)
+	if cs == nil {
+		return int32(score), fp != nil
+	}
+	score, mask = computeCallSiteScore(cs, fp, score, mask)
+	if profileHotness != nil {
+		hot, cold := profileHotness(caller, call, callee)
+		score, mask = computeProfileScore(hot, cold, score, mask)
+	}
+	if fp != nil {
+		score = applyPolicyHook(callee, fp, score)
+	}
+	cs.Score, cs.ScoreMask = score, mask
+	if callSiteScores != nil {
+		recordCallSiteScore(caller, cs, int(cost), score, mask)
+	}
+	logCallSiteScore(caller, cs, cost, score, mask)
+	if debugTrace&debugTraceScoring != 0 {
+		traceEvent("score", "func", callee.Sym().Name, "caller", caller.Sym().Name,
+			"cost", cost, "score", score, "adjustments", mask)
+	}
+	return int32(score), true
+}
+
+// scoreHashMatch reports whether score adjustments should be applied
+// when scoring function 'fn' (for FuncScoreAdjustments) or the calls
+// made by 'fn' (for GetCallSiteScore). This is always the case unless
+// the -d=inlscorehash flag is in use, in which case only the functions
+// selected by the hash are adjusted, so that a regression caused by
+// the heuristics can be bisected down to a single function.
+func scoreHashMatch(fn *ir.Func) bool {
+	return base.InlScoreHash.MatchPkgFunc(base.Ctxt.Pkgpath, ir.FuncName(fn),
+		func() string { return "inline heuristic score adjustments" })
+}
+
+// CallSiteAdjustments returns a description of the score adjustments
+// that were applied when GetCallSiteScore last computed a score for
+// the call 'call' in 'caller', or an empty string if there were none
+// (or no score was computed).
+func CallSiteAdjustments(caller *ir.Func, call *ir.CallExpr) string {
+	cs := lookupCallSite(caller, call)
+	if cs == nil || cs.ScoreMask == 0 {
+		return ""
+	}
+	return cs.ScoreMask.String()
+}
+
+// computeProfileScore applies the adjustments for a call site that
+// the PGO profile says is hot or cold to 'score', returning the new
+// score and updated adjustment mask.
+func computeProfileScore(hot, cold bool, score int, mask scoreAdjustTyp) (int, scoreAdjustTyp) {
+	if hot {
+		score, mask = adjustScore(hotCallSiteAdj, score, mask)
+	} else if cold {
+		score, mask = adjustScore(coldCalleeAdj, score, mask)
+	}
+	return score, mask
+}
+
+// profileHotness, if non-nil, reports the hotness of a call site
+// according to the PGO profile supplied to the compiler. See
+// SetProfileHotness.
+var profileHotness func(caller *ir.Func, call *ir.CallExpr, callee *ir.Func) (hot, cold bool)
+
+// SetProfileHotness installs 'hotness' as a callback to be consulted
+// by GetCallSiteScore when a PGO profile is in use. The callback
+// reports whether the call 'call' from 'caller' to 'callee' is hot
+// according to the profile, or alternatively whether it is cold (for
+// example, because the callee never appears in the profile); hot call
+// sites get a score boost, and cold ones a penalty. The default sizes
+// of these adjustments may be overridden with the -d=inlhotcallsiteadj
+// and -d=inlcoldcalleeadj flags.
+func SetProfileHotness(hotness func(caller *ir.Func, call *ir.CallExpr, callee *ir.Func) (hot, cold bool)) {
+	profileHotness = hotness
+	if v := base.Debug.InlHotCallSiteAdj; v != 0 {
+		adjValues[hotCallSiteAdj] = v
+	}
+	if v := base.Debug.InlColdCalleeAdj; v != 0 {
+		adjValues[coldCalleeAdj] = v
+	}
+}
+
+// policyHook, if non-nil, is consulted to override the scores
+// computed for functions. See SetInlinePolicyHook.
+var policyHook func(fn *ir.Func, props *FuncProps, defaultScore int) (int, bool)
+
+// SetInlinePolicyHook installs 'hook' as a callback to be consulted
+// whenever a score has been computed for a function whose properties
+// are known (by FuncScoreAdjustments or GetCallSiteScore), for use in
+// experimenting with inlining policies. The hook is passed the
+// function, its properties, and the score computed by the default
+// heuristics; if it returns true, the score it returns is used in
+// place of the default one. Scores returned by the hook are clamped
+// to the range [0, math.MaxInt32]. Passing nil removes any previously
+// installed hook.
+func SetInlinePolicyHook(hook func(fn *ir.Func, props *FuncProps, defaultScore int) (score int, override bool)) {
+	policyHook = hook
+}
+
+// applyPolicyHook returns the result of consulting the policy hook
+// (if any) for function 'fn' with properties 'fp' and default score
+// 'score'.
+func applyPolicyHook(fn *ir.Func, fp *FuncProps, score int) int {
+	if policyHook == nil {
+		return score
+	}
+	hscore, override := policyHook(fn, fp, score)
+	if !override {
+		return score
+	}
+	switch {
+	case hscore < 0:
+		hscore = 0
+	case hscore > math.MaxInt32:
+		hscore = math.MaxInt32
+	}
+	if debugTrace&debugTraceScoring != 0 {
+		traceEvent("score", "func", fn.Sym().Name, "policyhook", true,
+			"defaultscore", score, "score", hscore)
+	}
+	return hscore
+}
+
+// callSiteTabs caches the call site tables computed for callers by
+// lookupCallSite.
+var callSiteTabs map[*ir.Func]CallSiteTab
+
+// lookupCallSite returns the CallSite for 'call' within 'caller',
+// computing the call site table for 'caller' on first use. The
+// table is built from the caller's body at that point, which means
+// that calls visited later on by the inliner will still be present
+// in their original form.
+func lookupCallSite(caller *ir.Func, call *ir.CallExpr) *CallSite {
+	if callSiteTabs == nil {
+		callSiteTabs = make(map[*ir.Func]CallSiteTab)
+	}
+	cstab, ok := callSiteTabs[caller]
+	if !ok {
+		cstab, _ = computeCallSiteTable(caller)
+		callSiteTabs[caller] = cstab
+	}
+	return cstab[call]
+}
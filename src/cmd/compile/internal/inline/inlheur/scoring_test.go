@@ -0,0 +1,900 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"bytes"
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/types"
+	"cmd/internal/obj"
+	"cmd/internal/src"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// scoreCase is a test case for checkScores: computeCallSiteScore for
+// call site 'cs', whose callee has properties 'fp', should produce
+// the score 'want' and the adjustment mask 'wmask'.
+type scoreCase struct {
+	what  string
+	cs    *CallSite
+	fp    *FuncProps
+	want  int
+	wmask scoreAdjustTyp
+}
+
+// checkScores runs computeCallSiteScore for each of 'cases', starting
+// from the score 'cost', and checks the resulting scores and masks.
+func checkScores(t *testing.T, cost int, cases []scoreCase) {
+	t.Helper()
+	for _, tc := range cases {
+		got, mask := computeCallSiteScore(tc.cs, tc.fp, cost, 0)
+		if got != tc.want || mask != tc.wmask {
+			t.Errorf("%s: got score %d mask %s, want score %d mask %s",
+				tc.what, got, mask, tc.want, tc.wmask)
+		}
+	}
+}
+
+// mkCallSite returns a call site with flags 'flags' for a direct call
+// with arguments 'args', adding the properties that depend only on
+// the arguments (ConstArgs, CallSiteAllArgsConst and
+// CallSitePassesFuncLit) as the call site analyzers would.
+func mkCallSite(flags CSPropBits, args ...ir.Node) *CallSite {
+	c := ir.NewCallExpr(src.NoXPos, ir.OCALLFUNC, ir.NewIdent(src.NoXPos, nil), args)
+	csp := CallSiteProps{Flags: flags}
+	(&constArgsAnalyzer{}).setResults(c, nil, &csp)
+	(&funcLitArgAnalyzer{}).setResults(c, nil, &csp)
+	return &CallSite{Call: c, Flags: csp.Flags, ConstArgs: csp.ConstArgs}
+}
+
+func TestTailCallScoring(t *testing.T) {
+	const cost = 60
+	tailrec := &FuncProps{Flags: FuncPropTailRecursive}
+	plain := &FuncProps{}
+	checkScores(t, cost, []scoreCase{
+		{"tail call", &CallSite{Flags: CallSiteTailPos}, plain, cost + adjValue(tailCallAdj), tailCallAdj},
+		{"tail call unknown callee", &CallSite{Flags: CallSiteTailPos}, nil, cost + adjValue(tailCallAdj), tailCallAdj},
+		{"tail call to tail recursive callee", &CallSite{Flags: CallSiteTailPos}, tailrec, cost, 0},
+		{"non-tail call", &CallSite{}, plain, cost, 0},
+	})
+}
+
+func TestMakeSizeScoring(t *testing.T) {
+	const cost = 40
+	constres := &FuncProps{ResultFlags: []ResultPropBits{ResultAlwaysSameConstant}}
+	plain := &FuncProps{ResultFlags: []ResultPropBits{ResultNoInfo}}
+	feedsMake := &CallSite{Flags: CallSiteFeedsMakeSize}
+	checkScores(t, cost, []scoreCase{
+		{"constant result feeds make", feedsMake, constres, cost + adjValue(makeSizeConstAdj), makeSizeConstAdj},
+		{"non-constant result feeds make", feedsMake, plain, cost, 0},
+		{"unknown callee feeds make", feedsMake, nil, cost, 0},
+		{"constant result elsewhere", &CallSite{}, constres, cost, 0},
+	})
+}
+
+func TestRangeBoundsCheckScoring(t *testing.T) {
+	const cost = 50
+	// callee(s []int, i int), where s is indexed by i
+	indexer := &FuncProps{ParamFlags: []ParamPropBits{ParamFeedsBoundsCheck, ParamNoInfo}}
+	plain := &FuncProps{ParamFlags: []ParamPropBits{ParamNoInfo, ParamNoInfo}}
+	checkScores(t, cost, []scoreCase{
+		{"indexer called in loop over arg",
+			&CallSite{Flags: CallSiteInRangeOverArg, RangedArgs: 1}, indexer,
+			cost + adjValue(rangeBoundsCheckAdj), rangeBoundsCheckAdj},
+		{"indexer called in loop over other arg",
+			&CallSite{Flags: CallSiteInRangeOverArg, RangedArgs: 2}, indexer, cost, 0},
+		{"non-indexer called in loop over arg",
+			&CallSite{Flags: CallSiteInRangeOverArg, RangedArgs: 1}, plain, cost, 0},
+		{"indexer called outside loop", &CallSite{}, indexer, cost, 0},
+		{"unknown callee called in loop over arg",
+			&CallSite{Flags: CallSiteInRangeOverArg, RangedArgs: 1}, nil, cost, 0},
+	})
+}
+
+func TestLoopBoundScoring(t *testing.T) {
+	const cost = 50
+	// callee(n int, x int), where n bounds a loop
+	looper := &FuncProps{ParamFlags: []ParamPropBits{ParamFeedsLoopBound, ParamNoInfo}}
+	plain := &FuncProps{ParamFlags: []ParamPropBits{ParamNoInfo, ParamNoInfo}}
+	x := ir.NewIdent(src.NoXPos, nil)
+	checkScores(t, cost, []scoreCase{
+		{"small constant bound", mkCallSite(0, ir.NewInt(src.NoXPos, 4), x), looper,
+			cost + adjValue(loopBoundConstAdj), loopBoundConstAdj},
+		{"large constant bound", mkCallSite(0, ir.NewInt(src.NoXPos, 1000), x), looper, cost, 0},
+		{"negative constant bound", mkCallSite(0, ir.NewInt(src.NoXPos, -1), x), looper, cost, 0},
+		{"non-constant bound", mkCallSite(0, x, ir.NewInt(src.NoXPos, 4)), looper, cost, 0},
+		{"constant for non-bound", mkCallSite(0, ir.NewInt(src.NoXPos, 4), x), plain, cost, 0},
+	})
+}
+
+func TestDeadArgScoring(t *testing.T) {
+	const cost = 50
+	// callee(n int, x int), where n is never read
+	unread := &FuncProps{ParamFlags: []ParamPropBits{ParamNeverRead, ParamNoInfo}}
+	plain := &FuncProps{ParamFlags: []ParamPropBits{ParamNoInfo, ParamNoInfo}}
+	x := ir.NewNameAt(src.NoXPos, types.NewPkg("p", "p").Lookup("x"), nil)
+	computed := ir.NewBinaryExpr(src.NoXPos, ir.OADD, x, x)
+	checkScores(t, cost, []scoreCase{
+		{"constant for unread param", mkCallSite(0, ir.NewInt(src.NoXPos, 4), x), unread,
+			cost + adjValue(deadArgAdj), deadArgAdj},
+		{"computed value for unread param", mkCallSite(0, computed, x), unread,
+			cost + adjValue(deadArgAdj), deadArgAdj},
+		{"variable for unread param", mkCallSite(0, x, ir.NewInt(src.NoXPos, 4)), unread, cost, 0},
+		{"constant for read param", mkCallSite(0, ir.NewInt(src.NoXPos, 4), x), plain, cost, 0},
+	})
+}
+
+func TestConstConcatArgScoring(t *testing.T) {
+	const cost = 50
+	// callee(s string, x string), where s is concatenated
+	concat := &FuncProps{
+		Flags:      FuncPropConcatDominated,
+		ParamFlags: []ParamPropBits{ParamFeedsStringConcat, ParamNoInfo},
+	}
+	minor := &FuncProps{ParamFlags: concat.ParamFlags}
+	x := ir.NewIdent(src.NoXPos, nil)
+	checkScores(t, cost, []scoreCase{
+		{"constant string", mkCallSite(0, ir.NewString(src.NoXPos, "a"), x), concat,
+			cost + adjValue(constConcatArgAdj), constConcatArgAdj},
+		{"non-constant string", mkCallSite(0, x, ir.NewString(src.NoXPos, "a")), concat, cost, 0},
+		{"concat not dominant", mkCallSite(0, ir.NewString(src.NoXPos, "a"), x), minor, cost, 0},
+	})
+}
+
+func TestItfCallScoring(t *testing.T) {
+	const cost = 50
+	itf := types.NewInterface(nil)
+	// callee(s Shape, x int), where s is the receiver of a method call
+	top := &FuncProps{ParamFlags: []ParamPropBits{ParamFeedsInterfaceMethodCall, ParamNoInfo}}
+	nested := &FuncProps{ParamFlags: []ParamPropBits{ParamMayFeedInterfaceMethodCall, ParamNoInfo}}
+	plain := &FuncProps{ParamFlags: []ParamPropBits{ParamNoInfo, ParamNoInfo}}
+	conv := func(t *types.Type) ir.Node {
+		x := ir.NewIdent(src.NoXPos, nil)
+		x.SetType(t)
+		return ir.NewConvExpr(src.NoXPos, ir.OCONVIFACE, itf, x)
+	}
+	x := ir.NewIdent(src.NoXPos, nil)
+	x.SetType(itf)
+	concrete := conv(types.NewStruct(nil))
+	checkScores(t, cost, []scoreCase{
+		{"concrete to top-level call", mkCallSite(0, concrete, x), top,
+			cost + adjValue(passConcreteToItfCallAdj), passConcreteToItfCallAdj},
+		{"concrete to nested call", mkCallSite(0, concrete, x), nested,
+			cost + adjValue(passConcreteToNestedItfCallAdj), passConcreteToNestedItfCallAdj},
+		{"interface to top-level call", mkCallSite(0, x, x), top, cost, 0},
+		{"interface converted to interface", mkCallSite(0, conv(itf), x), top, cost, 0},
+		{"concrete to non-receiver", mkCallSite(0, concrete, x), plain, cost, 0},
+	})
+}
+
+func TestTypeAssertScoring(t *testing.T) {
+	const cost = 50
+	itf := types.NewInterface(nil)
+	// callee(v any, x int), where v is the operand of a type switch
+	asserter := &FuncProps{ParamFlags: []ParamPropBits{ParamFeedsTypeAssert, ParamNoInfo}}
+	plain := &FuncProps{ParamFlags: []ParamPropBits{ParamNoInfo, ParamNoInfo}}
+	x := ir.NewIdent(src.NoXPos, nil)
+	x.SetType(itf)
+	y := ir.NewIdent(src.NoXPos, nil)
+	y.SetType(types.NewStruct(nil))
+	concrete := ir.NewConvExpr(src.NoXPos, ir.OCONVIFACE, itf, y)
+	checkScores(t, cost, []scoreCase{
+		{"concrete to type switch", mkCallSite(0, concrete, x), asserter,
+			cost + adjValue(passConcreteToTypeAssertAdj), passConcreteToTypeAssertAdj},
+		{"interface to type switch", mkCallSite(0, x, concrete), asserter, cost, 0},
+		{"concrete to non-asserted param", mkCallSite(0, concrete, x), plain, cost, 0},
+	})
+}
+
+func TestDivOrShiftScoring(t *testing.T) {
+	const cost = 50
+	// callee(d int, x int), where d is a divisor
+	divider := &FuncProps{ParamFlags: []ParamPropBits{ParamFeedsDivOrShift, ParamNoInfo}}
+	plain := &FuncProps{ParamFlags: []ParamPropBits{ParamNoInfo, ParamNoInfo}}
+	x := ir.NewIdent(src.NoXPos, nil)
+	checkScores(t, cost, []scoreCase{
+		{"power of two divisor", mkCallSite(0, ir.NewInt(src.NoXPos, 8), x), divider,
+			cost + adjValue(passPow2ToDivAdj), passPow2ToDivAdj},
+		{"other constant divisor", mkCallSite(0, ir.NewInt(src.NoXPos, 10), x), divider,
+			cost + adjValue(passConstToDivAdj), passConstToDivAdj},
+		{"zero divisor", mkCallSite(0, ir.NewInt(src.NoXPos, 0), x), divider,
+			cost + adjValue(passConstToDivAdj), passConstToDivAdj},
+		{"non-constant divisor", mkCallSite(0, x, ir.NewInt(src.NoXPos, 8)), divider, cost, 0},
+		{"constant for non-divisor", mkCallSite(0, ir.NewInt(src.NoXPos, 8), x), plain, cost, 0},
+	})
+}
+
+func TestIndirectCallScoring(t *testing.T) {
+	const cost = 50
+	// callee(f func(), x int), where f is called
+	top := &FuncProps{ParamFlags: []ParamPropBits{ParamFeedsIndirectCall, ParamNoInfo}}
+	nested := &FuncProps{ParamFlags: []ParamPropBits{ParamMayFeedIndirectCall, ParamNoInfo}}
+	plain := &FuncProps{ParamFlags: []ParamPropBits{ParamNoInfo, ParamNoInfo}}
+	fn := ir.NewNameAt(src.NoXPos, types.NewPkg("p", "p").Lookup("f"), nil)
+	fn.Class = ir.PFUNC
+	x := ir.NewIdent(src.NoXPos, nil)
+	checkScores(t, cost, []scoreCase{
+		{"func to top-level call", mkCallSite(0, fn, x), top,
+			cost + adjValue(passFuncToIndirectCallAdj), passFuncToIndirectCallAdj},
+		{"func to nested call", mkCallSite(0, fn, x), nested,
+			cost + adjValue(passFuncToNestedIndirectCallAdj), passFuncToNestedIndirectCallAdj},
+		{"func value to top-level call", mkCallSite(0, x, x), top, cost, 0},
+		{"func to non-called param", mkCallSite(0, fn, x), plain, cost, 0},
+	})
+}
+
+func TestYieldScoring(t *testing.T) {
+	const cost = 50
+	// callee(x int, yield func(int) bool), where yield is called in
+	// a loop
+	inLoop := &FuncProps{ParamFlags: []ParamPropBits{ParamNoInfo, ParamMayFeedIndirectCall | ParamYieldInLoop}}
+	notInLoop := &FuncProps{ParamFlags: []ParamPropBits{ParamNoInfo, ParamMayFeedIndirectCall}}
+	saved := base.Ctxt
+	defer func() { base.Ctxt = saved }()
+	base.Ctxt = &obj.Link{} // for closure naming
+	pkg := types.NewPkg("p", "p")
+	outer := ir.NewFunc(src.NoXPos, src.NoXPos, pkg.Lookup("F"), types.NewSignature(nil, nil, nil))
+	lit := ir.NewClosureFunc(src.NoXPos, src.NoXPos, ir.OCLOSURE,
+		types.NewSignature(nil, nil, nil), outer, &ir.Package{}).OClosure
+	fn := ir.NewNameAt(src.NoXPos, pkg.Lookup("f"), nil)
+	fn.Class = ir.PFUNC
+	x := ir.NewIdent(src.NoXPos, nil)
+	checkScores(t, cost, []scoreCase{
+		{"func lit to yield in loop", mkCallSite(0, x, lit), inLoop,
+			cost + adjValue(passFuncLitToYieldAdj), passFuncLitToYieldAdj},
+		{"named func to yield in loop", mkCallSite(0, x, fn), inLoop,
+			cost + adjValue(passFuncToNestedIndirectCallAdj), passFuncToNestedIndirectCallAdj},
+		{"func lit to yield not in loop", mkCallSite(0, x, lit), notInLoop,
+			cost + adjValue(passFuncToNestedIndirectCallAdj), passFuncToNestedIndirectCallAdj},
+	})
+}
+
+func TestIfOrSwitchScoring(t *testing.T) {
+	const cost = 50
+	// callee(mode int, x int), where mode feeds a switch
+	top := &FuncProps{ParamFlags: []ParamPropBits{ParamFeedsIfOrSwitch, ParamNoInfo}}
+	nested := &FuncProps{ParamFlags: []ParamPropBits{ParamMayFeedIfOrSwitch, ParamNoInfo}}
+	plain := &FuncProps{ParamFlags: []ParamPropBits{ParamNoInfo, ParamNoInfo}}
+	div := &FuncProps{ParamFlags: []ParamPropBits{ParamNoInfo, ParamFeedsDivOrShift}}
+	x := ir.NewIdent(src.NoXPos, nil)
+	one, three := ir.NewInt(src.NoXPos, 1), ir.NewInt(src.NoXPos, 3)
+	checkScores(t, cost, []scoreCase{
+		{"constant to top-level switch", mkCallSite(0, one, x), top,
+			cost + adjValue(passConstToIfAdj), passConstToIfAdj},
+		{"constant to nested switch", mkCallSite(0, one, x), nested,
+			cost + adjValue(passConstToNestedIfAdj), passConstToNestedIfAdj},
+		{"variable to top-level switch", mkCallSite(0, x, one), top, cost, 0},
+		{"constant to non-switch param", mkCallSite(0, one, x), plain, cost, 0},
+		{"all constants to top-level switch", mkCallSite(0, one, three), top,
+			cost + adjValue(passConstToIfAdj) + adjValue(allArgsConstAdj),
+			passConstToIfAdj | allArgsConstAdj},
+		{"all constants to divisor", mkCallSite(0, one, three), div,
+			cost + adjValue(passConstToDivAdj) + adjValue(allArgsConstAdj),
+			passConstToDivAdj | allArgsConstAdj},
+		{"all constants to plain params", mkCallSite(0, one, three), plain, cost, 0},
+	})
+}
+
+func TestResultFeedsCondScoring(t *testing.T) {
+	const cost = 40
+	constRes := &FuncProps{ResultFlags: []ResultPropBits{ResultAlwaysSameConstant}}
+	globalRes := &FuncProps{ResultFlags: []ResultPropBits{ResultAlwaysSameGlobal}}
+	plainRes := &FuncProps{ResultFlags: []ResultPropBits{ResultNoInfo}}
+	multiRes := &FuncProps{ResultFlags: []ResultPropBits{ResultAlwaysSameConstant, ResultNoInfo}}
+	feedsCond := &CallSite{Flags: CallSiteResultFeedsCond}
+	checkScores(t, cost, []scoreCase{
+		{"constant feeds cond", feedsCond, constRes,
+			cost + adjValue(resultFeedsCondAdj), resultFeedsCondAdj},
+		{"global feeds cond", feedsCond, globalRes,
+			cost + adjValue(resultFeedsCondAdj), resultFeedsCondAdj},
+		{"unknown result feeds cond", feedsCond, plainRes, cost, 0},
+		{"multiple results feed cond", feedsCond, multiRes, cost, 0},
+		{"unknown callee", feedsCond, nil, cost, 0},
+		{"constant not feeding cond", &CallSite{}, constRes, cost, 0},
+	})
+}
+
+func TestAllocNoEscapeScoring(t *testing.T) {
+	const cost = 40
+	allocs := &FuncProps{Flags: FuncPropAllocates, ResultFlags: []ResultPropBits{ResultIsAllocatedMem}}
+	noAlloc := &FuncProps{ResultFlags: []ResultPropBits{ResultNoInfo}}
+	fresh := &FuncProps{Flags: FuncPropAllocates,
+		ResultFlags: []ResultPropBits{ResultIsAllocatedMem | ResultFreshAlloc}}
+	noEscape := &CallSite{Flags: CallSiteResultNoEscape}
+	checkScores(t, cost, []scoreCase{
+		{"allocating callee, result doesn't escape", noEscape, allocs,
+			cost + adjValue(allocNoEscapeAdj), allocNoEscapeAdj},
+		{"allocating callee, result may escape", &CallSite{}, allocs, cost, 0},
+		{"non-allocating callee", noEscape, noAlloc, cost, 0},
+		{"unknown callee", noEscape, nil, cost, 0},
+		{"fresh allocation, result doesn't escape", noEscape, fresh,
+			cost + adjValue(freshAllocAdj), freshAllocAdj},
+		{"fresh allocation, result may escape", &CallSite{}, fresh, cost, 0},
+	})
+}
+
+func TestColdCallSiteScoring(t *testing.T) {
+	const cost = 40
+	fp := &FuncProps{}
+	checkScores(t, cost, []scoreCase{
+		{"cold call site", &CallSite{Flags: CallSiteCold}, fp,
+			cost + adjValue(coldCallSiteAdj), coldCallSiteAdj},
+		{"normal call site", &CallSite{}, fp, cost, 0},
+	})
+}
+
+func TestErrorPathScoring(t *testing.T) {
+	const cost = 40
+	fp := &FuncProps{}
+	checkScores(t, cost, []scoreCase{
+		{"error path", &CallSite{Flags: CallSiteOnErrorPath}, fp,
+			cost + adjValue(errorPathAdj), errorPathAdj},
+		{"panic path", &CallSite{Flags: CallSiteOnPanicPath}, fp,
+			cost + adjValue(errorPathAdj), errorPathAdj},
+		{"both", &CallSite{Flags: CallSiteOnErrorPath | CallSiteOnPanicPath}, fp,
+			cost + adjValue(errorPathAdj), errorPathAdj},
+		{"normal path", &CallSite{}, fp, cost, 0},
+	})
+}
+
+func TestEventLoopScoring(t *testing.T) {
+	const cost = 40
+	fp := &FuncProps{}
+	checkScores(t, cost, []scoreCase{
+		{"event loop", &CallSite{Flags: CallSiteInLoop | CallSiteInEventLoop}, fp,
+			cost + adjValue(eventLoopAdj), eventLoopAdj},
+		{"select in event loop", &CallSite{Flags: CallSiteInLoop | CallSiteInSelect | CallSiteInEventLoop}, fp,
+			cost + adjValue(eventLoopAdj), eventLoopAdj},
+		{"select only", &CallSite{Flags: CallSiteInSelect}, fp, cost, 0},
+		{"error path in event loop", &CallSite{Flags: CallSiteInEventLoop | CallSiteOnErrorPath}, fp,
+			cost + adjValue(errorPathAdj), errorPathAdj},
+	})
+}
+
+func TestNilErrorCheckScoring(t *testing.T) {
+	const cost = 40
+	// callee() (int, error), with and without an always-nil error
+	nilErr := &FuncProps{ResultFlags: []ResultPropBits{ResultNoInfo,
+		ResultAlwaysSameConstant | ResultErrorAlwaysNil}}
+	plain := &FuncProps{ResultFlags: []ResultPropBits{ResultNoInfo, ResultNoInfo}}
+	checked := &CallSite{Flags: CallSiteErrorChecked}
+	checkScores(t, cost, []scoreCase{
+		{"checked nil error", checked, nilErr,
+			cost + adjValue(nilErrorCheckAdj), nilErrorCheckAdj},
+		{"unchecked nil error", &CallSite{}, nilErr, cost, 0},
+		{"checked error", checked, plain, cost, 0},
+	})
+}
+
+func TestConcurrencyInLoopScoring(t *testing.T) {
+	const cost = 40
+	spawner := &FuncProps{Flags: FuncPropSpawnsGoroutine}
+	locker := &FuncProps{Flags: FuncPropUsesMutex}
+	plain := &FuncProps{}
+	inLoop := &CallSite{Flags: CallSiteInLoop}
+	checkScores(t, cost, []scoreCase{
+		{"goroutine in loop", inLoop, spawner,
+			cost + adjValue(concurrencyInLoopAdj), concurrencyInLoopAdj},
+		{"mutex in loop", inLoop, locker,
+			cost + adjValue(concurrencyInLoopAdj), concurrencyInLoopAdj},
+		{"mutex not in loop", &CallSite{}, locker, cost, 0},
+		{"plain in loop", inLoop, plain, cost, 0},
+	})
+}
+
+func TestDeadResultScoring(t *testing.T) {
+	const cost = 40
+	dead := ResultNoInfo | ResultDiscardedByCallers
+	results := func(r ...ResultPropBits) *FuncProps {
+		return &FuncProps{ResultFlags: r}
+	}
+	checkScores(t, cost, []scoreCase{
+		{"no results", &CallSite{}, results(), cost, 0},
+		{"discarded result", &CallSite{}, results(dead),
+			cost + adjValue(deadResultAdj), deadResultAdj},
+		{"all results discarded", &CallSite{}, results(dead, ResultAlwaysSameConstant|ResultDiscardedByCallers),
+			cost + adjValue(deadResultAdj), deadResultAdj},
+		{"some results discarded", &CallSite{}, results(dead, ResultNoInfo), cost, 0},
+	})
+}
+
+func TestCallSiteScoreTable(t *testing.T) {
+	entries := []*callSiteScore{
+		{file: "b.go", line: 3, col: 1, caller: "F", callee: "p.g", cost: 80, score: 80, adjs: "none"},
+		{file: "a.go", line: 10, col: 2, caller: "G", callee: "p.h", cost: 57, score: 32, adjs: "wrapper:-20,tailCall:-5"},
+		{file: "a.go", line: 9, col: 4, caller: "G", callee: "p.g", cost: 80, score: 65, adjs: "makeSizeConst:-15"},
+	}
+	sortCallSiteScores(entries)
+	var buf bytes.Buffer
+	writeCallSiteScores(&buf, entries, false, false)
+	want := `pos        caller  callee  cost  adjustments              score
+a.go:9:4   G       p.g     80    makeSizeConst:-15        65
+a.go:10:2  G       p.h     57    wrapper:-20,tailCall:-5  32
+b.go:3:1   F       p.g     80    none                     80
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got table:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestCallSiteScoreSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.go")
+	src := "package p\n\nfunc F() {\n\tx := g(1,\t2)\n}\n"
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	entries := []*callSiteScore{
+		{path: path, file: "a.go", line: 4, col: 7, caller: "F", callee: "p.g", cost: 80, score: 80, adjs: "none"},
+		{path: path, file: "a.go", line: 40, col: 1, caller: "F", callee: "p.h", cost: 57, score: 57, adjs: "none"},
+	}
+	addCallSiteSource(entries)
+	var buf bytes.Buffer
+	writeCallSiteScores(&buf, entries, true, false)
+	want := `pos        caller  callee  cost  adjustments  score  source
+a.go:4:7   F       p.g     80    none         80     x := g(1, 2)
+a.go:40:1  F       p.h     57    none         57
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got table:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestCallSiteScoreChanged(t *testing.T) {
+	entries := []*callSiteScore{
+		{file: "a.go", line: 3, col: 1, caller: "F", callee: "p.g", cost: 97, score: 57, adjs: "wrapper:-40",
+			decided: true, inlined: true, threshold: 80},
+		{file: "a.go", line: 4, col: 1, caller: "F", callee: "p.h", cost: 64, score: 84, adjs: "errorPath:+20",
+			decided: true, inlined: false, threshold: 80},
+		{file: "a.go", line: 5, col: 1, caller: "F", callee: "p.k", cost: 50, score: 30, adjs: "leaf:-20",
+			decided: true, inlined: true, threshold: 80},
+		{file: "a.go", line: 6, col: 1, caller: "F", callee: "p.m", cost: 97, score: 57, adjs: "wrapper:-40"},
+	}
+	var buf bytes.Buffer
+	writeCallSiteScores(&buf, changedCallSiteScores(entries), false, true)
+	want := `pos       caller  callee  cost  adjustments    score  threshold  decision
+a.go:3:1  F       p.g     97    wrapper:-40    57     80         enabled
+a.go:4:1  F       p.h     64    errorPath:+20  84     80         suppressed
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got table:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestScoreAdjustments(t *testing.T) {
+	saved := make(map[scoreAdjustTyp]int, len(adjValues))
+	for typ, v := range adjValues {
+		saved[typ] = v
+	}
+	defer func() {
+		for typ, v := range saved {
+			adjValues[typ] = v
+		}
+	}()
+
+	if err := parseScoreAdjustments("wrapper:-30/TailCallAdj:0/resultfeedscond:7"); err != nil {
+		t.Fatalf("parseScoreAdjustments: %v", err)
+	}
+	for typ, want := range map[scoreAdjustTyp]int{
+		wrapperAdj:         -30,
+		tailCallAdj:        0,
+		resultFeedsCondAdj: 7,
+		casLoopAdj:         saved[casLoopAdj],
+	} {
+		if got := adjValue(typ); got != want {
+			t.Errorf("adjValue(%v): got %d want %d", typ, got, want)
+		}
+	}
+
+	// Invalid specs should be rejected without applying any of
+	// the adjustments.
+	for _, spec := range []string{
+		"wrapper",
+		"wrapper:x",
+		"nosuchthing:3",
+		"casloop:1/wrapper",
+	} {
+		if err := parseScoreAdjustments(spec); err == nil {
+			t.Errorf("parseScoreAdjustments(%q): expected error", spec)
+		}
+	}
+	if got := adjValue(casLoopAdj); got != saved[casLoopAdj] {
+		t.Errorf("casLoopAdj changed by invalid spec: got %d want %d", got, saved[casLoopAdj])
+	}
+}
+
+func TestProfileScoring(t *testing.T) {
+	const cost = 50
+	saved := map[scoreAdjustTyp]int{
+		hotCallSiteAdj: adjValues[hotCallSiteAdj],
+		coldCalleeAdj:  adjValues[coldCalleeAdj],
+	}
+	defer func() {
+		for typ, v := range saved {
+			adjValues[typ] = v
+		}
+		profileHotness = nil
+	}()
+
+	check := func(what string, hot, cold bool, want int, wmask scoreAdjustTyp) {
+		t.Helper()
+		got, mask := computeProfileScore(hot, cold, cost, 0)
+		if got != want || mask != wmask {
+			t.Errorf("%s: got score %d mask %x, want score %d mask %x",
+				what, got, mask, want, wmask)
+		}
+	}
+	check("hot", true, false, cost+adjValue(hotCallSiteAdj), hotCallSiteAdj)
+	check("cold", false, true, cost+adjValue(coldCalleeAdj), coldCalleeAdj)
+	check("neither", false, false, cost, 0)
+
+	// Adjustment sizes can be tuned via debug flags.
+	defer func(hot, cold int) {
+		base.Debug.InlHotCallSiteAdj = hot
+		base.Debug.InlColdCalleeAdj = cold
+	}(base.Debug.InlHotCallSiteAdj, base.Debug.InlColdCalleeAdj)
+	base.Debug.InlHotCallSiteAdj = -7
+	base.Debug.InlColdCalleeAdj = 3
+	SetProfileHotness(func(*ir.Func, *ir.CallExpr, *ir.Func) (bool, bool) {
+		return false, false
+	})
+	check("tuned hot", true, false, cost-7, hotCallSiteAdj)
+	check("tuned cold", false, true, cost+3, coldCalleeAdj)
+}
+
+func TestFormatWrapperScoring(t *testing.T) {
+	const cost = 50
+	// wrapper(format string, args ...any), forwarding format
+	fwd := &FuncProps{
+		Flags:      FuncPropFormatWrapper,
+		ParamFlags: []ParamPropBits{ParamFeedsFormatString, ParamNoInfo},
+	}
+	// wrapper(x int), with a constant format
+	constfmt := &FuncProps{
+		Flags:      FuncPropFormatWrapper,
+		ParamFlags: []ParamPropBits{ParamNoInfo},
+	}
+	checkScores(t, cost, []scoreCase{
+		{"constant format passed", &CallSite{ConstArgs: 1}, fwd,
+			cost + adjValue(formatConstAdj), formatConstAdj},
+		{"non-constant format passed", &CallSite{ConstArgs: 2}, fwd,
+			cost + adjValue(formatNonConstAdj), formatNonConstAdj},
+		{"constant format in wrapper", &CallSite{}, constfmt,
+			cost + adjValue(formatConstAdj), formatConstAdj},
+		{"not a format wrapper", &CallSite{ConstArgs: 1}, &FuncProps{}, cost, 0},
+	})
+}
+
+func TestWideCallsScoring(t *testing.T) {
+	const cost = 30
+	for _, tc := range []struct {
+		maxArgs int
+		want    int
+	}{
+		{0, cost},
+		{wideCallArgs - 1, cost},
+		{wideCallArgs, cost + adjValue(wideCallsAdj)},
+		{wideCallArgs + 4, cost + adjValue(wideCallsAdj)},
+	} {
+		fp := &FuncProps{MaxCallArgs: tc.maxArgs, NumCalls: 1}
+		if got, _ := computeFuncScore(fp, cost); got != tc.want {
+			t.Errorf("MaxCallArgs %d: got score %d, want %d",
+				tc.maxArgs, got, tc.want)
+		}
+	}
+}
+
+func TestLeafScoring(t *testing.T) {
+	const cost = 30
+	leaf := &FuncProps{Flags: FuncPropIsLeaf}
+	if got, mask := computeFuncScore(leaf, cost); got != cost+adjValue(leafAdj) || mask != leafAdj {
+		t.Errorf("leaf: got score %d mask %s, want score %d mask %s",
+			got, mask, cost+adjValue(leafAdj), leafAdj)
+	}
+	nearly := &FuncProps{Flags: FuncPropNearlyLeaf, NumCalls: 1}
+	if got, mask := computeFuncScore(nearly, cost); got != cost+adjValue(leafAdj) || mask != leafAdj {
+		t.Errorf("nearly leaf: got score %d mask %s, want score %d mask %s",
+			got, mask, cost+adjValue(leafAdj), leafAdj)
+	}
+	if got, mask := computeFuncScore(&FuncProps{NumCalls: 1}, cost); got != cost || mask != 0 {
+		t.Errorf("non-leaf: got score %d mask %s, want score %d", got, mask, cost)
+	}
+}
+
+func TestLowLevelScoring(t *testing.T) {
+	const cost = 30
+	fp := &FuncProps{Flags: FuncPropLowLevel, NumCalls: 1}
+	if got, mask := computeFuncScore(fp, cost); got != cost+adjValue(lowLevelAdj) || mask != lowLevelAdj {
+		t.Errorf("low level: got score %d mask %s, want score %d mask %s",
+			got, mask, cost+adjValue(lowLevelAdj), lowLevelAdj)
+	}
+	// Plain use of unsafe.Pointer doesn't count.
+	fp = &FuncProps{Flags: FuncPropUsesUnsafe, NumCalls: 1}
+	if got, mask := computeFuncScore(fp, cost); got != cost || mask != 0 {
+		t.Errorf("uses unsafe: got score %d mask %s, want score %d", got, mask, cost)
+	}
+}
+
+func TestTrivialWrapperScoring(t *testing.T) {
+	for _, tc := range []struct {
+		what  string
+		flags FuncPropBits
+		cost  int
+		wmask scoreAdjustTyp
+	}{
+		{"trivial wrapper", FuncPropIsWrapper | FuncPropTrivialWrapper, 80, trivialWrapperAdj},
+		{"wrapper with constant", FuncPropIsWrapper, 80, wrapperAdj},
+	} {
+		got, mask := computeFuncScore(&FuncProps{Flags: tc.flags}, tc.cost)
+		if want := tc.cost + adjValue(tc.wmask); got != want || mask != tc.wmask {
+			t.Errorf("%s: got score %d mask %s, want score %d mask %s",
+				tc.what, got, mask, want, tc.wmask)
+		}
+	}
+	// A trivial wrapper within the default budget should score low
+	// enough to be inlined even into a big function.
+	if got, _ := computeFuncScore(&FuncProps{Flags: FuncPropIsWrapper | FuncPropTrivialWrapper}, 80); got > 0 {
+		t.Errorf("trivial wrapper of cost 80: got score %d, want <= 0", got)
+	}
+}
+
+func TestSetInlineBudget(t *testing.T) {
+	saved := adjValues[trivialWrapperAdj]
+	defer func() { adjValues[trivialWrapperAdj] = saved }()
+
+	SetInlineBudget(120)
+	fp := &FuncProps{Flags: FuncPropIsWrapper | FuncPropTrivialWrapper}
+	if got, _ := computeFuncScore(fp, 120); got > 0 {
+		t.Errorf("trivial wrapper of cost 120 with budget 120: got score %d, want <= 0", got)
+	}
+}
+
+func TestPolicyHook(t *testing.T) {
+	defer SetInlinePolicyHook(nil)
+	const dflt = 40
+	pure := &FuncProps{Flags: FuncPropIsPure}
+	plain := &FuncProps{}
+
+	if got := applyPolicyHook(nil, pure, dflt); got != dflt {
+		t.Errorf("no hook: got score %d, want %d", got, dflt)
+	}
+	var hookScore int
+	SetInlinePolicyHook(func(fn *ir.Func, props *FuncProps, defaultScore int) (int, bool) {
+		if defaultScore != dflt {
+			t.Errorf("hook passed default score %d, want %d", defaultScore, dflt)
+		}
+		return hookScore, props.Flags&FuncPropIsPure != 0
+	})
+	for _, tc := range []struct {
+		what      string
+		fp        *FuncProps
+		hookScore int
+		want      int
+	}{
+		{"no override", plain, 5, dflt},
+		{"override", pure, 5, 5},
+		{"override negative", pure, -100, 0},
+		{"override too large", pure, math.MaxInt, math.MaxInt32},
+	} {
+		hookScore = tc.hookScore
+		if got := applyPolicyHook(nil, tc.fp, dflt); got != tc.want {
+			t.Errorf("%s: got score %d, want %d", tc.what, got, tc.want)
+		}
+	}
+}
+
+// TestScoreMonotonicInSize checks that, holding all other features
+// of a function and call site constant, a larger size (inline cost)
+// never produces a lower (more inlinable) score than a smaller one.
+func TestScoreMonotonicInSize(t *testing.T) {
+	// Enumerate combinations of up to two function flags and up to
+	// two call site flags, along with a representative set of result
+	// flags. Since each adjustment is applied at most once and is
+	// triggered by at most a flag or two, larger combinations don't
+	// add coverage (and enumerating all of them takes far too long).
+	resultFlags := [][]ResultPropBits{
+		nil,
+		{ResultNoInfo},
+		{ResultAlwaysSameConstant},
+		{ResultIsAllocatedMem, ResultAlwaysSameConstant},
+	}
+	score := func(fp *FuncProps, csflags CSPropBits, cost int) int {
+		s, mask := computeFuncScore(fp, cost)
+		cs := &CallSite{Flags: csflags}
+		if csflags&CallSiteInRangeOverArg != 0 {
+			cs.RangedArgs = 1
+		}
+		s, _ = computeCallSiteScore(cs, fp, s, mask)
+		return s
+	}
+	for _, f := range upToTwoFlags(_FuncPropBits_value[:]) {
+		ff := FuncPropBits(f)
+		for _, rf := range resultFlags {
+			fp := &FuncProps{
+				Flags:       ff,
+				ParamFlags:  []ParamPropBits{ParamFeedsBoundsCheck},
+				ResultFlags: rf,
+			}
+			for _, c := range upToTwoFlags(_CSPropBits_value[:]) {
+				csf := CSPropBits(c)
+				prev := score(fp, csf, 0)
+				for cost := 1; cost <= 200; cost++ {
+					cur := score(fp, csf, cost)
+					if cur < prev {
+						t.Fatalf("score not monotonic for flags=%s results=%v csflags=%s: cost %d score %d, cost %d score %d",
+							ff, rf, csf, cost-1, prev, cost, cur)
+					}
+					prev = cur
+				}
+			}
+		}
+	}
+}
+
+// upToTwoFlags returns the combinations of at most two of the flag
+// values in 'vals' (skipping any zero value).
+func upToTwoFlags(vals []uint64) []uint64 {
+	rv := []uint64{0}
+	for i, v := range vals {
+		if v == 0 {
+			continue
+		}
+		rv = append(rv, v)
+		for _, w := range vals[i+1:] {
+			if w != 0 {
+				rv = append(rv, v|w)
+			}
+		}
+	}
+	return rv
+}
+
+func TestScoreStats(t *testing.T) {
+	EnableScoreStats()
+	defer func() { scoreStats = nil }()
+
+	// Score concurrently, to exercise the atomic counters.
+	const n = 10
+	wrapper := &FuncProps{Flags: FuncPropIsWrapper}
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s, mask := computeFuncScore(wrapper, 30)
+			computeCallSiteScore(&CallSite{Flags: CallSiteTailPos}, wrapper, s, mask)
+		}()
+	}
+	wg.Wait()
+
+	var sb strings.Builder
+	DumpScoreStats(&sb)
+	got := sb.String()
+	for _, want := range []string{
+		"tailCallAdj          count 10 delta -50\n",
+		"wrapperAdj           count 10 delta -200\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("DumpScoreStats output missing %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "casLoopAdj") {
+		t.Errorf("DumpScoreStats output has unexpected casLoopAdj entry:\n%s", got)
+	}
+}
+
+func TestBudgetExtension(t *testing.T) {
+	defer func(spec string, tab map[*ir.Func]*FuncProps) {
+		base.Debug.InlBudgetExtend = spec
+		funcPropsTab = tab
+	}(base.Debug.InlBudgetExtend, funcPropsTab)
+	base.Debug.InlBudgetExtend = "FuncPropIsLeaf:20/ParamFeedsIfOrSwitch:5/FuncPropNeverReturns:30"
+
+	pkg := types.NewPkg("p", "p")
+	mkfn := func(name string, fp *FuncProps) *ir.Func {
+		fn := ir.NewFunc(src.NoXPos, src.NoXPos, pkg.Lookup(name), nil)
+		if fp != nil {
+			funcPropsTab[fn] = fp
+		}
+		return fn
+	}
+	funcPropsTab = make(map[*ir.Func]*FuncProps)
+	testcases := []struct {
+		fn   *ir.Func
+		want int32
+	}{
+		{mkfn("leaf", &FuncProps{Flags: FuncPropIsLeaf}), 20},
+		{mkfn("param", &FuncProps{ParamFlags: []ParamPropBits{0, ParamFeedsIfOrSwitch}}), 5},
+		{mkfn("both", &FuncProps{Flags: FuncPropIsLeaf | FuncPropNeverReturns}), 30},
+		{mkfn("none", &FuncProps{Flags: FuncPropIsWrapper}), 0},
+		{mkfn("unknown", nil), 0},
+	}
+	for _, tc := range testcases {
+		if got := BudgetExtension(tc.fn); got != tc.want {
+			t.Errorf("%s: got extension %d, want %d", tc.fn.Sym().Name, got, tc.want)
+		}
+	}
+}
+
+func TestCoverageCold(t *testing.T) {
+	const profile = `mode: set
+example.com/p/a.go:3.12,5.2 1 0
+example.com/p/a.go:7.12,9.2 1 0
+example.com/p/a.go:8.3,8.20 1 0
+example.com/p/a.go:8.3,8.20 1 2
+example.com/p/b.go:3.12,5.2 1 1
+`
+	prof, err := parseCoverProfile(strings.NewReader(profile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := prof["example.com/p/a.go"]
+	testcases := []struct {
+		what       string
+		blocks     []coverBlock
+		start, end uint
+		want       bool
+	}{
+		{"never executed", a, 3, 5, true},
+		{"block executed in merged profile", a, 7, 9, false},
+		{"no blocks in range", a, 11, 13, false},
+		{"executed", prof["example.com/p/b.go"], 3, 5, false},
+		{"file not in profile", prof["example.com/p/c.go"], 3, 5, false},
+	}
+	for _, tc := range testcases {
+		if got := coverRangeCold(tc.blocks, tc.start, tc.end); got != tc.want {
+			t.Errorf("%s: got cold %v, want %v", tc.what, got, tc.want)
+		}
+	}
+
+	if _, err := parseCoverProfile(strings.NewReader("a.go:3.12 1\n")); err == nil {
+		t.Errorf("malformed profile parsed without error")
+	}
+
+	cold := &FuncProps{Flags: FuncPropCoverageCold}
+	if got, mask := computeFuncScore(cold, 50); got != 50+adjValue(coverageColdAdj) || mask != coverageColdAdj {
+		t.Errorf("got score %d mask %v for coverage-cold callee", got, mask)
+	}
+}
+
+func TestInlineHintScoring(t *testing.T) {
+	const cost = 50
+	for _, tc := range []struct {
+		what  string
+		flags FuncPropBits
+		want  int
+		wmask scoreAdjustTyp
+	}{
+		{"hot", FuncPropHintHot, cost + adjValue(hintHotAdj), hintHotAdj},
+		{"cold", FuncPropHintCold, cost + adjValue(hintColdAdj), hintColdAdj},
+		{"prefer-inline", FuncPropHintPreferInline,
+			cost + adjValue(hintPreferInlineAdj), hintPreferInlineAdj},
+		{"no hint", 0, cost, 0},
+	} {
+		got, mask := computeFuncScore(&FuncProps{Flags: tc.flags}, cost)
+		if got != tc.want || mask != tc.wmask {
+			t.Errorf("%s: got score %d mask %x, want score %d mask %x",
+				tc.what, got, mask, tc.want, tc.wmask)
+		}
+	}
+}
+
+func TestMaskAdjustments(t *testing.T) {
+	mask := leafAdj | hintColdAdj
+	got := strings.Join(maskAdjustments(mask), ",")
+	want := fmt.Sprintf("leaf:%+d,hintCold:%+d", adjValue(leafAdj), adjValue(hintColdAdj))
+	if got != want {
+		t.Errorf("maskAdjustments(%v) = %q, want %q", mask, got, want)
+	}
+	if adjs := maskAdjustments(0); len(adjs) != 0 {
+		t.Errorf("maskAdjustments(0) = %q, want none", adjs)
+	}
+}
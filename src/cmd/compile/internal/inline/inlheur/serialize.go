@@ -6,11 +6,20 @@ package inlheur
 
 import "strings"
 
+// serialVersion is the version of the encoding written by
+// SerializeToString, which is stored in its first byte. It must be
+// bumped whenever the encoding changes, so that properties written
+// by a different toolchain are ignored rather than misread.
+const serialVersion = 1
+
+// SerializeToString encodes 'fp' as a string, for inclusion in export
+// data; DeserializeFromString decodes it.
 func (fp *FuncProps) SerializeToString() string {
 	if fp == nil {
 		return ""
 	}
 	var sb strings.Builder
+	sb.WriteByte(serialVersion)
 	writeUleb128(&sb, uint64(fp.Flags))
 	writeUleb128(&sb, uint64(len(fp.ParamFlags)))
 	for _, pf := range fp.ParamFlags {
@@ -20,46 +29,129 @@ func (fp *FuncProps) SerializeToString() string {
 	for _, rf := range fp.ResultFlags {
 		writeUleb128(&sb, uint64(rf))
 	}
+	writeUleb128(&sb, fp.ResultAffectingParams)
+	writeUleb128(&sb, uint64(fp.NumReturns))
+	if fp.SingleTailReturn {
+		writeUleb128(&sb, 1)
+	} else {
+		writeUleb128(&sb, 0)
+	}
+	writeUleb128(&sb, uint64(fp.MaxCallArgs))
+	writeUleb128(&sb, uint64(fp.NumCalls))
+	writeUleb128(&sb, uint64(fp.NodeCounts.Stmts))
+	writeUleb128(&sb, uint64(fp.NodeCounts.Exprs))
+	writeUleb128(&sb, uint64(fp.NodeCounts.ControlFlow))
+	writeUleb128(&sb, uint64(len(fp.Hotspot)))
+	sb.WriteString(fp.Hotspot)
+	writeUleb128(&sb, uint64(fp.Captures.Vars))
+	writeUleb128(&sb, uint64(fp.Captures.ByRef))
+	if fp.Captures.Escapes {
+		writeUleb128(&sb, 1)
+	} else {
+		writeUleb128(&sb, 0)
+	}
 	return sb.String()
 }
 
+// DeserializeFromString decodes properties encoded by
+// SerializeToString. It returns nil if 's' is empty or malformed (for
+// example if it was truncated, or written by a toolchain using a
+// different version of the encoding), in which case callers proceed
+// as if no properties were available.
 func DeserializeFromString(s string) *FuncProps {
-	if len(s) == 0 {
+	if len(s) == 0 || s[0] != serialVersion {
 		return nil
 	}
 	var fp FuncProps
-	var v uint64
-	sl := []byte(s)
-	v, sl = readULEB128(sl)
-	fp.Flags = FuncPropBits(v)
-	v, sl = readULEB128(sl)
-	fp.ParamFlags = make([]ParamPropBits, v)
+	r := propsReader{sl: []byte(s[1:])}
+	fp.Flags = FuncPropBits(r.uleb())
+	fp.ParamFlags = make([]ParamPropBits, r.count())
 	for i := range fp.ParamFlags {
-		v, sl = readULEB128(sl)
-		fp.ParamFlags[i] = ParamPropBits(v)
+		fp.ParamFlags[i] = ParamPropBits(r.uleb())
 	}
-	v, sl = readULEB128(sl)
-	fp.ResultFlags = make([]ResultPropBits, v)
+	fp.ResultFlags = make([]ResultPropBits, r.count())
 	for i := range fp.ResultFlags {
-		v, sl = readULEB128(sl)
-		fp.ResultFlags[i] = ResultPropBits(v)
+		fp.ResultFlags[i] = ResultPropBits(r.uleb())
+	}
+	fp.ResultAffectingParams = r.uleb()
+	fp.NumReturns = int(r.uleb())
+	fp.SingleTailReturn = r.uleb() != 0
+	fp.MaxCallArgs = int(r.uleb())
+	fp.NumCalls = int(r.uleb())
+	fp.NodeCounts.Stmts = int(r.uleb())
+	fp.NodeCounts.Exprs = int(r.uleb())
+	fp.NodeCounts.ControlFlow = int(r.uleb())
+	fp.Hotspot = r.str()
+	fp.Captures.Vars = int(r.uleb())
+	fp.Captures.ByRef = int(r.uleb())
+	fp.Captures.Escapes = r.uleb() != 0
+	if r.bad || len(r.sl) != 0 {
+		return nil
 	}
 	return &fp
 }
 
-func readULEB128(sl []byte) (value uint64, rsl []byte) {
-	var shift uint
+// propsReader decodes the values making up the encoding written by
+// SerializeToString from 'sl', setting 'bad' (and returning zero
+// values from then on) if the input runs out or is malformed.
+type propsReader struct {
+	sl  []byte
+	bad bool
+}
+
+// uleb reads a ULEB128-encoded value.
+func (r *propsReader) uleb() uint64 {
+	if r.bad {
+		return 0
+	}
+	v, sl, ok := readULEB128(r.sl)
+	if !ok {
+		r.bad = true
+		return 0
+	}
+	r.sl = sl
+	return v
+}
+
+// count reads the number of entries in a list, each of which takes
+// at least a byte, so that a bogus count can't trigger a huge
+// allocation.
+func (r *propsReader) count() int {
+	v := r.uleb()
+	if v > uint64(len(r.sl)) {
+		r.bad = true
+		return 0
+	}
+	return int(v)
+}
+
+// str reads a length-prefixed string.
+func (r *propsReader) str() string {
+	n := r.count()
+	if r.bad {
+		return ""
+	}
+	s := string(r.sl[:n])
+	r.sl = r.sl[n:]
+	return s
+}
 
-	for {
-		b := sl[0]
-		sl = sl[1:]
-		value |= (uint64(b&0x7F) << shift)
+// readULEB128 decodes a ULEB128-encoded value from the start of
+// 'sl', returning it and the rest of 'sl'. The third result is false
+// if 'sl' ends in the middle of the value, or the value overflows.
+func readULEB128(sl []byte) (value uint64, rsl []byte, ok bool) {
+	var shift uint
+	for i, b := range sl {
+		if shift >= 64 {
+			return 0, nil, false
+		}
+		value |= uint64(b&0x7F) << shift
 		if b&0x80 == 0 {
-			break
+			return value, sl[i+1:], true
 		}
 		shift += 7
 	}
-	return value, sl
+	return 0, nil, false
 }
 
 func writeUleb128(sb *strings.Builder, v uint64) {
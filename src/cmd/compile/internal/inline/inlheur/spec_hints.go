@@ -0,0 +1,148 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlheur
+
+import (
+	"cmd/compile/internal/ir"
+	"fmt"
+	"go/constant"
+	"strings"
+)
+
+// specHint records a suggestion that a given parameter of a function
+// is a candidate for specialization, since the same constant value is
+// passed for that parameter at every call site we can see. Here 'pidx'
+// is the index of the parameter (counting the receiver, if any, as
+// slot 0, as with FuncProps.ParamFlags), 'pname' is its name, 'ncalls'
+// is the number of call sites, and 'val' is the constant value.
+type specHint struct {
+	pidx   int
+	pname  string
+	ncalls int
+	val    string
+}
+
+// paramConstState tracks the constant values passed for a specific
+// callee parameter across a series of call sites. The 'seen' flag is
+// set once we've visited the first call site; 'varies' is set if we
+// find a non-constant argument or a constant that differs from one
+// seen earlier.
+type paramConstState struct {
+	val    constant.Value
+	seen   bool
+	varies bool
+}
+
+// computeSpecHints examines the call site tables for the functions in
+// 'entries' and returns a map from callee to a list of specialization
+// hints for the callee's parameters. Functions in 'funcValues' (those
+// referenced as values somewhere in the package) are excluded, since
+// they may be called from places we can't see, as are params that
+// don't affect the callee's results (see FuncProps).
+func computeSpecHints(entries []fnInlHeur, funcValues map[*ir.Func]bool) map[*ir.Func][]specHint {
+	states := make(map[*ir.Func][]paramConstState)
+	ncalls := make(map[*ir.Func]int)
+	props := make(map[*ir.Func]*FuncProps)
+	for _, e := range entries {
+		props[e.fn] = e.props
+		for _, cs := range e.cstab {
+			callee := cs.Callee
+			args := cs.Call.Args
+			if callee.Type().IsVariadic() || cs.Call.IsDDD {
+				continue
+			}
+			st := states[callee]
+			if st == nil {
+				st = make([]paramConstState, len(callee.Type().RecvParams()))
+				states[callee] = st
+			}
+			if len(args) != len(st) {
+				continue
+			}
+			ncalls[callee]++
+			for i, arg := range args {
+				lit, isConst := isLiteral(arg)
+				switch {
+				case !isConst:
+					st[i].varies = true
+				case !st[i].seen:
+					st[i].val = lit
+				case !isSameLiteral(lit, st[i].val):
+					st[i].varies = true
+				}
+				st[i].seen = true
+			}
+		}
+	}
+	hints := make(map[*ir.Func][]specHint)
+	for callee, st := range states {
+		if funcValues[callee] {
+			continue
+		}
+		params := callee.Type().RecvParams()
+		for i := range st {
+			if !st[i].seen || st[i].varies {
+				continue
+			}
+			// No point specializing on a param that doesn't affect
+			// the callee's results or side effects.
+			if fp := props[callee]; fp != nil && i < 64 &&
+				fp.ResultAffectingParams&(1<<i) == 0 {
+				continue
+			}
+			pname := "_"
+			if s := params[i].Sym; s != nil {
+				pname = s.Name
+			}
+			val := "nil"
+			if st[i].val != nil {
+				val = st[i].val.String()
+			}
+			hints[callee] = append(hints[callee], specHint{
+				pidx:   i,
+				pname:  pname,
+				ncalls: ncalls[callee],
+				val:    val,
+			})
+		}
+	}
+	return hints
+}
+
+// specHintsToString renders 'hints' in human-readable form for
+// inclusion in a function properties dump, with each line prefixed
+// by 'prefix'. The result is empty if there are no hints.
+func specHintsToString(hints []specHint, prefix string) string {
+	if len(hints) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s%s\n", prefix, specHintsTag)
+	for _, h := range hints {
+		fmt.Fprintf(&sb, "%s  %d %s calls=%d value=%s\n",
+			prefix, h.pidx, h.pname, h.ncalls, h.val)
+	}
+	return sb.String()
+}
+
+// parseSpecHint parses a single hint line as produced by
+// specHintsToString (minus the prefix).
+func parseSpecHint(line string) (specHint, error) {
+	var h specHint
+	line = strings.TrimSpace(line)
+	head, val, ok := strings.Cut(line, " value=")
+	if !ok {
+		return h, fmt.Errorf("malformed specialization hint %q", line)
+	}
+	if _, err := fmt.Sscanf(head, "%d %s calls=%d", &h.pidx, &h.pname, &h.ncalls); err != nil {
+		return h, fmt.Errorf("malformed specialization hint %q: %v", line, err)
+	}
+	h.val = val
+	return h, nil
+}
+
+// specHintsTag is the header line for the hints section of a
+// function's entry in the function properties dump.
+const specHintsTag = "SpecializationHints"
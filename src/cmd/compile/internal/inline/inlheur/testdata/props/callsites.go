@@ -0,0 +1,725 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// DO NOT EDIT (use 'go test -v -update-expected' instead.)
+// See cmd/compile/internal/inline/inlheur/testdata/props/README.txt
+// for more information on the format of this file.
+// schema version 2
+// <endfilepreamble>
+
+package callsites
+
+import (
+	"flag"
+	"os"
+	"sync"
+)
+
+// callsites.go T_spec_callee 33 0 1 6
+// Flags FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNoInfo
+//   1 ParamFeedsIfOrSwitch
+//   2 ParamNoInfo
+// ResultAffectingParams 0 1 2
+// NumReturns 2
+// NodeCounts stmts=1 exprs=8 control=3
+// SpecializationHints
+//   1 mode calls=3 value=3
+// <endpropsdump>
+// {"Flags":["FuncPropIsLeaf"],"ParamFlags":[[],["ParamFeedsIfOrSwitch"],[]],"ResultFlags":[[]],"ResultAffectingParams":7,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":8,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_spec_callee(x int, mode int, name string) int {
+	if mode == 3 {
+		return x * 2
+	}
+	println(name)
+	return x
+}
+
+// callsites.go T_spec_caller1 54 0 1 6
+// Flags FuncPropStraightLine|FuncPropNearlyLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 3
+// NumCalls 1
+// NodeCounts stmts=1 exprs=4 control=1
+// CallSites
+//   0 callsites.go:55:22 CallSiteTailPos T_spec_callee score=-23 adj=tailCallAdj|leafAdj|passConstToIfAdj
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":3,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":4,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_spec_caller1(x int) int {
+	return T_spec_callee(x, 3, "a")
+}
+
+// callsites.go T_spec_caller2 72 0 1 6
+// Flags FuncPropStraightLine|FuncPropNearlyLeaf
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 3
+// NumCalls 2
+// NodeCounts stmts=2 exprs=11 control=1
+// CallSites
+//   0 callsites.go:73:22 0 T_spec_callee score=-18 adj=leafAdj|passConstToIfAdj
+//   1 callsites.go:73:51 0 T_spec_callee score=-18 adj=leafAdj|passConstToIfAdj
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":3,"NumCalls":2,"NodeCounts":{"Stmts":2,"Exprs":11,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_spec_caller2(x, y int) int {
+	return T_spec_callee(x+y, 3, "b") + T_spec_callee(y, 3, "a")
+}
+
+// callsites.go T_spec_funcval 85 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=3 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_spec_funcval(x int, mode int) int {
+	return x + mode
+}
+
+// callsites.go T_spec_funcval_caller 103 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropNearlyLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 2
+// NodeCounts stmts=4 exprs=10 control=1
+// CallSites
+//   0 callsites.go:105:23 0 T_spec_funcval score=-6 adj=leafAdj
+//   1 callsites.go:105:33 0 T_spec_funcval score=-6 adj=leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":2,"NodeCounts":{"Stmts":4,"Exprs":10,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_spec_funcval_caller(x int) int {
+	f := T_spec_funcval
+	return T_spec_funcval(x, 1) + f(x, 2)
+}
+
+type S struct {
+	v int
+}
+
+// callsites.go (*S).T_spec_method 126 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf|FuncPropReceiverOnly
+// ParamFlags
+//   0 ParamNoInfo
+//   1 ParamFeedsDivOrShift
+//   2 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0 1 2
+// NumReturns 2
+// NodeCounts stmts=0 exprs=7 control=3
+// SpecializationHints
+//   1 shift calls=2 value=2
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf","FuncPropReceiverOnly"],"ParamFlags":[[],["ParamFeedsDivOrShift"],["ParamFeedsIfOrSwitch"]],"ResultFlags":[[]],"ResultAffectingParams":7,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":7,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func (s *S) T_spec_method(shift uint, flag bool) int {
+	if flag {
+		return s.v << shift
+	}
+	return s.v
+}
+
+// callsites.go T_spec_method_caller 147 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropNearlyLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 3
+// NumCalls 2
+// NodeCounts stmts=2 exprs=9 control=1
+// CallSites
+//   0 callsites.go:148:24 0 (*S).T_spec_method score=-40 adj=leafAdj|passConstToIfAdj|passPow2ToDivAdj
+//   1 callsites.go:148:51 0 (*S).T_spec_method score=-40 adj=leafAdj|passConstToIfAdj|passPow2ToDivAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":3,"NumCalls":2,"NodeCounts":{"Stmts":2,"Exprs":9,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_spec_method_caller(s *S) int {
+	return s.T_spec_method(2, true) + s.T_spec_method(2, false)
+}
+
+// callsites.go T_make_size 161 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultFlags
+//   0 ResultAlwaysSameConstant
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=1 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[["ResultAlwaysSameConstant"]],"ResultAffectingParams":0,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":1,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_make_size() int {
+	return 64
+}
+
+// callsites.go T_make_size_caller 180 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropAllocates|FuncPropNearlyLeaf
+// ResultFlags
+//   0 ResultIsAllocatedMem
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 0
+// NumCalls 1
+// NodeCounts stmts=1 exprs=3 control=1
+// Hotspot callsites.go:181:13
+// CallSites
+//   0 callsites.go:181:36 CallSiteFeedsMakeSize T_make_size score=-23 adj=makeSizeConstAdj|leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropAllocates","FuncPropNearlyLeaf"],"ParamFlags":[],"ResultFlags":[["ResultIsAllocatedMem"]],"ResultAffectingParams":0,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":3,"ControlFlow":1},"Hotspot":"callsites.go:181:13","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_make_size_caller() []byte {
+	return make([]byte, 0, T_make_size())
+}
+
+// callsites.go T_callsite_in_loop 202 0 1 6
+// Flags FuncPropIsPure|FuncPropNearlyLeaf
+// ParamFlags
+//   0 ParamFeedsLoopBound
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 3
+// NodeCounts stmts=11 exprs=27 control=3
+// Hotspot callsites.go:204:2
+// CallSites
+//   0 callsites.go:205:22 CallSiteInLoop callsiteHelper score=-6 adj=leafAdj
+//   1 callsites.go:208:22 CallSiteInLoop callsiteHelper score=-6 adj=leafAdj
+//   2 callsites.go:210:27 0 callsiteHelper score=-6 adj=leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropNearlyLeaf"],"ParamFlags":[["ParamFeedsLoopBound"]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":3,"NodeCounts":{"Stmts":11,"Exprs":27,"ControlFlow":3},"Hotspot":"callsites.go:204:2","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_callsite_in_loop(n int) int {
+	t := 0
+	for i := 0; i < n; i++ {
+		t += callsiteHelper(i)
+	}
+	for _, v := range []int{1, 2} {
+		t += callsiteHelper(v)
+	}
+	return t + callsiteHelper(n)
+}
+
+// callsites.go T_callsite_panic_path 230 0 1 6
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 4
+// NodeCounts stmts=5 exprs=16 control=3
+// CallSites
+//   0 callsites.go:232:17 CallSiteOnPanicPath callsiteHelper score=14 adj=leafAdj|errorPathAdj
+//   1 callsites.go:237:17 CallSiteOnPanicPath callsiteHelper score=14 adj=leafAdj|errorPathAdj
+//   2 callsites.go:238:10 CallSiteOnPanicPath|CallSiteAllArgsConst Exit
+//   3 callsites.go:240:23 CallSiteTailPos callsiteHelper score=-11 adj=tailCallAdj|leafAdj
+// <endpropsdump>
+// {"Flags":[],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":4,"NodeCounts":{"Stmts":5,"Exprs":16,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_callsite_panic_path(x int) int {
+	if x < 0 {
+		callsiteHelper(x)
+		panic("bad")
+	}
+	switch x {
+	case 1:
+		callsiteHelper(x)
+		os.Exit(1)
+	}
+	return callsiteHelper(x)
+}
+
+func callsiteHelper(x int) int {
+	return x + 1
+}
+
+// callsites.go T_alloc_callee 259 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropAllocates|FuncPropIsLeaf
+// ResultFlags
+//   0 ResultIsAllocatedMem|ResultFreshAlloc
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=4 control=1
+// Hotspot callsites.go:260:9
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropAllocates","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[["ResultIsAllocatedMem","ResultFreshAlloc"]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":4,"ControlFlow":1},"Hotspot":"callsites.go:260:9","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_alloc_callee(v int) *S {
+	return &S{v: v}
+}
+
+// callsites.go T_alloc_conditional 274 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0
+// NumReturns 2
+// NodeCounts stmts=0 exprs=8 control=3
+// Hotspot callsites.go:278:9
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":8,"ControlFlow":3},"Hotspot":"callsites.go:278:9","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_alloc_conditional(v int) *S {
+	if v < 0 {
+		return nil
+	}
+	return &S{v: v}
+}
+
+// callsites.go T_alloc_noescape_direct 294 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropNearlyLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=3 control=1
+// CallSites
+//   0 callsites.go:295:23 CallSiteResultNoEscape T_alloc_callee score=-40 adj=leafAdj|freshAllocAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_alloc_noescape_direct(v int) int {
+	return T_alloc_callee(v).v
+}
+
+// callsites.go T_alloc_noescape_local 311 0 1 6
+// Flags FuncPropIsPure|FuncPropNearlyLeaf
+// ResultAffectingParams 0
+// NumReturns 2
+// MaxCallArgs 1
+// NumCalls 2
+// NodeCounts stmts=4 exprs=14 control=3
+// CallSites
+//   0 callsites.go:312:21 CallSiteResultNoEscape T_alloc_callee score=-40 adj=leafAdj|freshAllocAdj
+//   1 callsites.go:316:34 CallSiteResultNoEscape T_alloc_conditional score=1 adj=leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropNearlyLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":1,"NumCalls":2,"NodeCounts":{"Stmts":4,"Exprs":14,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_alloc_noescape_local(v int) int {
+	p := T_alloc_callee(v)
+	if p == nil {
+		return 0
+	}
+	return p.v + T_alloc_conditional(v).v
+}
+
+// callsites.go T_alloc_escapes 332 0 1 6
+// Flags FuncPropIsWrapper|FuncPropIsPure|FuncPropStraightLine|FuncPropTrivialWrapper|FuncPropNearlyLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=2 control=1
+// CallSites
+//   0 callsites.go:333:23 CallSiteTailPos T_alloc_callee score=-10 adj=tailCallAdj|leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsWrapper","FuncPropIsPure","FuncPropStraightLine","FuncPropTrivialWrapper","FuncPropNearlyLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":2,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_alloc_escapes(v int) *S {
+	return T_alloc_callee(v)
+}
+
+// callsites.go T_alloc_addr_taken 349 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropNearlyLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=3 exprs=7 control=1
+// CallSites
+//   0 callsites.go:350:21 0 T_alloc_callee score=-5 adj=leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":3,"Exprs":7,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_alloc_addr_taken(v int) *int {
+	p := T_alloc_callee(v)
+	return &p.v
+}
+
+var callsiteOnce sync.Once
+
+// callsites.go T_cold_once 379 0 1 6
+// Flags FuncPropMayBlock|FuncPropStraightLine
+// MaxCallArgs 2
+// NumCalls 2
+// NodeCounts stmts=2 exprs=6 control=0
+// Hotspot callsites.go:380:17
+// CallSites
+//   0 callsites.go:380:17 CallSitePassesFuncLit (*Once).Do score=66 adj=0
+//   1 callsites.go:383:16 CallSiteAllArgsConst callsiteHelper score=-6 adj=leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropMayBlock","FuncPropStraightLine"],"ParamFlags":[],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":2,"NumCalls":2,"NodeCounts":{"Stmts":2,"Exprs":6,"ControlFlow":0},"Hotspot":"callsites.go:380:17","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// callsites.go T_cold_once.func1 380 0 1 18
+// Flags FuncPropIsWrapper|FuncPropIsPure|FuncPropStraightLine|FuncPropNearlyLeaf
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=2 control=0
+// Captures vars=0 byref=0 escapes
+// CallSites
+//   0 callsites.go:381:17 CallSiteCold|CallSiteAllArgsConst callsiteHelper score=19 adj=coldCallSiteAdj|leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsWrapper","FuncPropIsPure","FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":2,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":true}}
+// <endfuncpreamble>
+func T_cold_once() {
+	callsiteOnce.Do(func() {
+		callsiteHelper(1)
+	})
+	callsiteHelper(2)
+}
+
+// callsites.go T_cold_flag_setup 399 0 1 6
+// Flags FuncPropStraightLine
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 3
+// NumCalls 2
+// NodeCounts stmts=2 exprs=5 control=1
+// CallSites
+//   0 callsites.go:400:17 CallSiteTailPos|CallSiteCold Int score=83 adj=tailCallAdj|coldCallSiteAdj
+//   1 callsites.go:400:37 CallSiteAllArgsConst callsiteHelper score=-6 adj=leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine"],"ParamFlags":[],"ResultFlags":[[]],"ResultAffectingParams":0,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":3,"NumCalls":2,"NodeCounts":{"Stmts":2,"Exprs":5,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_cold_flag_setup() *int {
+	return flag.Int("n", callsiteHelper(3), "count")
+}
+
+// callsites.go T_error_path 419 0 1 6
+// Flags FuncPropNearlyLeaf
+// ResultAffectingParams 0
+// NumReturns 3
+// MaxCallArgs 1
+// NumCalls 5
+// NodeCounts stmts=11 exprs=36 control=6
+// CallSites
+//   0 callsites.go:420:17 CallSiteErrorChecked parse score=1 adj=leafAdj
+//   1 callsites.go:422:17 CallSiteOnErrorPath wrap score=12 adj=leafAdj|errorPathAdj
+//   2 callsites.go:425:7 CallSiteOnErrorPath note score=12 adj=leafAdj|errorPathAdj
+//   3 callsites.go:429:7 0 note score=-8 adj=leafAdj
+//   4 callsites.go:431:7 CallSiteOnErrorPath note score=12 adj=leafAdj|errorPathAdj
+// <endpropsdump>
+// {"Flags":["FuncPropNearlyLeaf"],"ParamFlags":[[]],"ResultFlags":[[],[]],"ResultAffectingParams":1,"NumReturns":3,"SingleTailReturn":false,"MaxCallArgs":1,"NumCalls":5,"NodeCounts":{"Stmts":11,"Exprs":36,"ControlFlow":6},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_error_path(p string) (int, error) {
+	n, err := parse(p)
+	if err != nil {
+		return 0, wrap(err)
+	}
+	if n < 0 {
+		note(n)
+		return 0, os.ErrInvalid
+	}
+	if err == nil {
+		note(n)
+	} else {
+		note(-n)
+	}
+	return n, nil
+}
+
+func parse(p string) (int, error) {
+	if p == "" {
+		return 0, os.ErrNotExist
+	}
+	return len(p), nil
+}
+
+func wrap(err error) error {
+	return err
+}
+
+func note(n int) {
+	println(n)
+}
+
+type mvT struct {
+	n int
+}
+
+func (m *mvT) add(x int) int {
+	return m.n + x
+}
+
+func callsFunc(f func(int) int) int {
+	return f(2)
+}
+
+func callsMethod(f func(*mvT, int) int, m *mvT) int {
+	return f(m, 2)
+}
+
+// callsites.go T_methvalue_call 480 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropNearlyLeaf
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=3 exprs=6 control=1
+// CallSites
+//   0 callsites.go:482:10 CallSiteTailPos (*mvT).add score=-10 adj=tailCallAdj|leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":3,"Exprs":6,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_methvalue_call(m *mvT, x int) int {
+	f := m.add
+	return f(x)
+}
+
+// callsites.go T_methvalue_arg 498 0 1 6
+// Flags FuncPropStraightLine
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=3 control=1
+// CallSites
+//   0 callsites.go:499:18 CallSiteTailPos callsFunc score=36 adj=tailCallAdj|passFuncToIndirectCallAdj
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_methvalue_arg(m *mvT) int {
+	return callsFunc(m.add)
+}
+
+// callsites.go T_methexpr_arg 515 0 1 6
+// Flags FuncPropStraightLine
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 1
+// NodeCounts stmts=1 exprs=3 control=1
+// CallSites
+//   0 callsites.go:516:20 CallSiteTailPos callsMethod score=37 adj=tailCallAdj|passFuncToIndirectCallAdj
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_methexpr_arg(m *mvT) int {
+	return callsMethod((*mvT).add, m)
+}
+
+func neverFails(x int) (int, error) {
+	return x, nil
+}
+
+// callsites.go T_errcheck_nil_error 536 0 1 6
+// Flags FuncPropNearlyLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=7 exprs=20 control=2
+// CallSites
+//   0 callsites.go:537:22 CallSiteErrorChecked neverFails score=-32 adj=leafAdj|nilErrorCheckAdj
+// <endpropsdump>
+// {"Flags":["FuncPropNearlyLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":7,"Exprs":20,"ControlFlow":2},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_errcheck_nil_error(x int) int {
+	v, err := neverFails(x)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// callsites.go T_select_once 559 0 1 6
+// Flags FuncPropMayBlock|FuncPropUsesChannels|FuncPropUsesSelect|FuncPropNearlyLeaf
+// ResultAffectingParams 1
+// NumReturns 2
+// MaxCallArgs 1
+// NumCalls 3
+// NodeCounts stmts=8 exprs=12 control=3
+// Hotspot callsites.go:560:2
+// CallSites
+//   0 callsites.go:562:24 CallSiteTailPos|CallSiteInSelect callsiteHelper score=-11 adj=tailCallAdj|leafAdj
+//   1 callsites.go:563:26 CallSiteInSelect|CallSiteAllArgsConst callsiteHelper score=-6 adj=leafAdj
+//   2 callsites.go:565:23 CallSiteTailPos|CallSiteAllArgsConst callsiteHelper score=-11 adj=tailCallAdj|leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropMayBlock","FuncPropUsesChannels","FuncPropUsesSelect","FuncPropNearlyLeaf"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":2,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":1,"NumCalls":3,"NodeCounts":{"Stmts":8,"Exprs":12,"ControlFlow":3},"Hotspot":"callsites.go:560:2","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_select_once(c chan int, d chan int) int {
+	select {
+	case v := <-c:
+		return callsiteHelper(v)
+	case d <- callsiteHelper(1):
+	}
+	return callsiteHelper(2)
+}
+
+// callsites.go T_event_loop 581 0 1 6
+// Flags FuncPropMayBlock|FuncPropUsesChannels|FuncPropUsesSelect|FuncPropNearlyLeaf
+// NumReturns 1
+// MaxCallArgs 1
+// NumCalls 2
+// NodeCounts stmts=11 exprs=19 control=3
+// Hotspot callsites.go:585:3
+// CallSites
+//   0 callsites.go:584:24 CallSiteInLoop|CallSiteInEventLoop callsiteHelper score=-21 adj=leafAdj|eventLoopAdj
+//   1 callsites.go:587:25 CallSiteInLoop|CallSiteInSelect|CallSiteInEventLoop callsiteHelper score=-21 adj=leafAdj|eventLoopAdj
+// <endpropsdump>
+// {"Flags":["FuncPropMayBlock","FuncPropUsesChannels","FuncPropUsesSelect","FuncPropNearlyLeaf"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":0,"NumReturns":1,"SingleTailReturn":false,"MaxCallArgs":1,"NumCalls":2,"NodeCounts":{"Stmts":11,"Exprs":19,"ControlFlow":3},"Hotspot":"callsites.go:585:3","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_event_loop(c chan int, done chan bool) int {
+	sum := 0
+	for {
+		sum += callsiteHelper(sum)
+		select {
+		case v := <-c:
+			sum += callsiteHelper(v)
+		case <-done:
+			return sum
+		}
+	}
+}
+
+// callsites.go T_all_const_args 607 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropNearlyLeaf
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 2
+// NodeCounts stmts=2 exprs=7 control=1
+// CallSites
+//   0 callsites.go:608:19 CallSiteAllArgsConst modeSwitch score=-24 adj=leafAdj|passConstToIfAdj|allArgsConstAdj
+//   1 callsites.go:608:38 CallSiteAllArgsConst modeSwitch score=-24 adj=leafAdj|passConstToIfAdj|allArgsConstAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[],"ResultFlags":[[]],"ResultAffectingParams":0,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":2,"NodeCounts":{"Stmts":2,"Exprs":7,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_all_const_args() int {
+	return modeSwitch(1, 8) + modeSwitch(2, 3)
+}
+
+// callsites.go T_some_const_args 624 0 1 6
+// Flags FuncPropIsWrapper|FuncPropIsPure|FuncPropStraightLine|FuncPropNearlyLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 1
+// NodeCounts stmts=1 exprs=3 control=1
+// CallSites
+//   0 callsites.go:625:19 CallSiteTailPos modeSwitch score=-19 adj=tailCallAdj|leafAdj|passConstToIfAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsWrapper","FuncPropIsPure","FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_some_const_args(x int) int {
+	return modeSwitch(1, x)
+}
+
+func modeSwitch(mode, x int) int {
+	switch mode {
+	case 0:
+		return x
+	case 1:
+		return x * 2
+	}
+	return x * 3
+}
+
+// callsites.go T_yield_in_loop 651 0 1 6
+// ParamFlags
+//   0 ParamFeedsLoopBound
+//   1 ParamMayFeedIndirectCall|ParamYieldInLoop
+// ResultAffectingParams 0 1
+// NumReturns 1
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=4 exprs=11 control=3
+// Hotspot callsites.go:652:2
+// <endpropsdump>
+// {"Flags":[],"ParamFlags":[["ParamFeedsLoopBound"],["ParamMayFeedIndirectCall","ParamYieldInLoop"]],"ResultFlags":[],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":false,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":4,"Exprs":11,"ControlFlow":3},"Hotspot":"callsites.go:652:2","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_yield_in_loop(n int, yield func(int) bool) {
+	for i := 0; i < n; i++ {
+		if !yield(i) {
+			return
+		}
+	}
+}
+
+// callsites.go T_yield_once 671 0 1 6
+// Flags FuncPropStraightLine
+// ParamFlags
+//   0 ParamNoInfo
+//   1 ParamFeedsIndirectCall
+// ResultAffectingParams 0 1
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=2 control=0
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine"],"ParamFlags":[[],["ParamFeedsIndirectCall"]],"ResultFlags":[],"ResultAffectingParams":3,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":2,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_yield_once(v int, yield func(int) bool) {
+	yield(v)
+}
+
+// callsites.go T_pass_func_lit_to_yield 714 0 1 6
+// Flags FuncPropStraightLine
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 2
+// NodeCounts stmts=4 exprs=10 control=1
+// Hotspot callsites.go:716:21
+// CallSites
+//   0 callsites.go:716:17 CallSitePassesFuncLit T_yield_in_loop score=45 adj=passFuncLitToYieldAdj
+//   1 callsites.go:720:14 CallSitePassesFuncLit T_yield_once score=40 adj=passFuncToIndirectCallAdj
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":2,"NodeCounts":{"Stmts":4,"Exprs":10,"ControlFlow":1},"Hotspot":"callsites.go:716:21","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// callsites.go T_pass_func_lit_to_yield.func1 716 0 1 21
+// Flags FuncPropStraightLine|FuncPropIsLeaf
+// ResultFlags
+//   0 ResultAlwaysSameConstant
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=1 exprs=3 control=1
+// Captures vars=1 byref=1 escapes
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[["ResultAlwaysSameConstant"]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":1,"ByRef":1,"Escapes":true}}
+// <endfuncpreamble>
+// callsites.go T_pass_func_lit_to_yield.func2 720 0 1 18
+// Flags FuncPropStraightLine|FuncPropIsLeaf
+// ResultFlags
+//   0 ResultAlwaysSameConstant
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=1 exprs=3 control=1
+// Captures vars=1 byref=1 escapes
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[["ResultAlwaysSameConstant"]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":1,"ByRef":1,"Escapes":true}}
+// <endfuncpreamble>
+func T_pass_func_lit_to_yield(n int) int {
+	sum := 0
+	T_yield_in_loop(n, func(i int) bool {
+		sum += i
+		return true
+	})
+	T_yield_once(n, func(i int) bool {
+		sum -= i
+		return true
+	})
+	return sum
+}
@@ -5,25 +5,37 @@
 // DO NOT EDIT (use 'go test -v -update-expected' instead.)
 // See cmd/compile/internal/inline/inlheur/testdata/props/README.txt
 // for more information on the format of this file.
+// schema version 2
 // <endfilepreamble>
 
 package funcflags
 
-import "os"
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
-// funcflags.go T_simple 19 0 1
-// Flags FuncPropNeverReturns
+// funcflags.go T_simple 27 0 1 6
+// Flags FuncPropNeverReturns|FuncPropStraightLine|FuncPropIsLeaf
+// NodeCounts stmts=0 exprs=3 control=0
 // <endpropsdump>
-// {"Flags":1,"ParamFlags":null,"ResultFlags":[]}
+// {"Flags":["FuncPropNeverReturns","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":3,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_simple() {
 	panic("bad")
 }
 
-// funcflags.go T_nested 28 0 1
-// Flags FuncPropNeverReturns
+// funcflags.go T_nested 40 0 1 6
+// Flags FuncPropNeverReturns|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0
+// NodeCounts stmts=0 exprs=9 control=1
 // <endpropsdump>
-// {"Flags":1,"ParamFlags":null,"ResultFlags":[]}
+// {"Flags":["FuncPropNeverReturns","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[],"ResultAffectingParams":1,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":9,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_nested(x int) {
 	if x < 10 {
@@ -33,10 +45,13 @@ func T_nested(x int) {
 	}
 }
 
-// funcflags.go T_block1 41 0 1
-// Flags FuncPropNeverReturns
+// funcflags.go T_block1 56 0 1 6
+// Flags FuncPropNeverReturns|FuncPropStraightLine|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNeverRead
+// NodeCounts stmts=0 exprs=3 control=0
 // <endpropsdump>
-// {"Flags":1,"ParamFlags":null,"ResultFlags":[]}
+// {"Flags":["FuncPropNeverReturns","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[["ParamNeverRead"]],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":3,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_block1(x int) {
 	panic("bad")
@@ -45,9 +60,15 @@ func T_block1(x int) {
 	}
 }
 
-// funcflags.go T_block2 52 0 1
+// funcflags.go T_block2 73 0 1 6
+// Flags FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0
+// NumReturns 1
+// NodeCounts stmts=0 exprs=6 control=2
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[]}
+// {"Flags":["FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":6,"ControlFlow":2},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_block2(x int) {
 	if x < 10 {
@@ -56,10 +77,14 @@ func T_block2(x int) {
 	panic("bad")
 }
 
-// funcflags.go T_switches1 64 0 1
-// Flags FuncPropNeverReturns
+// funcflags.go T_switches1 89 0 1 6
+// Flags FuncPropNeverReturns|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0
+// NodeCounts stmts=2 exprs=12 control=1
 // <endpropsdump>
-// {"Flags":1,"ParamFlags":null,"ResultFlags":[]}
+// {"Flags":["FuncPropNeverReturns","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[],"ResultAffectingParams":1,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":2,"Exprs":12,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_switches1(x int) {
 	switch x {
@@ -71,9 +96,14 @@ func T_switches1(x int) {
 	panic("whatev")
 }
 
-// funcflags.go T_switches1a 78 0 1
+// funcflags.go T_switches1a 108 0 1 6
+// Flags FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0
+// NodeCounts stmts=1 exprs=5 control=1
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[]}
+// {"Flags":["FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[],"ResultAffectingParams":1,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":5,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_switches1a(x int) {
 	switch x {
@@ -82,9 +112,15 @@ func T_switches1a(x int) {
 	}
 }
 
-// funcflags.go T_switches2 89 0 1
+// funcflags.go T_switches2 125 0 1 6
+// Flags FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0
+// NumReturns 1
+// NodeCounts stmts=3 exprs=12 control=2
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[]}
+// {"Flags":["FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":3,"Exprs":12,"ControlFlow":2},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_switches2(x int) {
 	switch x {
@@ -98,9 +134,14 @@ func T_switches2(x int) {
 	panic("whatev")
 }
 
-// funcflags.go T_switches3 105 0 1
+// funcflags.go T_switches3 146 0 1 6
+// Flags FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsTypeAssert
+// ResultAffectingParams 0
+// NodeCounts stmts=2 exprs=9 control=1
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[]}
+// {"Flags":["FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsTypeAssert"]],"ResultFlags":[],"ResultAffectingParams":1,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":2,"Exprs":9,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_switches3(x interface{}) {
 	switch x.(type) {
@@ -111,10 +152,12 @@ func T_switches3(x interface{}) {
 	}
 }
 
-// funcflags.go T_switches4 119 0 1
-// Flags FuncPropNeverReturns
+// funcflags.go T_switches4 162 0 1 6
+// Flags FuncPropNeverReturns|FuncPropIsLeaf
+// ResultAffectingParams 0
+// NodeCounts stmts=4 exprs=14 control=2
 // <endpropsdump>
-// {"Flags":1,"ParamFlags":null,"ResultFlags":[]}
+// {"Flags":["FuncPropNeverReturns","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[],"ResultAffectingParams":1,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":4,"Exprs":14,"ControlFlow":2},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_switches4(x int) {
 	switch x {
@@ -130,9 +173,13 @@ func T_switches4(x int) {
 	panic("whatev")
 }
 
-// funcflags.go T_recov 137 0 1
+// funcflags.go T_recov 184 0 1 6
+// Flags FuncPropContainsRecover|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNeverRead
+// NodeCounts stmts=4 exprs=8 control=1
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[]}
+// {"Flags":["FuncPropContainsRecover","FuncPropIsLeaf"],"ParamFlags":[["ParamNeverRead"]],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":4,"Exprs":8,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_recov(x int) {
 	if x := recover(); x != nil {
@@ -140,10 +187,95 @@ func T_recov(x int) {
 	}
 }
 
-// funcflags.go T_forloops1 148 0 1
-// Flags FuncPropNeverReturns
+// funcflags.go T_defer_recover 209 0 1 6
+// Flags FuncPropContainsRecover|FuncPropStraightLine|FuncPropContainsDefer
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 0
+// NumCalls 1
+// NodeCounts stmts=3 exprs=3 control=1
+// Hotspot funcflags.go:210:8
+// <endpropsdump>
+// {"Flags":["FuncPropContainsRecover","FuncPropStraightLine","FuncPropContainsDefer"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":1,"NodeCounts":{"Stmts":3,"Exprs":3,"ControlFlow":1},"Hotspot":"funcflags.go:210:8","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// funcflags.go T_defer_recover.func1 210 0 1 8
+// Flags FuncPropContainsRecover|FuncPropIsLeaf
+// NodeCounts stmts=5 exprs=9 control=1
+// Captures vars=1 byref=1
+// <endpropsdump>
+// {"Flags":["FuncPropContainsRecover","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":5,"Exprs":9,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":1,"ByRef":1,"Escapes":false}}
+// <endfuncpreamble>
+func T_defer_recover(x int) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = r.(error)
+		}
+	}()
+	println(x)
+	return nil
+}
+
+// funcflags.go T_defer_norecover 235 0 1 6
+// Flags FuncPropStraightLine|FuncPropContainsDefer
+// ResultAffectingParams 0
+// MaxCallArgs 0
+// NumCalls 1
+// NodeCounts stmts=3 exprs=2 control=0
+// Hotspot funcflags.go:236:8
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropContainsDefer"],"ParamFlags":[[]],"ResultFlags":[],"ResultAffectingParams":1,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":1,"NodeCounts":{"Stmts":3,"Exprs":2,"ControlFlow":0},"Hotspot":"funcflags.go:236:8","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// funcflags.go T_defer_norecover.func1 236 0 1 8
+// Flags FuncPropStraightLine|FuncPropIsLeaf
+// NodeCounts stmts=1 exprs=1 control=0
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":1,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_defer_norecover(x int) {
+	defer func() {
+		println("done")
+	}()
+	println(x)
+}
+
+// funcflags.go T_closure_recover_not_deferred 263 0 1 6
+// Flags FuncPropStraightLine|FuncPropIsLeaf
+// ResultFlags
+//   0 ResultAlwaysSameFunc
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=3 exprs=5 control=1
+// Hotspot funcflags.go:264:7
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[["ResultAlwaysSameFunc"]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":3,"Exprs":5,"ControlFlow":1},"Hotspot":"funcflags.go:264:7","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// funcflags.go T_closure_recover_not_deferred.func1 264 0 1 7
+// Flags FuncPropContainsRecover|FuncPropStraightLine|FuncPropIsLeaf
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=2 exprs=1 control=1
+// Captures vars=0 byref=0 escapes
+// <endpropsdump>
+// {"Flags":["FuncPropContainsRecover","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[[]],"ResultAffectingParams":0,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":2,"Exprs":1,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":true}}
+// <endfuncpreamble>
+func T_closure_recover_not_deferred(x int) func() any {
+	f := func() any {
+		return recover()
+	}
+	println(x)
+	return f
+}
+
+// funcflags.go T_forloops1 280 0 1 6
+// Flags FuncPropNeverReturns|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNeverRead
+// NodeCounts stmts=0 exprs=3 control=1
+// Hotspot funcflags.go:281:2
 // <endpropsdump>
-// {"Flags":1,"ParamFlags":null,"ResultFlags":[]}
+// {"Flags":["FuncPropNeverReturns","FuncPropIsLeaf"],"ParamFlags":[["ParamNeverRead"]],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":3,"ControlFlow":1},"Hotspot":"funcflags.go:281:2","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_forloops1(x int) {
 	for {
@@ -151,9 +283,14 @@ func T_forloops1(x int) {
 	}
 }
 
-// funcflags.go T_forloops2 158 0 1
+// funcflags.go T_forloops2 295 0 1 6
+// Flags FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNeverRead
+// NodeCounts stmts=1 exprs=4 control=2
+// Hotspot funcflags.go:296:2
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[]}
+// {"Flags":["FuncPropIsLeaf"],"ParamFlags":[["ParamNeverRead"]],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":4,"ControlFlow":2},"Hotspot":"funcflags.go:296:2","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_forloops2(x int) {
 	for {
@@ -165,9 +302,14 @@ func T_forloops2(x int) {
 	}
 }
 
-// funcflags.go T_forloops3 172 0 1
+// funcflags.go T_forloops3 314 0 1 6
+// Flags FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNeverRead
+// NodeCounts stmts=6 exprs=22 control=3
+// Hotspot funcflags.go:315:2
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[]}
+// {"Flags":["FuncPropIsLeaf"],"ParamFlags":[["ParamNeverRead"]],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":6,"Exprs":22,"ControlFlow":3},"Hotspot":"funcflags.go:315:2","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_forloops3(x int) {
 	for i := 0; i < 101; i++ {
@@ -184,9 +326,12 @@ func T_forloops3(x int) {
 	panic("whatev")
 }
 
-// funcflags.go T_hasgotos 191 0 1
+// funcflags.go T_hasgotos 336 0 1 6
+// Flags FuncPropIsLeaf
+// ResultAffectingParams 0 1
+// NodeCounts stmts=3 exprs=10 control=7
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[]}
+// {"Flags":["FuncPropIsLeaf"],"ParamFlags":[[],[]],"ResultFlags":[],"ResultAffectingParams":3,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":3,"Exprs":10,"ControlFlow":7},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_hasgotos(x int, y int) {
 	{
@@ -211,9 +356,16 @@ func T_hasgotos(x int, y int) {
 	}
 }
 
-// funcflags.go T_break_with_label 218 0 1
+// funcflags.go T_break_with_label 370 0 1 6
+// Flags FuncPropIsLeaf
+// ParamFlags
+//   0 ParamMayFeedIfOrSwitch
+//   1 ParamNeverRead
+// ResultAffectingParams 0
+// NodeCounts stmts=1 exprs=10 control=4
+// Hotspot funcflags.go:375:2
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[]}
+// {"Flags":["FuncPropIsLeaf"],"ParamFlags":[["ParamMayFeedIfOrSwitch"],["ParamNeverRead"]],"ResultFlags":[],"ResultAffectingParams":1,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":10,"ControlFlow":4},"Hotspot":"funcflags.go:375:2","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_break_with_label(x int, y int) {
 	// presence of break with label should pessimize this func
@@ -229,10 +381,19 @@ lab1:
 	}
 }
 
-// funcflags.go T_callsexit 237 0 1
+// funcflags.go T_callsexit 398 0 1 6
 // Flags FuncPropNeverReturns
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0
+// MaxCallArgs 1
+// NumCalls 2
+// NodeCounts stmts=2 exprs=7 control=1
+// CallSites
+//   0 funcflags.go:400:10 CallSiteOnPanicPath|CallSiteAllArgsConst Exit
+//   1 funcflags.go:402:9 CallSiteAllArgsConst Exit
 // <endpropsdump>
-// {"Flags":1,"ParamFlags":null,"ResultFlags":[]}
+// {"Flags":["FuncPropNeverReturns"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[],"ResultAffectingParams":1,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":1,"NumCalls":2,"NodeCounts":{"Stmts":2,"Exprs":7,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_callsexit(x int) {
 	if x < 0 {
@@ -241,9 +402,15 @@ func T_callsexit(x int) {
 	os.Exit(2)
 }
 
-// funcflags.go T_exitinexpr 248 0 1
+// funcflags.go T_exitinexpr 415 0 1 6
+// ResultAffectingParams 0
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=2 exprs=5 control=1
+// CallSites
+//   0 funcflags.go:420:18 CallSiteResultFeedsCond exprcallsexit score=62 adj=0
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[]}
+// {"Flags":[],"ParamFlags":[[]],"ResultFlags":[],"ResultAffectingParams":1,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":2,"Exprs":5,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_exitinexpr(x int) {
 	// This function does indeed unconditionally call exit, since the
@@ -255,10 +422,13 @@ func T_exitinexpr(x int) {
 	}
 }
 
-// funcflags.go T_select_noreturn 264 0 1
-// Flags FuncPropNeverReturns
+// funcflags.go T_select_noreturn 433 0 1 6
+// Flags FuncPropNeverReturns|FuncPropMayBlock|FuncPropIsLeaf|FuncPropUsesChannels|FuncPropUsesSelect
+// ResultAffectingParams 2
+// NodeCounts stmts=11 exprs=24 control=1
+// Hotspot funcflags.go:435:2
 // <endpropsdump>
-// {"Flags":1,"ParamFlags":null,"ResultFlags":[]}
+// {"Flags":["FuncPropNeverReturns","FuncPropMayBlock","FuncPropIsLeaf","FuncPropUsesChannels","FuncPropUsesSelect"],"ParamFlags":[[],[],[]],"ResultFlags":[],"ResultAffectingParams":4,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":11,"Exprs":24,"ControlFlow":1},"Hotspot":"funcflags.go:435:2","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_select_noreturn(chi chan int, chf chan float32, p *int) {
 	rv := 0
@@ -272,9 +442,14 @@ func T_select_noreturn(chi chan int, chf chan float32, p *int) {
 	panic("bad")
 }
 
-// funcflags.go T_select_mayreturn 281 0 1
+// funcflags.go T_select_mayreturn 454 0 1 6
+// Flags FuncPropMayBlock|FuncPropIsLeaf|FuncPropUsesChannels|FuncPropUsesSelect
+// ResultAffectingParams 2
+// NumReturns 1
+// NodeCounts stmts=11 exprs=25 control=2
+// Hotspot funcflags.go:456:2
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[0]}
+// {"Flags":["FuncPropMayBlock","FuncPropIsLeaf","FuncPropUsesChannels","FuncPropUsesSelect"],"ParamFlags":[[],[],[]],"ResultFlags":[[]],"ResultAffectingParams":4,"NumReturns":1,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":11,"Exprs":25,"ControlFlow":2},"Hotspot":"funcflags.go:456:2","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_select_mayreturn(chi chan int, chf chan float32, p *int) int {
 	rv := 0
@@ -289,7 +464,1050 @@ func T_select_mayreturn(chi chan int, chf chan float32, p *int) int {
 	panic("bad")
 }
 
+// funcflags.go T_select_default 475 0 1 6
+// Flags FuncPropIsLeaf|FuncPropUsesChannels|FuncPropUsesSelect
+// ResultAffectingParams 0 1
+// NumReturns 2
+// NodeCounts stmts=3 exprs=4 control=3
+// <endpropsdump>
+// {"Flags":["FuncPropIsLeaf","FuncPropUsesChannels","FuncPropUsesSelect"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":3,"Exprs":4,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_select_default(ch chan int, x int) bool {
+	select {
+	case ch <- x:
+		return true
+	default:
+		return false
+	}
+}
+
+// funcflags.go T_blocking_recv 494 0 1 6
+// Flags FuncPropMayBlock|FuncPropStraightLine|FuncPropIsLeaf|FuncPropUsesChannels
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=2 exprs=7 control=1
+// Hotspot funcflags.go:495:7
+// <endpropsdump>
+// {"Flags":["FuncPropMayBlock","FuncPropStraightLine","FuncPropIsLeaf","FuncPropUsesChannels"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":2,"Exprs":7,"ControlFlow":1},"Hotspot":"funcflags.go:495:7","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_blocking_recv(ch chan int) int {
+	v := <-ch
+	return v + 1
+}
+
+// funcflags.go T_blocking_select 507 0 1 6
+// Flags FuncPropMayBlock|FuncPropIsLeaf|FuncPropUsesChannels|FuncPropUsesSelect
+// NumReturns 2
+// NodeCounts stmts=6 exprs=13 control=3
+// Hotspot funcflags.go:508:2
+// <endpropsdump>
+// {"Flags":["FuncPropMayBlock","FuncPropIsLeaf","FuncPropUsesChannels","FuncPropUsesSelect"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":0,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":6,"Exprs":13,"ControlFlow":3},"Hotspot":"funcflags.go:508:2","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_blocking_select(a, b chan int) int {
+	select {
+	case v := <-a:
+		return v
+	case v := <-b:
+		return -v
+	}
+}
+
+// funcflags.go T_range_chan 526 0 1 6
+// Flags FuncPropMayBlock|FuncPropIsLeaf|FuncPropUsesChannels
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=4 exprs=9 control=2
+// Hotspot funcflags.go:528:11
+// <endpropsdump>
+// {"Flags":["FuncPropMayBlock","FuncPropIsLeaf","FuncPropUsesChannels"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":4,"Exprs":9,"ControlFlow":2},"Hotspot":"funcflags.go:528:11","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_range_chan(ch chan int) int {
+	t := 0
+	for v := range ch {
+		t += v
+	}
+	return t
+}
+
+// funcflags.go T_mutex_lock 547 0 1 6
+// Flags FuncPropMayBlock|FuncPropStraightLine|FuncPropUsesMutex
+// ResultAffectingParams 0 1
+// MaxCallArgs 1
+// NumCalls 2
+// NodeCounts stmts=3 exprs=7 control=0
+// Hotspot funcflags.go:548:9
+// CallSites
+//   0 funcflags.go:548:9 0 (*Mutex).Lock score=66 adj=0
+//   1 funcflags.go:550:11 0 (*Mutex).Unlock score=72 adj=0
+// <endpropsdump>
+// {"Flags":["FuncPropMayBlock","FuncPropStraightLine","FuncPropUsesMutex"],"ParamFlags":[[],[]],"ResultFlags":[],"ResultAffectingParams":3,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":1,"NumCalls":2,"NodeCounts":{"Stmts":3,"Exprs":7,"ControlFlow":0},"Hotspot":"funcflags.go:548:9","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_mutex_lock(mu *sync.Mutex, p *int) {
+	mu.Lock()
+	*p++
+	mu.Unlock()
+}
+
+// funcflags.go T_sleep 565 0 1 6
+// Flags FuncPropIsWrapper|FuncPropMayBlock|FuncPropStraightLine|FuncPropTrivialWrapper
+// ResultAffectingParams 0
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=2 control=0
+// Hotspot funcflags.go:566:12
+// CallSites
+//   0 funcflags.go:566:12 0 Sleep
+// <endpropsdump>
+// {"Flags":["FuncPropIsWrapper","FuncPropMayBlock","FuncPropStraightLine","FuncPropTrivialWrapper"],"ParamFlags":[[]],"ResultFlags":[],"ResultAffectingParams":1,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":2,"ControlFlow":0},"Hotspot":"funcflags.go:566:12","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// funcflags.go T_send_in_closure 589 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultFlags
+//   0 ResultAlwaysSameInlinableFunc
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=1 control=1
+// Hotspot funcflags.go:590:9
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[["ResultAlwaysSameInlinableFunc"]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":1,"ControlFlow":1},"Hotspot":"funcflags.go:590:9","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// funcflags.go T_send_in_closure.func1 590 0 1 9
+// Flags FuncPropMayBlock|FuncPropStraightLine|FuncPropIsLeaf|FuncPropUsesChannels
+// NodeCounts stmts=1 exprs=2 control=0
+// Hotspot funcflags.go:591:6
+// Captures vars=1 byref=0 escapes
+// <endpropsdump>
+// {"Flags":["FuncPropMayBlock","FuncPropStraightLine","FuncPropIsLeaf","FuncPropUsesChannels"],"ParamFlags":[],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":2,"ControlFlow":0},"Hotspot":"funcflags.go:591:6","Captures":{"Vars":1,"ByRef":0,"Escapes":true}}
+// <endfuncpreamble>
+func T_send_in_closure(ch chan int) func() {
+	return func() {
+		ch <- 1
+	}
+}
+
 func exprcallsexit(x int) int {
 	os.Exit(x)
 	return x
 }
+
+// funcflags.go T_pure_arith 611 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=5 control=1
+// SpecializationHints
+//   1 y calls=1 value=2
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":5,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_pure_arith(x, y int) int {
+	return x*y + 1
+}
+
+// funcflags.go T_pure_calls_pure 628 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropNearlyLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 1
+// NodeCounts stmts=5 exprs=19 control=1
+// CallSites
+//   0 funcflags.go:630:21 0 T_pure_arith score=-4 adj=leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":1,"NodeCounts":{"Stmts":5,"Exprs":19,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_pure_calls_pure(x int) int {
+	var a [2]int
+	a[0] = T_pure_arith(x, 2)
+	a[1] = x
+	return a[0] + a[1]
+}
+
+var GI int
+
+// funcflags.go T_impure_global_write 646 0 1 6
+// Flags FuncPropStraightLine|FuncPropIsLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=1 exprs=5 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":5,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_impure_global_write(x int) int {
+	GI = x
+	return x + 1
+}
+
+// funcflags.go T_impure_ptr_write 658 0 1 6
+// Flags FuncPropStraightLine|FuncPropIsLeaf
+// ResultAffectingParams 0 1
+// NodeCounts stmts=1 exprs=3 control=0
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[],[]],"ResultFlags":[],"ResultAffectingParams":3,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":3,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_impure_ptr_write(p *int, x int) {
+	*p = x
+}
+
+// funcflags.go T_impure_calls_impure 675 0 1 6
+// Flags FuncPropStraightLine|FuncPropNearlyLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=4 control=1
+// CallSites
+//   0 funcflags.go:676:30 0 T_impure_global_write score=-3 adj=leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":4,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_impure_calls_impure(x int) int {
+	return T_impure_global_write(x) + 1
+}
+
+// funcflags.go T_calls_fatal_wrapper 691 0 1 6
+// Flags FuncPropNeverReturns|FuncPropStraightLine|FuncPropNearlyLeaf
+// ParamFlags
+//   0 ParamNeverRead
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=2 control=0
+// CallSites
+//   0 funcflags.go:692:14 CallSiteAllArgsConst fatalWrapper score=-7 adj=leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropNeverReturns","FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[["ParamNeverRead"]],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":2,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_calls_fatal_wrapper(x int) {
+	fatalWrapper("bad")
+}
+
+// funcflags.go T_calls_fatal_wrapper_cond 710 0 1 6
+// Flags FuncPropNearlyLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=6 control=2
+// CallSites
+//   0 funcflags.go:712:15 CallSiteOnPanicPath|CallSiteAllArgsConst fatalWrapper score=13 adj=leafAdj|errorPathAdj
+// <endpropsdump>
+// {"Flags":["FuncPropNearlyLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":6,"ControlFlow":2},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_calls_fatal_wrapper_cond(x int) int {
+	if x < 0 {
+		fatalWrapper("neg")
+	}
+	return x
+}
+
+// funcflags.go T_calls_exit_wrapper_wrapper 730 0 1 6
+// Flags FuncPropNeverReturns
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=8 control=1
+// CallSites
+//   0 funcflags.go:732:21 CallSiteOnPanicPath exitWrapperWrapper score=3 adj=trivialWrapperAdj|errorPathAdj
+// <endpropsdump>
+// {"Flags":["FuncPropNeverReturns"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[],"ResultAffectingParams":1,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":8,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_calls_exit_wrapper_wrapper(x int) {
+	if x != 0 {
+		exitWrapperWrapper(x)
+	}
+	panic("zero")
+}
+
+func fatalWrapper(msg string) {
+	panic(msg)
+}
+
+func exitWrapperWrapper(code int) {
+	exitWrapper(code)
+}
+
+func exitWrapper(code int) {
+	os.Exit(code)
+}
+
+// funcflags.go T_rec_calls_fatal 760 0 1 6
+// Flags FuncPropNeverReturns|FuncPropIsWrapper|FuncPropStraightLine|FuncPropRecursive|FuncPropTrivialWrapper
+// ResultAffectingParams 0
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=2 control=0
+// CallSites
+//   0 funcflags.go:761:10 0 recFatal score=72 adj=0
+// <endpropsdump>
+// {"Flags":["FuncPropNeverReturns","FuncPropIsWrapper","FuncPropStraightLine","FuncPropRecursive","FuncPropTrivialWrapper"],"ParamFlags":[[]],"ResultFlags":[],"ResultAffectingParams":1,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":2,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_rec_calls_fatal(x int) {
+	recFatal(x)
+}
+
+func recFatal(x int) {
+	if x > 0 {
+		T_rec_calls_fatal(x - 1)
+	}
+	panic("done")
+}
+
+// funcflags.go T_simple_defer 790 0 1 6
+// Flags FuncPropStraightLine|FuncPropContainsDefer
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 0
+// NumCalls 1
+// NodeCounts stmts=2 exprs=4 control=1
+// Hotspot funcflags.go:791:8
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropContainsDefer"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":1,"NodeCounts":{"Stmts":2,"Exprs":4,"ControlFlow":1},"Hotspot":"funcflags.go:791:8","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// funcflags.go T_simple_defer.func1 791 0 1 8
+// Flags FuncPropStraightLine|FuncPropIsLeaf
+// NodeCounts stmts=1 exprs=1 control=0
+// Captures vars=1 byref=0
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":1,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":1,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_simple_defer(x int) int {
+	defer func() { println(x) }()
+	return x + 1
+}
+
+// funcflags.go T_loop_defer 814 0 1 6
+// Flags FuncPropContainsDefer|FuncPropOpenDeferIneligible
+// ParamFlags
+//   0 ParamFeedsLoopBound
+// ResultAffectingParams 0
+// MaxCallArgs 0
+// NumCalls 1
+// NodeCounts stmts=3 exprs=5 control=1
+// Hotspot funcflags.go:815:14
+// <endpropsdump>
+// {"Flags":["FuncPropContainsDefer","FuncPropOpenDeferIneligible"],"ParamFlags":[["ParamFeedsLoopBound"]],"ResultFlags":[],"ResultAffectingParams":1,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":1,"NodeCounts":{"Stmts":3,"Exprs":5,"ControlFlow":1},"Hotspot":"funcflags.go:815:14","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// funcflags.go T_loop_defer.func1 816 0 1 9
+// Flags FuncPropStraightLine|FuncPropIsLeaf
+// NodeCounts stmts=1 exprs=1 control=0
+// Captures vars=1 byref=1
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":1,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":1,"ByRef":1,"Escapes":false}}
+// <endfuncpreamble>
+func T_loop_defer(xs []int) {
+	for _, x := range xs {
+		defer func() { println(x) }()
+	}
+}
+
+// funcflags.go T_label_defer 837 0 1 6
+// Flags FuncPropContainsDefer|FuncPropOpenDeferIneligible
+// ResultAffectingParams 0
+// MaxCallArgs 0
+// NumCalls 1
+// NodeCounts stmts=3 exprs=6 control=3
+// Hotspot funcflags.go:839:8
+// <endpropsdump>
+// {"Flags":["FuncPropContainsDefer","FuncPropOpenDeferIneligible"],"ParamFlags":[[]],"ResultFlags":[],"ResultAffectingParams":1,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":1,"NodeCounts":{"Stmts":3,"Exprs":6,"ControlFlow":3},"Hotspot":"funcflags.go:839:8","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// funcflags.go T_label_defer.func1 839 0 1 8
+// Flags FuncPropStraightLine|FuncPropIsLeaf
+// NodeCounts stmts=1 exprs=1 control=0
+// Captures vars=1 byref=1
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":1,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":1,"ByRef":1,"Escapes":false}}
+// <endfuncpreamble>
+func T_label_defer(x int) {
+again:
+	defer func() { println(x) }()
+	x--
+	if x > 0 {
+		goto again
+	}
+}
+
+// funcflags.go T_many_returns_defer 871 0 1 6
+// Flags FuncPropContainsDefer|FuncPropOpenDeferIneligible
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0
+// NumReturns 8
+// MaxCallArgs 0
+// NumCalls 2
+// NodeCounts stmts=11 exprs=18 control=9
+// Hotspot funcflags.go:872:8
+// <endpropsdump>
+// {"Flags":["FuncPropContainsDefer","FuncPropOpenDeferIneligible"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":8,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":2,"NodeCounts":{"Stmts":11,"Exprs":18,"ControlFlow":9},"Hotspot":"funcflags.go:872:8","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// funcflags.go T_many_returns_defer.func1 872 0 1 8
+// Flags FuncPropStraightLine|FuncPropIsLeaf
+// NodeCounts stmts=1 exprs=1 control=0
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":1,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// funcflags.go T_many_returns_defer.func2 873 0 1 8
+// Flags FuncPropStraightLine|FuncPropIsLeaf
+// NodeCounts stmts=1 exprs=1 control=0
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":1,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_many_returns_defer(x int) int {
+	defer func() { println(1) }()
+	defer func() { println(2) }()
+	switch x {
+	case 1:
+		return 10
+	case 2:
+		return 20
+	case 3:
+		return 30
+	case 4:
+		return 40
+	case 5:
+		return 50
+	case 6:
+		return 60
+	case 7:
+		return 70
+	}
+	return 0
+}
+
+// funcflags.go T_self_recursive 907 0 1 6
+// Flags FuncPropIsPure|FuncPropRecursive
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0
+// NumReturns 2
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=10 control=3
+// CallSites
+//   0 funcflags.go:911:29 0 T_self_recursive
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropRecursive"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":10,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_self_recursive(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return n * T_self_recursive(n-1)
+}
+
+// funcflags.go T_mutually_recursive_even 928 0 1 6
+// Flags FuncPropRecursive
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0
+// NumReturns 2
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=8 control=3
+// CallSites
+//   0 funcflags.go:932:33 CallSiteTailPos T_mutually_recursive_odd
+// <endpropsdump>
+// {"Flags":["FuncPropRecursive"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":8,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_mutually_recursive_even(n int) bool {
+	if n == 0 {
+		return true
+	}
+	return T_mutually_recursive_odd(n - 1)
+}
+
+// funcflags.go T_mutually_recursive_odd 949 0 1 6
+// Flags FuncPropRecursive
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0
+// NumReturns 2
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=8 control=3
+// CallSites
+//   0 funcflags.go:953:34 CallSiteTailPos T_mutually_recursive_even score=64 adj=tailCallAdj
+// <endpropsdump>
+// {"Flags":["FuncPropRecursive"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":8,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_mutually_recursive_odd(n int) bool {
+	if n == 0 {
+		return false
+	}
+	return T_mutually_recursive_even(n - 1)
+}
+
+// funcflags.go T_calls_recursive 969 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=4 control=1
+// CallSites
+//   0 funcflags.go:970:25 0 T_self_recursive
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":4,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_calls_recursive(n int) int {
+	return T_self_recursive(n) + 1
+}
+
+// funcflags.go T_leaf 984 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNoInfo
+//   1 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0 1
+// NumReturns 2
+// NodeCounts stmts=0 exprs=14 control=3
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[[],["ParamFeedsIfOrSwitch"]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":14,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_leaf(p *[4]int, i int) int {
+	if i < 0 || i >= len(p) {
+		return -1
+	}
+	return p[i] * 2
+}
+
+// funcflags.go T_spawns_goroutine 1009 0 1 6
+// Flags FuncPropStraightLine|FuncPropSpawnsGoroutine
+// ResultAffectingParams 0
+// MaxCallArgs 0
+// NumCalls 1
+// NodeCounts stmts=2 exprs=1 control=0
+// Hotspot funcflags.go:1010:5
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropSpawnsGoroutine"],"ParamFlags":[[]],"ResultFlags":[],"ResultAffectingParams":1,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":1,"NodeCounts":{"Stmts":2,"Exprs":1,"ControlFlow":0},"Hotspot":"funcflags.go:1010:5","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// funcflags.go T_spawns_goroutine.func1 1010 0 1 5
+// Flags FuncPropMayBlock|FuncPropStraightLine|FuncPropIsLeaf|FuncPropUsesChannels
+// NodeCounts stmts=1 exprs=2 control=0
+// Hotspot funcflags.go:1010:17
+// Captures vars=1 byref=0 escapes
+// <endpropsdump>
+// {"Flags":["FuncPropMayBlock","FuncPropStraightLine","FuncPropIsLeaf","FuncPropUsesChannels"],"ParamFlags":[],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":2,"ControlFlow":0},"Hotspot":"funcflags.go:1010:17","Captures":{"Vars":1,"ByRef":0,"Escapes":true}}
+// <endfuncpreamble>
+func T_spawns_goroutine(ch chan int) {
+	go func() { ch <- 1 }()
+}
+
+// funcflags.go T_chan_ops 1021 0 1 6
+// Flags FuncPropMayBlock|FuncPropIsLeaf|FuncPropUsesChannels
+// ResultAffectingParams 0 1
+// NodeCounts stmts=2 exprs=5 control=1
+// Hotspot funcflags.go:1022:11
+// <endpropsdump>
+// {"Flags":["FuncPropMayBlock","FuncPropIsLeaf","FuncPropUsesChannels"],"ParamFlags":[[],[]],"ResultFlags":[],"ResultAffectingParams":3,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":2,"Exprs":5,"ControlFlow":1},"Hotspot":"funcflags.go:1022:11","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_chan_ops(in, out chan int) {
+	for v := range in {
+		out <- v
+	}
+}
+
+// funcflags.go T_select_poll 1034 0 1 6
+// Flags FuncPropIsLeaf|FuncPropUsesChannels|FuncPropUsesSelect
+// NumReturns 2
+// NodeCounts stmts=3 exprs=6 control=3
+// <endpropsdump>
+// {"Flags":["FuncPropIsLeaf","FuncPropUsesChannels","FuncPropUsesSelect"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":0,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":3,"Exprs":6,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_select_poll(ch chan int) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+// funcflags.go T_mutex_unlock 1058 0 1 6
+// Flags FuncPropMayBlock|FuncPropStraightLine|FuncPropUsesMutex
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 2
+// NodeCounts stmts=4 exprs=9 control=1
+// Hotspot funcflags.go:1059:10
+// CallSites
+//   0 funcflags.go:1059:10 0 (*RWMutex).RLock score=77 adj=0
+//   1 funcflags.go:1061:12 0 (*RWMutex).RUnlock score=78 adj=0
+// <endpropsdump>
+// {"Flags":["FuncPropMayBlock","FuncPropStraightLine","FuncPropUsesMutex"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":2,"NodeCounts":{"Stmts":4,"Exprs":9,"ControlFlow":1},"Hotspot":"funcflags.go:1059:10","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_mutex_unlock(mu *sync.RWMutex, p *int) int {
+	mu.RLock()
+	v := *p
+	mu.RUnlock()
+	return v
+}
+
+// funcflags.go T_concat 1079 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf|FuncPropConcatDominated
+// ParamFlags
+//   0 ParamFeedsStringConcat
+//   1 ParamFeedsStringConcat
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=4 control=1
+// SpecializationHints
+//   0 prefix calls=1 value="hello"
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf","FuncPropConcatDominated"],"ParamFlags":[["ParamFeedsStringConcat"],["ParamFeedsStringConcat"]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":4,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_concat(prefix, name string) string {
+	return prefix + ": " + name
+}
+
+// funcflags.go T_concat_fmt 1100 0 1 6
+// Flags FuncPropStraightLine|FuncPropAllocates|FuncPropConcatDominated
+// ParamFlags
+//   0 ParamFeedsStringConcat
+//   1 ParamFeedsStringConcat
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 3
+// NumCalls 1
+// NodeCounts stmts=1 exprs=9 control=1
+// Hotspot funcflags.go:1101:19
+// CallSites
+//   0 funcflags.go:1101:19 CallSiteTailPos Sprint
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropAllocates","FuncPropConcatDominated"],"ParamFlags":[["ParamFeedsStringConcat"],["ParamFeedsStringConcat"]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":3,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":9,"ControlFlow":1},"Hotspot":"funcflags.go:1101:19","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_concat_fmt(key string, val []byte) string {
+	return fmt.Sprint(key, "=", string(val))
+}
+
+// funcflags.go T_append_bytes 1116 0 1 6
+// Flags FuncPropStraightLine|FuncPropAllocates|FuncPropIsLeaf|FuncPropConcatDominated
+// ParamFlags
+//   0 ParamNoInfo
+//   1 ParamFeedsStringConcat
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=1 exprs=2 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropAllocates","FuncPropIsLeaf","FuncPropConcatDominated"],"ParamFlags":[[],["ParamFeedsStringConcat"]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":2,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_append_bytes(b []byte, s string) []byte {
+	return append(b, s...)
+}
+
+// funcflags.go T_concat_reassigned 1132 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNoInfo
+//   1 ParamFeedsStringConcat
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=1 exprs=8 control=2
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[[],["ParamFeedsStringConcat"]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":8,"ControlFlow":2},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_concat_reassigned(prefix, name string) string {
+	if prefix == "" {
+		prefix = "none"
+	}
+	return prefix + name
+}
+
+// funcflags.go T_concat_minor 1152 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsStringConcat
+//   1 ParamFeedsLoopBound
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=5 exprs=22 control=3
+// Hotspot funcflags.go:1154:14
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsStringConcat"],["ParamFeedsLoopBound"]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":5,"Exprs":22,"ControlFlow":3},"Hotspot":"funcflags.go:1154:14","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_concat_minor(s string, p []int) int {
+	t := 0
+	for _, v := range p {
+		if v > 0 {
+			t += v * 2
+		} else {
+			t -= v
+		}
+	}
+	return t + len(s+"x")
+}
+
+// funcflags.go T_concat_caller 1177 0 1 6
+// Flags FuncPropIsWrapper|FuncPropIsPure|FuncPropStraightLine|FuncPropNearlyLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 1
+// NodeCounts stmts=1 exprs=3 control=1
+// CallSites
+//   0 funcflags.go:1178:17 CallSiteTailPos T_concat score=-25 adj=tailCallAdj|leafAdj|constConcatArgAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsWrapper","FuncPropIsPure","FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_concat_caller(name string) string {
+	return T_concat("hello", name)
+}
+
+type recvT struct {
+	a    int
+	b    []int
+	m    map[string]int
+	next *recvT
+}
+
+var recvGlobal int
+
+// funcflags.go (*recvT).T_recv_get 1199 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf|FuncPropReceiverOnly
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=2 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf","FuncPropReceiverOnly"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":2,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func (r *recvT) T_recv_get() int {
+	return r.a
+}
+
+// funcflags.go (*recvT).T_recv_set 1210 0 1 6
+// Flags FuncPropStraightLine|FuncPropIsLeaf|FuncPropReceiverOnly
+// ResultAffectingParams 0 1
+// NodeCounts stmts=1 exprs=3 control=0
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropIsLeaf","FuncPropReceiverOnly"],"ParamFlags":[[],[]],"ResultFlags":[],"ResultAffectingParams":3,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":3,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func (r *recvT) T_recv_set(v int) {
+	r.a = v
+}
+
+// funcflags.go recvT.T_recv_val_sum 1223 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf|FuncPropReceiverOnly
+// ResultAffectingParams 0 1 2
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=12 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf","FuncPropReceiverOnly"],"ParamFlags":[[],[],[]],"ResultFlags":[[]],"ResultAffectingParams":7,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":12,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func (r recvT) T_recv_val_sum(i int, k string) int {
+	return r.a + r.b[i] + r.m[k]
+}
+
+// funcflags.go (*recvT).T_recv_next_a 1235 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf|FuncPropReceiverOnly
+// ResultAffectingParams 0
+// NumReturns 2
+// NodeCounts stmts=0 exprs=8 control=3
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf","FuncPropReceiverOnly"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":8,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func (r *recvT) T_recv_next_a() int {
+	if r.next == nil {
+		return 0
+	}
+	return r.next.a
+}
+
+// funcflags.go (*recvT).T_recv_global 1251 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=4 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":4,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func (r *recvT) T_recv_global() int {
+	return r.a + recvGlobal
+}
+
+// funcflags.go (*recvT).T_recv_call 1268 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropNearlyLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=3 control=1
+// CallSites
+//   0 funcflags.go:1269:26 CallSiteTailPos (*recvT).T_recv_get score=-12 adj=tailCallAdj|leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func (r *recvT) T_recv_call() int {
+	return r.next.T_recv_get()
+}
+
+// funcflags.go (*recvT).T_recv_other 1281 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=5 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":5,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func (r *recvT) T_recv_other(o *recvT) int {
+	return r.a + o.a
+}
+
+// funcflags.go (*recvT).T_recv_nofields 1297 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNeverRead
+//   1 ParamNoInfo
+// ResultAffectingParams 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=3 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[["ParamNeverRead"],[]],"ResultFlags":[[]],"ResultAffectingParams":2,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func (r *recvT) T_recv_nofields(x int) int {
+	return x * 2
+}
+
+// funcflags.go T_recv_not_method 1310 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=2 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":2,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_recv_not_method(r *recvT) int {
+	return r.a
+}
+
+// funcflags.go T_impure_atomic_load 1327 0 1 6
+// Flags FuncPropIsWrapper|FuncPropStraightLine|FuncPropIsLeaf|FuncPropTrivialWrapper
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=2 control=1
+// CallSites
+//   0 funcflags.go:1328:25 CallSiteTailPos LoadInt32
+// <endpropsdump>
+// {"Flags":["FuncPropIsWrapper","FuncPropStraightLine","FuncPropIsLeaf","FuncPropTrivialWrapper"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":2,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_impure_atomic_load(p *int32) int32 {
+	return atomic.LoadInt32(p)
+}
+
+// funcflags.go T_impure_atomic_method 1344 0 1 6
+// Flags FuncPropStraightLine
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=4 control=1
+// CallSites
+//   0 funcflags.go:1345:15 0 (*Int64).Load score=4 adj=0
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":4,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_impure_atomic_method(c *atomic.Int64) int64 {
+	return c.Load() + 1
+}
+
+// funcflags.go T_hint_hot 1358 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf|FuncPropHintHot
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=3 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf","FuncPropHintHot"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+//go:inlinehint hot
+func T_hint_hot(x int) int {
+	return x + 1
+}
+
+// funcflags.go T_hint_cold 1372 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf|FuncPropHintCold
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=3 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf","FuncPropHintCold"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+//go:inlinehint cold
+func T_hint_cold(x int) int {
+	return x + 1
+}
+
+// funcflags.go T_hint_prefer_inline 1386 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf|FuncPropHintPreferInline
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=3 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf","FuncPropHintPreferInline"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+//go:inlinehint prefer-inline
+func T_hint_prefer_inline(x int) int {
+	return x + 1
+}
+
+// funcflags.go T_capture_byval 1411 0 1 6
+// Flags FuncPropStraightLine
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 0
+// NumCalls 1
+// NodeCounts stmts=1 exprs=1 control=1
+// Hotspot funcflags.go:1412:9
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":1,"ControlFlow":1},"Hotspot":"funcflags.go:1412:9","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// funcflags.go T_capture_byval.func1 1412 0 1 9
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=3 control=1
+// Captures vars=2 byref=0
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[[]],"ResultAffectingParams":0,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":2,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_capture_byval(x, y int) int {
+	return func() int {
+		return x + y
+	}()
+}
+
+// funcflags.go T_capture_byref 1436 0 1 6
+// Flags FuncPropStraightLine
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 0
+// NumCalls 2
+// NodeCounts stmts=4 exprs=6 control=1
+// Hotspot funcflags.go:1437:7
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":2,"NodeCounts":{"Stmts":4,"Exprs":6,"ControlFlow":1},"Hotspot":"funcflags.go:1437:7","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// funcflags.go T_capture_byref.func1 1437 0 1 7
+// Flags FuncPropStraightLine|FuncPropIsLeaf
+// NodeCounts stmts=1 exprs=2 control=0
+// Captures vars=1 byref=1
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":2,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":1,"ByRef":1,"Escapes":false}}
+// <endfuncpreamble>
+func T_capture_byref(x int) int {
+	f := func() {
+		x++
+	}
+	f()
+	f()
+	return x
+}
+
+// funcflags.go T_capture_escapes 1466 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultFlags
+//   0 ResultAlwaysSameInlinableFunc
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=1 control=1
+// Hotspot funcflags.go:1467:9
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[["ResultAlwaysSameInlinableFunc"]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":1,"ControlFlow":1},"Hotspot":"funcflags.go:1467:9","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// funcflags.go T_capture_escapes.func1 1467 0 1 9
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=1 control=1
+// Captures vars=1 byref=0 escapes
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[[]],"ResultAffectingParams":0,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":1,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":1,"ByRef":0,"Escapes":true}}
+// <endfuncpreamble>
+func T_capture_escapes(x int) func() int {
+	return func() int {
+		return x
+	}
+}
+
+// funcflags.go T_nearly_leaf 1486 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropNearlyLeaf
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 2
+// NodeCounts stmts=2 exprs=5 control=1
+// CallSites
+//   0 funcflags.go:1487:17 0 tinyLeaf score=-6 adj=leafAdj
+//   1 funcflags.go:1487:31 0 tinyLeaf score=-6 adj=leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":2,"NodeCounts":{"Stmts":2,"Exprs":5,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_nearly_leaf(x, y int) int {
+	return tinyLeaf(x) + tinyLeaf(y)
+}
+
+// funcflags.go T_not_nearly_leaf 1503 0 1 6
+// Flags FuncPropIsWrapper|FuncPropIsPure|FuncPropStraightLine|FuncPropTrivialWrapper
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=2 control=1
+// CallSites
+//   0 funcflags.go:1504:16 CallSiteTailPos notTiny score=-5 adj=tailCallAdj|leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsWrapper","FuncPropIsPure","FuncPropStraightLine","FuncPropTrivialWrapper"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":2,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_not_nearly_leaf(x int) int {
+	return notTiny(x)
+}
+
+func tinyLeaf(x int) int {
+	return x * 3
+}
+
+func notTiny(x int) int {
+	return tinyLeaf(x) + 1
+}
@@ -0,0 +1,754 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// DO NOT EDIT (use 'go test -v -update-expected' instead.)
+// See cmd/compile/internal/inline/inlheur/testdata/props/README.txt
+// for more information on the format of this file.
+// schema version 2
+// <endfilepreamble>
+
+package params
+
+// params.go T_param_ignored 22 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=3 exprs=10 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":3,"Exprs":10,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_param_ignored(x, y int) int {
+	z := y + 1
+	_ = z
+	return x * 2
+}
+
+// params.go T_param_via_local 40 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNoInfo
+//   1 ParamNeverRead
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=4 exprs=11 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[],["ParamNeverRead"]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":4,"Exprs":11,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_param_via_local(x, y int) int {
+	a := x + 1
+	b := a * 3
+	return b
+}
+
+// params.go T_param_feeds_cond 57 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNeverRead
+//   1 ParamFeedsIfOrSwitch
+// ResultAffectingParams 1
+// NumReturns 2
+// NodeCounts stmts=0 exprs=5 control=3
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[["ParamNeverRead"],["ParamFeedsIfOrSwitch"]],"ResultFlags":[[]],"ResultAffectingParams":2,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":5,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_param_feeds_cond(x, y int) int {
+	if y > 10 {
+		return 1
+	}
+	return 2
+}
+
+// params.go T_param_stored 75 0 1 6
+// Flags FuncPropStraightLine|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNoInfo
+//   1 ParamNoInfo
+//   2 ParamNeverRead
+// ResultAffectingParams 0 1
+// NodeCounts stmts=1 exprs=3 control=0
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[],[],["ParamNeverRead"]],"ResultFlags":[],"ResultAffectingParams":3,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":3,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_param_stored(p *int, y, z int) {
+	*p = y
+}
+
+var G int
+
+// params.go T_param_stored_global 91 0 1 6
+// Flags FuncPropStraightLine|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNoInfo
+//   1 ParamNeverRead
+// ResultAffectingParams 0
+// NodeCounts stmts=3 exprs=5 control=0
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[],["ParamNeverRead"]],"ResultFlags":[],"ResultAffectingParams":1,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":3,"Exprs":5,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_param_stored_global(x, y int) {
+	t := x
+	G = t
+}
+
+// params.go T_param_captured 120 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNeverRead
+//   1 ParamNoInfo
+// ResultFlags
+//   0 ResultAlwaysSameInlinableFunc
+// ResultAffectingParams 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=1 control=1
+// Hotspot params.go:121:9
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[["ParamNeverRead"],[]],"ResultFlags":[["ResultAlwaysSameInlinableFunc"]],"ResultAffectingParams":2,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":1,"ControlFlow":1},"Hotspot":"params.go:121:9","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// params.go T_param_captured.func1 121 0 1 9
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=1 control=1
+// Captures vars=1 byref=0 escapes
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[[]],"ResultAffectingParams":0,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":1,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":1,"ByRef":0,"Escapes":true}}
+// <endfuncpreamble>
+func T_param_captured(x, y int) func() int {
+	return func() int {
+		return y
+	}
+}
+
+// params.go T_param_feeds_call 140 0 1 6
+// Flags FuncPropStraightLine|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNeverRead
+//   1 ParamNoInfo
+// ResultFlags
+//   0 ResultAlwaysSameConstant
+// ResultAffectingParams 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=1 exprs=2 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[["ParamNeverRead"],[]],"ResultFlags":[["ResultAlwaysSameConstant"]],"ResultAffectingParams":2,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":2,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_param_feeds_call(x, y int) int {
+	println(y)
+	return 0
+}
+
+type S struct {
+	f int
+}
+
+// params.go (*S).T_method_ignores_recv 161 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNeverRead
+//   1 ParamNoInfo
+// ResultAffectingParams 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=1 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[["ParamNeverRead"],[]],"ResultFlags":[[]],"ResultAffectingParams":2,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":1,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func (s *S) T_method_ignores_recv(x int) int {
+	return x
+}
+
+// params.go T_bounds_indexer 177 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsBoundsCheck
+//   1 ParamNoInfo
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=5 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsBoundsCheck"],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":5,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_bounds_indexer(s []int, i int) int {
+	return s[i] * 2
+}
+
+// params.go T_bounds_const_index 190 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=3 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_bounds_const_index(s []int) int {
+	return s[0]
+}
+
+// params.go T_bounds_reassigned 203 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=1 exprs=7 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":7,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_bounds_reassigned(s []int, i int) int {
+	s = s[1:]
+	return s[i]
+}
+
+// params.go T_bounds_string 220 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsBoundsCheck
+//   1 ParamNoInfo
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=3 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsBoundsCheck"],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_bounds_string(s string, i int) byte {
+	return s[i]
+}
+
+// params.go T_bounds_loop_caller 240 0 1 6
+// Flags FuncPropIsPure|FuncPropNearlyLeaf
+// ParamFlags
+//   0 ParamFeedsLoopBound
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 1
+// NodeCounts stmts=5 exprs=11 control=2
+// Hotspot params.go:242:11
+// CallSites
+//   0 params.go:243:24 CallSiteInRangeOverArg|CallSiteInLoop T_bounds_indexer score=-24 adj=rangeBoundsCheckAdj|leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropNearlyLeaf"],"ParamFlags":[["ParamFeedsLoopBound"]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":1,"NodeCounts":{"Stmts":5,"Exprs":11,"ControlFlow":2},"Hotspot":"params.go:242:11","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_bounds_loop_caller(s []int) int {
+	t := 0
+	for i := range s {
+		t += T_bounds_indexer(s, i)
+	}
+	return t
+}
+
+// params.go T_loop_bound_for 261 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsLoopBound
+//   1 ParamNoInfo
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=6 exprs=14 control=2
+// Hotspot params.go:263:2
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsLoopBound"],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":6,"Exprs":14,"ControlFlow":2},"Hotspot":"params.go:263:2","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_loop_bound_for(n int, x int) int {
+	t := 0
+	for i := 0; i < n; i++ {
+		t += x
+	}
+	return t
+}
+
+// params.go T_loop_bound_len 282 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsBoundsCheck|ParamFeedsLoopBound
+//   1 ParamFeedsLoopBound
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=6 exprs=21 control=2
+// Hotspot params.go:284:2
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsBoundsCheck","ParamFeedsLoopBound"],["ParamFeedsLoopBound"]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":6,"Exprs":21,"ControlFlow":2},"Hotspot":"params.go:284:2","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_loop_bound_len(s []int, k int) int {
+	t := 0
+	for i := 0; i < len(s) && i != k; i++ {
+		t += s[i]
+	}
+	return t
+}
+
+// params.go T_loop_bound_range 302 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsLoopBound
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=3 exprs=7 control=2
+// Hotspot params.go:304:6
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsLoopBound"]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":3,"Exprs":7,"ControlFlow":2},"Hotspot":"params.go:304:6","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_loop_bound_range(s string) int {
+	t := 0
+	for range s {
+		t++
+	}
+	return t
+}
+
+// params.go T_loop_bound_reassigned 320 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=4 exprs=11 control=2
+// Hotspot params.go:322:2
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":4,"Exprs":11,"ControlFlow":2},"Hotspot":"params.go:322:2","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_loop_bound_reassigned(n int) int {
+	t := 0
+	for n > 0 {
+		t += n
+		n--
+	}
+	return t
+}
+
+// params.go T_loop_bound_chan 339 0 1 6
+// Flags FuncPropMayBlock|FuncPropIsLeaf|FuncPropUsesChannels
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=4 exprs=9 control=2
+// Hotspot params.go:341:11
+// <endpropsdump>
+// {"Flags":["FuncPropMayBlock","FuncPropIsLeaf","FuncPropUsesChannels"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":4,"Exprs":9,"ControlFlow":2},"Hotspot":"params.go:341:11","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_loop_bound_chan(ch chan int) int {
+	t := 0
+	for v := range ch {
+		t += v
+	}
+	return t
+}
+
+type Shape interface {
+	Area() int
+}
+
+type square int
+
+func (s square) Area() int {
+	return int(s) * int(s)
+}
+
+// params.go T_itf_method_call 371 0 1 6
+// Flags FuncPropStraightLine
+// ParamFlags
+//   0 ParamFeedsInterfaceMethodCall
+//   1 ParamNoInfo
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=4 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine"],"ParamFlags":[["ParamFeedsInterfaceMethodCall"],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":4,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_itf_method_call(s Shape, x int) int {
+	return s.Area() + x
+}
+
+// params.go T_itf_method_call_nested 387 0 1 6
+// ParamFlags
+//   0 ParamMayFeedInterfaceMethodCall
+//   1 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0 1
+// NumReturns 2
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=6 control=3
+// <endpropsdump>
+// {"Flags":[],"ParamFlags":[["ParamMayFeedInterfaceMethodCall"],["ParamFeedsIfOrSwitch"]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":6,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_itf_method_call_nested(s Shape, x int) int {
+	if x > 0 {
+		return s.Area()
+	}
+	return x
+}
+
+// params.go T_itf_method_call_reassigned 404 0 1 6
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=2 exprs=7 control=2
+// <endpropsdump>
+// {"Flags":[],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":2,"Exprs":7,"ControlFlow":2},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_itf_method_call_reassigned(s Shape, t Shape) int {
+	if s == nil {
+		s = t
+	}
+	return s.Area()
+}
+
+// params.go T_itf_method_caller 425 0 1 6
+// Flags FuncPropStraightLine
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 2
+// NodeCounts stmts=2 exprs=11 control=1
+// CallSites
+//   0 params.go:426:26 0 T_itf_method_call score=43 adj=passConcreteToItfCallAdj
+//   1 params.go:426:67 0 T_itf_method_call_nested score=57 adj=passConcreteToNestedItfCallAdj
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":2,"NodeCounts":{"Stmts":2,"Exprs":11,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_itf_method_caller(x int) int {
+	return T_itf_method_call(square(x), x) + T_itf_method_call_nested(square(x), x)
+}
+
+// params.go T_indirect_call 443 0 1 6
+// Flags FuncPropStraightLine
+// ParamFlags
+//   0 ParamFeedsIndirectCall
+//   1 ParamNoInfo
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=4 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine"],"ParamFlags":[["ParamFeedsIndirectCall"],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":4,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_indirect_call(f func(int) int, x int) int {
+	return f(x) + 1
+}
+
+// params.go T_indirect_call_nested 461 0 1 6
+// ParamFlags
+//   0 ParamMayFeedIndirectCall
+//   1 ParamNoInfo
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=5 exprs=12 control=2
+// Hotspot params.go:462:2
+// <endpropsdump>
+// {"Flags":[],"ParamFlags":[["ParamMayFeedIndirectCall"],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":5,"Exprs":12,"ControlFlow":2},"Hotspot":"params.go:462:2","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_indirect_call_nested(f func(int) int, x int) int {
+	for i := 0; i < x; i++ {
+		x = f(i)
+	}
+	return x
+}
+
+// params.go T_indirect_call_caller 493 0 1 6
+// Flags FuncPropStraightLine
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 2
+// NodeCounts stmts=2 exprs=7 control=1
+// Hotspot params.go:494:25
+// CallSites
+//   0 params.go:494:24 CallSitePassesFuncLit T_indirect_call score=43 adj=passFuncToIndirectCallAdj
+//   1 params.go:495:25 0 T_indirect_call_nested score=66 adj=passFuncToNestedIndirectCallAdj
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":2,"NodeCounts":{"Stmts":2,"Exprs":7,"ControlFlow":1},"Hotspot":"params.go:494:25","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// params.go T_indirect_call_caller.func1 494 0 1 25
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=3 control=1
+// Captures vars=0 byref=0 escapes
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":true}}
+// <endfuncpreamble>
+func T_indirect_call_caller(x int) int {
+	return T_indirect_call(func(y int) int { return y * 2 }, x) +
+		T_indirect_call_nested(indirectHelper, x)
+}
+
+func indirectHelper(y int) int {
+	return y + 1
+}
+
+const debugParams = false
+
+// params.go T_unused_params 531 0 1 6
+// Flags FuncPropStraightLine|FuncPropSpawnsGoroutine
+// ParamFlags
+//   0 ParamNeverRead
+//   1 ParamNeverRead
+//   2 ParamNeverRead
+//   3 ParamNoInfo
+//   4 ParamNoInfo
+// ResultAffectingParams 3 4
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 0
+// NumCalls 1
+// NodeCounts stmts=3 exprs=4 control=1
+// Hotspot params.go:536:5
+// SpecializationHints
+//   4 w calls=1 value=4
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropSpawnsGoroutine"],"ParamFlags":[["ParamNeverRead"],["ParamNeverRead"],["ParamNeverRead"],[],[]],"ResultFlags":[[]],"ResultAffectingParams":24,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":1,"NodeCounts":{"Stmts":3,"Exprs":4,"ControlFlow":1},"Hotspot":"params.go:536:5","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// params.go T_unused_params.func1 536 0 1 5
+// Flags FuncPropStraightLine|FuncPropIsLeaf
+// NodeCounts stmts=1 exprs=2 control=0
+// Captures vars=1 byref=0 escapes
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":2,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":1,"ByRef":0,"Escapes":true}}
+// <endfuncpreamble>
+func T_unused_params(x int, _ string, y int, z *int, w int) int {
+	if debugParams {
+		println(x)
+	}
+	y = 2
+	go func() { println(*z) }()
+	return w
+}
+
+// params.go T_unused_params_caller 553 0 1 6
+// Flags FuncPropStraightLine
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 5
+// NumCalls 1
+// NodeCounts stmts=1 exprs=6 control=1
+// CallSites
+//   0 params.go:554:24 CallSiteTailPos T_unused_params
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":5,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":6,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_unused_params_caller(p *int) int {
+	return T_unused_params(1, "a", len("abc")+3, p, 4)
+}
+
+// params.go T_feeds_if_switch 572 0 1 6
+// Flags FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+//   1 ParamFeedsIfOrSwitch
+//   2 ParamMayFeedIfOrSwitch
+// ResultAffectingParams 0 1 2
+// NumReturns 3
+// NodeCounts stmts=3 exprs=16 control=6
+// SpecializationHints
+//   0 mode calls=1 value=1
+//   1 verbose calls=1 value=false
+// <endpropsdump>
+// {"Flags":["FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"],["ParamFeedsIfOrSwitch"],["ParamMayFeedIfOrSwitch"]],"ResultFlags":[[]],"ResultAffectingParams":7,"NumReturns":3,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":3,"Exprs":16,"ControlFlow":6},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_feeds_if_switch(mode int, verbose bool, s string) int {
+	if verbose && mode > 2 {
+		println(s)
+	}
+	switch mode {
+	case 0:
+		return len(s)
+	case 1:
+		if s == "" {
+			return -1
+		}
+	}
+	return mode
+}
+
+// params.go T_feeds_if_not_simple 595 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ResultAffectingParams 0 1
+// NumReturns 2
+// NodeCounts stmts=2 exprs=10 control=3
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":2,"Exprs":10,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_feeds_if_not_simple(x int, y int) int {
+	z := y * 2
+	if x < z {
+		return x
+	}
+	return 0
+}
+
+// params.go T_feeds_if_switch_caller 616 0 1 6
+// Flags FuncPropStraightLine
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 3
+// NumCalls 1
+// NodeCounts stmts=1 exprs=4 control=1
+// CallSites
+//   0 params.go:617:26 CallSiteTailPos T_feeds_if_switch score=-10 adj=tailCallAdj|leafAdj|passConstToIfAdj
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":3,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":4,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_feeds_if_switch_caller(s string) int {
+	return T_feeds_if_switch(1, false, s)
+}
+
+// params.go T_type_switch 631 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsTypeAssert
+//   1 ParamNoInfo
+// ResultAffectingParams 0 1
+// NumReturns 3
+// NodeCounts stmts=2 exprs=12 control=4
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsTypeAssert"],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":3,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":2,"Exprs":12,"ControlFlow":4},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_type_switch(v any, n int) int {
+	switch x := v.(type) {
+	case int:
+		return x + n
+	case string:
+		return len(x)
+	}
+	return n
+}
+
+// params.go T_type_assert 653 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsTypeAssert
+//   1 ParamNoInfo
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=5 exprs=17 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsTypeAssert"],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":5,"Exprs":17,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_type_assert(v any, w any) bool {
+	_, ok := v.(error)
+	return ok && w != nil
+}
+
+type stringer interface{ String() string }
+
+// params.go T_type_assert_reassigned 670 0 1 6
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=2 exprs=9 control=2
+// <endpropsdump>
+// {"Flags":[],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":2,"Exprs":9,"ControlFlow":2},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_type_assert_reassigned(v any) string {
+	if v == nil {
+		v = "none"
+	}
+	return v.(stringer).String()
+}
+
+// params.go T_feeds_div 689 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNoInfo
+//   1 ParamFeedsDivOrShift
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=7 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[],["ParamFeedsDivOrShift"]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":7,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_feeds_div(x, d int) int {
+	return x/d + x%3
+}
+
+// params.go T_feeds_shift 705 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNoInfo
+//   1 ParamFeedsDivOrShift
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=1 exprs=6 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[],["ParamFeedsDivOrShift"]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":6,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_feeds_shift(x uint64, s int) uint64 {
+	x <<= uint(s)
+	return x >> 1
+}
+
+// params.go T_feeds_float_div 719 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=3 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_feeds_float_div(x, d float64) float64 {
+	return x / d
+}
+
+// params.go T_close_chan 734 0 1 6
+// Flags FuncPropStraightLine|FuncPropIsLeaf
+// ResultFlags
+//   0 ResultAlwaysSameConstant
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=3 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[["ResultAlwaysSameConstant"]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_close_chan(c chan int) int {
+	close(c)
+	return 0
+}
+
+// params.go T_send_chan 751 0 1 6
+// Flags FuncPropMayBlock|FuncPropStraightLine|FuncPropIsLeaf|FuncPropUsesChannels
+// ResultFlags
+//   0 ResultAlwaysSameConstant
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=1 exprs=3 control=1
+// Hotspot params.go:752:4
+// <endpropsdump>
+// {"Flags":["FuncPropMayBlock","FuncPropStraightLine","FuncPropIsLeaf","FuncPropUsesChannels"],"ParamFlags":[[],[]],"ResultFlags":[["ResultAlwaysSameConstant"]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":3,"ControlFlow":1},"Hotspot":"params.go:752:4","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_send_chan(c chan int, x int) int {
+	c <- x
+	return 0
+}
@@ -5,27 +5,43 @@
 // DO NOT EDIT (use 'go test -v -update-expected' instead.)
 // See cmd/compile/internal/inline/inlheur/testdata/props/README.txt
 // for more information on the format of this file.
+// schema version 2
 // <endfilepreamble>
 
 package returns1
 
-import "unsafe"
+import (
+	"reflect"
+	"unsafe"
+)
 
-// returns.go T_simple_allocmem 20 0 1
+// returns.go T_simple_allocmem 29 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropAllocates|FuncPropIsLeaf
 // ResultFlags
-//   0 ResultIsAllocatedMem
+//   0 ResultIsAllocatedMem|ResultFreshAlloc
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=2 control=1
+// Hotspot returns.go:30:9
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[2]}
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropAllocates","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[["ResultIsAllocatedMem","ResultFreshAlloc"]],"ResultAffectingParams":0,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":2,"ControlFlow":1},"Hotspot":"returns.go:30:9","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_simple_allocmem() *Bar {
 	return &Bar{}
 }
 
-// returns.go T_allocmem_two_returns 30 0 1
+// returns.go T_allocmem_two_returns 46 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
 // ResultFlags
-//   0 ResultIsAllocatedMem
+//   0 ResultIsAllocatedMem|ResultFreshAlloc
+// ResultAffectingParams 0
+// NumReturns 2
+// NodeCounts stmts=0 exprs=9 control=3
+// Hotspot returns.go:49:13
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[2]}
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[["ResultIsAllocatedMem","ResultFreshAlloc"]],"ResultAffectingParams":1,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":9,"ControlFlow":3},"Hotspot":"returns.go:49:13","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_allocmem_two_returns(x int) *Bar {
 	// multiple returns
@@ -36,11 +52,18 @@ func T_allocmem_two_returns(x int) *Bar {
 	}
 }
 
-// returns.go T_allocmem_three_returns 45 0 1
+// returns.go T_allocmem_three_returns 68 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
 // ResultFlags
 //   0 ResultIsAllocatedMem
+// ResultAffectingParams 0
+// NumReturns 3
+// NodeCounts stmts=3 exprs=16 control=5
+// Hotspot returns.go:72:14
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[2]}
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[["ResultIsAllocatedMem"]],"ResultAffectingParams":1,"NumReturns":3,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":3,"Exprs":16,"ControlFlow":5},"Hotspot":"returns.go:72:14","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_allocmem_three_returns(x int) []*Bar {
 	// more multiple returns
@@ -55,22 +78,33 @@ func T_allocmem_three_returns(x int) []*Bar {
 	return make([]*Bar, 0, 10)
 }
 
-// returns.go T_return_nil 64 0 1
+// returns.go T_return_nil 91 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
 // ResultFlags
 //   0 ResultAlwaysSameConstant
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=1 control=1
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[8]}
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[["ResultAlwaysSameConstant"]],"ResultAffectingParams":0,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":1,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_return_nil() *Bar {
 	// simple case: no alloc
 	return nil
 }
 
-// returns.go T_multi_return_nil 75 0 1
+// returns.go T_multi_return_nil 109 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+//   1 ParamFeedsIfOrSwitch
 // ResultFlags
 //   0 ResultAlwaysSameConstant
+// ResultAffectingParams 0 1
+// NumReturns 2
+// NodeCounts stmts=0 exprs=5 control=3
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[8]}
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"],["ParamFeedsIfOrSwitch"]],"ResultFlags":[["ResultAlwaysSameConstant"]],"ResultAffectingParams":3,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":5,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_multi_return_nil(x, y bool) *Bar {
 	if x && y {
@@ -79,11 +113,18 @@ func T_multi_return_nil(x, y bool) *Bar {
 	return nil
 }
 
-// returns.go T_multi_return_nil_anomoly 88 0 1
+// returns.go T_multi_return_nil_anomoly 129 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+//   1 ParamFeedsIfOrSwitch
 // ResultFlags
 //   0 ResultIsConcreteTypeConvertedToInterface
+// ResultAffectingParams 0 1
+// NumReturns 2
+// NodeCounts stmts=4 exprs=11 control=3
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[4]}
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"],["ParamFeedsIfOrSwitch"]],"ResultFlags":[["ResultIsConcreteTypeConvertedToInterface"]],"ResultAffectingParams":3,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":4,"Exprs":11,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_multi_return_nil_anomoly(x, y bool) Itf {
 	if x && y {
@@ -94,9 +135,16 @@ func T_multi_return_nil_anomoly(x, y bool) Itf {
 	return barnil
 }
 
-// returns.go T_multi_return_some_nil 101 0 1
+// returns.go T_multi_return_some_nil 149 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+//   1 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0 1
+// NumReturns 2
+// NodeCounts stmts=0 exprs=6 control=3
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[0]}
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"],["ParamFeedsIfOrSwitch"]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":6,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_multi_return_some_nil(x, y bool) *Bar {
 	if x && y {
@@ -106,9 +154,16 @@ func T_multi_return_some_nil(x, y bool) *Bar {
 	}
 }
 
-// returns.go T_mixed_returns 113 0 1
+// returns.go T_mixed_returns 168 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0
+// NumReturns 2
+// NodeCounts stmts=0 exprs=7 control=3
+// Hotspot returns.go:171:13
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[0]}
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":7,"ControlFlow":3},"Hotspot":"returns.go:171:13","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_mixed_returns(x int) *Bar {
 	// mix of alloc and non-alloc
@@ -119,9 +174,16 @@ func T_mixed_returns(x int) *Bar {
 	}
 }
 
-// returns.go T_mixed_returns_slice 126 0 1
+// returns.go T_mixed_returns_slice 188 0 1 6
+// Flags FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0
+// NumReturns 3
+// NodeCounts stmts=5 exprs=23 control=5
+// Hotspot returns.go:192:14
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[0]}
+// {"Flags":["FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":3,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":5,"Exprs":23,"ControlFlow":5},"Hotspot":"returns.go:192:14","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_mixed_returns_slice(x int) []*Bar {
 	// mix of alloc and non-alloc
@@ -137,23 +199,40 @@ func T_mixed_returns_slice(x int) []*Bar {
 	return ba[:]
 }
 
-// returns.go T_maps_and_channels 149 0 1
+// returns.go T_maps_and_channels 220 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropAllocates|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNeverRead
+//   1 ParamNoInfo
 // ResultFlags
 //   0 ResultNoInfo
 //   1 ResultNoInfo
 //   2 ResultNoInfo
 //   3 ResultAlwaysSameConstant
+// ResultAffectingParams 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=6 control=1
+// Hotspot returns.go:222:16
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[0,0,0,8]}
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropAllocates","FuncPropIsLeaf"],"ParamFlags":[["ParamNeverRead"],[]],"ResultFlags":[[],[],[],["ResultAlwaysSameConstant"]],"ResultAffectingParams":2,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":6,"ControlFlow":1},"Hotspot":"returns.go:222:16","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_maps_and_channels(x int, b bool) (bool, map[int]int, chan bool, unsafe.Pointer) {
 	// maps and channels
 	return b, make(map[int]int), make(chan bool), nil
 }
 
-// returns.go T_assignment_to_named_returns 158 0 1
+// returns.go T_assignment_to_named_returns 237 0 1 6
+// Flags FuncPropAllocates|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=3 exprs=12 control=2
+// Hotspot returns.go:239:10
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[0,0]}
+// {"Flags":["FuncPropAllocates","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[[],[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":3,"Exprs":12,"ControlFlow":2},"Hotspot":"returns.go:239:10","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_assignment_to_named_returns(x int) (r1 *uint64, r2 *uint64) {
 	// assignments to named returns and then "return" not supported
@@ -165,12 +244,20 @@ func T_assignment_to_named_returns(x int) (r1 *uint64, r2 *uint64) {
 	return
 }
 
-// returns.go T_named_returns_but_return_explicit_values 175 0 1
+// returns.go T_named_returns_but_return_explicit_values 262 0 1 6
+// Flags FuncPropAllocates|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
 // ResultFlags
-//   0 ResultIsAllocatedMem
-//   1 ResultIsAllocatedMem
+//   0 ResultIsAllocatedMem|ResultFreshAlloc
+//   1 ResultIsAllocatedMem|ResultFreshAlloc
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=5 exprs=16 control=2
+// Hotspot returns.go:264:12
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[2,2]}
+// {"Flags":["FuncPropAllocates","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[["ResultIsAllocatedMem","ResultFreshAlloc"],["ResultIsAllocatedMem","ResultFreshAlloc"]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":5,"Exprs":16,"ControlFlow":2},"Hotspot":"returns.go:264:12","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_named_returns_but_return_explicit_values(x int) (r1 *uint64, r2 *uint64) {
 	// named returns ok if all returns are non-empty
@@ -182,21 +269,37 @@ func T_named_returns_but_return_explicit_values(x int) (r1 *uint64, r2 *uint64)
 	return rx1, rx2
 }
 
-// returns.go T_return_concrete_type_to_itf 191 0 1
+// returns.go T_return_concrete_type_to_itf 286 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropAllocates|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNeverRead
+//   1 ParamNeverRead
 // ResultFlags
 //   0 ResultIsConcreteTypeConvertedToInterface
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=3 control=1
+// Hotspot returns.go:287:9
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[4]}
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropAllocates","FuncPropIsLeaf"],"ParamFlags":[["ParamNeverRead"],["ParamNeverRead"]],"ResultFlags":[["ResultIsConcreteTypeConvertedToInterface"]],"ResultAffectingParams":0,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":3,"ControlFlow":1},"Hotspot":"returns.go:287:9","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_return_concrete_type_to_itf(x, y int) Itf {
 	return &Bar{}
 }
 
-// returns.go T_return_concrete_type_to_itfwith_copy 201 0 1
+// returns.go T_return_concrete_type_to_itfwith_copy 304 0 1 6
+// Flags FuncPropStraightLine|FuncPropAllocates|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNeverRead
+//   1 ParamNeverRead
 // ResultFlags
 //   0 ResultIsConcreteTypeConvertedToInterface
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=3 exprs=7 control=1
+// Hotspot returns.go:305:7
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[4]}
+// {"Flags":["FuncPropStraightLine","FuncPropAllocates","FuncPropIsLeaf"],"ParamFlags":[["ParamNeverRead"],["ParamNeverRead"]],"ResultFlags":[["ResultIsConcreteTypeConvertedToInterface"]],"ResultAffectingParams":0,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":3,"Exprs":7,"ControlFlow":1},"Hotspot":"returns.go:305:7","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_return_concrete_type_to_itfwith_copy(x, y int) Itf {
 	b := &Bar{}
@@ -204,9 +307,17 @@ func T_return_concrete_type_to_itfwith_copy(x, y int) Itf {
 	return b
 }
 
-// returns.go T_return_concrete_type_to_itf_mixed 211 0 1
+// returns.go T_return_concrete_type_to_itf_mixed 322 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+//   1 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0 1
+// NumReturns 2
+// NodeCounts stmts=2 exprs=10 control=3
+// Hotspot returns.go:324:8
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[0]}
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"],["ParamFeedsIfOrSwitch"]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":2,"Exprs":10,"ControlFlow":3},"Hotspot":"returns.go:324:8","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_return_concrete_type_to_itf_mixed(x, y int) Itf {
 	if x < y {
@@ -216,11 +327,14 @@ func T_return_concrete_type_to_itf_mixed(x, y int) Itf {
 	return nil
 }
 
-// returns.go T_return_same_func 225 0 1
+// returns.go T_return_same_func 339 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
 // ResultFlags
 //   0 ResultAlwaysSameInlinableFunc
+// NumReturns 2
+// NodeCounts stmts=0 exprs=5 control=3
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[32]}
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[["ResultAlwaysSameInlinableFunc"]],"ResultAffectingParams":0,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":5,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_return_same_func() func(int) int {
 	if G < 10 {
@@ -230,9 +344,12 @@ func T_return_same_func() func(int) int {
 	}
 }
 
-// returns.go T_return_different_funcs 237 0 1
+// returns.go T_return_different_funcs 354 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// NumReturns 2
+// NodeCounts stmts=0 exprs=5 control=3
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[0]}
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[[]],"ResultAffectingParams":0,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":5,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_return_different_funcs() func(int) int {
 	if G != 10 {
@@ -242,15 +359,25 @@ func T_return_different_funcs() func(int) int {
 	}
 }
 
-// returns.go T_return_same_closure 255 0 1
+// returns.go T_return_same_closure 382 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
 // ResultFlags
 //   0 ResultAlwaysSameInlinableFunc
+// NumReturns 2
+// NodeCounts stmts=2 exprs=8 control=3
+// Hotspot returns.go:383:7
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[32]}
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[["ResultAlwaysSameInlinableFunc"]],"ResultAffectingParams":0,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":2,"Exprs":8,"ControlFlow":3},"Hotspot":"returns.go:383:7","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
-// returns.go T_return_same_closure.func1 256 0 1
+// returns.go T_return_same_closure.func1 383 0 1 7
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=1 control=1
+// Captures vars=0 byref=0 escapes
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[0]}
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":1,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":true}}
 // <endfuncpreamble>
 func T_return_same_closure() func(int) int {
 	p := func(q int) int { return q }
@@ -261,19 +388,36 @@ func T_return_same_closure() func(int) int {
 	}
 }
 
-// returns.go T_return_different_closures 278 0 1
+// returns.go T_return_different_closures 422 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// NumReturns 2
+// NodeCounts stmts=2 exprs=8 control=3
+// Hotspot returns.go:423:7
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[0]}
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[[]],"ResultAffectingParams":0,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":2,"Exprs":8,"ControlFlow":3},"Hotspot":"returns.go:423:7","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
-// returns.go T_return_different_closures.func1 279 0 1
+// returns.go T_return_different_closures.func1 423 0 1 7
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=1 control=1
+// Captures vars=0 byref=0 escapes
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[0]}
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":1,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":true}}
 // <endfuncpreamble>
-// returns.go T_return_different_closures.func2 283 0 1
+// returns.go T_return_different_closures.func2 427 0 1 10
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNeverRead
 // ResultFlags
 //   0 ResultAlwaysSameConstant
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=1 control=1
+// Captures vars=0 byref=0 escapes
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[8]}
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[["ParamNeverRead"]],"ResultFlags":[["ResultAlwaysSameConstant"]],"ResultAffectingParams":0,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":1,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":true}}
 // <endfuncpreamble>
 func T_return_different_closures() func(int) int {
 	p := func(q int) int { return q }
@@ -284,19 +428,37 @@ func T_return_different_closures() func(int) int {
 	}
 }
 
-// returns.go T_return_noninlinable 301 0 1
+// returns.go T_return_noninlinable 463 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
 // ResultFlags
 //   0 ResultAlwaysSameFunc
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=2 exprs=4 control=1
+// Hotspot returns.go:464:10
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[16]}
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[["ResultAlwaysSameFunc"]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":2,"Exprs":4,"ControlFlow":1},"Hotspot":"returns.go:464:10","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
-// returns.go T_return_noninlinable.func1 302 0 1
+// returns.go T_return_noninlinable.func1 464 0 1 10
+// Flags FuncPropStraightLine|FuncPropContainsDefer
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 0
+// NumCalls 1
+// NodeCounts stmts=2 exprs=2 control=1
+// Hotspot returns.go:465:9
+// Captures vars=1 byref=0 escapes
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[0]}
+// {"Flags":["FuncPropStraightLine","FuncPropContainsDefer"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":1,"NodeCounts":{"Stmts":2,"Exprs":2,"ControlFlow":1},"Hotspot":"returns.go:465:9","Captures":{"Vars":1,"ByRef":0,"Escapes":true}}
 // <endfuncpreamble>
-// returns.go T_return_noninlinable.func1.1 303 0 1
+// returns.go T_return_noninlinable.func1.1 465 0 1 9
+// Flags FuncPropStraightLine|FuncPropIsLeaf
+// NodeCounts stmts=1 exprs=3 control=0
+// Captures vars=2 byref=0
 // <endpropsdump>
-// {"Flags":0,"ParamFlags":null,"ResultFlags":[]}
+// {"Flags":["FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":3,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":2,"ByRef":0,"Escapes":false}}
 // <endfuncpreamble>
 func T_return_noninlinable(x int) func(int) int {
 	noti := func(q int) int {
@@ -330,3 +492,776 @@ var GB Bar
 type Itf interface {
 	Plark()
 }
+
+// returns.go T_single_tail_return 505 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=2 exprs=8 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":2,"Exprs":8,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_single_tail_return(x int) int {
+	y := x * 2
+	return y + 1
+}
+
+// returns.go T_multi_return_early_exit 522 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+//   1 ParamFeedsLoopBound
+// ResultAffectingParams 0 1
+// NumReturns 3
+// NodeCounts stmts=2 exprs=15 control=6
+// Hotspot returns.go:526:14
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"],["ParamFeedsLoopBound"]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":3,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":2,"Exprs":15,"ControlFlow":6},"Hotspot":"returns.go:526:14","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_multi_return_early_exit(x int, s []int) int {
+	if x < 0 {
+		return -1
+	}
+	for i, v := range s {
+		if v == x {
+			return i
+		}
+	}
+	return len(s)
+}
+
+// returns.go T_returns_in_closure_only 554 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultFlags
+//   0 ResultAlwaysSameInlinableFunc
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=2 exprs=4 control=1
+// Hotspot returns.go:555:7
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[["ResultAlwaysSameInlinableFunc"]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":2,"Exprs":4,"ControlFlow":1},"Hotspot":"returns.go:555:7","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// returns.go T_returns_in_closure_only.func1 555 0 1 7
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// NumReturns 2
+// NodeCounts stmts=0 exprs=5 control=3
+// Captures vars=1 byref=0 escapes
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[[]],"ResultAffectingParams":0,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":5,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":1,"ByRef":0,"Escapes":true}}
+// <endfuncpreamble>
+func T_returns_in_closure_only(x int) func() int {
+	f := func() int {
+		if x > 0 {
+			return 1
+		}
+		return 0
+	}
+	return f
+}
+
+// returns.go T_call_args_wide 578 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropNearlyLeaf
+// ResultAffectingParams 0 1 2 3 4 5
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 6
+// NumCalls 2
+// NodeCounts stmts=2 exprs=15 control=1
+// CallSites
+//   0 returns.go:579:13 0 wide score=2 adj=leafAdj
+//   1 returns.go:579:38 0 wide score=2 adj=leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[[],[],[],[],[],[]],"ResultFlags":[[]],"ResultAffectingParams":63,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":6,"NumCalls":2,"NodeCounts":{"Stmts":2,"Exprs":15,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_call_args_wide(a, b, c, d, e, f int) int {
+	return wide(a, b, c, d, e, f) + wide(f, e, d, c, b, a)
+}
+
+// returns.go T_call_args_variadic 597 0 1 6
+// Flags FuncPropStraightLine|FuncPropAllocates|FuncPropNearlyLeaf
+// ResultAffectingParams 0
+// MaxCallArgs 3
+// NumCalls 4
+// NodeCounts stmts=5 exprs=18 control=0
+// Hotspot returns.go:599:10
+// CallSites
+//   0 returns.go:599:10 0 variadic score=-6 adj=leafAdj
+//   1 returns.go:600:10 0 variadic score=-6 adj=leafAdj
+//   2 returns.go:601:10 0 variadic score=-6 adj=leafAdj
+//   3 returns.go:602:14 0 (*Fwd2).meth score=-8 adj=leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropAllocates","FuncPropNearlyLeaf"],"ParamFlags":[[]],"ResultFlags":[],"ResultAffectingParams":1,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":3,"NumCalls":4,"NodeCounts":{"Stmts":5,"Exprs":18,"ControlFlow":0},"Hotspot":"returns.go:599:10","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_call_args_variadic(s []any) {
+	println(len(s))
+	variadic(1, 2, 3)
+	variadic(4, s...)
+	variadic(5)
+	(*Fwd2).meth(nil, 1)
+}
+
+// returns.go T_call_args_closure 630 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultFlags
+//   0 ResultAlwaysSameInlinableFunc
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=1 control=1
+// Hotspot returns.go:631:9
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[["ResultAlwaysSameInlinableFunc"]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":1,"ControlFlow":1},"Hotspot":"returns.go:631:9","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// returns.go T_call_args_closure.func1 631 0 1 9
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropNearlyLeaf
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 6
+// NumCalls 1
+// NodeCounts stmts=1 exprs=7 control=1
+// Captures vars=1 byref=0 escapes
+// CallSites
+//   0 returns.go:632:14 CallSiteTailPos wide score=-3 adj=tailCallAdj|leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[],"ResultFlags":[[]],"ResultAffectingParams":0,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":6,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":7,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":1,"ByRef":0,"Escapes":true}}
+// <endfuncpreamble>
+func T_call_args_closure(x int) func() int {
+	return func() int {
+		return wide(x, x, x, x, x, x)
+	}
+}
+
+func wide(a, b, c, d, e, f int) int {
+	return a + b + c + d + e + f
+}
+
+func variadic(x int, rest ...any) {
+	println(x, len(rest))
+}
+
+type Fwd2 struct{}
+
+func (f *Fwd2) meth(x int) {
+	println(x)
+}
+
+// returns.go T_unsafe_ptr_conv 659 0 1 6
+// Flags FuncPropUsesUnsafe|FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=3 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropUsesUnsafe","FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_unsafe_ptr_conv(p *int64) *float64 {
+	return (*float64)(unsafe.Pointer(p))
+}
+
+// returns.go T_unsafe_add 672 0 1 6
+// Flags FuncPropUsesUnsafe|FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf|FuncPropLowLevel
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=5 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropUsesUnsafe","FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf","FuncPropLowLevel"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":5,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_unsafe_add(p *byte, n int) *byte {
+	return (*byte)(unsafe.Add(unsafe.Pointer(p), n))
+}
+
+// returns.go T_unsafe_sizeof_only 688 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamNeverRead
+// ResultFlags
+//   0 ResultAlwaysSameConstant
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=1 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[["ParamNeverRead"]],"ResultFlags":[["ResultAlwaysSameConstant"]],"ResultAffectingParams":0,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":1,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_unsafe_sizeof_only(x int64) uintptr {
+	return unsafe.Sizeof(x)
+}
+
+// returns.go T_unsafe_in_closure 713 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultFlags
+//   0 ResultAlwaysSameInlinableFunc
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=1 control=1
+// Hotspot returns.go:714:9
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[["ResultAlwaysSameInlinableFunc"]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":1,"ControlFlow":1},"Hotspot":"returns.go:714:9","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// returns.go T_unsafe_in_closure.func1 714 0 1 9
+// Flags FuncPropUsesUnsafe|FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=3 control=1
+// Captures vars=1 byref=0 escapes
+// <endpropsdump>
+// {"Flags":["FuncPropUsesUnsafe","FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[[]],"ResultAffectingParams":0,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":1,"ByRef":0,"Escapes":true}}
+// <endfuncpreamble>
+func T_unsafe_in_closure(p *int) func() uintptr {
+	return func() uintptr {
+		return uintptr(unsafe.Pointer(p))
+	}
+}
+
+// returns.go T_unsafe_uintptr_arith 728 0 1 6
+// Flags FuncPropUsesUnsafe|FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf|FuncPropLowLevel
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=10 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropUsesUnsafe","FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf","FuncPropLowLevel"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":10,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_unsafe_uintptr_arith(p *int32, i int) *int32 {
+	return (*int32)(unsafe.Pointer(uintptr(unsafe.Pointer(p)) + uintptr(i)*4))
+}
+
+// returns.go T_reflect_value 746 0 1 6
+// Flags FuncPropStraightLine|FuncPropLowLevel
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 2
+// NodeCounts stmts=2 exprs=4 control=1
+// CallSites
+//   0 returns.go:747:31 CallSiteTailPos Value.Int score=56 adj=tailCallAdj
+//   1 returns.go:747:24 0 ValueOf score=62 adj=0
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropLowLevel"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":2,"NodeCounts":{"Stmts":2,"Exprs":4,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_reflect_value(x any) int64 {
+	return reflect.ValueOf(x).Int()
+}
+
+// returns.go T_linkname_call 762 0 1 6
+// Flags FuncPropIsWrapper|FuncPropStraightLine|FuncPropTrivialWrapper|FuncPropLowLevel
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 0
+// NumCalls 1
+// NodeCounts stmts=1 exprs=1 control=1
+// CallSites
+//   0 returns.go:763:17 CallSiteTailPos nanotime
+// <endpropsdump>
+// {"Flags":["FuncPropIsWrapper","FuncPropStraightLine","FuncPropTrivialWrapper","FuncPropLowLevel"],"ParamFlags":[],"ResultFlags":[[]],"ResultAffectingParams":0,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":1,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_linkname_call() int64 {
+	return nanotime()
+}
+
+//go:linkname nanotime runtime.nanotime
+func nanotime() int64
+
+// returns.go T_hotspot_nested_loop 781 0 1 6
+// Flags FuncPropAllocates|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsBoundsCheck|ParamFeedsLoopBound
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=8 exprs=26 control=3
+// Hotspot returns.go:784:12
+// <endpropsdump>
+// {"Flags":["FuncPropAllocates","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsBoundsCheck","ParamFeedsLoopBound"]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":8,"Exprs":26,"ControlFlow":3},"Hotspot":"returns.go:784:12","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_hotspot_nested_loop(s [][]int) int {
+	t := 0
+	for i := range s {
+		for j := range s[i] {
+			t += s[i][j]
+		}
+	}
+	p := new(int)
+	*p = t
+	return *p
+}
+
+// returns.go T_hotspot_blocking 803 0 1 6
+// Flags FuncPropMayBlock|FuncPropIsLeaf|FuncPropUsesChannels
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=4 exprs=14 control=2
+// Hotspot returns.go:807:13
+// <endpropsdump>
+// {"Flags":["FuncPropMayBlock","FuncPropIsLeaf","FuncPropUsesChannels"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":4,"Exprs":14,"ControlFlow":2},"Hotspot":"returns.go:807:13","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_hotspot_blocking(ch chan int, n int) int {
+	for i := 0; i < n; i++ {
+		n += i
+	}
+	return n + <-ch
+}
+
+// returns.go T_hotspot_none 819 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=3 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_hotspot_none(x int) int {
+	return x + 1
+}
+
+var errSentinel, errOther error
+
+// returns.go T_return_same_global 837 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultFlags
+//   0 ResultAlwaysSameGlobal
+// ResultAffectingParams 0
+// NumReturns 2
+// NodeCounts stmts=0 exprs=5 control=3
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[["ResultAlwaysSameGlobal"]],"ResultAffectingParams":1,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":5,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_return_same_global(x int) error {
+	if x < 0 {
+		return errSentinel
+	}
+	return errSentinel
+}
+
+// returns.go T_return_different_globals 854 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0
+// NumReturns 2
+// NodeCounts stmts=0 exprs=5 control=3
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":5,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_return_different_globals(x int) error {
+	if x < 0 {
+		return errSentinel
+	}
+	return errOther
+}
+
+// returns.go T_return_const 871 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultFlags
+//   0 ResultAlwaysSameConstant
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=1 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[["ResultAlwaysSameConstant"]],"ResultAffectingParams":0,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":1,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_return_const() int {
+	return 42
+}
+
+// returns.go T_result_feeds_cond 889 0 1 6
+// Flags FuncPropIsPure|FuncPropNearlyLeaf
+// ResultAffectingParams 0
+// NumReturns 4
+// MaxCallArgs 1
+// NumCalls 3
+// NodeCounts stmts=6 exprs=20 control=7
+// CallSites
+//   0 returns.go:890:32 CallSiteResultFeedsCond T_return_same_global score=-17 adj=resultFeedsCondAdj|leafAdj
+//   1 returns.go:893:23 CallSiteResultFeedsCond T_return_const score=-23 adj=resultFeedsCondAdj|leafAdj
+//   2 returns.go:897:19 CallSiteResultFeedsCond T_return_const score=-23 adj=resultFeedsCondAdj|leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropNearlyLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":4,"SingleTailReturn":false,"MaxCallArgs":1,"NumCalls":3,"NodeCounts":{"Stmts":6,"Exprs":20,"ControlFlow":7},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_result_feeds_cond(x int) int {
+	if err := T_return_same_global(x); err == errSentinel {
+		return 1
+	}
+	switch T_return_const() {
+	case 1:
+		return 2
+	}
+	if T_return_const() > x && x != 0 {
+		return 3
+	}
+	return 0
+}
+
+// returns.go T_named_value_error 917 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultFlags
+//   0 ResultNoInfo
+//   1 ResultAlwaysSameConstant|ResultErrorAlwaysNil
+// ResultAffectingParams 0
+// NumReturns 2
+// NodeCounts stmts=1 exprs=8 control=3
+// Hotspot returns.go:921:6
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[[],["ResultAlwaysSameConstant","ResultErrorAlwaysNil"]],"ResultAffectingParams":1,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":8,"ControlFlow":3},"Hotspot":"returns.go:921:6","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_named_value_error(x int) (v *Bar, err error) {
+	if x < 0 {
+		return
+	}
+	v = &Bar{}
+	return v, nil
+}
+
+// returns.go T_named_error_set_by_defer 943 0 1 6
+// Flags FuncPropStraightLine|FuncPropContainsDefer
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 0
+// NumCalls 1
+// NodeCounts stmts=2 exprs=3 control=1
+// Hotspot returns.go:944:8
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropContainsDefer"],"ParamFlags":[],"ResultFlags":[[],[]],"ResultAffectingParams":0,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":1,"NodeCounts":{"Stmts":2,"Exprs":3,"ControlFlow":1},"Hotspot":"returns.go:944:8","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// returns.go T_named_error_set_by_defer.func1 944 0 1 8
+// Flags FuncPropStraightLine|FuncPropIsLeaf
+// NodeCounts stmts=1 exprs=2 control=0
+// Captures vars=1 byref=1
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":2,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":1,"ByRef":1,"Escapes":false}}
+// <endfuncpreamble>
+func T_named_error_set_by_defer() (n int, err error) {
+	defer func() { err = errSentinel }()
+	return 1, nil
+}
+
+// returns.go T_named_error_set_by_recover 969 0 1 6
+// Flags FuncPropContainsRecover|FuncPropContainsDefer
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 0
+// NumCalls 1
+// NodeCounts stmts=2 exprs=9 control=2
+// Hotspot returns.go:970:8
+// <endpropsdump>
+// {"Flags":["FuncPropContainsRecover","FuncPropContainsDefer"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[[],[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":1,"NodeCounts":{"Stmts":2,"Exprs":9,"ControlFlow":2},"Hotspot":"returns.go:970:8","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// returns.go T_named_error_set_by_recover.func1 970 0 1 8
+// Flags FuncPropContainsRecover|FuncPropIsLeaf
+// NodeCounts stmts=5 exprs=8 control=1
+// Captures vars=1 byref=1
+// <endpropsdump>
+// {"Flags":["FuncPropContainsRecover","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":5,"Exprs":8,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":1,"ByRef":1,"Escapes":false}}
+// <endfuncpreamble>
+func T_named_error_set_by_recover(x int) (n int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errSentinel
+		}
+	}()
+	if x < 0 {
+		panic("bad")
+	}
+	return x, nil
+}
+
+// returns.go T_named_bare_return_set_by_defer 1000 0 1 6
+// Flags FuncPropStraightLine|FuncPropContainsDefer
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 0
+// NumCalls 1
+// NodeCounts stmts=2 exprs=1 control=1
+// Hotspot returns.go:1001:8
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropContainsDefer"],"ParamFlags":[[]],"ResultFlags":[[],[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":1,"NodeCounts":{"Stmts":2,"Exprs":1,"ControlFlow":1},"Hotspot":"returns.go:1001:8","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// returns.go T_named_bare_return_set_by_defer.func1 1001 0 1 8
+// Flags FuncPropIsLeaf
+// NodeCounts stmts=1 exprs=5 control=1
+// Captures vars=2 byref=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":5,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":2,"ByRef":1,"Escapes":false}}
+// <endfuncpreamble>
+func T_named_bare_return_set_by_defer(x int) (n int, err error) {
+	defer func() {
+		if x < 0 {
+			n = -1
+		}
+	}()
+	return
+}
+
+// returns.go T_named_error_set_by_closure 1033 0 1 6
+// Flags FuncPropStraightLine
+// ParamFlags
+//   0 ParamFeedsIndirectCall
+// ResultFlags
+//   0 ResultAlwaysSameConstant
+//   1 ResultNoInfo
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=4 control=1
+// Hotspot returns.go:1034:4
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine"],"ParamFlags":[["ParamFeedsIndirectCall"]],"ResultFlags":[["ResultAlwaysSameConstant"],[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":4,"ControlFlow":1},"Hotspot":"returns.go:1034:4","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// returns.go T_named_error_set_by_closure.func1 1034 0 1 4
+// Flags FuncPropStraightLine|FuncPropIsLeaf
+// NodeCounts stmts=1 exprs=2 control=0
+// Captures vars=1 byref=1 escapes
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[],"ResultAffectingParams":0,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":1,"Exprs":2,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":1,"ByRef":1,"Escapes":true}}
+// <endfuncpreamble>
+func T_named_error_set_by_closure(f func(func())) (n int, err error) {
+	f(func() { err = errSentinel })
+	return 0, nil
+}
+
+// returns.go T_value_ok 1046 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ResultAffectingParams 0 1
+// NumReturns 2
+// NodeCounts stmts=6 exprs=19 control=3
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[[],[]],"ResultFlags":[[],[]],"ResultAffectingParams":3,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":6,"Exprs":19,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_value_ok(m map[int]int, k int) (int, bool) {
+	if v, ok := m[k]; ok {
+		return v, true
+	}
+	return 0, false
+}
+
+// returns.go T_return_multi_call 1069 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropNearlyLeaf
+// ResultFlags
+//   0 ResultIsAllocatedMem|ResultFreshAlloc
+//   1 ResultAlwaysSameConstant|ResultErrorAlwaysNil
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=4 exprs=9 control=1
+// CallSites
+//   0 returns.go:1070:18 0 T_new_bar score=1 adj=leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[[]],"ResultFlags":[["ResultIsAllocatedMem","ResultFreshAlloc"],["ResultAlwaysSameConstant","ResultErrorAlwaysNil"]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":4,"Exprs":9,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_return_multi_call(x int) (*Bar, error) {
+	return T_new_bar(x)
+}
+
+// returns.go T_new_bar 1087 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultFlags
+//   0 ResultIsAllocatedMem|ResultFreshAlloc
+//   1 ResultAlwaysSameConstant|ResultErrorAlwaysNil
+// ResultAffectingParams 0
+// NumReturns 2
+// NodeCounts stmts=0 exprs=9 control=3
+// Hotspot returns.go:1089:13
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[["ResultIsAllocatedMem","ResultFreshAlloc"],["ResultAlwaysSameConstant","ResultErrorAlwaysNil"]],"ResultAffectingParams":1,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":9,"ControlFlow":3},"Hotspot":"returns.go:1089:13","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_new_bar(x int) (*Bar, error) {
+	if x < 0 {
+		return new(Bar), nil
+	}
+	return &Bar{}, nil
+}
+
+// returns.go T_return_multi_local 1110 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropNearlyLeaf
+// ResultFlags
+//   0 ResultIsAllocatedMem|ResultFreshAlloc
+//   1 ResultAlwaysSameConstant|ResultErrorAlwaysNil
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=7 exprs=15 control=1
+// CallSites
+//   0 returns.go:1111:21 0 T_new_bar score=1 adj=leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[[]],"ResultFlags":[["ResultIsAllocatedMem","ResultFreshAlloc"],["ResultAlwaysSameConstant","ResultErrorAlwaysNil"]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":7,"Exprs":15,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_return_multi_local(x int) (*Bar, error) {
+	b, err := T_new_bar(x)
+	return b, err
+}
+
+// returns.go T_err_always_nil 1128 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultFlags
+//   0 ResultNoInfo
+//   1 ResultAlwaysSameConstant|ResultErrorAlwaysNil
+// ResultAffectingParams 0
+// NumReturns 2
+// NodeCounts stmts=0 exprs=7 control=3
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[[],["ResultAlwaysSameConstant","ResultErrorAlwaysNil"]],"ResultAffectingParams":1,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":7,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_err_always_nil(x int) (int, error) {
+	if x < 0 {
+		return 0, nil
+	}
+	return x, nil
+}
+
+// returns.go T_err_always_nil_via_call 1151 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropNearlyLeaf
+// ResultFlags
+//   0 ResultNoInfo
+//   1 ResultAlwaysSameConstant|ResultErrorAlwaysNil
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=7 exprs=17 control=1
+// CallSites
+//   0 returns.go:1152:28 0 T_err_always_nil score=0 adj=leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[[]],"ResultFlags":[[],["ResultAlwaysSameConstant","ResultErrorAlwaysNil"]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":7,"Exprs":17,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_err_always_nil_via_call(x int) (int, error) {
+	v, err := T_err_always_nil(x)
+	return v + 1, err
+}
+
+func nilErr() error {
+	return nil
+}
+
+// returns.go T_err_always_nil_single 1176 0 1 6
+// Flags FuncPropIsPure|FuncPropNearlyLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultFlags
+//   0 ResultAlwaysSameConstant|ResultErrorAlwaysNil
+// ResultAffectingParams 0
+// NumReturns 2
+// MaxCallArgs 0
+// NumCalls 1
+// NodeCounts stmts=1 exprs=5 control=3
+// CallSites
+//   0 returns.go:1178:16 CallSiteTailPos|CallSiteOnErrorPath nilErr score=7 adj=tailCallAdj|leafAdj|errorPathAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropNearlyLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[["ResultAlwaysSameConstant","ResultErrorAlwaysNil"]],"ResultAffectingParams":1,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":5,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_err_always_nil_single(x int) error {
+	if x == 0 {
+		return nilErr()
+	}
+	return nil
+}
+
+// returns.go T_fresh_alloc_direct 1195 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropAllocates|FuncPropIsLeaf
+// ResultFlags
+//   0 ResultIsAllocatedMem|ResultFreshAlloc
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=4 control=1
+// Hotspot returns.go:1196:9
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropAllocates","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[["ResultIsAllocatedMem","ResultFreshAlloc"]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":4,"ControlFlow":1},"Hotspot":"returns.go:1196:9","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_fresh_alloc_direct(x int) *Bar {
+	return &Bar{x: x}
+}
+
+// returns.go T_fresh_alloc_var 1213 0 1 6
+// Flags FuncPropAllocates|FuncPropIsLeaf
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultFlags
+//   0 ResultIsAllocatedMem|ResultFreshAlloc
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=4 exprs=15 control=2
+// Hotspot returns.go:1214:10
+// <endpropsdump>
+// {"Flags":["FuncPropAllocates","FuncPropIsLeaf"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[["ResultIsAllocatedMem","ResultFreshAlloc"]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":4,"Exprs":15,"ControlFlow":2},"Hotspot":"returns.go:1214:10","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_fresh_alloc_var(x int) *Bar {
+	b := new(Bar)
+	b.x = x
+	if x < 0 {
+		b.x = -x
+	}
+	return b
+}
+
+// returns.go T_fresh_alloc_leaked 1238 0 1 6
+// Flags FuncPropStraightLine|FuncPropAllocates|FuncPropNearlyLeaf
+// ResultFlags
+//   0 ResultIsAllocatedMem
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=3 exprs=9 control=1
+// Hotspot returns.go:1239:7
+// CallSites
+//   0 returns.go:1240:6 0 leak score=-6 adj=leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropAllocates","FuncPropNearlyLeaf"],"ParamFlags":[[]],"ResultFlags":[["ResultIsAllocatedMem"]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":3,"Exprs":9,"ControlFlow":1},"Hotspot":"returns.go:1239:7","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_fresh_alloc_leaked(x int) *Bar {
+	b := &Bar{x: x}
+	leak(b)
+	return b
+}
+
+// returns.go T_fresh_alloc_addr_field 1256 0 1 6
+// Flags FuncPropStraightLine|FuncPropAllocates|FuncPropIsLeaf
+// ResultFlags
+//   0 ResultIsAllocatedMem
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=5 exprs=13 control=1
+// Hotspot returns.go:1257:7
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropAllocates","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[["ResultIsAllocatedMem"]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":5,"Exprs":13,"ControlFlow":1},"Hotspot":"returns.go:1257:7","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_fresh_alloc_addr_field(x int) *Bar {
+	b := &Bar{}
+	p := &b.x
+	*p = x
+	return b
+}
+
+func leak(b *Bar) {
+	sink = b
+}
+
+var sink interface{}
@@ -0,0 +1,682 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// DO NOT EDIT (use 'go test -v -update-expected' instead.)
+// See cmd/compile/internal/inline/inlheur/testdata/props/README.txt
+// for more information on the format of this file.
+// schema version 2
+// <endfilepreamble>
+
+package shapes
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"syscall"
+)
+
+// shapes.go T_cas_incr 35 0 1 6
+// Flags FuncPropCASLoop|FuncPropIsLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// MaxCallArgs 3
+// NumCalls 2
+// NodeCounts stmts=4 exprs=10 control=3
+// Hotspot shapes.go:36:2
+// CallSites
+//   0 shapes.go:37:26 CallSiteInLoop LoadInt32
+//   1 shapes.go:38:32 CallSiteInLoop|CallSiteResultFeedsCond CompareAndSwapInt32
+// <endpropsdump>
+// {"Flags":["FuncPropCASLoop","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":false,"MaxCallArgs":3,"NumCalls":2,"NodeCounts":{"Stmts":4,"Exprs":10,"ControlFlow":3},"Hotspot":"shapes.go:36:2","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_cas_incr(p *int32) {
+	for {
+		old := atomic.LoadInt32(p)
+		if atomic.CompareAndSwapInt32(p, old, old+1) {
+			return
+		}
+	}
+}
+
+// shapes.go T_cas_incr_break 59 0 1 6
+// Flags FuncPropCASLoop|FuncPropIsLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 3
+// NumCalls 2
+// NodeCounts stmts=5 exprs=12 control=4
+// Hotspot shapes.go:61:2
+// CallSites
+//   0 shapes.go:62:23 CallSiteInLoop LoadInt64
+//   1 shapes.go:63:32 CallSiteInLoop|CallSiteResultFeedsCond CompareAndSwapInt64
+// <endpropsdump>
+// {"Flags":["FuncPropCASLoop","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":3,"NumCalls":2,"NodeCounts":{"Stmts":5,"Exprs":12,"ControlFlow":4},"Hotspot":"shapes.go:61:2","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_cas_incr_break(p *int64) int64 {
+	var v int64
+	for {
+		v = atomic.LoadInt64(p)
+		if atomic.CompareAndSwapInt64(p, v, v*2) {
+			break
+		}
+	}
+	return v
+}
+
+// shapes.go T_cas_method 84 0 1 6
+// Flags FuncPropCASLoop
+// ResultAffectingParams 0 1
+// NumReturns 1
+// MaxCallArgs 3
+// NumCalls 2
+// NodeCounts stmts=4 exprs=10 control=3
+// Hotspot shapes.go:85:2
+// CallSites
+//   0 shapes.go:86:16 CallSiteInLoop (*Uint32).Load score=4 adj=0
+//   1 shapes.go:87:22 CallSiteInLoop|CallSiteResultFeedsCond (*Uint32).CompareAndSwap score=6 adj=0
+// <endpropsdump>
+// {"Flags":["FuncPropCASLoop"],"ParamFlags":[[],[]],"ResultFlags":[],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":false,"MaxCallArgs":3,"NumCalls":2,"NodeCounts":{"Stmts":4,"Exprs":10,"ControlFlow":3},"Hotspot":"shapes.go:85:2","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_cas_method(c *atomic.Uint32, mask uint32) {
+	for {
+		old := c.Load()
+		if c.CompareAndSwap(old, old|mask) {
+			return
+		}
+	}
+}
+
+// shapes.go T_cas_not_loop 107 0 1 6
+// Flags FuncPropStraightLine|FuncPropIsLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 3
+// NumCalls 2
+// NodeCounts stmts=4 exprs=10 control=1
+// CallSites
+//   0 shapes.go:108:25 0 LoadInt32
+//   1 shapes.go:109:35 CallSiteTailPos CompareAndSwapInt32
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":3,"NumCalls":2,"NodeCounts":{"Stmts":4,"Exprs":10,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_cas_not_loop(p *int32) bool {
+	old := atomic.LoadInt32(p)
+	return atomic.CompareAndSwapInt32(p, old, old+1)
+}
+
+// shapes.go T_cas_extra_work 126 0 1 6
+// Flags FuncPropIsLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// MaxCallArgs 3
+// NumCalls 2
+// NodeCounts stmts=5 exprs=11 control=3
+// Hotspot shapes.go:127:2
+// CallSites
+//   0 shapes.go:128:26 CallSiteInLoop LoadInt32
+//   1 shapes.go:130:32 CallSiteInLoop|CallSiteResultFeedsCond CompareAndSwapInt32
+// <endpropsdump>
+// {"Flags":["FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":false,"MaxCallArgs":3,"NumCalls":2,"NodeCounts":{"Stmts":5,"Exprs":11,"ControlFlow":3},"Hotspot":"shapes.go:127:2","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_cas_extra_work(p *int32) {
+	for {
+		old := atomic.LoadInt32(p)
+		println(old)
+		if atomic.CompareAndSwapInt32(p, old, old+1) {
+			return
+		}
+	}
+}
+
+// shapes.go T_forwarder 149 0 1 6
+// Flags FuncPropIsWrapper|FuncPropIsPure|FuncPropStraightLine|FuncPropTrivialWrapper|FuncPropNearlyLeaf
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 1
+// NodeCounts stmts=1 exprs=3 control=1
+// CallSites
+//   0 shapes.go:150:16 CallSiteTailPos wrapped score=-9 adj=tailCallAdj|leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsWrapper","FuncPropIsPure","FuncPropStraightLine","FuncPropTrivialWrapper","FuncPropNearlyLeaf"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_forwarder(a, b int) int {
+	return wrapped(a, b)
+}
+
+// shapes.go T_forwarder_noresult 164 0 1 6
+// Flags FuncPropIsWrapper|FuncPropStraightLine|FuncPropTrivialWrapper|FuncPropNearlyLeaf
+// ResultAffectingParams 0
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=2 control=0
+// CallSites
+//   0 shapes.go:165:6 0 sink score=-7 adj=leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsWrapper","FuncPropStraightLine","FuncPropTrivialWrapper","FuncPropNearlyLeaf"],"ParamFlags":[[]],"ResultFlags":[],"ResultAffectingParams":1,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":2,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_forwarder_noresult(x string) {
+	sink(x)
+}
+
+// shapes.go T_variadic_forwarder 179 0 1 6
+// Flags FuncPropIsWrapper|FuncPropStraightLine|FuncPropTrivialWrapper|FuncPropNearlyLeaf
+// ResultAffectingParams 0 1
+// MaxCallArgs 2
+// NumCalls 1
+// NodeCounts stmts=1 exprs=3 control=0
+// CallSites
+//   0 shapes.go:180:7 0 sinkv score=-4 adj=leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsWrapper","FuncPropStraightLine","FuncPropTrivialWrapper","FuncPropNearlyLeaf"],"ParamFlags":[[],[]],"ResultFlags":[],"ResultAffectingParams":3,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":2,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":3,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_variadic_forwarder(prefix string, args ...any) {
+	sinkv(prefix, args...)
+}
+
+// shapes.go T_forwarder_const_arg 196 0 1 6
+// Flags FuncPropIsWrapper|FuncPropIsPure|FuncPropStraightLine|FuncPropNearlyLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 1
+// NodeCounts stmts=1 exprs=3 control=1
+// CallSites
+//   0 shapes.go:197:16 CallSiteTailPos wrapped score=-9 adj=tailCallAdj|leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsWrapper","FuncPropIsPure","FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_forwarder_const_arg(a int) int {
+	return wrapped(a, 42)
+}
+
+// shapes.go T_not_forwarder_reordered 213 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropNearlyLeaf
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 1
+// NodeCounts stmts=1 exprs=3 control=1
+// CallSites
+//   0 shapes.go:214:16 CallSiteTailPos wrapped score=-9 adj=tailCallAdj|leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_not_forwarder_reordered(a, b int) int {
+	return wrapped(b, a)
+}
+
+// shapes.go T_not_forwarder_expr 230 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropNearlyLeaf
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 1
+// NodeCounts stmts=1 exprs=5 control=1
+// CallSites
+//   0 shapes.go:231:16 CallSiteTailPos wrapped score=-9 adj=tailCallAdj|leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropNearlyLeaf"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":5,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_not_forwarder_expr(a, b int) int {
+	return wrapped(a, b+1)
+}
+
+// shapes.go T_endian_u32 247 0 1 6
+// Flags FuncPropEndianConv|FuncPropStraightLine
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 1
+// NodeCounts stmts=1 exprs=4 control=1
+// CallSites
+//   0 shapes.go:248:35 CallSiteTailPos littleEndian.Uint32 score=26 adj=tailCallAdj
+// <endpropsdump>
+// {"Flags":["FuncPropEndianConv","FuncPropStraightLine"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":4,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_endian_u32(b []byte) uint32 {
+	return binary.LittleEndian.Uint32(b)
+}
+
+// shapes.go T_endian_u16_off 264 0 1 6
+// Flags FuncPropEndianConv|FuncPropStraightLine
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 1
+// NodeCounts stmts=1 exprs=7 control=1
+// CallSites
+//   0 shapes.go:265:36 0 bigEndian.Uint16 score=17 adj=0
+// <endpropsdump>
+// {"Flags":["FuncPropEndianConv","FuncPropStraightLine"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":7,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_endian_u16_off(b []byte, off int) int {
+	return int(binary.BigEndian.Uint16(b[off:]))
+}
+
+// shapes.go T_endian_put64 279 0 1 6
+// Flags FuncPropEndianConv|FuncPropStraightLine
+// ResultAffectingParams 0 1
+// MaxCallArgs 3
+// NumCalls 1
+// NodeCounts stmts=1 exprs=7 control=0
+// CallSites
+//   0 shapes.go:280:31 0 littleEndian.PutUint64 score=67 adj=0
+// <endpropsdump>
+// {"Flags":["FuncPropEndianConv","FuncPropStraightLine"],"ParamFlags":[[],[]],"ResultFlags":[],"ResultAffectingParams":3,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":3,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":7,"ControlFlow":0},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_endian_put64(b []byte, v uint64) {
+	binary.LittleEndian.PutUint64(b[8:], v)
+}
+
+// shapes.go T_not_endian_conv_global 295 0 1 6
+// Flags FuncPropStraightLine
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 1
+// NodeCounts stmts=1 exprs=4 control=1
+// CallSites
+//   0 shapes.go:296:35 CallSiteTailPos littleEndian.Uint32 score=26 adj=tailCallAdj
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine"],"ParamFlags":[],"ResultFlags":[[]],"ResultAffectingParams":0,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":4,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_not_endian_conv_global() uint32 {
+	return binary.LittleEndian.Uint32(GB)
+}
+
+// shapes.go T_not_endian_conv_work 312 0 1 6
+// Flags FuncPropStraightLine
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 1
+// NodeCounts stmts=1 exprs=6 control=1
+// CallSites
+//   0 shapes.go:313:35 0 littleEndian.Uint32 score=31 adj=0
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":6,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_not_endian_conv_work(b []byte) uint32 {
+	return binary.LittleEndian.Uint32(b) + 1
+}
+
+var GB []byte
+
+// shapes.go T_straight_line 327 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=6 exprs=18 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":6,"Exprs":18,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_straight_line(x, y int) int {
+	a := x * 3
+	b := a + y
+	c := b << 2
+	return c - x
+}
+
+// shapes.go T_not_straight_line_if 343 0 1 6
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=3 exprs=11 control=2
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":3,"Exprs":11,"ControlFlow":2},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_not_straight_line_if(x, y int) int {
+	a := x * 3
+	if a > y {
+		a = y
+	}
+	return a
+}
+
+// shapes.go T_straight_line_closure_if 371 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultFlags
+//   0 ResultAlwaysSameInlinableFunc
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=1 control=1
+// Hotspot shapes.go:372:9
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[["ResultAlwaysSameInlinableFunc"]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":1,"ControlFlow":1},"Hotspot":"shapes.go:372:9","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+// shapes.go T_straight_line_closure_if.func1 372 0 1 9
+// Flags FuncPropIsPure|FuncPropIsLeaf
+// NumReturns 2
+// NodeCounts stmts=0 exprs=5 control=3
+// Captures vars=1 byref=0 escapes
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropIsLeaf"],"ParamFlags":[],"ResultFlags":[[]],"ResultAffectingParams":0,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":5,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":1,"ByRef":0,"Escapes":true}}
+// <endfuncpreamble>
+func T_straight_line_closure_if(x int) func() int {
+	return func() int {
+		if x > 0 {
+			return 1
+		}
+		return 0
+	}
+}
+
+// shapes.go T_format_wrapper 396 0 1 6
+// Flags FuncPropIsWrapper|FuncPropFormatWrapper|FuncPropStraightLine|FuncPropConcatDominated|FuncPropTrivialWrapper
+// ParamFlags
+//   0 ParamFeedsFormatString|ParamFeedsStringConcat
+//   1 ParamNoInfo
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 1
+// NodeCounts stmts=1 exprs=3 control=1
+// CallSites
+//   0 shapes.go:397:20 CallSiteTailPos Sprintf
+// <endpropsdump>
+// {"Flags":["FuncPropIsWrapper","FuncPropFormatWrapper","FuncPropStraightLine","FuncPropConcatDominated","FuncPropTrivialWrapper"],"ParamFlags":[["ParamFeedsFormatString","ParamFeedsStringConcat"],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_format_wrapper(format string, args ...any) string {
+	return fmt.Sprintf(format, args...)
+}
+
+// shapes.go T_format_wrapper_fprintf 415 0 1 6
+// Flags FuncPropFormatWrapper|FuncPropStraightLine|FuncPropAllocates|FuncPropConcatDominated
+// ParamFlags
+//   0 ParamFeedsFormatString|ParamFeedsStringConcat
+//   1 ParamNoInfo
+// ResultAffectingParams 0 1
+// MaxCallArgs 3
+// NumCalls 1
+// NodeCounts stmts=1 exprs=7 control=0
+// Hotspot shapes.go:416:13
+// CallSites
+//   0 shapes.go:416:13 0 Fprintf
+// <endpropsdump>
+// {"Flags":["FuncPropFormatWrapper","FuncPropStraightLine","FuncPropAllocates","FuncPropConcatDominated"],"ParamFlags":[["ParamFeedsFormatString","ParamFeedsStringConcat"],[]],"ResultFlags":[],"ResultAffectingParams":3,"NumReturns":0,"SingleTailReturn":false,"MaxCallArgs":3,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":7,"ControlFlow":0},"Hotspot":"shapes.go:416:13","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_format_wrapper_fprintf(format string, x int) {
+	fmt.Fprintf(os.Stderr, format, x)
+}
+
+// shapes.go T_format_wrapper_const 433 0 1 6
+// Flags FuncPropFormatWrapper|FuncPropStraightLine|FuncPropAllocates
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 1
+// NodeCounts stmts=1 exprs=5 control=1
+// Hotspot shapes.go:434:19
+// CallSites
+//   0 shapes.go:434:19 CallSiteTailPos Errorf
+// <endpropsdump>
+// {"Flags":["FuncPropFormatWrapper","FuncPropStraightLine","FuncPropAllocates"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":5,"ControlFlow":1},"Hotspot":"shapes.go:434:19","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_format_wrapper_const(x int) error {
+	return fmt.Errorf("bad value %d", x)
+}
+
+// shapes.go T_not_format_wrapper_prefix 453 0 1 6
+// Flags FuncPropStraightLine|FuncPropConcatDominated
+// ParamFlags
+//   0 ParamFeedsStringConcat
+//   1 ParamNoInfo
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 1
+// NodeCounts stmts=1 exprs=5 control=1
+// CallSites
+//   0 shapes.go:454:20 CallSiteTailPos Sprintf
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropConcatDominated"],"ParamFlags":[["ParamFeedsStringConcat"],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":5,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_not_format_wrapper_prefix(format string, args ...any) string {
+	return fmt.Sprintf("pfx: "+format, args...)
+}
+
+// shapes.go T_format_wrapper_caller 471 0 1 6
+// Flags FuncPropStraightLine|FuncPropAllocates
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 1
+// NodeCounts stmts=1 exprs=5 control=1
+// Hotspot shapes.go:472:25
+// CallSites
+//   0 shapes.go:472:25 CallSiteTailPos T_format_wrapper score=-33 adj=tailCallAdj|formatConstAdj|trivialWrapperAdj
+// <endpropsdump>
+// {"Flags":["FuncPropStraightLine","FuncPropAllocates"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":5,"ControlFlow":1},"Hotspot":"shapes.go:472:25","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_format_wrapper_caller(x int) string {
+	return T_format_wrapper("%d", x)
+}
+
+type Fwd struct{ x int }
+
+// shapes.go (*Fwd).T_method_forwarder 490 0 1 6
+// Flags FuncPropIsWrapper|FuncPropIsPure|FuncPropStraightLine|FuncPropTrivialWrapper|FuncPropNearlyLeaf
+// ResultAffectingParams 0 1
+// NumReturns 1
+// SingleTailReturn
+// MaxCallArgs 2
+// NumCalls 1
+// NodeCounts stmts=1 exprs=3 control=1
+// CallSites
+//   0 shapes.go:491:17 CallSiteTailPos (*Fwd).target score=-10 adj=tailCallAdj|leafAdj
+// <endpropsdump>
+// {"Flags":["FuncPropIsWrapper","FuncPropIsPure","FuncPropStraightLine","FuncPropTrivialWrapper","FuncPropNearlyLeaf"],"ParamFlags":[[],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":2,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":3,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func (f *Fwd) T_method_forwarder(y int) int {
+	return f.target(y)
+}
+
+// shapes.go T_tail_recursive 509 0 1 6
+// Flags FuncPropTailRecursive|FuncPropIsPure|FuncPropRecursive
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+//   1 ParamNoInfo
+// ResultAffectingParams 0 1
+// NumReturns 2
+// MaxCallArgs 2
+// NumCalls 1
+// NodeCounts stmts=1 exprs=11 control=3
+// CallSites
+//   0 shapes.go:513:25 CallSiteTailPos T_tail_recursive
+// <endpropsdump>
+// {"Flags":["FuncPropTailRecursive","FuncPropIsPure","FuncPropRecursive"],"ParamFlags":[["ParamFeedsIfOrSwitch"],[]],"ResultFlags":[[]],"ResultAffectingParams":3,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":2,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":11,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_tail_recursive(n, acc int) int {
+	if n <= 1 {
+		return acc
+	}
+	return T_tail_recursive(n-1, acc*n)
+}
+
+// shapes.go T_not_tail_recursive 530 0 1 6
+// Flags FuncPropIsPure|FuncPropRecursive
+// ParamFlags
+//   0 ParamFeedsIfOrSwitch
+// ResultAffectingParams 0
+// NumReturns 2
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=1 exprs=10 control=3
+// CallSites
+//   0 shapes.go:534:33 0 T_not_tail_recursive
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropRecursive"],"ParamFlags":[["ParamFeedsIfOrSwitch"]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":1,"Exprs":10,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_not_tail_recursive(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return n * T_not_tail_recursive(n-1)
+}
+
+// shapes.go T_syscall_wrapper 550 0 1 6
+// Flags FuncPropSyscallWrapper
+// ResultAffectingParams 0
+// NumReturns 2
+// MaxCallArgs 3
+// NumCalls 1
+// NodeCounts stmts=7 exprs=33 control=3
+// Hotspot shapes.go:553:15
+// CallSites
+//   0 shapes.go:551:25 CallSiteErrorChecked Open score=75 adj=0
+// <endpropsdump>
+// {"Flags":["FuncPropSyscallWrapper"],"ParamFlags":[[]],"ResultFlags":[[],[]],"ResultAffectingParams":1,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":3,"NumCalls":1,"NodeCounts":{"Stmts":7,"Exprs":33,"ControlFlow":3},"Hotspot":"shapes.go:553:15","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_syscall_wrapper(p string) (*File, error) {
+	fd, err := syscall.Open(p, syscall.O_RDONLY, 0)
+	if err != nil {
+		return nil, &PathError{Op: "open", Path: p, Err: err}
+	}
+	return &File{fd}, nil
+}
+
+// shapes.go T_syscall_wrapper_errno 570 0 1 6
+// Flags FuncPropSyscallWrapper
+// ResultAffectingParams 0
+// NumReturns 2
+// MaxCallArgs 1
+// NumCalls 1
+// NodeCounts stmts=3 exprs=10 control=3
+// CallSites
+//   0 shapes.go:571:22 0 Close
+// <endpropsdump>
+// {"Flags":["FuncPropSyscallWrapper"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":1,"NumCalls":1,"NodeCounts":{"Stmts":3,"Exprs":10,"ControlFlow":3},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_syscall_wrapper_errno(fd int) error {
+	err := syscall.Close(fd)
+	if err == syscall.EINTR {
+		return nil
+	}
+	return err
+}
+
+// shapes.go T_not_syscall_wrapper 590 0 1 6
+// ResultAffectingParams 0
+// NumReturns 2
+// MaxCallArgs 3
+// NumCalls 1
+// NodeCounts stmts=8 exprs=26 control=3
+// Hotspot shapes.go:596:9
+// CallSites
+//   0 shapes.go:591:25 CallSiteErrorChecked Open score=75 adj=0
+// <endpropsdump>
+// {"Flags":[],"ParamFlags":[[]],"ResultFlags":[[],[]],"ResultAffectingParams":1,"NumReturns":2,"SingleTailReturn":false,"MaxCallArgs":3,"NumCalls":1,"NodeCounts":{"Stmts":8,"Exprs":26,"ControlFlow":3},"Hotspot":"shapes.go:596:9","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_not_syscall_wrapper(p string) (*File, error) {
+	fd, err := syscall.Open(p, syscall.O_RDONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	println(fd)
+	return &File{fd}, nil
+}
+
+// shapes.go T_array_ctor 608 0 1 6
+// Flags FuncPropArrayConstructor|FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultAffectingParams 0 1 2 3
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=5 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropArrayConstructor","FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[],[],[],[]],"ResultFlags":[[]],"ResultAffectingParams":15,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":5,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_array_ctor(r, g, b, a byte) [4]byte {
+	return [4]byte{r, g, b, a}
+}
+
+// shapes.go T_array_ctor_keyed 621 0 1 6
+// Flags FuncPropArrayConstructor|FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=9 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropArrayConstructor","FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":9,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_array_ctor_keyed(x int) [8]int {
+	return [8]int{0: x, 7: x * 2}
+}
+
+// shapes.go T_not_array_ctor_slice 637 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropAllocates|FuncPropIsLeaf
+// ResultFlags
+//   0 ResultIsAllocatedMem
+// ResultAffectingParams 0 1 2 3
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=0 exprs=5 control=1
+// Hotspot shapes.go:638:15
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropAllocates","FuncPropIsLeaf"],"ParamFlags":[[],[],[],[]],"ResultFlags":[["ResultIsAllocatedMem"]],"ResultAffectingParams":15,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":0,"Exprs":5,"ControlFlow":1},"Hotspot":"shapes.go:638:15","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_not_array_ctor_slice(r, g, b, a byte) []byte {
+	return []byte{r, g, b, a}
+}
+
+// shapes.go T_not_array_ctor_work 650 0 1 6
+// Flags FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf
+// ResultAffectingParams 0
+// NumReturns 1
+// SingleTailReturn
+// NodeCounts stmts=2 exprs=8 control=1
+// <endpropsdump>
+// {"Flags":["FuncPropIsPure","FuncPropStraightLine","FuncPropIsLeaf"],"ParamFlags":[[]],"ResultFlags":[[]],"ResultAffectingParams":1,"NumReturns":1,"SingleTailReturn":true,"MaxCallArgs":0,"NumCalls":0,"NodeCounts":{"Stmts":2,"Exprs":8,"ControlFlow":1},"Hotspot":"","Captures":{"Vars":0,"ByRef":0,"Escapes":false}}
+// <endfuncpreamble>
+func T_not_array_ctor_work(x int) [2]int {
+	y := x * x
+	return [2]int{x, y}
+}
+
+type File struct{ fd int }
+
+type PathError struct {
+	Op, Path string
+	Err      error
+}
+
+func (e *PathError) Error() string { return e.Op + " " + e.Path }
+
+func (f *Fwd) target(y int) int {
+	return f.x + y
+}
+
+func wrapped(a, b int) int {
+	return a*b + 1
+}
+
+var G string
+var GA []any
+
+func sink(s string) {
+	G = s
+}
+
+func sinkv(s string, args ...any) {
+	G = s
+	GA = args
+}
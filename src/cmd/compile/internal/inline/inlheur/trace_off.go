@@ -6,13 +6,21 @@
 
 package inlheur
 
+import "cmd/compile/internal/ir"
+
 const debugTrace = 0
 
 func enableDebugTrace(x int) {
 }
 
-func enableDebugTraceIfEnv() {
+func enableDebugTraceIfEnv(fn *ir.Func) {
 }
 
 func disableDebugTrace() {
 }
+
+func traceEvent(event string, kv ...any) {
+}
+
+func traceNode(event string, depth int, n ir.Node) {
+}
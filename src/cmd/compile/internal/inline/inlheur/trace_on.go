@@ -7,8 +7,17 @@
 package inlheur
 
 import (
+	"bytes"
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strconv"
+	"strings"
+	"sync"
 )
 
 var debugTrace = 0
@@ -17,7 +26,18 @@ func enableDebugTrace(x int) {
 	debugTrace = x
 }
 
-func enableDebugTraceIfEnv() {
+// enableDebugTraceIfEnv turns on tracing for the analysis of function
+// 'fn' according to the DEBUG_TRACE_INLHEUR environment variable. If
+// DEBUG_TRACE_INLHEUR_FUNCS is also set, tracing is only enabled for
+// functions whose names match the regular expression it contains
+// (e.g. "^MyFunc$|Helper"). Tracing can also be confined to the
+// packages whose import paths are listed (separated by commas) in
+// DEBUG_TRACE_INLHEUR_PKG (e.g. "net/http"), and to the functions
+// defined in the source files listed in DEBUG_TRACE_INLHEUR_SRCFILE
+// (e.g. "server.go"), so that a single compile can be traced in a
+// large build without editing code. A nil 'fn' matches any function
+// (but not any package).
+func enableDebugTraceIfEnv(fn *ir.Func) {
 	v := os.Getenv("DEBUG_TRACE_INLHEUR")
 	if v == "" {
 		return
@@ -32,9 +52,202 @@ func enableDebugTraceIfEnv() {
 	if err != nil {
 		return
 	}
+	if !tracePkgMatches() {
+		return
+	}
+	if fn != nil && (!traceFuncMatches(fn) || !traceFileMatches(fn)) {
+		return
+	}
 	debugTrace = i
 }
 
+// tracePkgMatches returns true if tracing should be enabled for the
+// package being compiled according to DEBUG_TRACE_INLHEUR_PKG, a
+// comma-separated list of import paths.
+func tracePkgMatches() bool {
+	v := os.Getenv("DEBUG_TRACE_INLHEUR_PKG")
+	if v == "" {
+		return true
+	}
+	for _, pkg := range strings.Split(v, ",") {
+		if pkg == base.Ctxt.Pkgpath {
+			return true
+		}
+	}
+	return false
+}
+
+// traceFileMatches returns true if tracing should be enabled for
+// function 'fn' according to DEBUG_TRACE_INLHEUR_SRCFILE, a
+// comma-separated list of source files. An entry matches a file with
+// that base name (as in "server.go"), or whose path ends with it (as
+// in "net/http/server.go").
+func traceFileMatches(fn *ir.Func) bool {
+	v := os.Getenv("DEBUG_TRACE_INLHEUR_SRCFILE")
+	if v == "" {
+		return true
+	}
+	file := filepath.ToSlash(base.Ctxt.InnermostPos(fn.Pos()).Filename())
+	for _, f := range strings.Split(v, ",") {
+		if f != "" && (file == f || strings.HasSuffix(file, "/"+f)) {
+			return true
+		}
+	}
+	return false
+}
+
 func disableDebugTrace() {
 	debugTrace = 0
 }
+
+// traceFuncsPat and traceFuncsRE cache the compiled form of the
+// DEBUG_TRACE_INLHEUR_FUNCS regular expression.
+var traceFuncsPat string
+var traceFuncsRE *regexp.Regexp
+
+// traceFuncMatches returns true if tracing should be enabled for
+// function 'fn' according to DEBUG_TRACE_INLHEUR_FUNCS.
+func traceFuncMatches(fn *ir.Func) bool {
+	pat := os.Getenv("DEBUG_TRACE_INLHEUR_FUNCS")
+	if pat == "" {
+		return true
+	}
+	if pat != traceFuncsPat {
+		re, err := regexp.Compile(pat)
+		if err != nil {
+			base.Fatalf("bad DEBUG_TRACE_INLHEUR_FUNCS regexp %q: %v", pat, err)
+		}
+		traceFuncsPat, traceFuncsRE = pat, re
+	}
+	return traceFuncsRE.MatchString(fn.Sym().Name)
+}
+
+// traceMu serializes the writing of trace events, since functions
+// may be analyzed concurrently.
+var traceMu sync.Mutex
+
+// traceOut is the file named by DEBUG_TRACE_INLHEUR_FILE, opened on
+// the first call to traceEvent, or nil if the variable is not set.
+var traceOut *os.File
+var traceOutOnce sync.Once
+
+// traceEvent emits a trace event of kind 'event' (for example
+// "funcstart", "visit", "result", "callsite" or "score"), described
+// by 'kv', a list of alternating keys and values. If the
+// DEBUG_TRACE_INLHEUR_FILE environment variable names a file, the
+// event is written there as a single-line JSON object, as in
+//
+//	{"event":"result","analyzer":"alloc","func":"F","allocates":true}
+//
+// so that traces can be diffed and filtered with tools such as jq.
+// Otherwise the event is written to the standard error as a line of
+// text of the form "=-= result analyzer=alloc func=F allocates=true".
+func traceEvent(event string, kv ...any) {
+	writeTraceEvent(0, event, kv)
+}
+
+// traceDepthLimit returns the maximum depth of the nodes traced by
+// traceNode, as given by the DEBUG_TRACE_INLHEUR_DEPTH environment
+// variable, or -1 if there is no limit.
+func traceDepthLimit() int {
+	traceDepthOnce.Do(func() {
+		traceDepth = -1
+		if v := os.Getenv("DEBUG_TRACE_INLHEUR_DEPTH"); v != "" {
+			d, err := strconv.Atoi(v)
+			if err != nil || d < 0 {
+				base.Fatalf("bad DEBUG_TRACE_INLHEUR_DEPTH value %q", v)
+			}
+			traceDepth = d
+		}
+	})
+	return traceDepth
+}
+
+var traceDepth int
+var traceDepthOnce sync.Once
+
+// traceNode emits a "pre" or "post" trace event (as given by 'event')
+// for the visit of node 'n' at depth 'depth' of the walk over the
+// function being analyzed, where the function itself is at depth
+// zero. In text form the event is indented according to its depth,
+// as in
+//
+//	=-= pre depth=0 op=DCLFUNC pos=p.go:3:6
+//	=-=   pre depth=1 op=IF pos=p.go:4:2
+//	=-=     pre depth=2 op=LT pos=p.go:4:7
+//
+// so that the events emitted by the analyzers themselves while
+// visiting a node (such as "visit" events) appear nested within the
+// node that triggered them. Nodes deeper than the limit given by
+// DEBUG_TRACE_INLHEUR_DEPTH are not traced.
+func traceNode(event string, depth int, n ir.Node) {
+	if limit := traceDepthLimit(); limit >= 0 && depth > limit {
+		return
+	}
+	writeTraceEvent(depth, event, []any{"depth", depth, "op", n.Op(),
+		"pos", ir.Line(n)})
+}
+
+// writeTraceEvent does the work of traceEvent, indenting the event
+// by 'indent' levels when it is written as text.
+func writeTraceEvent(indent int, event string, kv []any) {
+	traceOutOnce.Do(func() {
+		if path := os.Getenv("DEBUG_TRACE_INLHEUR_FILE"); path != "" {
+			f, err := os.Create(path)
+			if err != nil {
+				base.Fatalf("opening DEBUG_TRACE_INLHEUR_FILE: %v", err)
+			}
+			traceOut = f
+		}
+	})
+	if len(kv)%2 != 0 {
+		panic("traceEvent: odd number of key/value arguments")
+	}
+	var buf bytes.Buffer
+	if traceOut != nil {
+		buf.WriteString(`{"event":`)
+		writeTraceJSON(&buf, event)
+		for i := 0; i < len(kv); i += 2 {
+			buf.WriteByte(',')
+			writeTraceJSON(&buf, kv[i].(string))
+			buf.WriteByte(':')
+			writeTraceJSON(&buf, traceValue(kv[i+1]))
+		}
+		buf.WriteString("}\n")
+	} else {
+		buf.WriteString("=-= " + strings.Repeat("  ", indent) + event)
+		for i := 0; i < len(kv); i += 2 {
+			fmt.Fprintf(&buf, " %s=%v", kv[i], traceValue(kv[i+1]))
+		}
+		buf.WriteByte('\n')
+	}
+	traceMu.Lock()
+	defer traceMu.Unlock()
+	if traceOut != nil {
+		traceOut.Write(buf.Bytes())
+	} else {
+		os.Stderr.Write(buf.Bytes())
+	}
+}
+
+// traceValue converts the value 'v' of a trace event attribute into
+// a form suitable for output: booleans, integers and strings are
+// left as is, while other values are converted to strings.
+func traceValue(v any) any {
+	switch v := v.(type) {
+	case bool, int, int32, int64, uint, uint32, uint64, string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprint(v)
+}
+
+// writeTraceJSON writes the JSON encoding of 'v' to 'buf'.
+func writeTraceJSON(buf *bytes.Buffer, v any) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		base.Fatalf("encoding trace event: %v", err)
+	}
+	buf.Write(b)
+}
@@ -4,12 +4,33 @@
 
 package inlheur
 
-import "testing"
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
 
 func fpeq(fp1, fp2 FuncProps) bool {
 	if fp1.Flags != fp2.Flags {
 		return false
 	}
+	if fp1.ResultAffectingParams != fp2.ResultAffectingParams {
+		return false
+	}
+	if fp1.NumReturns != fp2.NumReturns ||
+		fp1.SingleTailReturn != fp2.SingleTailReturn {
+		return false
+	}
+	if fp1.MaxCallArgs != fp2.MaxCallArgs || fp1.NumCalls != fp2.NumCalls {
+		return false
+	}
+	if fp1.NodeCounts != fp2.NodeCounts || fp1.Hotspot != fp2.Hotspot {
+		return false
+	}
+	if fp1.Captures != fp2.Captures {
+		return false
+	}
 	if len(fp1.ParamFlags) != len(fp2.ParamFlags) {
 		return false
 	}
@@ -39,11 +60,44 @@ func TestSerDeser(t *testing.T) {
 			Flags:       1,
 			ResultFlags: []ResultPropBits{ResultAlwaysSameConstant},
 		},
+		FuncProps{
+			Flags:      FuncPropIsWrapper,
+			ParamFlags: []ParamPropBits{ParamNoInfo, ParamNoInfo},
+		},
 		FuncProps{
 			Flags:       1,
 			ParamFlags:  []ParamPropBits{0x99, 0xaa, 0xfffff},
 			ResultFlags: []ResultPropBits{0xfeedface},
 		},
+		FuncProps{
+			ParamFlags:            []ParamPropBits{0, 0, 0},
+			ResultAffectingParams: 0x5,
+		},
+		FuncProps{
+			ResultAffectingParams: 1 << 63,
+		},
+		FuncProps{
+			ResultFlags:      []ResultPropBits{0},
+			NumReturns:       1,
+			SingleTailReturn: true,
+		},
+		FuncProps{
+			NumReturns: 300,
+		},
+		FuncProps{
+			MaxCallArgs: 9,
+			NumCalls:    1000,
+		},
+		FuncProps{
+			NodeCounts: NodeCounts{Stmts: 3, Exprs: 200, ControlFlow: 1},
+		},
+		FuncProps{
+			Hotspot:  "foo.go:12:3",
+			NumCalls: 1,
+		},
+		FuncProps{
+			Captures: ClosureCaptures{Vars: 3, ByRef: 1, Escapes: true},
+		},
 	}
 
 	for k, tc := range testcases {
@@ -63,3 +117,103 @@ func TestSerDeser(t *testing.T) {
 		t.Errorf("nil serialize/deserialize failed")
 	}
 }
+
+func TestDeserMalformed(t *testing.T) {
+	fp := FuncProps{
+		Flags:       FuncPropIsWrapper,
+		ParamFlags:  []ParamPropBits{ParamNoInfo, 0xfffff},
+		ResultFlags: []ResultPropBits{ResultAlwaysSameConstant},
+		Hotspot:     "foo.go:12:3",
+		Captures:    ClosureCaptures{Vars: 3, ByRef: 1, Escapes: true},
+	}
+	s := fp.SerializeToString()
+	for i := 1; i < len(s); i++ {
+		if got := DeserializeFromString(s[:i]); got != nil {
+			t.Errorf("truncated to %d bytes: got %s, want nil", i, got)
+		}
+	}
+	for what, bad := range map[string]string{
+		"other version":  string(rune(serialVersion+1)) + s[1:],
+		"trailing bytes": s + "\x00",
+		"huge count":     string([]byte{serialVersion, 0, 0xff, 0xff, 0xff, 0x7f}),
+		"overlong value": string(append([]byte{serialVersion}, bytes.Repeat([]byte{0xff}, 11)...)),
+	} {
+		if got := DeserializeFromString(bad); got != nil {
+			t.Errorf("%s: got %s, want nil", what, got)
+		}
+	}
+}
+
+func TestSummary(t *testing.T) {
+	testcases := []struct {
+		fp   FuncProps
+		want string
+	}{
+		{FuncProps{}, "none"},
+		{FuncProps{ParamFlags: []ParamPropBits{0, 0}, NumCalls: 3}, "none"},
+		{
+			FuncProps{
+				Flags:       FuncPropIsPure | FuncPropStraightLine,
+				ParamFlags:  []ParamPropBits{0, ParamFeedsLoopBound},
+				ResultFlags: []ResultPropBits{ResultIsAllocatedMem},
+			},
+			"flags=FuncPropIsPure|FuncPropStraightLine params=[1:ParamFeedsLoopBound] results=[0:ResultIsAllocatedMem]",
+		},
+	}
+	for k, tc := range testcases {
+		if got := tc.fp.Summary(); got != tc.want {
+			t.Errorf("test %d: got %q, want %q", k, got, tc.want)
+		}
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	testcases := []FuncProps{
+		FuncProps{},
+		FuncProps{
+			Flags:       FuncPropIsPure | FuncPropIsLeaf,
+			ParamFlags:  []ParamPropBits{ParamNoInfo, ParamNeverRead | ParamFeedsLoopBound},
+			ResultFlags: []ResultPropBits{ResultAlwaysSameConstant},
+			NumReturns:  2,
+			Hotspot:     "foo.go:12:3",
+		},
+		FuncProps{
+			// unnamed bits are encoded as integers
+			Flags:       1 << 63,
+			ResultFlags: []ResultPropBits{0xfeedface},
+		},
+	}
+	for k, tc := range testcases {
+		data, err := json.Marshal(&tc)
+		if err != nil {
+			t.Fatalf("test %d: %v", k, err)
+		}
+		fp, err := ParseFuncProps(data)
+		if err != nil {
+			t.Fatalf("test %d: parsing %s: %v", k, data, err)
+		}
+		if !fpeq(*fp, tc) {
+			t.Errorf("test %d: got:\n%s\nwant:\n%s\n", k, fp, &tc)
+		}
+	}
+
+	data, err := json.Marshal(&testcases[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"ParamFlags":[[],["ParamFeedsLoopBound","ParamNeverRead"]]`; !strings.Contains(string(data), want) {
+		t.Errorf("got %s, want it to contain %s", data, want)
+	}
+
+	// Integer flags, as in older dumps, are accepted too.
+	fp, err := ParseFuncProps([]byte(`{"Flags":2048,"ParamFlags":[0,1024]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fp.Flags != FuncPropIsPure || len(fp.ParamFlags) != 2 || fp.ParamFlags[1] != ParamNeverRead {
+		t.Errorf("got %+v for integer flags", fp)
+	}
+	if _, err := ParseFuncProps([]byte(`{"Flags":["FuncPropBogus"]}`)); err == nil {
+		t.Errorf("no error for unknown flag name")
+	}
+}
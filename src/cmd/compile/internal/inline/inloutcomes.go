@@ -0,0 +1,231 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inline
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/inline/inlheur"
+	"cmd/compile/internal/ir"
+)
+
+// This file implements the "-d=inlheuroutcomes=file" summary, which
+// lists the direct call sites whose inlining decision was changed by
+// the inline heuristics (-d=inlheuristics): those that were inlined
+// only because the heuristics lowered their score to within the
+// maximum cost allowed for the call, and those that were not inlined
+// only because the heuristics raised it above. This makes it possible
+// to attribute changes in binary size or performance between builds
+// with and without the heuristics to specific call sites. The summary
+// for each package is appended to the file, so that a single file
+// can describe all of the packages in a build, and has the form
+//
+//	inline heuristics outcomes for package p:
+//	  enabled: 2 call sites (cost 212)
+//	  suppressed: 1 call site (cost 64)
+//	  foo.go:12:9: F -> p.g: enabled cost=97 base_threshold=80 score=57 threshold=80 adjustments=wrapperAdj
+//	  foo.go:31:3: F -> p.h: enabled cost=85 base_threshold=80 score=85 threshold=100 adjustments=none
+//	  foo.go:40:7: G -> p.k: suppressed cost=64 base_threshold=80 score=84 threshold=80 adjustments=errorPathAdj
+//
+// where the cost totals are the sums of the callees' unadjusted
+// inline costs. The baseline decision compares the unadjusted cost
+// against "base_threshold", the maximum cost that would apply to the
+// call without any budget extension (see -d=inlbudgetextend), which
+// is the PGO hot call site budget for hot call sites in small callers
+// (see baseInlineCostOK), and the heuristics decision compares the
+// score against "threshold", the maximum cost actually applied to the
+// call. Calls
+// within function bodies that were themselves inlined are not
+// included.
+//
+// The "-d=inlheurdiffcsv=file" option records the same call sites
+// for the purpose of comparing the baseline cost model (in which a
+// call is inlined if the callee's unadjusted inline cost is within
+// the threshold) with the heuristics, but appends them to the file
+// as CSV records for processing with other tools, one per call site,
+// with both scores and decisions:
+//
+//	pkg,pos,caller,callee,base_threshold,base_score,base_inlined,threshold,heur_score,heur_inlined,adjustments
+//	p,foo.go:12:9,F,p.g,80,97,false,80,57,true,wrapperAdj
+//
+// The header line is written only by the compilation that creates the
+// file, so that the records for all of the packages in a build can be
+// collected in one file.
+
+// heurOutcome records a call site whose inlining decision was changed
+// by the inline heuristics.
+type heurOutcome struct {
+	pos            string
+	caller, callee string
+	inlined        bool
+	baseInlined    bool
+	cost, score    int32
+	baseThreshold  int32
+	threshold      int32
+	adjustments    string
+}
+
+// heurOutcomes holds the outcomes recorded so far, and
+// heurOutcomeIndex the index in heurOutcomes of the outcome for each
+// call, so that a call considered more than once is only listed once
+// (with the last outcome recorded for it).
+var (
+	heurOutcomes     []heurOutcome
+	heurOutcomeIndex = make(map[*ir.CallExpr]int)
+)
+
+// recordingHeurOutcomes reports whether the decisions changed by the
+// inline heuristics are being recorded (see recordHeurOutcome).
+func recordingHeurOutcomes() bool {
+	return base.Debug.InlHeuristics != 0 &&
+		(base.Debug.InlHeurOutcomes != "" || base.Debug.InlHeurDiffCSV != "")
+}
+
+// recordHeurOutcome records the inliner's decision for the call 'call'
+// from 'caller' to 'callee', where 'inlined' is the decision, 'score'
+// is the callee's adjusted cost, 'threshold' is the maximum cost
+// allowed for the call and 'bigCaller' indicates that the caller is a
+// big function, if the decision would have been different without the
+// inline heuristics (see baseInlineCostOK).
+func recordHeurOutcome(caller *ir.Func, call *ir.CallExpr, callee *ir.Func, inlined bool, score, threshold int32, bigCaller bool) {
+	if base.Ctxt.PosTable.Pos(call.Pos()).Base().InliningIndex() >= 0 {
+		// Call from within an inlined body.
+		return
+	}
+	baseInlined, baseThreshold := baseInlineCostOK(call, caller, callee, bigCaller)
+	if baseInlined == inlined {
+		// Same decision without the heuristics.
+		return
+	}
+	o := heurOutcome{
+		pos:           ir.Line(call),
+		caller:        ir.FuncName(caller),
+		callee:        ir.PkgFuncName(callee),
+		inlined:       inlined,
+		baseInlined:   baseInlined,
+		cost:          callee.Inl.Cost,
+		score:         score,
+		baseThreshold: baseThreshold,
+		threshold:     threshold,
+		adjustments:   inlheur.CallSiteAdjustments(caller, call),
+	}
+	if i, ok := heurOutcomeIndex[call]; ok {
+		heurOutcomes[i] = o
+		return
+	}
+	heurOutcomeIndex[call] = len(heurOutcomes)
+	heurOutcomes = append(heurOutcomes, o)
+}
+
+// writeHeurOutcomes appends the summary for package 'pkg' to the file
+// 'path'.
+func writeHeurOutcomes(path, pkg string) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "inline heuristics outcomes for package %s:\n", pkg)
+	var nenabled, nsuppressed int
+	var enabledCost, suppressedCost int32
+	for _, o := range heurOutcomes {
+		if o.inlined {
+			nenabled++
+			enabledCost += o.cost
+		} else {
+			nsuppressed++
+			suppressedCost += o.cost
+		}
+	}
+	fmt.Fprintf(&buf, "  enabled: %s (cost %d)\n", callSitesCount(nenabled), enabledCost)
+	fmt.Fprintf(&buf, "  suppressed: %s (cost %d)\n", callSitesCount(nsuppressed), suppressedCost)
+	for _, o := range heurOutcomes {
+		outcome := "suppressed"
+		if o.inlined {
+			outcome = "enabled"
+		}
+		adjs := o.adjustments
+		if adjs == "" {
+			adjs = "none"
+		}
+		fmt.Fprintf(&buf, "  %s: %s -> %s: %s cost=%d base_threshold=%d score=%d threshold=%d adjustments=%s\n",
+			o.pos, o.caller, o.callee, outcome, o.cost, o.baseThreshold, o.score, o.threshold, adjs)
+	}
+
+	// Write the summary with a single call, so that summaries
+	// appended by concurrent compilations aren't interleaved.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0666)
+	if err != nil {
+		base.Fatalf("opening inline heuristics outcomes file %q: %v", path, err)
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		base.Fatalf("writing inline heuristics outcomes file %q: %v", path, err)
+	}
+	if err := f.Close(); err != nil {
+		base.Fatalf("closing inline heuristics outcomes file %q: %v", path, err)
+	}
+}
+
+// writeHeurDiffCSV appends the CSV records for the outcomes recorded
+// for package 'pkg' to the file 'path', preceded by a header line if
+// this call creates the file. Creating the file exclusively ensures
+// that only one of several concurrent compilations writes the header.
+func writeHeurDiffCSV(path, pkg string) {
+	created := true
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL|os.O_APPEND, 0666)
+	if errors.Is(err, fs.ErrExist) {
+		created = false
+		f, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0666)
+	}
+	if err != nil {
+		base.Fatalf("opening inline heuristics diff file %q: %v", path, err)
+	}
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if created {
+		w.Write([]string{"pkg", "pos", "caller", "callee",
+			"base_threshold", "base_score", "base_inlined",
+			"threshold", "heur_score", "heur_inlined", "adjustments"})
+	}
+	for _, o := range heurOutcomes {
+		w.Write([]string{pkg, o.pos, o.caller, o.callee,
+			strconv.Itoa(int(o.baseThreshold)),
+			strconv.Itoa(int(o.cost)), strconv.FormatBool(o.baseInlined),
+			strconv.Itoa(int(o.threshold)),
+			strconv.Itoa(int(o.score)), strconv.FormatBool(o.inlined),
+			o.adjustments})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		base.Fatalf("writing inline heuristics diff file %q: %v", path, err)
+	}
+	// As with writeHeurOutcomes, write the records with a single
+	// call so that those of concurrent compilations aren't
+	// interleaved.
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		base.Fatalf("writing inline heuristics diff file %q: %v", path, err)
+	}
+	if err := f.Close(); err != nil {
+		base.Fatalf("closing inline heuristics diff file %q: %v", path, err)
+	}
+}
+
+// resetHeurOutcomes discards the outcomes recorded for the package,
+// once they have been written out.
+func resetHeurOutcomes() {
+	heurOutcomes = nil
+	heurOutcomeIndex = make(map[*ir.CallExpr]int)
+}
+
+// callSitesCount returns "1 call site" or "N call sites".
+func callSitesCount(n int) string {
+	if n == 1 {
+		return "1 call site"
+	}
+	return fmt.Sprintf("%d call sites", n)
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inline
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/inline/inlheur"
+	"cmd/compile/internal/ir"
+)
+
+// This file implements the "-d=inlreasons=file" report, which lists
+// the inliner's decision for each direct call site in the package,
+// one per line, in the order in which the inliner visited them. Each
+// line has the form
+//
+//	pos: caller -> callee: decision cost=C score=S threshold=T adjustments=A
+//
+// where 'decision' is either "inlined" or "not inlined: <reason>",
+// C is the callee's inline cost, S is the cost as adjusted by the
+// inline heuristics (the same as C unless -d=inlheuristics is in
+// effect), T is the maximum cost allowed for the call, and A lists
+// the heuristic score adjustments that were applied, if any. Calls
+// that are rejected before their cost is considered report "n/a"
+// for the cost, score and threshold. Calls within function bodies
+// that were themselves inlined into the caller are not included.
+
+// inlDecision records the inliner's decision for a call site.
+type inlDecision struct {
+	pos            string
+	file           string // absolute path of the file containing the call
+	line, col      uint
+	caller, callee string
+	callerFn       *ir.Func
+	calleeFn       *ir.Func
+	reason         string // empty if the call was inlined
+	cost, score    int32  // not meaningful if threshold is -1
+	threshold      int32  // -1 if not computed
+	adjustments    string
+}
+
+// inlDecisions holds the decisions recorded so far.
+var inlDecisions []inlDecision
+
+// recordingInlDecisions reports whether the inliner's decisions are
+// being recorded, for the "-d=inlreasons" report, the
+// "-d=inldecisionsjson" file (see inldecisions_json.go) or the
+// "-d=inlcallgraphdot" graph (see inlgraph_dot.go).
+func recordingInlDecisions() bool {
+	return base.Debug.InlReasons != "" || base.Debug.InlDecisionsJSON != "" ||
+		base.Debug.InlCallGraphDOT != ""
+}
+
+// cannotInlineReasons records the reasons why functions in the
+// package being compiled were found not to be inlinable by CanInline.
+var cannotInlineReasons = make(map[*ir.Func]string)
+
+// notInlinableReason returns the reason that 'fn' has no inline body.
+func notInlinableReason(fn *ir.Func) string {
+	if fn.Inl != nil {
+		return "no inline body available"
+	}
+	if reason := cannotInlineReasons[fn]; reason != "" {
+		return "callee not inlinable: " + reason
+	}
+	return "callee not inlinable"
+}
+
+// recordInlDecision records the inliner's decision for the call
+// 'call' from 'caller' to 'callee', where 'reason' is the reason the
+// call wasn't inlined (or empty if it was), 'score' is the callee's
+// adjusted cost, and 'threshold' is the maximum cost allowed for the
+// call. Both of the latter are -1 if they weren't computed.
+func recordInlDecision(caller *ir.Func, call *ir.CallExpr, callee *ir.Func, reason string, score, threshold int32) {
+	if base.Ctxt.PosTable.Pos(call.Pos()).Base().InliningIndex() >= 0 {
+		// Call from within an inlined body.
+		return
+	}
+	p := base.Ctxt.InnermostPos(call.Pos())
+	file := p.Filename()
+	if file != "<autogenerated>" {
+		if abs, err := filepath.Abs(file); err == nil {
+			file = abs
+		}
+	}
+	d := inlDecision{
+		pos:       ir.Line(call),
+		file:      file,
+		line:      p.Line(),
+		col:       p.Col(),
+		caller:    ir.FuncName(caller),
+		callee:    ir.PkgFuncName(callee),
+		callerFn:  caller,
+		calleeFn:  callee,
+		reason:    reason,
+		cost:      -1,
+		score:     score,
+		threshold: threshold,
+	}
+	if threshold >= 0 {
+		d.cost = callee.Inl.Cost
+		if base.Debug.InlHeuristics != 0 {
+			d.adjustments = inlheur.CallSiteAdjustments(caller, call)
+		}
+	}
+	inlDecisions = append(inlDecisions, d)
+}
+
+// writeInlDecisions writes out the decisions recorded so far to the
+// file 'path'.
+func writeInlDecisions(path string) {
+	f, err := os.Create(path)
+	if err != nil {
+		base.Fatalf("opening inline reasons file %q: %v", path, err)
+	}
+	w := bufio.NewWriter(f)
+	for _, d := range inlDecisions {
+		decision := "inlined"
+		if d.reason != "" {
+			decision = "not inlined: " + d.reason
+		}
+		fmt.Fprintf(w, "%s: %s -> %s: %s", d.pos, d.caller, d.callee, decision)
+		if d.threshold < 0 {
+			fmt.Fprintf(w, " cost=n/a score=n/a threshold=n/a")
+		} else {
+			fmt.Fprintf(w, " cost=%d score=%d threshold=%d", d.cost, d.score, d.threshold)
+		}
+		adjs := d.adjustments
+		if adjs == "" {
+			adjs = "none"
+		}
+		fmt.Fprintf(w, " adjustments=%s\n", adjs)
+	}
+	if err := w.Flush(); err != nil {
+		base.Fatalf("writing inline reasons file %q: %v", path, err)
+	}
+	if err := f.Close(); err != nil {
+		base.Fatalf("closing inline reasons file %q: %v", path, err)
+	}
+}
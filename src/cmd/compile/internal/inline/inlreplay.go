@@ -0,0 +1,88 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inline
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+)
+
+// This file implements the "-d=inlreplay=file" command line flag,
+// which reads the inlining decisions from a file previously written
+// with "-d=inldecisionsjson" (see inldecisions_json.go) and forces
+// the inliner to make the same decisions, bypassing the cost check
+// (and with it the inline heuristics' scoring) for each call site
+// listed in the file. This makes it possible to reproduce the
+// inlining of one compiler version with another when comparing
+// performance, or to bisect a change in the heuristics by editing
+// the decisions for individual call sites.
+//
+// Call sites are matched by file name (ignoring its directory), line,
+// column and callee, so the file remains usable as long as the source
+// is unchanged. Call sites not listed in the file, including all calls
+// within inlined bodies (which the decisions file doesn't record),
+// are decided as usual. A replayed decision can't overcome the checks
+// that don't depend on cost: a call to a function with no inline body,
+// for example, is never inlined. The decisions are ignored entirely if
+// the file was written for a different package.
+
+// replayKey identifies a call site in a decisions file.
+type replayKey struct {
+	file      string
+	line, col uint
+	callee    string
+}
+
+// inlReplay maps the call sites listed in the -d=inlreplay file to
+// whether they should be inlined, or is nil if the flag isn't in
+// effect.
+var inlReplay map[replayKey]bool
+
+// loadInlReplay reads the decisions file 'path' for package 'pkg',
+// setting up inlReplay.
+func loadInlReplay(path, pkg string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		base.Fatalf("reading inline decisions file: %v", err)
+	}
+	var doc jsonInlDecisions
+	if err := json.Unmarshal(data, &doc); err != nil {
+		base.Fatalf("reading inline decisions file %q: %v", path, err)
+	}
+	if doc.Version != inlDecisionsVersion {
+		base.Fatalf("reading inline decisions file %q: unsupported version %d (want %d)",
+			path, doc.Version, inlDecisionsVersion)
+	}
+	inlReplay = make(map[replayKey]bool)
+	if doc.Package != pkg {
+		return
+	}
+	for _, c := range doc.Calls {
+		k := replayKey{filepath.Base(c.File), c.Line, c.Col, c.Callee}
+		inlReplay[k] = c.Inlined
+	}
+}
+
+// replayedInlDecision returns the decision given by the -d=inlreplay
+// file for the call 'n' to 'callee' (true if the call should be
+// inlined). The second result is false if the flag isn't in effect or
+// the file doesn't list the call.
+func replayedInlDecision(n *ir.CallExpr, callee *ir.Func) (bool, bool) {
+	if inlReplay == nil {
+		return false, false
+	}
+	if base.Ctxt.PosTable.Pos(n.Pos()).Base().InliningIndex() >= 0 {
+		// Call from within an inlined body.
+		return false, false
+	}
+	p := base.Ctxt.InnermostPos(n.Pos())
+	k := replayKey{filepath.Base(p.Filename()), p.Line(), p.Col(), ir.PkgFuncName(callee)}
+	inlined, ok := inlReplay[k]
+	return inlined, ok
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inline
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+)
+
+// This file implements the "-d=inlbudgetwhatif=N1/N2/..." report,
+// which estimates the effect of raising the inline budget. For each
+// increment N, it reports how many of the package's functions that
+// were rejected as too expensive would have been inlinable had the
+// inline budget been N higher, and how many of the direct call sites
+// that weren't inlined because of their cost would have been inlined
+// had the maximum cost for each call been N higher, along with the
+// aggregate cost of each. For example
+//
+//	inline budget what-if for package p:
+//	  +10: 2 functions (cost 175), 3 call sites (cost 262)
+//	  +20: 4 functions (cost 364), 7 call sites (cost 630)
+//
+// A call to a function that is not inlinable only because of its
+// cost is counted at a given increment if the function is inlinable
+// at that increment and its cost is within the call's raised
+// maximum. The counts at each increment include those at smaller
+// ones. When the report is requested, the inliner computes the full
+// cost of each function rather than stopping once the budget has
+// been exceeded, so that the costs are exact. Calls within function
+// bodies that were themselves inlined are not included.
+
+// whatIfCost records the cost of a function or call site rejected by
+// the inliner, and the budget or maximum cost it exceeded.
+type whatIfCost struct {
+	cost, limit int32
+}
+
+var (
+	// whatIfIncrements holds the budget increments to report on.
+	whatIfIncrements []int32
+	// whatIfFuncs records the functions rejected by CanInline as
+	// too expensive.
+	whatIfFuncs = make(map[*ir.Func]whatIfCost)
+	// whatIfCalls records the call sites rejected by mkinlcall
+	// because of their cost, or because the callee is in
+	// whatIfFuncs.
+	whatIfCalls []whatIfCost
+)
+
+// parseWhatIfIncrements parses 'spec', the value of the
+// -d=inlbudgetwhatif flag, returning the budget increments it lists
+// (in the order given).
+func parseWhatIfIncrements(spec string) ([]int32, error) {
+	var incs []int32
+	for _, s := range strings.Split(spec, "/") {
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("bad budget increment %q", s)
+		}
+		incs = append(incs, int32(n))
+	}
+	return incs, nil
+}
+
+// enableWhatIf parses 'spec', the value of the -d=inlbudgetwhatif
+// flag, and sets up the collection of the data for the report.
+func enableWhatIf(spec string) {
+	incs, err := parseWhatIfIncrements(spec)
+	if err != nil {
+		base.Fatalf("invalid -d=inlbudgetwhatif=%s: %v", spec, err)
+	}
+	whatIfIncrements = incs
+}
+
+// recordWhatIfFunc records that CanInline rejected 'fn', whose cost
+// is 'cost', as exceeding the inline budget 'budget'.
+func recordWhatIfFunc(fn *ir.Func, cost, budget int32) {
+	whatIfFuncs[fn] = whatIfCost{cost: cost, limit: budget}
+}
+
+// recordWhatIfCall records that the call 'call' to 'callee' was not
+// inlined because its cost (or score, with -d=inlheuristics) 'cost'
+// exceeds the maximum cost 'maxCost' allowed for it. If 'callee' is
+// not inlinable, 'cost' is ignored; the call is recorded only if the
+// callee was rejected as too expensive, using the callee's cost.
+func recordWhatIfCall(call *ir.CallExpr, callee *ir.Func, cost, maxCost int32) {
+	if base.Ctxt.PosTable.Pos(call.Pos()).Base().InliningIndex() >= 0 {
+		// Call from within an inlined body.
+		return
+	}
+	if callee.Inl == nil {
+		fc, ok := whatIfFuncs[callee]
+		if !ok {
+			return
+		}
+		cost = fc.cost
+		if fc.limit < maxCost {
+			maxCost = fc.limit
+		}
+	}
+	whatIfCalls = append(whatIfCalls, whatIfCost{cost: cost, limit: maxCost})
+}
+
+// writeWhatIfReport writes the report for package 'pkg' to 'w'.
+func writeWhatIfReport(w io.Writer, pkg string) {
+	fmt.Fprintf(w, "inline budget what-if for package %s:\n", pkg)
+	for _, inc := range whatIfIncrements {
+		nfuncs, fcost := 0, 0
+		for _, fc := range whatIfFuncs {
+			if fc.cost <= fc.limit+inc {
+				nfuncs++
+				fcost += int(fc.cost)
+			}
+		}
+		ncalls, ccost := 0, 0
+		for _, cc := range whatIfCalls {
+			if cc.cost <= cc.limit+inc {
+				ncalls++
+				ccost += int(cc.cost)
+			}
+		}
+		fmt.Fprintf(w, "  +%d: %d functions (cost %d), %d call sites (cost %d)\n",
+			inc, nfuncs, fcost, ncalls, ccost)
+	}
+	whatIfFuncs = make(map[*ir.Func]whatIfCost)
+	whatIfCalls = nil
+}
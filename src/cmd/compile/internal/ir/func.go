@@ -129,6 +129,10 @@ type Func struct {
 	// within a package.
 	ABIRefs obj.ABISet
 
+	// InlineHint is the hint given for the function with the
+	// //go:inlinehint directive, if any.
+	InlineHint InlineHint
+
 	NumDefers  int32 // number of defer calls in the function
 	NumReturns int32 // number of explicit returns in the function
 
@@ -152,6 +156,19 @@ type WasmImport struct {
 	Name   string
 }
 
+// InlineHint is a hint, given with the //go:inlinehint directive,
+// about how the inline heuristics should treat calls to a function.
+// Unlike //go:noinline it doesn't force a decision; it only adjusts
+// the scores of the function's call sites.
+type InlineHint uint8
+
+const (
+	InlineHintNone         InlineHint = iota
+	InlineHintHot                     // "hot": func is called often
+	InlineHintCold                    // "cold": func is rarely called
+	InlineHintPreferInline            // "prefer-inline": inline func when possible
+)
+
 // NewFunc returns a new Func with the given name and type.
 //
 // fpos is the position of the "func" token, and npos is the position
@@ -204,6 +221,13 @@ type Inline struct {
 	// initializing the result parameters until immediately before the
 	// "return" statement.
 	CanDelayResults bool
+
+	// Function properties computed by the inlining heuristics,
+	// encoded as a string (see inlheur.FuncProps.SerializeToString).
+	// Empty if the properties weren't computed. This field is carried
+	// in export data, so that the properties of imported functions are
+	// also available.
+	Properties string
 }
 
 // A Mark represents a scope boundary.
@@ -174,6 +174,37 @@ func s15a8(x *[15]int64) [15]int64 {
 		}
 	})
 
+	t.Run("InlHeur", func(t *testing.T) {
+		const heurCode = `package x
+func double(x int) int {
+	return x * 2
+}
+func F(y int) int {
+	return double(y) + 1
+}
+`
+		heur := filepath.Join(dir, "heur.go")
+		if err := os.WriteFile(heur, []byte(heurCode), 0644); err != nil {
+			t.Fatal(err)
+		}
+		run := []string{testenv.GoToolPath(t), "tool", "compile", "-p=x", "-json=0,file://log/heur",
+			"-d=inlheuristics=1", "-o", filepath.Join(dir, "heur.o"), heur}
+		t.Log(run)
+		cmd := testenv.Command(t, run[0], run[1:]...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("%v: %v\n%s", run, err, out)
+		}
+		logged, err := os.ReadFile(filepath.Join(dir, "log", "heur", "x", "heur.json"))
+		if err != nil {
+			t.Fatal("-json=0,file://log/heur missing expected log file")
+		}
+		slogged := normalize(logged, string(uriIfy(dir)), string(uriIfy("tmpdir")))
+		t.Logf("%s", slogged)
+		want(t, slogged, `{"range":{"start":{"line":2,"character":6},"end":{"line":2,"character":6}},"severity":3,"code":"inlheurFuncProps","source":"go compiler","message":"props: flags=FuncPropIsPure|FuncPropStraightLine|FuncPropIsLeaf"}`)
+		want(t, slogged, `"code":"inlheurCallSiteScore","source":"go compiler","message":"callee: x.double, cost: 4, score: -6, adjustments: leafAdj"}`)
+	})
+
 	// Some architectures don't fault on nil dereference, so nilchecks are eliminated differently.
 	// The N-way copy test also doesn't need to run N-ways N times.
 	if runtime.GOARCH != "amd64" {
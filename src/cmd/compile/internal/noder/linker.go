@@ -279,6 +279,7 @@ func (l *linker) relocFuncExt(w *pkgbits.Encoder, name *ir.Name) {
 			w.String("")
 		}
 	}
+	w.Uint64(uint64(name.Func.InlineHint))
 
 	// Relocated extension data.
 	w.Bool(true)
@@ -296,6 +297,7 @@ func (l *linker) relocFuncExt(w *pkgbits.Encoder, name *ir.Name) {
 	if inl := name.Func.Inl; w.Bool(inl != nil) {
 		w.Len(int(inl.Cost))
 		w.Bool(inl.CanDelayResults)
+		w.String(inl.Properties)
 	}
 
 	w.Sync(pkgbits.SyncEOF)
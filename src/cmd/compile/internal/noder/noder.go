@@ -171,6 +171,7 @@ type pragmas struct {
 	Pos        []pragmaPos   // position of each individual flag
 	Embeds     []pragmaEmbed
 	WasmImport *WasmImport
+	InlineHint *pragmaInlineHint
 }
 
 // WasmImport stores metadata associated with the //go:wasmimport pragma
@@ -185,6 +186,11 @@ type pragmaPos struct {
 	Pos  syntax.Pos
 }
 
+type pragmaInlineHint struct {
+	Pos  syntax.Pos
+	Hint ir.InlineHint
+}
+
 type pragmaEmbed struct {
 	Pos      syntax.Pos
 	Patterns []string
@@ -204,6 +210,9 @@ func (p *noder) checkUnusedDuringParse(pragma *pragmas) {
 	if pragma.WasmImport != nil {
 		p.error(syntax.Error{Pos: pragma.WasmImport.Pos, Msg: "misplaced go:wasmimport directive"})
 	}
+	if pragma.InlineHint != nil {
+		p.error(syntax.Error{Pos: pragma.InlineHint.Pos, Msg: "misplaced go:inlinehint directive"})
+	}
 }
 
 // pragma is called concurrently if files are parsed concurrently.
@@ -269,6 +278,18 @@ func (p *noder) pragma(pos syntax.Pos, blankLine bool, text string, old syntax.P
 		}
 		p.linknames = append(p.linknames, linkname{pos, f[1], target})
 
+	case text == "go:inlinehint", strings.HasPrefix(text, "go:inlinehint "):
+		f := strings.Fields(text)
+		hint := ir.InlineHintNone
+		if len(f) == 2 {
+			hint = inlineHint(f[1])
+		}
+		if hint == ir.InlineHintNone {
+			p.error(syntax.Error{Pos: pos, Msg: "usage: //go:inlinehint hot|cold|prefer-inline"})
+			break
+		}
+		pragma.InlineHint = &pragmaInlineHint{pos, hint}
+
 	case text == "go:embed", strings.HasPrefix(text, "go:embed "):
 		args, err := parseGoEmbed(text[len("go:embed"):])
 		if err != nil {
@@ -326,6 +347,21 @@ func (p *noder) pragma(pos syntax.Pos, blankLine bool, text string, old syntax.P
 	return pragma
 }
 
+// inlineHint returns the inline hint named by the argument 'arg' of
+// a //go:inlinehint directive, or ir.InlineHintNone if it isn't
+// recognized.
+func inlineHint(arg string) ir.InlineHint {
+	switch arg {
+	case "hot":
+		return ir.InlineHintHot
+	case "cold":
+		return ir.InlineHintCold
+	case "prefer-inline":
+		return ir.InlineHintPreferInline
+	}
+	return ir.InlineHintNone
+}
+
 // isCgoGeneratedFile reports whether pos is in a file
 // generated by cgo, which is to say a file with name
 // beginning with "_cgo_". Such files are allowed to
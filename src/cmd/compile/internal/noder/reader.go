@@ -1102,6 +1102,7 @@ func (r *reader) funcExt(name *ir.Name, method *types.Sym) {
 			}
 		}
 	}
+	fn.InlineHint = ir.InlineHint(r.Uint64())
 
 	if r.Bool() {
 		assert(name.Defn == nil)
@@ -1117,6 +1118,7 @@ func (r *reader) funcExt(name *ir.Name, method *types.Sym) {
 			fn.Inl = &ir.Inline{
 				Cost:            int32(r.Len()),
 				CanDelayResults: r.Bool(),
+				Properties:      r.String(),
 			}
 		}
 	} else {
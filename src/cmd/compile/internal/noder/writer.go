@@ -1018,6 +1018,10 @@ func (w *writer) funcExt(obj *types2.Func) {
 		w.p.errorf(decl, "go:nosplit and go:systemstack cannot be combined")
 	}
 	wi := asWasmImport(decl.Pragma)
+	hint := asInlineHint(decl.Pragma)
+	if hint != ir.InlineHintNone && pragma&ir.Noinline != 0 {
+		w.p.errorf(decl, "go:inlinehint and go:noinline cannot be combined")
+	}
 
 	if decl.Body != nil {
 		if pragma&ir.Noescape != 0 {
@@ -1070,6 +1074,7 @@ func (w *writer) funcExt(obj *types2.Func) {
 			w.String("")
 		}
 	}
+	w.Uint64(uint64(hint))
 
 	w.Bool(false) // stub extension
 	w.Reloc(pkgbits.RelocBody, body)
@@ -2589,6 +2594,10 @@ func (pw *pkgWriter) checkPragmas(p syntax.Pragma, allowed ir.PragmaFlag, embedO
 			pw.errorf(e.Pos, "misplaced go:embed directive")
 		}
 	}
+
+	if pragma.InlineHint != nil && allowed != funcPragmas {
+		pw.errorf(pragma.InlineHint.Pos, "misplaced go:inlinehint directive")
+	}
 }
 
 func (w *writer) pkgInit(noders []*noder) {
@@ -2917,6 +2926,13 @@ func asPragmaFlag(p syntax.Pragma) ir.PragmaFlag {
 	return p.(*pragmas).Flag
 }
 
+func asInlineHint(p syntax.Pragma) ir.InlineHint {
+	if p == nil || p.(*pragmas).InlineHint == nil {
+		return ir.InlineHintNone
+	}
+	return p.(*pragmas).InlineHint.Hint
+}
+
 func asWasmImport(p syntax.Pragma) *WasmImport {
 	if p == nil {
 		return nil
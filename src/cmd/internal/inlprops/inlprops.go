@@ -0,0 +1,141 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package inlprops reads and writes the table of inline heuristics
+// function properties that the compiler records in the object files
+// it writes when the heuristics are enabled (-d=inlheuristics=1), so
+// that tools can examine the properties without recompiling with a
+// dump flag.
+//
+// The table for a package is stored in a data symbol named SymPrefix
+// followed by the package path. It holds one entry per function, in
+// order of function name, giving the function's linker symbol name
+// and its properties in the same human-readable form as the
+// compiler's function properties dumps (see
+// cmd/compile/internal/inline/inlheur). The symbol is not referenced
+// by any other, so the linker discards it.
+package inlprops
+
+import (
+	"cmd/internal/archive"
+	"cmd/internal/goobj"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// SymPrefix is the prefix of the name of the symbol holding the
+// properties table for a package.
+const SymPrefix = "go:inlprops."
+
+// Entry is an entry in a properties table.
+type Entry struct {
+	Func  string // linker symbol name, such as "example.com/p.F"
+	Props string // properties, one per line
+}
+
+// Encode returns the encoded form of the table 'entries': the number
+// of entries, then for each the length and bytes of the function
+// name followed by the length and bytes of the properties, with all
+// numbers written as unsigned varints.
+func Encode(entries []Entry) []byte {
+	b := binary.AppendUvarint(nil, uint64(len(entries)))
+	for _, e := range entries {
+		b = binary.AppendUvarint(b, uint64(len(e.Func)))
+		b = append(b, e.Func...)
+		b = binary.AppendUvarint(b, uint64(len(e.Props)))
+		b = append(b, e.Props...)
+	}
+	return b
+}
+
+var errCorrupt = errors.New("corrupt inline properties table")
+
+// Decode decodes a table produced by Encode.
+func Decode(b []byte) ([]Entry, error) {
+	n, b, err := readUvarint(b)
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(len(b)) {
+		return nil, errCorrupt
+	}
+	entries := make([]Entry, n)
+	for i := range entries {
+		var fn, props string
+		if fn, b, err = readString(b); err != nil {
+			return nil, err
+		}
+		if props, b, err = readString(b); err != nil {
+			return nil, err
+		}
+		entries[i] = Entry{Func: fn, Props: props}
+	}
+	if len(b) != 0 {
+		return nil, errCorrupt
+	}
+	return entries, nil
+}
+
+func readUvarint(b []byte) (uint64, []byte, error) {
+	v, n := binary.Uvarint(b)
+	if n <= 0 {
+		return 0, nil, errCorrupt
+	}
+	return v, b[n:], nil
+}
+
+func readString(b []byte) (string, []byte, error) {
+	n, b, err := readUvarint(b)
+	if err != nil {
+		return "", nil, err
+	}
+	if n > uint64(len(b)) {
+		return "", nil, errCorrupt
+	}
+	return string(b[:n]), b[n:], nil
+}
+
+// ReadFile returns the entries of the properties tables in the Go
+// object file or archive 'path'. The result is empty if the file was
+// compiled without the inline heuristics.
+func ReadFile(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	a, err := archive.Parse(f, false)
+	if err != nil {
+		return nil, err
+	}
+	var entries []Entry
+	for _, e := range a.Entries {
+		if e.Type != archive.EntryGoObj {
+			continue
+		}
+		b := make([]byte, e.Obj.Size)
+		if _, err := f.ReadAt(b, e.Obj.Offset); err != nil {
+			return nil, err
+		}
+		r := goobj.NewReaderFromBytes(b, false)
+		if r == nil {
+			return nil, fmt.Errorf("%s: %s: not a Go object file", path, e.Name)
+		}
+		ndef := uint32(r.NSym() + r.NHashed64def() + r.NHasheddef() + r.NNonpkgdef())
+		for i := uint32(0); i < ndef; i++ {
+			if !strings.HasPrefix(r.Sym(i).Name(r), SymPrefix) {
+				continue
+			}
+			t, err := Decode(r.Data(i))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %v", path, e.Name, err)
+			}
+			entries = append(entries, t...)
+		}
+	}
+	return entries, nil
+}
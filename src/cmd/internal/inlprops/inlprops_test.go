@@ -0,0 +1,33 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inlprops
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	for _, entries := range [][]Entry{
+		{},
+		{{Func: "p.F", Props: "Flags FuncPropIsLeaf\n"}},
+		{{Func: "p.F", Props: ""}, {Func: "p.(*T).M", Props: "NumReturns 1\nSingleTailReturn\n"}},
+	} {
+		got, err := Decode(Encode(entries))
+		if err != nil {
+			t.Fatalf("Decode(Encode(%v)): %v", entries, err)
+		}
+		if !reflect.DeepEqual(got, entries) {
+			t.Errorf("Decode(Encode(%v)) = %v", entries, got)
+		}
+	}
+
+	b := Encode([]Entry{{Func: "p.F", Props: "Flags FuncPropIsLeaf\n"}})
+	for i := 0; i < len(b); i++ {
+		if _, err := Decode(b[:i]); err == nil {
+			t.Errorf("Decode of table truncated to %d bytes: no error", i)
+		}
+	}
+}
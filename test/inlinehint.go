@@ -0,0 +1,27 @@
+// errorcheck
+
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Verify that malformed //go:inlinehint directives are diagnosed.
+
+package p
+
+//go:inlinehint hot
+func f1() {}
+
+//go:inlinehint cold
+func f2() {}
+
+//go:inlinehint prefer-inline
+func f3() {}
+
+//go:inlinehint // ERROR "usage: //go:inlinehint hot|cold|prefer-inline"
+func f4() {}
+
+//go:inlinehint warm // ERROR "usage: //go:inlinehint hot|cold|prefer-inline"
+func f5() {}
+
+//go:inlinehint hot cold // ERROR "usage: //go:inlinehint hot|cold|prefer-inline"
+func f6() {}
@@ -0,0 +1,16 @@
+// errorcheck
+
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Verify that //go:inlinehint can't be combined with //go:noinline.
+
+package p
+
+//go:inlinehint hot
+func f1() {}
+
+//go:inlinehint prefer-inline
+//go:noinline
+func f2() {} // ERROR "go:inlinehint and go:noinline cannot be combined"